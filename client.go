@@ -1,10 +1,14 @@
 package ipmi
 
 import (
+	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/bougou/go-ipmi/open"
+	"github.com/bougou/go-ipmi/si"
 	"golang.org/x/net/proxy"
 )
 
@@ -15,6 +19,9 @@ const (
 	InterfaceLanplus Interface = "lanplus"
 	InterfaceOpen    Interface = "open"
 	InterfaceTool    Interface = "tool"
+	InterfaceKCS     Interface = "kcs"
+	InterfaceBT      Interface = "bt"
+	InterfaceSSIF    Interface = "ssif"
 
 	DefaultExchangeTimeoutSec   int = 20
 	DefaultKeepAliveIntervalSec int = 30
@@ -30,11 +37,29 @@ type Client struct {
 
 	debug bool
 
+	// logger receives the debug messages instead of stdout if set.
+	logger Logger
+
+	// interceptors are called around each exchange, the first one is the outermost.
+	interceptors []Interceptor
+
+	metrics Metrics
+
 	maxPrivilegeLevel PrivilegeLevel
 
+	// transport is only set by NewClientWithTransport or WithTransport,
+	// otherwise the transport is chosen by Interface.
+	transport Transport
+
 	openipmi *openipmi
+	toolipmi *toolipmi
+	siipmi   *siipmi
 	session  *session
 
+	// target and transit are set by WithTarget and WithTransit to bridge the requests.
+	target  *bridgeTarget
+	transit *bridgeTarget
+
 	// this flags controls which IPMI version (1.5 or 2.0) be used by Client to send Request
 	v20 bool
 
@@ -42,8 +67,40 @@ type Client struct {
 	timeout    time.Duration
 	bufferSize int
 
+	// retries is the number of retransmissions of a RMCP/RMCP+ request
+	// if no reply is received within attemptTimeout.
+	retries        int
+	attemptTimeout time.Duration
+
+	// pipeline is nil unless more than one concurrent in-flight request is allowed.
+	pipeline *pipeline
+
 	l sync.Mutex
 
+	// reconnectPolicy controls whether and how a lost lan/lanplus session is re-established.
+	reconnectPolicy *ReconnectPolicy
+	// sessionLock is held for reading by the exchanges within a session,
+	// and for writing while the session is being re-established.
+	sessionLock sync.RWMutex
+	// sessionGen is increased each time the session is re-established.
+	sessionGen uint64
+
+	// retryPolicy controls whether and how the requests failed with busy completion codes are retried.
+	retryPolicy *RetryPolicy
+
+	// sdrCache caches the records of the SDR Repository, see WithSDRCache.
+	sdrCache *SDRCache
+	// sdrCacheKey is the device GUID of the BMC, it's the key of the records in sdrCache.
+	sdrCacheKey  *[16]byte
+	sdrCacheLock sync.Mutex
+
+	// sdrPieceSize and deviceSDRPieceSize are the max number of bytes of the partial reads
+	// of the SDR records, 0 means the entire record can be read at once, see sdrReader.
+	sdrPieceSize       atomic.Uint32
+	deviceSDRPieceSize atomic.Uint32
+
+	keepAliveOnce sync.Once
+
 	// closedCh is closed when Client.Close() is called.
 	// used to notify other goroutines that Client is closed.
 	closedCh chan bool
@@ -58,23 +115,140 @@ func NewOpenClient() (*Client, error) {
 		bufferSize: DefaultBufferSize,
 
 		openipmi: &openipmi{
-			myAddr:     myAddr,
-			targetAddr: myAddr,
+			myAddr: myAddr,
 		},
 	}, nil
 }
 
+// NewOpenClientWithDevice creates an open interface client which sends the requests to dev,
+// instead of the device file of linux ipmi driver opened by Connect.
+// It's mostly used to test the code using the open interface with a faked device.
+func NewOpenClientWithDevice(dev open.Device) (*Client, error) {
+	c, err := NewOpenClient()
+	if err != nil {
+		return nil, err
+	}
+	c.openipmi.dev = dev
+	return c, nil
+}
+
+// NewKCSClient creates an IPMI client which talks to the local BMC by the KCS system interface
+// through the I/O ports (/dev/port), without the linux ipmi driver.
+// The base is the I/O port address of the KCS data register, 0 means si.DefaultKCSBase (0xca2).
+func NewKCSClient(base uint16) (*Client, error) {
+	c := newSIClient(InterfaceKCS)
+	c.siipmi.open = func() (si.Conn, error) {
+		return si.OpenKCS(base)
+	}
+	return c, nil
+}
+
+// NewBTClient creates an IPMI client which talks to the local BMC by the BT system interface
+// through the I/O ports (/dev/port), without the linux ipmi driver.
+// The base is the I/O port address of the BT_CTRL register, 0 means si.DefaultBTBase (0xe4).
+// The buffer sizes, timeout and retries of the interface are got by GetBTInterfaceCapabilities on Connect.
+func NewBTClient(base uint16) (*Client, error) {
+	c := newSIClient(InterfaceBT)
+	c.siipmi.open = func() (si.Conn, error) {
+		return si.OpenBT(base)
+	}
+	return c, nil
+}
+
+// NewSSIFClient creates an IPMI client which talks to the local BMC by the SSIF system interface
+// on the i2c bus (/dev/i2c-<bus>), without the linux ipmi driver.
+// The addr is the 7-bit SMBus slave address of the BMC, 0 means si.DefaultSSIFAddr (0x10).
+func NewSSIFClient(bus int, addr uint8) (*Client, error) {
+	c := newSIClient(InterfaceSSIF)
+	c.siipmi.open = func() (si.Conn, error) {
+		return si.OpenSSIF(bus, addr)
+	}
+	return c, nil
+}
+
+// NewSIClientWithConn creates an IPMI client which exchanges by the system interface conn,
+// like a si.KCS on a simulated or memory-mapped register set.
+func NewSIClientWithConn(intf Interface, conn si.Conn) (*Client, error) {
+	if conn == nil {
+		return nil, fmt.Errorf("nil conn")
+	}
+	switch intf {
+	case InterfaceKCS, InterfaceBT, InterfaceSSIF:
+	default:
+		return nil, fmt.Errorf("not supported system interface, supported: kcs,bt,ssif")
+	}
+	c := newSIClient(intf)
+	c.siipmi.conn = conn
+	return c, nil
+}
+
+func newSIClient(intf Interface) *Client {
+	return &Client{
+		Interface:  intf,
+		timeout:    time.Second * time.Duration(DefaultExchangeTimeoutSec),
+		bufferSize: DefaultBufferSize,
+
+		maxPrivilegeLevel: PrivilegeLevelUnspecified,
+
+		siipmi: &siipmi{},
+	}
+}
+
 // NewToolClient creates an IPMI client based ipmitool.
 // You should pass the file path of ipmitool binary or path of a wrapper script
 // that would be executed.
+//
+// The ipmitool talks to the local BMC, unless Host, Username and Password
+// of the client are set, see NewToolLanplusClient.
+//
+// For compatibility, Host is still taken as the path of ipmitool if Username is not set.
 func NewToolClient(path string) (*Client, error) {
-
 	return &Client{
-		Host:      path,
 		Interface: "tool",
+
+		maxPrivilegeLevel: PrivilegeLevelUnspecified,
+
+		toolipmi: &toolipmi{
+			path: path,
+		},
+		session: &session{
+			v20: v20{
+				cipherSuiteID: CipherSuiteIDReserved,
+			},
+		},
 	}, nil
 }
 
+// NewToolLanplusClient creates an IPMI client based ipmitool which talks to
+// the remote BMC by the lanplus interface of ipmitool (ipmitool -I lanplus -H host -U user -E).
+// The password is passed to ipmitool by the IPMI_PASSWORD environment variable,
+// so that it's not visible in the process list.
+//
+// The options like WithCipherSuiteID (-C), WithMaxPrivilegeLevel (-L), WithBMCKey (-y),
+// WithRetries (-R), WithAttemptTimeout (-N), WithTarget (-b -t -l) and WithTransit (-B -T)
+// are passed to ipmitool.
+func NewToolLanplusClient(path string, host string, port int, user string, pass string) (*Client, error) {
+	if host == "" {
+		return nil, fmt.Errorf("empty host")
+	}
+	if len(user) > IPMI_MAX_USER_NAME_LENGTH {
+		return nil, fmt.Errorf("user name (%s) too long, exceed (%d) characters", user, IPMI_MAX_USER_NAME_LENGTH)
+	}
+	if len(user) == 0 {
+		return nil, fmt.Errorf("empty username")
+	}
+
+	c, err := NewToolClient(path)
+	if err != nil {
+		return nil, err
+	}
+	c.Host = host
+	c.Port = port
+	c.Username = user
+	c.Password = pass
+	return c, nil
+}
+
 func NewClient(host string, port int, user string, pass string) (*Client, error) {
 	if len(user) > IPMI_MAX_USER_NAME_LENGTH {
 		return nil, fmt.Errorf("user name (%s) too long, exceed (%d) characters", user, IPMI_MAX_USER_NAME_LENGTH)
@@ -152,6 +326,22 @@ func (c *Client) WithTimeout(timeout time.Duration) *Client {
 	return c
 }
 
+// WithRetries sets how many times a lan/lanplus request is retransmitted
+// if no reply is received in time, like the -R option of ipmitool.
+// Default is 0, that is no retransmission.
+func (c *Client) WithRetries(retries int) *Client {
+	c.retries = retries
+	return c
+}
+
+// WithAttemptTimeout sets how long to wait for the reply of each transmission
+// of a lan/lanplus request, like the -N option of ipmitool.
+// If not set, each attempt waits for the whole timeout set by WithTimeout.
+func (c *Client) WithAttemptTimeout(timeout time.Duration) *Client {
+	c.attemptTimeout = timeout
+	return c
+}
+
 func (c *Client) WithBufferSize(bufferSize int) *Client {
 	c.bufferSize = bufferSize
 
@@ -171,6 +361,21 @@ func (c *Client) WithCipherSuiteID(cipherSuiteID CipherSuiteID) *Client {
 	return c
 }
 
+// WithBMCKey sets the BMC key (Kg) for the "two-key" login of lanplus interface, see 13.33.
+// Kg instead of the user password is used to generate the session integrity key (SIK).
+// The key is padded with zeros to 20 bytes, and truncated if longer.
+// For the BMC key to take effect, you must call WithBMCKey before calling Connect method.
+func (c *Client) WithBMCKey(key []byte) *Client {
+	if c.session != nil {
+		if len(key) == 0 {
+			c.session.v20.bmcKey = nil
+		} else {
+			c.session.v20.bmcKey = padBytes(string(key), BMCKeyLength, 0x00)
+		}
+	}
+	return c
+}
+
 // WithMaxPrivilegeLevel sets a specified session privilege level to use.
 func (c *Client) WithMaxPrivilegeLevel(privilegeLevel PrivilegeLevel) *Client {
 	c.maxPrivilegeLevel = privilegeLevel
@@ -181,68 +386,71 @@ func (c *Client) SessionPrivilegeLevel() PrivilegeLevel {
 	return c.maxPrivilegeLevel
 }
 
-// Connect connects to the bmc by specified Interface.
+// Connect connects to the bmc by the Transport of the client.
 func (c *Client) Connect() error {
+	return c.ConnectContext(context.Background())
+}
+
+// ConnectContext is like Connect but takes a context.
+// The context only bounds the connection setup, the session keepalive
+// is stopped by Close.
+func (c *Client) ConnectContext(ctx context.Context) error {
 	// Optional RMCP Ping/Pong mechanism
 	// pongRes, err := c.RmcpPing()
 	// if err != nil {
-	// return fmt.Errorf("RMCP Ping failed, err: %s", err)
+	// return fmt.Errorf("RMCP Ping failed, err: %w", err)
 	// }
 	// if pongRes.IPMISupported {
 	// return fmt.Errorf("ipmi not supported")
 	// }
 
-	switch c.Interface {
-	case "", InterfaceOpen:
-		var devnum int32 = 0
-		return c.ConnectOpen(devnum)
-
-	case InterfaceTool:
-		var devnum int32 = 0
-		return c.ConnectTool(devnum)
-
-	case InterfaceLanplus:
-		c.v20 = true
-		return c.Connect20()
-
-	case InterfaceLan:
-		c.v20 = false
-		return c.Connect15()
-
-	default:
-		return fmt.Errorf("not supported interface, supported: lan,lanplus,open")
+	transport, err := c.Transport()
+	if err != nil {
+		return err
 	}
+	return transport.Connect(ctx)
 }
 
 func (c *Client) Close() error {
-	switch c.Interface {
-	case "", InterfaceOpen:
-		return c.closeOpen()
-
-	case InterfaceTool:
-		return c.closeTool()
+	return c.CloseContext(context.Background())
+}
 
-	case InterfaceLan, InterfaceLanplus:
-		return c.closeLAN()
+// CloseContext is like Close but takes a context.
+func (c *Client) CloseContext(ctx context.Context) error {
+	transport, err := c.Transport()
+	if err != nil {
+		return err
 	}
-
-	return nil
+	return transport.Close(ctx)
 }
 
 func (c *Client) Exchange(request Request, response Response) error {
-	switch c.Interface {
-	case "", InterfaceOpen:
-		return c.exchangeOpen(request, response)
-
-	case InterfaceTool:
-		return c.exchangeTool(request, response)
+	return c.ExchangeContext(context.Background(), request, response)
+}
 
-	case InterfaceLan, InterfaceLanplus:
-		return c.exchangeLAN(request, response)
+// ExchangeContext sends the request and fills the response by the Transport of the client.
+// If ctx is canceled or its deadline is exceeded, the in-flight exchange is
+// aborted and the error returned wraps ctx.Err().
+func (c *Client) ExchangeContext(ctx context.Context, request Request, response Response) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("exchange aborted, err: %w", err)
+	}
 
+	transport, err := c.Transport()
+	if err != nil {
+		return err
 	}
 
-	return nil
+	exchange := transport.Exchange
+	if c.logger != nil || len(c.interceptors) != 0 || c.metrics != nil {
+		exchange = func(ctx context.Context, request Request, response Response) error {
+			return c.exchangeIntercepted(ctx, transport, request, response)
+		}
+	}
+	if c.retryPolicy != nil {
+		return c.exchangeRetry(ctx, request, response, exchange)
+	}
+	return exchange(ctx, request, response)
 }
 
 func (c *Client) lock() {
@@ -186,7 +186,7 @@ func (c *Client) generate_sik() ([]byte, error) {
 
 	b, err := generate_auth_hmac(c.session.v20.authAlg, input, hmacKey)
 	if err != nil {
-		return nil, fmt.Errorf("generate hmac failed, err: %s", err)
+		return nil, fmt.Errorf("generate hmac failed, err: %w", err)
 	}
 
 	c.DebugBytes("sik mac computed by the remote console:", b, 16)
@@ -211,7 +211,7 @@ func (c *Client) generate_k1() ([]byte, error) {
 	hmacKey := c.session.v20.sik
 	b, err := generate_auth_hmac(c.session.v20.authAlg, CONST_1[:], hmacKey)
 	if err != nil {
-		return nil, fmt.Errorf("generate hmac failed, err: %s", err)
+		return nil, fmt.Errorf("generate hmac failed, err: %w", err)
 	}
 
 	c.DebugBytes("generated k1:", b, 16)
@@ -236,7 +236,7 @@ func (c *Client) generate_k2() ([]byte, error) {
 	hmacKey := c.session.v20.sik
 	b, err := generate_auth_hmac(c.session.v20.authAlg, CONST_2[:], hmacKey)
 	if err != nil {
-		return nil, fmt.Errorf("generate hmac failed, err: %s", err)
+		return nil, fmt.Errorf("generate hmac failed, err: %w", err)
 	}
 	c.DebugBytes("generated k2:", b, 16)
 
@@ -266,7 +266,7 @@ func (c *Client) generate_rakp2_authcode() ([]byte, error) {
 
 	b, err := generate_auth_hmac(c.session.v20.authAlg, buffer, hmacKey)
 	if err != nil {
-		return nil, fmt.Errorf("generate hmac failed, err: %s", err)
+		return nil, fmt.Errorf("generate hmac failed, err: %w", err)
 	}
 
 	c.DebugBytes("rakp2 generated authcode", b, 16)
@@ -326,7 +326,7 @@ func (c *Client) generate_rakp3_authcode() ([]byte, error) {
 
 	b, err := generate_auth_hmac(c.session.v20.authAlg, input, hmacKey)
 	if err != nil {
-		return nil, fmt.Errorf("generate hmac failed, err: %s", err)
+		return nil, fmt.Errorf("generate hmac failed, err: %w", err)
 	}
 
 	c.DebugBytes("rakp3 generated authcode", b, 16)
@@ -354,42 +354,43 @@ func (c *Client) generate_rakp4_authcode() ([]byte, error) {
 	hmacKey := c.session.v20.sik
 	c.DebugBytes("rakp4 auth code key", hmacKey, 16)
 
-	b, err := generate_auth_hmac(c.session.v20.integrityAlg, input, hmacKey)
+	b, err := generate_auth_hmac(c.session.v20.authAlg, input, hmacKey)
 	if err != nil {
-		return nil, fmt.Errorf("generate hmac failed, err: %s", err)
+		return nil, fmt.Errorf("generate hmac failed, err: %w", err)
 	}
 
 	c.DebugBytes("rakp4 generated authcode", b, 16)
 
-	var errHmacLen = func(length int, integrityAlg IntegrityAlg) error {
-		return fmt.Errorf("the length of generated mac is not long enough, should be at least (%d) for integrity algorithm (%0x)", len(b), integrityAlg)
+	var errHmacLen = func(length int, authAlg AuthAlg) error {
+		return fmt.Errorf("the length of generated mac is not long enough, should be at least (%d) for authentication algorithm (%0x)", length, authAlg)
 	}
 
 	var out = b
 
-	integrityAlg := c.session.v20.integrityAlg
-	switch integrityAlg {
-	case IntegrityAlg_None:
+	// The Integrity Check Value of rakp4 is generated by the authentication algorithm
+	// (not the integrity algorithm) negotiated for the session, and is truncated to
+	// 12 bytes for RAKP-HMAC-SHA1, 16 bytes for RAKP-HMAC-MD5 and RAKP-HMAC-SHA256.
+	authAlg := c.session.v20.authAlg
+	switch authAlg {
+	case AuthAlgRAKP_None:
 		// nothing need to do
-	case IntegrityAlg_HMAC_MD5_128:
-		// need to copy 16 bytes
+	case AuthAlgRAKP_HMAC_MD5:
 		if len(b) < 16 {
-			err = errHmacLen(len(b), integrityAlg)
+			err = errHmacLen(16, authAlg)
 		}
 		out = b[0:16]
-	case IntegrityAlg_HMAC_SHA1_96:
-		// need to copy 12 bytes
+	case AuthAlgRAKP_HMAC_SHA1:
 		if len(b) < 12 {
-			err = errHmacLen(len(b), integrityAlg)
+			err = errHmacLen(12, authAlg)
 		}
 		out = b[0:12]
-	case IntegrityAlg_HMAC_SHA256_128:
+	case AuthAlgRAKP_HMAC_SHA256:
 		if len(b) < 16 {
-			err = errHmacLen(len(b), integrityAlg)
+			err = errHmacLen(16, authAlg)
 		}
 		out = b[0:16]
 	default:
-		err = fmt.Errorf("rakp4 message: no support for integrity algorithm %x", c.session.v20.integrityAlg)
+		err = fmt.Errorf("rakp4 message: no support for authentication algorithm %x", c.session.v20.authAlg)
 	}
 	c.DebugBytes("rakp4 used authcode", out, 16)
 
@@ -0,0 +1,155 @@
+package ipmi
+
+// bridgeTarget is the IPMB (or other channel) address the requests are bridged to.
+type bridgeTarget struct {
+	channel   uint8
+	slaveAddr uint8
+	lun       uint8
+}
+
+// WithTarget bridges the subsequent requests to the controller of slaveAddr
+// on the channel behind the BMC, like the -b, -t and -l options of ipmitool.
+// It's used to talk to the satellite controllers like the ME/NM of Intel platforms,
+// or the node BMCs of a blade chassis.
+// A zero slaveAddr (or the BMC address 0x20 on the primary IPMB) disables bridging.
+//
+// For lan and lanplus interfaces, the requests are encapsulated in tracked Send Message requests,
+// the BMC delivers the reply of the bridged request in a separate message.
+// The session management commands are never bridged.
+// For the open interface, the requests are sent to the IPMB address of the target,
+// and the driver does the Send Message and Get Message for them.
+// The tool interface passes the target to ipmitool.
+func (c *Client) WithTarget(channel uint8, slaveAddr uint8, lun uint8) *Client {
+	if slaveAddr == 0 || (slaveAddr == BMC_SA && channel == 0) {
+		c.target = nil
+		return c
+	}
+	c.target = &bridgeTarget{
+		channel:   channel,
+		slaveAddr: slaveAddr,
+		lun:       lun & 0x03,
+	}
+	return c
+}
+
+// WithTransit bridges the requests to the target (set by WithTarget) through
+// the transit controller of slaveAddr on the channel, that is double bridging,
+// like the -B and -T options of ipmitool.
+// A zero slaveAddr disables double bridging.
+func (c *Client) WithTransit(channel uint8, slaveAddr uint8) *Client {
+	if slaveAddr == 0 {
+		c.transit = nil
+		return c
+	}
+	c.transit = &bridgeTarget{
+		channel:   channel,
+		slaveAddr: slaveAddr,
+	}
+	return c
+}
+
+// bridgeable reports whether the request should be bridged to the target.
+// The requests for managing the session with the BMC are never bridged.
+func bridgeable(request Request) bool {
+	cmd := request.Command()
+	if cmd.NetFn != NetFnAppRequest {
+		return true
+	}
+	switch cmd.ID {
+	case
+		CommandGetMessage.ID,
+		CommandSendMessage.ID,
+		CommandGetChannelAuthCapabilities.ID,
+		CommandGetSessionChallenge.ID,
+		CommandActivateSession.ID,
+		CommandSetSessionPrivilegeLevel.ID,
+		CommandCloseSession.ID,
+		CommandGetSessionInfo.ID,
+		CommandActivatePayload.ID,
+		CommandDeactivatePayload.ID,
+		CommandGetChannelCipherSuites.ID:
+		return false
+	}
+	return true
+}
+
+// bridgeIPMIRequest encapsulates the IPMI request to the BMC in the tracked Send Message
+// requests to the target (and the transit controller if double bridging).
+// All the encapsulated requests use the same Requester Sequence number,
+// so that the replies are matched to the request by the sequence number.
+// see 6.13 BMC Message Bridging, and 22.7 Send Message Command
+func (c *Client) bridgeIPMIRequest(ipmiReq *IPMIRequest) *IPMIRequest {
+	seq := ipmiReq.RequesterSequence
+
+	inner := &IPMIRequest{
+		ResponderAddr:     c.target.slaveAddr,
+		NetFn:             ipmiReq.NetFn,
+		ResponderLUN:      c.target.lun,
+		RequesterAddr:     BMC_SA,
+		RequesterSequence: seq,
+		Command:           ipmiReq.Command,
+		CommandData:       ipmiReq.CommandData,
+	}
+	inner.ComputeChecksum()
+
+	channel := c.target.channel
+	msg := inner.Pack()
+
+	if c.transit != nil {
+		sendMessage := &SendMessageRequest{
+			TrackMask:     0x01,
+			ChannelNumber: c.target.channel,
+			MessageData:   msg,
+		}
+		transit := &IPMIRequest{
+			ResponderAddr:     c.transit.slaveAddr,
+			NetFn:             NetFnAppRequest,
+			ResponderLUN:      uint8(IPMB_LUN_BMC),
+			RequesterAddr:     BMC_SA,
+			RequesterSequence: seq,
+			Command:           CommandSendMessage.ID,
+			CommandData:       sendMessage.Pack(),
+		}
+		transit.ComputeChecksum()
+
+		channel = c.transit.channel
+		msg = transit.Pack()
+	}
+
+	sendMessage := &SendMessageRequest{
+		TrackMask:     0x01,
+		ChannelNumber: channel,
+		MessageData:   msg,
+	}
+	outer := &IPMIRequest{
+		ResponderAddr:     ipmiReq.ResponderAddr,
+		NetFn:             NetFnAppRequest,
+		ResponderLUN:      ipmiReq.ResponderLUN,
+		RequesterAddr:     ipmiReq.RequesterAddr,
+		RequesterSequence: seq,
+		RequesterLUN:      ipmiReq.RequesterLUN,
+		Command:           CommandSendMessage.ID,
+		CommandData:       sendMessage.Pack(),
+	}
+	outer.ComputeChecksum()
+
+	return outer
+}
+
+// bridgedCommand returns the command of the request encapsulated in the tracked
+// Send Message request(s) of the IPMI message, ok is false if it is not bridged.
+func bridgedCommand(ipmiMsg []byte) (cmd uint8, ok bool) {
+	msg := ipmiMsg
+	for len(msg) >= 7 &&
+		NetFn(msg[1]>>2) == NetFnAppRequest &&
+		msg[5] == CommandSendMessage.ID &&
+		msg[6]>>6 == 0x01 {
+		// skip the 6 bytes header and the channel byte of Send Message request
+		msg = msg[7:]
+		ok = true
+	}
+	if !ok || len(msg) < 6 {
+		return 0, false
+	}
+	return msg[5], true
+}
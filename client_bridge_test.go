@@ -0,0 +1,119 @@
+package ipmi
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+)
+
+func Test_bridgeIPMIRequest(t *testing.T) {
+	client, err := NewClient("127.0.0.1", 623, "user", "pass")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.WithTarget(6, 0x2c, 0)
+
+	ipmiReq, err := client.BuildIPMIRequest(&GetDeviceIDRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	seq := ipmiReq.RequesterSequence
+
+	// Send Message (track request, channel 6) to the BMC,
+	// encapsulating Get Device ID to 0x2c with the same sequence number
+	expect := []byte{
+		0x20, 0x18, 0xc8, 0x81, seq << 2, 0x34, 0x46,
+		0x2c, 0x18, 0xbc, 0x20, seq << 2, 0x01, 0,
+		0,
+	}
+	expect[13] = -(0x20 + seq<<2 + 0x01)
+	expect[14] = -(0x81 + seq<<2 + 0x34 + 0x46 + 0x2c + 0x18 + 0xbc + 0x20 + seq<<2 + 0x01 + expect[13])
+	if got := ipmiReq.Pack(); !bytes.Equal(got, expect) {
+		t.Errorf("bridged request not match\nexpect: % x\ngot:    % x", expect, got)
+	}
+
+	cmd, ok := bridgedCommand(ipmiReq.Pack())
+	if !ok || cmd != CommandGetDeviceID.ID {
+		t.Errorf("bridged command not match, got: %#02x, %v", cmd, ok)
+	}
+
+	// double bridging
+	client.WithTransit(7, 0x82)
+	ipmiReq, err = client.BuildIPMIRequest(&GetDeviceIDRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := ipmiReq.Pack()
+	if msg[6] != 0x47 || msg[7] != 0x82 || msg[12] != CommandSendMessage.ID || msg[13] != 0x46 || msg[14] != 0x2c {
+		t.Errorf("double bridged request not match, got: % x", msg)
+	}
+	if cmd, ok := bridgedCommand(msg); !ok || cmd != CommandGetDeviceID.ID {
+		t.Errorf("double bridged command not match, got: %#02x, %v", cmd, ok)
+	}
+
+	// the session commands are never bridged
+	ipmiReq, err = client.BuildIPMIRequest(&GetSessionInfoRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ipmiReq.Command != CommandGetSessionInfo.ID {
+		t.Errorf("session command bridged")
+	}
+}
+
+func Test_exchangeLAN_Bridged(t *testing.T) {
+	for _, embedded := range []bool{false, true} {
+		server, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+		if err != nil {
+			t.Fatalf("listen udp failed, err: %s", err)
+		}
+
+		// The server replies the Send Message request, then the bridged Get Device ID request,
+		// either in a separate message or embedded in the reply of Send Message.
+		deviceID := []byte{0x20, 0x81, 0x01, 0x10, 0x02, 0xbf, 0x57, 0x01, 0x00, 0x34, 0x12}
+		go func() {
+			buf := make([]byte, 1024)
+			n, addr, err := server.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			recv := buf[:n]
+			rqSeq := recv[14+4] >> 2
+			if recv[14+5] != CommandSendMessage.ID {
+				_, _ = server.WriteToUDP(buildLAN15Response(recv, rqSeq, nil), addr)
+				return
+			}
+
+			// the inner request starts after the 7 bytes header of the Send Message request
+			inner := append(make([]byte, 14), recv[14+7:]...)
+			innerRes := buildLAN15Response(inner, rqSeq, deviceID)
+
+			if embedded {
+				_, _ = server.WriteToUDP(buildLAN15Response(recv, rqSeq, innerRes[14:]), addr)
+				return
+			}
+			_, _ = server.WriteToUDP(buildLAN15Response(recv, rqSeq, nil), addr)
+			_, _ = server.WriteToUDP(innerRes, addr)
+		}()
+
+		addr := server.LocalAddr().(*net.UDPAddr)
+		client, err := NewClient(addr.IP.String(), addr.Port, "user", "pass")
+		if err != nil {
+			t.Fatalf("new client failed, err: %s", err)
+		}
+		client.WithInterface(InterfaceLan).WithTimeout(2*time.Second).WithTarget(6, 0x2c, 0)
+		client.v20 = false
+
+		res, err := client.GetDeviceID()
+		if err != nil {
+			t.Fatalf("GetDeviceID (embedded: %v) failed, err: %s", embedded, err)
+		}
+		if res.ManufacturerID != 0x157 || res.ProductID != 0x1234 {
+			t.Errorf("device id response (embedded: %v) not match, got: %+v", embedded, res)
+		}
+
+		client.udpClient.Close()
+		server.Close()
+	}
+}
@@ -0,0 +1,199 @@
+package ipmi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// The dump files are in the same formats as ipmitool:
+//   - `ipmitool sdr dump <file>` writes the raw SDR records (header and body) one after another.
+//   - `ipmitool sel save <file>` writes one line of text per standard SEL record,
+//     the 7 bytes of the event message in hex followed by a '#' comment.
+//     It's the same format that `ipmitool event file <file>` reads.
+//   - `ipmitool fru read <id> <file>` writes the raw FRU inventory data.
+
+// DumpSDRRepository writes the raw records of the SDR Repository to w, like `ipmitool sdr dump`.
+// The written data can be parsed back by ParseSDRDump.
+func (c *Client) DumpSDRRepository(w io.Writer) error {
+	return c.DumpSDRRepositoryContext(context.Background(), w)
+}
+
+// DumpSDRRepositoryContext is like DumpSDRRepository but takes a context.
+func (c *Client) DumpSDRRepositoryContext(ctx context.Context, w io.Writer) error {
+	// the records are buffered, so a restarted walk does not write the records twice
+	var buf bytes.Buffer
+	start := func() {
+		buf.Reset()
+	}
+	err := c.walkSDRs(ctx, start, func(data []byte, nextRecordID uint16) (bool, error) {
+		buf.Write(data)
+		return false, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := buf.WriteTo(w); err != nil {
+		return fmt.Errorf("write sdr records failed, err: %w", err)
+	}
+	return nil
+}
+
+// ParseSDRFile parses the SDR records of the file written by `ipmitool sdr dump` or DumpSDRRepository.
+func ParseSDRFile(file string) ([]*SDR, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("open sdr file failed, err: %w", err)
+	}
+	defer f.Close()
+
+	sdrs, err := ParseSDRDump(f)
+	if err != nil {
+		return nil, fmt.Errorf("parse sdr file (%s) failed, err: %w", file, err)
+	}
+	return sdrs, nil
+}
+
+// ParseSDRDump parses the raw SDR records read from r.
+// The NextRecordID of each SDR is set to the Record ID of the following record, and FFFFh for the last one.
+func ParseSDRDump(r io.Reader) ([]*SDR, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read failed, err: %w", err)
+	}
+	return parseSDRRecords(data)
+}
+
+// SaveSEL writes the standard SEL records to w, like `ipmitool sel save`.
+// The OEM SEL records are skipped, because they can't be represented by the file format.
+func (c *Client) SaveSEL(w io.Writer) error {
+	return c.SaveSELContext(context.Background(), w)
+}
+
+// SaveSELContext is like SaveSEL but takes a context.
+func (c *Client) SaveSELContext(ctx context.Context, w io.Writer) error {
+	sels, err := c.GetSELEntriesContext(ctx, 0)
+	if err != nil {
+		return fmt.Errorf("GetSELEntries failed, err: %w", err)
+	}
+	return WriteSELFile(w, sels)
+}
+
+// WriteSELFile writes the standard SEL records to w in the format of `ipmitool sel save`.
+// The OEM SEL records are skipped.
+func WriteSELFile(w io.Writer, sels []*SEL) error {
+	bw := bufio.NewWriter(w)
+	for _, sel := range sels {
+		s := sel.Standard
+		if s == nil {
+			continue
+		}
+
+		eventType := uint8(s.EventReadingType)
+		if s.EventDir {
+			eventType |= 0x80
+		}
+		fmt.Fprintf(bw, "0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x # %s #0x%02x %s\n",
+			s.EvMRev, uint8(s.SensorType), uint8(s.SensorNumber), eventType,
+			s.EventData.EventData1, s.EventData.EventData2, s.EventData.EventData3,
+			s.SensorType, uint8(s.SensorNumber), s.EventString())
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("write sel records failed, err: %w", err)
+	}
+	return nil
+}
+
+// ParseSELFile parses the SEL records of the file written by `ipmitool sel save` or SaveSEL.
+func ParseSELFile(file string) ([]*SEL, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("open sel file failed, err: %w", err)
+	}
+	defer f.Close()
+
+	sels, err := ParseSELSave(f)
+	if err != nil {
+		return nil, fmt.Errorf("parse sel file (%s) failed, err: %w", file, err)
+	}
+	return sels, nil
+}
+
+// ParseSELSave parses the SEL records in the format of `ipmitool sel save` read from r.
+// The empty lines and the comments after '#' are skipped.
+//
+// The file only holds the event messages, so the records are standard SEL records
+// without timestamp and generator ID, and the Record IDs are numbered from 1 in the file order.
+func ParseSELSave(r io.Reader) ([]*SEL, error) {
+	sels := make([]*SEL, 0)
+
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 7 {
+			return nil, fmt.Errorf("line %d: expected 7 bytes, got %d", lineNo, len(fields))
+		}
+
+		var msg [7]uint8
+		for i, field := range fields {
+			v, err := strconv.ParseUint(field, 0, 8)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid byte (%s), err: %w", lineNo, field, err)
+			}
+			msg[i] = uint8(v)
+		}
+
+		sels = append(sels, &SEL{
+			RecordID:   uint16(len(sels) + 1),
+			RecordType: SELRecordType(0x02), // system event record
+			Standard: &SELStandard{
+				Timestamp:        parseTimestamp(0),
+				EvMRev:           msg[0],
+				SensorType:       SensorType(msg[1]),
+				SensorNumber:     SensorNumber(msg[2]),
+				EventDir:         EventDir(isBit7Set(msg[3])),
+				EventReadingType: EventReadingType(msg[3] & 0x7f),
+				EventData: EventData{
+					EventData1: msg[4],
+					EventData2: msg[5],
+					EventData3: msg[6],
+				},
+			},
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read failed, err: %w", err)
+	}
+
+	return sels, nil
+}
+
+// DumpFRU writes the raw FRU inventory data of the FRU device to w, like `ipmitool fru read`.
+func (c *Client) DumpFRU(deviceID uint8, w io.Writer) error {
+	return c.DumpFRUContext(context.Background(), deviceID, w)
+}
+
+// DumpFRUContext is like DumpFRU but takes a context.
+func (c *Client) DumpFRUContext(ctx context.Context, deviceID uint8, w io.Writer) error {
+	data, err := c.GetFRUDataContext(ctx, deviceID)
+	if err != nil {
+		return fmt.Errorf("GetFRUData failed, err: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("write fru data failed, err: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package ipmi
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseSDRDump(t *testing.T) {
+	t.Parallel()
+
+	dump := append(oemSDR(1, 0x01), oemSDR(3, 0x02, 0x03)...)
+	sdrs, err := ParseSDRDump(bytes.NewReader(dump))
+	if err != nil {
+		t.Fatalf("ParseSDRDump failed, err: %s", err)
+	}
+	if len(sdrs) != 2 {
+		t.Fatalf("expected 2 sdrs, got %d", len(sdrs))
+	}
+	if sdrs[0].RecordHeader.RecordID != 1 || sdrs[0].NextRecordID != 3 {
+		t.Errorf("unexpected first sdr, record id %d, next record id %#04x", sdrs[0].RecordHeader.RecordID, sdrs[0].NextRecordID)
+	}
+	if sdrs[1].NextRecordID != 0xffff || !bytes.Equal(sdrs[1].OEM.OEMData, []byte{0x02, 0x03}) {
+		t.Errorf("unexpected last sdr, next record id %#04x, data %x", sdrs[1].NextRecordID, sdrs[1].OEM.OEMData)
+	}
+
+	if _, err := ParseSDRDump(bytes.NewReader(dump[:len(dump)-1])); err == nil {
+		t.Errorf("expect error for truncated dump")
+	}
+}
+
+func TestParseSELSave(t *testing.T) {
+	t.Parallel()
+
+	// saved by ipmitool sel save
+	saved := `0x04 0x01 0x30 0x01 0x59 0x5b 0x5a # Temperature #0x30 Upper Non-critical going high
+0x04 0x08 0x72 0xef 0x01 0xff 0xff # Power Supply #0x72 Power Supply Failure detected
+
+0x04 0x0f 0x50 0x6f 0xa0 0x00 0x00 # System Firmwares #0x50 System Firmware Progress
+`
+	sels, err := ParseSELSave(strings.NewReader(saved))
+	if err != nil {
+		t.Fatalf("ParseSELSave failed, err: %s", err)
+	}
+	if len(sels) != 3 {
+		t.Fatalf("expected 3 sels, got %d", len(sels))
+	}
+	s := sels[1].Standard
+	if sels[1].RecordID != 2 || s.SensorType != SensorTypePowerSupply || s.SensorNumber != 0x72 ||
+		s.EventDir != EventDirDeassertion || s.EventReadingType != EventReadingTypeSensorSpecific || s.EventData.EventData1 != 0x01 {
+		t.Errorf("unexpected sel: %+v", s)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSELFile(&buf, sels); err != nil {
+		t.Fatalf("WriteSELFile failed, err: %s", err)
+	}
+	resaved, err := ParseSELSave(&buf)
+	if err != nil {
+		t.Fatalf("ParseSELSave of written file failed, err: %s", err)
+	}
+	for i := range sels {
+		if !bytes.Equal(resaved[i].Pack(), sels[i].Pack()) {
+			t.Errorf("sel %d not match, got %x, expected %x", i, resaved[i].Pack(), sels[i].Pack())
+		}
+	}
+
+	if _, err := ParseSELSave(strings.NewReader("0x04 0x01 0x30\n")); err == nil {
+		t.Errorf("expect error for short line")
+	}
+	if _, err := ParseSELSave(strings.NewReader("0x04 0x01 0x30 0x01 0x59 0x5b 0x100\n")); err == nil {
+		t.Errorf("expect error for invalid byte")
+	}
+}
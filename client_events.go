@@ -0,0 +1,187 @@
+package ipmi
+
+import (
+	"context"
+	"fmt"
+	"unsafe"
+
+	"github.com/bougou/go-ipmi/open"
+)
+
+// DefaultEventsBufferSize is the number of the received events buffered,
+// the events are dropped if not consumed in time.
+const DefaultEventsBufferSize int = 64
+
+// EventKind is the kind of the message received asynchronously by the open interface.
+type EventKind uint8
+
+const (
+	// EventKindEvent is the event message from the Event Message Buffer of the BMC.
+	EventKindEvent EventKind = iota
+	// EventKindCommand is the command request received from IPMB or other channels,
+	// it should be responded by RespondCommand.
+	EventKindCommand
+	// EventKindOEM is the OEM message.
+	EventKindOEM
+)
+
+func (kind EventKind) String() string {
+	switch kind {
+	case EventKindEvent:
+		return "event"
+	case EventKindCommand:
+		return "command"
+	case EventKindOEM:
+		return "oem"
+	}
+	return "unknown"
+}
+
+// EventMessage is a message received asynchronously by the open interface.
+type EventMessage struct {
+	Kind EventKind
+
+	// Channel, SourceAddr and SourceLUN are the address the message is received from.
+	// For the commands received from LAN channels, SourceAddr is the remote software ID.
+	Channel    uint8
+	SourceAddr uint8
+	SourceLUN  uint8
+
+	NetFn   NetFn
+	Command uint8
+	Data    []byte
+
+	// SEL is the event message in SEL record format, only for EventKindEvent.
+	SEL *SEL
+
+	addr  open.IPMI_ADDR
+	msgID int64
+}
+
+func (ev *EventMessage) Format() string {
+	if ev.SEL != nil && ev.SEL.Standard != nil {
+		return fmt.Sprintf("%s from channel %d, sensor: %s (%#02x), event: %s",
+			ev.Kind, ev.Channel, ev.SEL.Standard.SensorType, ev.SEL.Standard.SensorNumber, ev.SEL.Standard.EventString())
+	}
+	return fmt.Sprintf("%s from channel %d addr %#02x, netfn: %#02x, cmd: %#02x, data: % x",
+		ev.Kind, ev.Channel, ev.SourceAddr, ev.NetFn, ev.Command, ev.Data)
+}
+
+// Events returns the channel of the event messages and the command requests received
+// asynchronously from the ipmi device. It's only supported by the open interface.
+// The events are buffered since Connect, the events not consumed in time are dropped.
+// The channel is closed after the client is closed.
+func (c *Client) Events() (<-chan *EventMessage, error) {
+	if c.Interface != InterfaceOpen && c.Interface != "" {
+		return nil, fmt.Errorf("events are only supported by the open interface")
+	}
+	if c.openipmi == nil || c.openipmi.dev == nil {
+		return nil, fmt.Errorf("the open interface is not connected")
+	}
+
+	mux := c.openMux()
+
+	c.lock()
+	defer c.unlock()
+
+	if c.openipmi.events == nil {
+		events := make(chan *EventMessage, DefaultEventsBufferSize)
+		c.openipmi.events = events
+		go func() {
+			defer close(events)
+			for msg := range mux.Messages() {
+				ev := c.parseEvent(msg)
+				if ev == nil {
+					continue
+				}
+				select {
+				case events <- ev:
+				default:
+					c.DebugfYellow("drop event, the events channel is full\n")
+				}
+			}
+		}()
+	}
+
+	return c.openipmi.events, nil
+}
+
+// parseEvent converts the received message to EventMessage, it returns nil for the messages not surfaced.
+func (c *Client) parseEvent(msg *open.Message) *EventMessage {
+	ev := &EventMessage{
+		Channel: uint8(msg.Addr.Channel),
+		NetFn:   NetFn(msg.NetFn),
+		Command: msg.Cmd,
+		Data:    msg.Data,
+		addr:    msg.Addr,
+		msgID:   msg.MsgID,
+	}
+
+	switch msg.Addr.AddrType {
+	case open.IPMI_IPMB_ADDR_TYPE, open.IPMI_IPMB_BROADCAST_ADDR_TYPE:
+		addr := msg.Addr.IPMBAddr()
+		ev.SourceAddr, ev.SourceLUN = addr.SlaveAddr, addr.LUN
+	case open.IPMI_LAN_ADDR_TYPE:
+		addr := msg.Addr.LANAddr()
+		ev.SourceAddr, ev.SourceLUN = addr.RemoteSWID, addr.LUN
+	}
+
+	switch msg.RecvType {
+	case open.IPMI_ASYNC_EVENT_RECV_TYPE:
+		ev.Kind = EventKindEvent
+		sel, err := ParseSEL(msg.Data)
+		if err != nil {
+			c.DebugfRed("parse event message failed, err: %s\n", err)
+		}
+		ev.SEL = sel
+	case open.IPMI_CMD_RECV_TYPE:
+		ev.Kind = EventKindCommand
+	case open.IPMI_OEM_RECV_TYPE:
+		ev.Kind = EventKindOEM
+	default:
+		// like IPMI_RESPONSE_RESPONSE_TYPE, the result of RespondCommand
+		return nil
+	}
+
+	c.Debug("<< Event", ev)
+	return ev
+}
+
+// RegisterForCommand asks the driver to deliver the command requests of netFn and cmd
+// received by the BMC to Events.
+func (c *Client) RegisterForCommand(netFn NetFn, cmd uint8) error {
+	if c.openipmi == nil || c.openipmi.dev == nil {
+		return fmt.Errorf("the open interface is not connected")
+	}
+	spec := open.IPMI_CMDSPEC{
+		NetFn: uint8(netFn),
+		Cmd:   cmd,
+	}
+	if err := c.openipmi.dev.Ioctl(open.IPMICTL_REGISTER_FOR_CMD, unsafe.Pointer(&spec)); err != nil {
+		return fmt.Errorf("ioctl failed, could not register for command, err: %w", err)
+	}
+	return nil
+}
+
+// RespondCommand sends the response of the command request received by Events.
+func (c *Client) RespondCommand(ctx context.Context, ev *EventMessage, completionCode CompletionCode, data []byte) error {
+	if ev.Kind != EventKindCommand {
+		return fmt.Errorf("only the command requests can be responded")
+	}
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("respond aborted, err: %w", err)
+	}
+
+	resData := append([]byte{uint8(completionCode)}, data...)
+	msg := open.IPMI_MSG{
+		NetFn:   uint8(ev.NetFn) | 0x01, // the response netfn is odd
+		Cmd:     ev.Command,
+		Data:    &resData[0],
+		DataLen: uint16(len(resData)),
+	}
+	req := open.NewReq(&ev.addr, ev.msgID, msg)
+	if err := c.openMux().Send(req); err != nil {
+		return fmt.Errorf("send response failed, err: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package ipmi
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// redactedCommands are the commands whose request or response data carry passwords or keys,
+// only the length of their data is logged.
+var redactedCommands = []Command{
+	CommandSetUserPassword,
+	CommandSetChannelSecurityKeys,
+}
+
+// ExchangeInfo describes an exchange passed to the interceptors.
+// Command and Request are set before the exchange, the others are set after the exchange returns.
+type ExchangeInfo struct {
+	Command Command
+
+	// Request is the request data.
+	Request []byte
+
+	// Response is the response data without the completion code, it's nil if no response data is unpacked.
+	Response []byte
+
+	// CompletionCode is the completion code of the response,
+	// it's CompletionCodeNormal if the exchange succeeded or failed without a response.
+	CompletionCode CompletionCode
+
+	Latency time.Duration
+	Err     error
+}
+
+// Interceptor is called around each exchange of the client, like tracing each exchange as a span.
+// It must call next to do the exchange, and the ctx passed to next is used by the exchange.
+// The fields of info filled by the exchange are available after next returns.
+//
+//	client.WithInterceptors(func(ctx context.Context, info *ipmi.ExchangeInfo, next func(context.Context) error) error {
+//		ctx, span := tracer.Start(ctx, info.Command.Name)
+//		defer span.End()
+//		return next(ctx)
+//	})
+type Interceptor func(ctx context.Context, info *ExchangeInfo, next func(ctx context.Context) error) error
+
+// WithInterceptors appends the interceptors of the client, the first one is the outermost.
+func (c *Client) WithInterceptors(interceptors ...Interceptor) *Client {
+	c.interceptors = append(c.interceptors, interceptors...)
+	return c
+}
+
+// exchangeIntercepted exchanges by the transport through the interceptors, then logs and measures the exchange.
+func (c *Client) exchangeIntercepted(ctx context.Context, transport Transport, request Request, response Response) error {
+	info := &ExchangeInfo{
+		Command: request.Command(),
+		Request: request.Pack(),
+	}
+
+	next := func(ctx context.Context) error {
+		recorder := &responseRecorder{Response: response}
+		start := time.Now()
+		err := transport.Exchange(ctx, request, recorder)
+
+		info.Latency = time.Since(start)
+		info.Err = err
+		info.Response = nil
+		if recorder.unpacked {
+			info.Response = recorder.data
+		}
+		info.CompletionCode = CompletionCodeNormal
+		var respErr *ResponseError
+		if errors.As(err, &respErr) {
+			info.CompletionCode = respErr.CompletionCode()
+		}
+		return err
+	}
+	for i := len(c.interceptors) - 1; i >= 0; i-- {
+		interceptor, inner := c.interceptors[i], next
+		next = func(ctx context.Context) error {
+			return interceptor(ctx, info, inner)
+		}
+	}
+
+	err := next(ctx)
+
+	if c.metrics != nil {
+		c.metrics.ObserveExchange(c.Host, info.Command, info.CompletionCode, info.Latency, err)
+		if isTimeoutError(err) {
+			c.metrics.ObserveTimeout(c.Host, info.Command)
+		}
+	}
+
+	if c.logger != nil {
+		args := []any{
+			"command", info.Command.Name,
+			"netfn", uint8(info.Command.NetFn),
+			"cmd", info.Command.ID,
+			"completion_code", uint8(info.CompletionCode),
+			"latency", info.Latency,
+			"request", logData(info.Command, info.Request),
+			"response", logData(info.Command, info.Response),
+		}
+		if err != nil {
+			args = append(args, "error", err.Error())
+		}
+		c.logger.Debug("ipmi exchange", args...)
+	}
+	return err
+}
+
+// logData returns the hex encoded data of the command for logging,
+// the data of the redacted commands is replaced by its length.
+func logData(cmd Command, data []byte) string {
+	for _, redacted := range redactedCommands {
+		if cmd.ID == redacted.ID && cmd.NetFn == redacted.NetFn {
+			return fmt.Sprintf("<redacted %d bytes>", len(data))
+		}
+	}
+	return hex.EncodeToString(data)
+}
@@ -0,0 +1,136 @@
+package ipmi
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+// recordLogger keeps the logged messages.
+type recordLogger struct {
+	messages []string
+	args     [][]any
+}
+
+func (l *recordLogger) Debug(msg string, args ...any) {
+	l.messages = append(l.messages, msg)
+	l.args = append(l.args, args)
+}
+
+func Test_WithInterceptors(t *testing.T) {
+	transport := &rawTransport{
+		replies: map[Command][]byte{
+			{ID: CommandGetDeviceID.ID, NetFn: CommandGetDeviceID.NetFn}: {
+				0x00, 0x20, 0x81, 0x01, 0x10, 0x02, 0xbf, 0x57, 0x01, 0x00, 0x34, 0x12,
+			},
+		},
+	}
+	client, err := NewClientWithTransport(transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type ctxKey struct{}
+	var calls []string
+	var infos []ExchangeInfo
+	client.WithInterceptors(
+		func(ctx context.Context, info *ExchangeInfo, next func(ctx context.Context) error) error {
+			calls = append(calls, "outer")
+			return next(context.WithValue(ctx, ctxKey{}, "span"))
+		},
+		func(ctx context.Context, info *ExchangeInfo, next func(ctx context.Context) error) error {
+			calls = append(calls, fmt.Sprintf("inner %v", ctx.Value(ctxKey{})))
+			err := next(ctx)
+			infos = append(infos, *info)
+			return err
+		},
+	)
+
+	if _, err := client.GetDeviceID(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetSELInfo(); err == nil {
+		t.Fatal("expect GetSELInfo failed")
+	}
+
+	if len(calls) != 4 || calls[0] != "outer" || calls[1] != "inner span" {
+		t.Errorf("interceptors not called in order, got: %v", calls)
+	}
+	if len(infos) != 2 {
+		t.Fatalf("expect 2 exchanges, got: %d", len(infos))
+	}
+	if infos[0].Command != CommandGetDeviceID || len(infos[0].Response) != 11 || infos[0].Err != nil {
+		t.Errorf("exchange info not match, got: %+v", infos[0])
+	}
+	if infos[1].Command != CommandGetSELInfo || infos[1].CompletionCode != CompletionCodeInvalidCommand || infos[1].Err == nil {
+		t.Errorf("exchange info not match, got: %+v", infos[1])
+	}
+}
+
+func Test_WithLogger(t *testing.T) {
+	transport := &rawTransport{}
+	client, err := NewClientWithTransport(transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := &recordLogger{}
+	client.WithLogger(logger)
+
+	// the debug messages are not sent to the logger unless debug is enabled
+	client.DebugfRed("session %s\n", "closed")
+	if len(logger.messages) != 0 {
+		t.Fatalf("expect no messages, got: %v", logger.messages)
+	}
+
+	client.WithDebug(true)
+	client.DebugfRed("session %s\n", "closed")
+	client.DebugBytes("rmcp", []byte{0x06, 0x00, 0xff, 0x07}, 16)
+	if _, err := client.GetSELInfo(); err == nil {
+		t.Fatal("expect GetSELInfo failed")
+	}
+
+	if len(logger.messages) != 3 {
+		t.Fatalf("expect 3 messages, got: %v", logger.messages)
+	}
+	if logger.messages[0] != "session closed" {
+		t.Errorf("message not match, got: %q", logger.messages[0])
+	}
+	if fmt.Sprint(logger.args[1]) != "[length 4 bytes 0600ff07]" {
+		t.Errorf("args not match, got: %v", logger.args[1])
+	}
+
+	args := map[any]any{}
+	for i := 0; i+1 < len(logger.args[2]); i += 2 {
+		args[logger.args[2][i]] = logger.args[2][i+1]
+	}
+	if args["command"] != CommandGetSELInfo.Name || args["completion_code"] != uint8(CompletionCodeInvalidCommand) || args["error"] == nil {
+		t.Errorf("exchange log not match, got: %v", logger.args[2])
+	}
+}
+
+func Test_WithLogger_Redacted(t *testing.T) {
+	transport := &rawTransport{
+		replies: map[Command][]byte{
+			{ID: CommandSetUserPassword.ID, NetFn: CommandSetUserPassword.NetFn}: {0x00},
+		},
+	}
+	client, err := NewClientWithTransport(transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := &recordLogger{}
+	client.WithLogger(logger)
+
+	if _, err := client.SetUserPassword(2, "secret", false); err != nil {
+		t.Fatal(err)
+	}
+
+	args := map[any]any{}
+	exchangeArgs := logger.args[len(logger.args)-1]
+	for i := 0; i+1 < len(exchangeArgs); i += 2 {
+		args[exchangeArgs[i]] = exchangeArgs[i+1]
+	}
+	if args["command"] != CommandSetUserPassword.Name || args["request"] != "<redacted 18 bytes>" {
+		t.Errorf("exchange log not redacted, got: %v", exchangeArgs)
+	}
+}
@@ -0,0 +1,15 @@
+package ipmi
+
+// Logger receives the debug messages of the client as structured logs.
+// The args are alternating keys and values, like log/slog, so a *slog.Logger can be used directly.
+type Logger interface {
+	Debug(msg string, args ...any)
+}
+
+// WithLogger sets the logger of the client. Once set, each exchange is logged with
+// the command, netfn, completion code, latency and the request and response data,
+// and the debug messages enabled by WithDebug are sent to the logger instead of printed to stdout.
+func (c *Client) WithLogger(logger Logger) *Client {
+	c.logger = logger
+	return c
+}
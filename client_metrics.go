@@ -0,0 +1,51 @@
+package ipmi
+
+import (
+	"time"
+)
+
+// Metrics receives the measurements of the exchanges and sessions of the client,
+// like the adapter in the metrics/prometheus package. The host is the Host of the client.
+// The implementations must be safe for concurrent use.
+type Metrics interface {
+	// ObserveExchange is called after each exchange. The completionCode is the completion code of the response,
+	// it's CompletionCodeNormal if the exchange succeeded or failed without a response.
+	ObserveExchange(host string, command Command, completionCode CompletionCode, latency time.Duration, err error)
+
+	// ObserveTimeout is called if no response of the request is received in time.
+	ObserveTimeout(host string, command Command)
+
+	// ObserveRetransmission is called each time a lan/lanplus request is retransmitted.
+	ObserveRetransmission(host string, command Command)
+
+	// ObserveReconnect is called each time a lost lan/lanplus session is re-established,
+	// err is the error of the re-establishment.
+	ObserveReconnect(host string, err error)
+
+	// ObserveCipherSuite is called when a lanplus session is established with the cipher suite.
+	ObserveCipherSuite(host string, cipherSuiteID CipherSuiteID)
+}
+
+// WithMetrics sets the metrics of the client.
+func (c *Client) WithMetrics(metrics Metrics) *Client {
+	c.metrics = metrics
+	return c
+}
+
+func (c *Client) observeRetransmission(command Command) {
+	if c.metrics != nil {
+		c.metrics.ObserveRetransmission(c.Host, command)
+	}
+}
+
+func (c *Client) observeReconnect(err error) {
+	if c.metrics != nil {
+		c.metrics.ObserveReconnect(c.Host, err)
+	}
+}
+
+func (c *Client) observeCipherSuite(cipherSuiteID CipherSuiteID) {
+	if c.metrics != nil {
+		c.metrics.ObserveCipherSuite(c.Host, cipherSuiteID)
+	}
+}
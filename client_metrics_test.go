@@ -0,0 +1,75 @@
+package ipmi
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordMetrics keeps the observed measurements.
+type recordMetrics struct {
+	mu              sync.Mutex
+	exchanges       []Command
+	completionCodes []CompletionCode
+	timeouts        int
+	reconnects      []error
+}
+
+func (m *recordMetrics) ObserveExchange(host string, command Command, completionCode CompletionCode, latency time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.exchanges = append(m.exchanges, command)
+	m.completionCodes = append(m.completionCodes, completionCode)
+}
+
+func (m *recordMetrics) ObserveTimeout(host string, command Command) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.timeouts++
+}
+
+func (m *recordMetrics) ObserveRetransmission(host string, command Command) {}
+
+func (m *recordMetrics) ObserveReconnect(host string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reconnects = append(m.reconnects, err)
+}
+
+func (m *recordMetrics) ObserveCipherSuite(host string, cipherSuiteID CipherSuiteID) {}
+
+func Test_WithMetrics(t *testing.T) {
+	transport := &rawTransport{
+		replies: map[Command][]byte{
+			{ID: CommandGetDeviceID.ID, NetFn: CommandGetDeviceID.NetFn}: {
+				0x00, 0x20, 0x81, 0x01, 0x10, 0x02, 0xbf, 0x57, 0x01, 0x00, 0x34, 0x12,
+			},
+		},
+	}
+	client, err := NewClientWithTransport(transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	metrics := &recordMetrics{}
+	client.WithMetrics(metrics)
+
+	if _, err := client.GetDeviceID(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetSELInfo(); err == nil {
+		t.Fatal("expect GetSELInfo failed")
+	}
+
+	if len(metrics.exchanges) != 2 {
+		t.Fatalf("expect 2 exchanges, got: %d", len(metrics.exchanges))
+	}
+	if metrics.exchanges[0] != CommandGetDeviceID || metrics.completionCodes[0] != CompletionCodeNormal {
+		t.Errorf("exchange not match, got: %v %v", metrics.exchanges[0], metrics.completionCodes[0])
+	}
+	if metrics.exchanges[1] != CommandGetSELInfo || metrics.completionCodes[1] != CompletionCodeInvalidCommand {
+		t.Errorf("exchange not match, got: %v %v", metrics.exchanges[1], metrics.completionCodes[1])
+	}
+	if metrics.timeouts != 0 {
+		t.Errorf("expect no timeouts, got: %d", metrics.timeouts)
+	}
+}
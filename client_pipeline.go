@@ -0,0 +1,416 @@
+package ipmi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// MaxPipelineWindow is the max number of concurrent in-flight requests on one session.
+// The IPMI Requester Sequence number occupies only 6 bits, and 0 is not used.
+const MaxPipelineWindow = int(IPMIRequesterSequenceMax) - 1
+
+// The demultiplexing keys of the replies which are not IPMI payloads.
+// The keys of IPMI payloads are the Requester Sequence numbers.
+const (
+	pipelineKeySessionSetup uint16 = 0x100 // | response payload type
+	pipelineKeyASF          uint16 = 0x200
+	pipelineKeySOL          uint16 = 0x300
+)
+
+const (
+	// pipelineReadBackoff is the delay after a failed read of the pipeline reader,
+	// it's doubled for each consecutive failure.
+	pipelineReadBackoff = 10 * time.Millisecond
+	// pipelineReadMaxErrors is the number of consecutive failed reads after which the pipeline reader stops.
+	pipelineReadMaxErrors = 8
+)
+
+// pipeline allows several lan/lanplus requests to be in flight on one session.
+// A single reader goroutine receives all the replies and dispatches them
+// to the waiting requests by the IPMI Requester Sequence number.
+type pipeline struct {
+	// window is a semaphore limiting the number of in-flight requests.
+	window chan struct{}
+
+	// sendMu serializes building, registering and sending the requests,
+	// so that a Requester Sequence number is never used by two in-flight requests.
+	sendMu sync.Mutex
+
+	mu      sync.Mutex
+	pending map[uint16]chan []byte
+	// conn is the udp connection the reader goroutine is reading from.
+	conn   net.Conn
+	reader *pipelineReader
+}
+
+// pipelineReader is the reader goroutine of one udp connection.
+type pipelineReader struct {
+	// done is closed if the reader stops because of the read errors, err is the last read error.
+	done chan struct{}
+	err  error
+
+	// stopped is set by pipeline.stop, exited is closed when the reader goroutine returns.
+	stopped bool
+	exited  chan struct{}
+}
+
+func newPipeline(window int) *pipeline {
+	return &pipeline{
+		window:  make(chan struct{}, window),
+		pending: make(map[uint16]chan []byte),
+	}
+}
+
+// WithPipelineWindow allows at most window requests to be in flight
+// concurrently on one lan/lanplus session. The replies are matched to the requests
+// by the IPMI Requester Sequence number.
+//
+// The default window 1 disables pipelining, that is the requests are sent one by one.
+// The window should be set before calling Connect.
+func (c *Client) WithPipelineWindow(window int) *Client {
+	if window > MaxPipelineWindow {
+		window = MaxPipelineWindow
+	}
+	if window <= 1 {
+		c.pipeline = nil
+		return c
+	}
+	c.pipeline = newPipeline(window)
+	return c
+}
+
+// pipelineWindow returns the number of requests that can be in flight concurrently.
+func (c *Client) pipelineWindow() int {
+	if c.pipeline == nil {
+		return 1
+	}
+	return cap(c.pipeline.window)
+}
+
+func (p *pipeline) acquire(ctx context.Context) error {
+	select {
+	case p.window <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("wait for pipeline window aborted, err: %w", ctx.Err())
+	}
+}
+
+func (p *pipeline) release() {
+	<-p.window
+}
+
+func (p *pipeline) isPending(key uint16) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, ok := p.pending[key]
+	return ok
+}
+
+func (p *pipeline) register(key uint16) (chan []byte, error) {
+	return p.registerBuffered(key, 4)
+}
+
+// registerBuffered is like register, but the returned channel buffers size messages,
+// it's used for the streams like SOL which receive many messages.
+func (p *pipeline) registerBuffered(key uint16, size int) (chan []byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.pending[key]; ok {
+		return nil, fmt.Errorf("a request with the same key (%#04x) is already in flight", key)
+	}
+	ch := make(chan []byte, size)
+	p.pending[key] = ch
+	return ch, nil
+}
+
+func (p *pipeline) unregister(key uint16) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.pending, key)
+}
+
+// dispatch delivers the received message to the request waiting for key.
+// The message is dropped if no request is waiting or the waiter is falling behind.
+func (p *pipeline) dispatch(key uint16, msg []byte) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ch, ok := p.pending[key]
+	if !ok {
+		return false
+	}
+	select {
+	case ch <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+// startReader starts the reader goroutine for the current udp connection if it is not started yet,
+// and returns the reader of the connection.
+func (p *pipeline) startReader(c *Client, conn net.Conn) *pipelineReader {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn == conn {
+		return p.reader
+	}
+	reader := &pipelineReader{done: make(chan struct{}), exited: make(chan struct{})}
+	p.conn = conn
+	p.reader = reader
+
+	go func() {
+		defer close(reader.exited)
+
+		// a zero deadline means Read does not time out
+		_ = conn.SetReadDeadline(time.Time{})
+
+		buf := make([]byte, c.bufferSize)
+		var failures int
+		for {
+			n, err := conn.Read(buf)
+			if err != nil {
+				if errors.Is(err, net.ErrClosed) {
+					c.Debugf("udp connection closed, pipeline reader stopped\n")
+					return
+				}
+				if p.isStopped(reader) {
+					c.Debugf("pipeline reader stopped\n")
+					return
+				}
+				c.DebugfRed("pipeline reader read failed, err: %s\n", err)
+
+				failures++
+				if failures >= pipelineReadMaxErrors {
+					p.stopReader(conn, reader, err)
+					return
+				}
+				time.Sleep(pipelineReadBackoff << (failures - 1))
+				continue
+			}
+			failures = 0
+
+			msg := make([]byte, n)
+			copy(msg, buf[:n])
+			c.DebugBytes("recv", msg, 16)
+
+			key, err := c.pipelineKey(msg)
+			if err != nil {
+				c.DebugfRed("drop undecodable response, err: %s\n", err)
+				continue
+			}
+			if !p.dispatch(key, msg) {
+				c.DebugfYellow("drop unexpected response of key (%#04x)\n", key)
+			}
+		}
+	}()
+
+	return reader
+}
+
+// stopReader stops the reader of conn because of the read error err, the waiting requests fail with err,
+// and the next request starts a new reader.
+func (p *pipeline) stopReader(conn net.Conn, reader *pipelineReader, err error) {
+	p.mu.Lock()
+	if p.conn == conn {
+		p.conn = nil
+		p.reader = nil
+	}
+	p.mu.Unlock()
+
+	reader.err = err
+	close(reader.done)
+}
+
+// stop stops the reader goroutine and waits until it returns.
+// The udp connection is left open, so it can be used by the non-pipelined exchanges.
+func (p *pipeline) stop() {
+	p.mu.Lock()
+	conn, reader := p.conn, p.reader
+	p.conn = nil
+	p.reader = nil
+	if reader != nil {
+		reader.stopped = true
+	}
+	p.mu.Unlock()
+
+	if reader == nil {
+		return
+	}
+	// moving the read deadline to now unblocks the pending Read
+	_ = conn.SetReadDeadline(time.Now())
+	<-reader.exited
+}
+
+func (p *pipeline) isStopped(reader *pipelineReader) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return reader.stopped
+}
+
+// key returns the demultiplexing key of the reply of the expected request.
+func (expect *rmcpExpect) key() uint16 {
+	switch expect.payloadType {
+	case PayloadTypeIPMI:
+		return uint16(expect.rqSeq)
+	default:
+		return pipelineKeySessionSetup | uint16(expect.payloadType+1)
+	}
+}
+
+// pipelineKey decodes the received message just enough to get its demultiplexing key.
+func (c *Client) pipelineKey(msg []byte) (uint16, error) {
+	rmcp := &Rmcp{}
+	if err := rmcp.Unpack(msg); err != nil {
+		return 0, fmt.Errorf("unpack rmcp failed, err: %w", err)
+	}
+
+	if rmcp.ASF != nil {
+		return pipelineKeyASF, nil
+	}
+
+	var ipmiPayload []byte
+
+	if rmcp.Session15 != nil {
+		ipmiPayload = rmcp.Session15.Payload
+	}
+
+	if rmcp.Session20 != nil {
+		sessionHdr := rmcp.Session20.SessionHeader20
+		switch sessionHdr.PayloadType {
+		case PayloadTypeRmcpOpenSessionResponse, PayloadTypeRAKPMessage2, PayloadTypeRAKPMessage4:
+			return pipelineKeySessionSetup | uint16(sessionHdr.PayloadType), nil
+
+		case PayloadTypeSOL:
+			return pipelineKeySOL, nil
+
+		case PayloadTypeIPMI:
+			ipmiPayload = rmcp.Session20.SessionPayload
+			if sessionHdr.PayloadEncrypted {
+				d, err := c.decryptPayload(ipmiPayload)
+				if err != nil {
+					return 0, fmt.Errorf("decrypt session payload failed, err: %w", err)
+				}
+				ipmiPayload = d
+			}
+
+		default:
+			return 0, fmt.Errorf("not supported payload type (%s)", sessionHdr.PayloadType)
+		}
+	}
+
+	ipmiRes := IPMIResponse{}
+	if err := ipmiRes.Unpack(ipmiPayload); err != nil {
+		return 0, fmt.Errorf("unpack ipmiRes failed, err: %w", err)
+	}
+	return uint16(ipmiRes.RequesterSequence), nil
+}
+
+// exchangeLANPipelined is like exchangeLAN, but other requests can be in flight
+// while waiting for the reply of this request.
+func (c *Client) exchangeLANPipelined(ctx context.Context, request Request, response Response) error {
+	p := c.pipeline
+
+	if err := p.acquire(ctx); err != nil {
+		return err
+	}
+	defer p.release()
+
+	conn, err := c.udpClient.getConn()
+	if err != nil {
+		return fmt.Errorf("init udp connection failed, err: %w", err)
+	}
+	reader := p.startReader(c, conn)
+
+	c.Debug(">> Command Request", request)
+
+	p.sendMu.Lock()
+	payloadType, rawPayload, err := c.buildRawPayload(request)
+	if err != nil {
+		p.sendMu.Unlock()
+		return fmt.Errorf("build RMCP+ request msg failed, err: %w", err)
+	}
+	c.DebugBytes("rawPayload", rawPayload, 16)
+	expect := newRmcpExpect(payloadType, rawPayload)
+	key := expect.key()
+	if _, ok := request.(*RmcpPingRequest); ok {
+		key = pipelineKeyASF
+	}
+	recvCh, err := p.register(key)
+	p.sendMu.Unlock()
+	if err != nil {
+		return err
+	}
+	defer p.unregister(key)
+
+	attemptTimeout := c.attemptTimeout
+	if attemptTimeout <= 0 {
+		attemptTimeout = c.timeout
+	}
+
+	attempts := c.retries + 1
+	for attempt := 1; ; attempt++ {
+		err = c.exchangeLANPipelinedOnce(ctx, conn, reader, request, response, payloadType, rawPayload, expect, recvCh, attemptTimeout)
+		if err == nil || !isTimeoutError(err) || ctx.Err() != nil {
+			return err
+		}
+		if attempt >= attempts {
+			return fmt.Errorf("%w: no response after %d attempts, err: %w", ErrTimeout, attempts, err)
+		}
+		c.DebugfYellow("no response in %s, retransmit (%d/%d)\n", attemptTimeout, attempt, c.retries)
+		c.observeRetransmission(request.Command())
+	}
+}
+
+func (c *Client) exchangeLANPipelinedOnce(ctx context.Context, conn net.Conn, reader *pipelineReader, request Request, response Response, payloadType PayloadType, rawPayload []byte, expect *rmcpExpect, recvCh chan []byte, timeout time.Duration) error {
+	c.pipeline.sendMu.Lock()
+	rmcp, err := c.buildRmcp(request, payloadType, rawPayload)
+	if err != nil {
+		c.pipeline.sendMu.Unlock()
+		return fmt.Errorf("build RMCP+ request msg failed, err: %w", err)
+	}
+	c.Debug(">>>>>> RMCP Request", rmcp)
+	sent := rmcp.Pack()
+	c.DebugBytes("sent", sent, 16)
+	_, err = conn.Write(sent)
+	c.pipeline.sendMu.Unlock()
+	if err != nil {
+		return fmt.Errorf("write to conn failed, err: %w", err)
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("canceled from caller, err: %w", ctx.Err())
+
+		case <-timer.C:
+			return fmt.Errorf("client udp exchange msg failed, err: %w", os.ErrDeadlineExceeded)
+
+		case <-reader.done:
+			return fmt.Errorf("pipeline reader stopped, err: %w", reader.err)
+
+		case recv := <-recvCh:
+			err := c.parseRmcpResponse(recv, response, expect)
+			if err == errUnmatchedResponse {
+				c.DebugfYellow("drop unmatched response\n")
+				continue
+			}
+			if err != nil {
+				// Warn, must directly return err. (DO NOT wrap err to another error)
+				// The error returned by parseRmcpResponse might be of *ResponseError type.
+				return err
+			}
+			c.Debug("<< Command Response", response)
+			return nil
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package ipmi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test_exchangeLANPipelined(t *testing.T) {
+	const window = 8
+
+	server, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("listen udp failed, err: %s", err)
+	}
+	defer server.Close()
+
+	// The server waits until all the requests are in flight,
+	// then replies them in the reverse order, echoing rqSeq in the response data.
+	go func() {
+		var msgs [][]byte
+		var addr *net.UDPAddr
+		for len(msgs) < window {
+			buf := make([]byte, 1024)
+			n, a, err := server.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			msgs = append(msgs, buf[:n])
+			addr = a
+		}
+		for i := len(msgs) - 1; i >= 0; i-- {
+			rqSeq := msgs[i][14+4] >> 2
+			_, _ = server.WriteToUDP(buildLAN15Response(msgs[i], rqSeq, []byte{rqSeq, 0x00}), addr)
+		}
+	}()
+
+	addr := server.LocalAddr().(*net.UDPAddr)
+	client, err := NewClient(addr.IP.String(), addr.Port, "user", "pass")
+	if err != nil {
+		t.Fatalf("new client failed, err: %s", err)
+	}
+	client.WithInterface(InterfaceLan).WithTimeout(5 * time.Second).WithPipelineWindow(window)
+	client.v20 = false
+	defer client.udpClient.Close()
+
+	var wg sync.WaitGroup
+	results := make([]*GetSelfTestResultsResponse, window)
+	errs := make([]error, window)
+	for i := 0; i < window; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = client.GetSelfTestResults()
+		}(i)
+	}
+	wg.Wait()
+
+	seen := map[uint8]bool{}
+	for i := 0; i < window; i++ {
+		if errs[i] != nil {
+			t.Fatalf("GetSelfTestResults failed, err: %s", errs[i])
+		}
+		if seen[results[i].Byte1] {
+			t.Errorf("response of rqSeq (%d) delivered twice", results[i].Byte1)
+		}
+		seen[results[i].Byte1] = true
+	}
+}
+
+func Test_exchangeLANPipelined_Lanplus(t *testing.T) {
+	const window = 8
+	const consoleSessionID uint32 = 0x0a0b0c0d
+	const bmcSessionID uint32 = 0x01020304
+
+	server, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("listen udp failed, err: %s", err)
+	}
+	defer server.Close()
+
+	// both sides of the session share the keys, and the BMC side encrypts
+	// and authenticates its replies with the session id of the console.
+	activate := func(c *Client, sessionID uint32) {
+		c.session.v20.state = SessionStateActive
+		c.session.v20.bmcSessionID = sessionID
+		c.session.v20.integrityAlg = IntegrityAlg_HMAC_SHA1_96
+		c.session.v20.cryptAlg = CryptAlg_AES_CBC_128
+		c.session.v20.k1 = bytes.Repeat([]byte{0x11}, 20)
+		c.session.v20.k2 = bytes.Repeat([]byte{0x22}, 20)
+	}
+	bmc, err := NewClient("127.0.0.1", 623, "user", "pass")
+	if err != nil {
+		t.Fatalf("new client failed, err: %s", err)
+	}
+	activate(bmc, consoleSessionID)
+
+	// The server waits until all the requests are in flight,
+	// then replies them in the reverse order, echoing rqSeq in the response data.
+	go func() {
+		var reqs [][]byte
+		var addr *net.UDPAddr
+		for len(reqs) < window {
+			buf := make([]byte, 1024)
+			n, a, err := server.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			rmcp := &Rmcp{}
+			if err := rmcp.Unpack(buf[:n]); err != nil || rmcp.Session20 == nil {
+				continue
+			}
+			ipmiReq, err := bmc.decryptPayload(rmcp.Session20.SessionPayload)
+			if err != nil {
+				continue
+			}
+			reqs = append(reqs, ipmiReq)
+			addr = a
+		}
+		for i := len(reqs) - 1; i >= 0; i-- {
+			rqSeq := reqs[i][4] >> 2
+			// reuse the lan builder by prepending a dummy IPMI v1.5 session header
+			payload := buildLAN15Response(append(make([]byte, 14), reqs[i]...), rqSeq, []byte{rqSeq, 0x00})[14:]
+			rmcp, err := bmc.buildRmcp(&GetSelfTestResultsRequest{}, PayloadTypeIPMI, payload)
+			if err != nil {
+				return
+			}
+			_, _ = server.WriteToUDP(rmcp.Pack(), addr)
+		}
+	}()
+
+	addr := server.LocalAddr().(*net.UDPAddr)
+	client, err := NewClient(addr.IP.String(), addr.Port, "user", "pass")
+	if err != nil {
+		t.Fatalf("new client failed, err: %s", err)
+	}
+	client.WithTimeout(5 * time.Second).WithPipelineWindow(window).WithReconnectPolicy(&ReconnectPolicy{})
+	activate(client, bmcSessionID)
+	client.session.v20.consoleSessionID = consoleSessionID
+	defer client.udpClient.Close()
+
+	var wg sync.WaitGroup
+	results := make([]*GetSelfTestResultsResponse, window)
+	errs := make([]error, window)
+	for i := 0; i < window; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = client.GetSelfTestResults()
+		}(i)
+	}
+	wg.Wait()
+
+	seen := map[uint8]bool{}
+	for i := 0; i < window; i++ {
+		if errs[i] != nil {
+			t.Fatalf("GetSelfTestResults failed, err: %s", errs[i])
+		}
+		if seen[results[i].Byte1] {
+			t.Errorf("response of rqSeq (%d) delivered twice", results[i].Byte1)
+		}
+		seen[results[i].Byte1] = true
+	}
+}
+
+func Test_sdrsToSensors_StopOnError(t *testing.T) {
+	server, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("listen udp failed, err: %s", err)
+	}
+	defer server.Close()
+
+	// the server fails all the Get Sensor Reading requests
+	var readings int32
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			n, addr, err := server.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			if buf[14+5] == CommandGetSensorReading.ID {
+				atomic.AddInt32(&readings, 1)
+			}
+			rqSeq := buf[14+4] >> 2
+			res := buildLAN15Response(buf[:n], rqSeq, nil)
+			// set the completion code, and fix the checksum
+			res[14+6] = uint8(CompletionCodeUnspecifiedError)
+			res[len(res)-1] -= uint8(CompletionCodeUnspecifiedError)
+			_, _ = server.WriteToUDP(res, addr)
+		}
+	}()
+
+	addr := server.LocalAddr().(*net.UDPAddr)
+	client, err := NewClient(addr.IP.String(), addr.Port, "user", "pass")
+	if err != nil {
+		t.Fatalf("new client failed, err: %s", err)
+	}
+	client.WithInterface(InterfaceLan).WithTimeout(5 * time.Second)
+	client.v20 = false
+	defer client.udpClient.Close()
+
+	var sdrs []*SDR
+	for i := 1; i <= 4; i++ {
+		sdrs = append(sdrs, &SDR{
+			RecordHeader: &SDRHeader{RecordID: uint16(i), RecordType: SDRRecordTypeFullSensor},
+			Full:         &SDRFull{SensorNumber: SensorNumber(i)},
+		})
+	}
+
+	if _, err := client.sdrsToSensors(context.Background(), sdrs); err == nil {
+		t.Error("expect error when the sensor reading fails")
+	}
+	if n := atomic.LoadInt32(&readings); n != 1 {
+		t.Errorf("expect the sensors read one by one until the first error, got %d readings", n)
+	}
+
+	// the concurrent reads are not scheduled once ctx is done
+	client.WithPipelineWindow(2)
+	atomic.StoreInt32(&readings, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.sdrsToSensors(ctx, sdrs); !errors.Is(err, context.Canceled) {
+		t.Errorf("expect context canceled, got: %v", err)
+	}
+	if n := atomic.LoadInt32(&readings); n != 0 {
+		t.Errorf("expect no reading after ctx is done, got %d readings", n)
+	}
+}
+
+// failingConn is a net.Conn whose reads always fail.
+type failingConn struct {
+	net.Conn
+	reads int
+}
+
+func (c *failingConn) Read(b []byte) (int, error) {
+	c.reads++
+	return 0, errors.New("connection refused")
+}
+
+func (c *failingConn) SetReadDeadline(t time.Time) error {
+	return nil
+}
+
+func Test_pipelineReaderStops(t *testing.T) {
+	client, err := NewClient("127.0.0.1", 623, "user", "pass")
+	if err != nil {
+		t.Fatalf("new client failed, err: %s", err)
+	}
+	p := newPipeline(2)
+	conn := &failingConn{}
+
+	reader := p.startReader(client, conn)
+	select {
+	case <-reader.done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("pipeline reader not stopped")
+	}
+
+	if reader.err == nil {
+		t.Error("pipeline reader stopped without error")
+	}
+	if conn.reads != pipelineReadMaxErrors {
+		t.Errorf("pipeline reader read %d times, want %d", conn.reads, pipelineReadMaxErrors)
+	}
+	if p.startReader(client, conn) == reader {
+		t.Error("stopped pipeline reader not restarted")
+	}
+}
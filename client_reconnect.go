@@ -0,0 +1,226 @@
+package ipmi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// reconnectCloseSessionTimeout limits the best-effort Close Session of the lost session before reconnecting.
+const reconnectCloseSessionTimeout = time.Second
+
+// ReconnectPolicy controls how the Client re-establishes a lan/lanplus session
+// which is dropped by the BMC, see Client.WithReconnectPolicy.
+type ReconnectPolicy struct {
+	// MaxAttempts is the number of times the session activation is re-run
+	// for one failed request. Zero disables reconnection.
+	MaxAttempts int
+
+	// CompletionCodes lists the completion codes which indicate the session is lost.
+	// None by default.
+	//
+	// Some BMCs do not discard the requests of a timed out or evicted session,
+	// but reply them with "Insufficient privilege level" (D4h) as session-less requests.
+	// D4h is also returned for the commands above the session privilege,
+	// so if it's listed, the session is only treated as lost if a Get Session Info request
+	// within the same session fails with D4h too.
+	CompletionCodes []CompletionCode
+
+	// ReconnectOnTimeout also treats the request as failed because of a lost session
+	// if no reply is received after all retransmissions.
+	// BMCs normally discard the requests of an invalid session silently.
+	ReconnectOnTimeout bool
+
+	// OnReconnect is called after each re-establishment attempt if not nil.
+	// cause is the error which triggers the attempt, err is the result of the attempt.
+	OnReconnect func(cause error, err error)
+}
+
+// WithReconnectPolicy enables the transparent session re-establishment for lan/lanplus interface.
+// When a request fails because the BMC no longer accepts the session,
+// the Client re-runs Connect15/Connect20 and then replays the request.
+func (c *Client) WithReconnectPolicy(policy *ReconnectPolicy) *Client {
+	c.reconnectPolicy = policy
+	return c
+}
+
+type sessionSetupKey struct{}
+
+// withSessionSetup marks ctx as being used to activate a session.
+// The exchanges within session setup never trigger reconnection.
+func withSessionSetup(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sessionSetupKey{}, true)
+}
+
+func isSessionSetup(ctx context.Context) bool {
+	v, _ := ctx.Value(sessionSetupKey{}).(bool)
+	return v
+}
+
+// exchangeLANReconnect is exchangeLAN with session re-establishment applied.
+func (c *Client) exchangeLANReconnect(ctx context.Context, request Request, response Response) error {
+	if isSessionSetup(ctx) {
+		// the session lock is already held by reconnect if this is a re-establishment
+		return c.exchangeLAN(ctx, request, response)
+	}
+
+	c.sessionLock.RLock()
+	gen := c.sessionGen
+	err := c.exchangeLAN(ctx, request, response)
+	c.sessionLock.RUnlock()
+
+	if c.reconnectPolicy == nil {
+		return err
+	}
+
+	for attempt := 1; attempt <= c.reconnectPolicy.MaxAttempts && c.isSessionLost(ctx, err); attempt++ {
+		c.DebugfYellow("session lost, reconnect (%d/%d), err: %s\n", attempt, c.reconnectPolicy.MaxAttempts, err)
+
+		if reconnectErr := c.reconnect(ctx, gen, err); reconnectErr != nil {
+			return fmt.Errorf("reconnect failed, err: %w", reconnectErr)
+		}
+
+		c.sessionLock.RLock()
+		gen = c.sessionGen
+		err = c.exchangeLAN(ctx, request, response)
+		c.sessionLock.RUnlock()
+	}
+
+	return err
+}
+
+// isSessionLost reports whether the request failed with err because the session is lost.
+func (c *Client) isSessionLost(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+
+	if !c.sessionActive() {
+		return false
+	}
+
+	if errors.Is(err, ErrSessionInvalid) {
+		return true
+	}
+
+	if c.reconnectPolicy.ReconnectOnTimeout && isTimeoutError(err) {
+		return true
+	}
+
+	var respErr *ResponseError
+	if errors.As(err, &respErr) {
+		for _, cc := range c.reconnectPolicy.CompletionCodes {
+			if respErr.CompletionCode() != cc {
+				continue
+			}
+			if cc == CompletionCodeCannotExecuteCommandSecurityRestrict {
+				return c.probeSessionLost(ctx)
+			}
+			return true
+		}
+	}
+
+	return false
+}
+
+// sessionActive reports whether the session is active, the session states are
+// read under the session lock as they are written by reconnect from other goroutines.
+func (c *Client) sessionActive() bool {
+	c.sessionLock.RLock()
+	defer c.sessionLock.RUnlock()
+
+	if c.v20 {
+		return c.session.v20.state == SessionStateActive
+	}
+	return c.session.v15.active
+}
+
+// probeSessionLost sends Get Session Info, which is allowed for any session privilege level,
+// within the current session, and reports whether the session is lost by its result.
+func (c *Client) probeSessionLost(ctx context.Context) bool {
+	c.sessionLock.RLock()
+	defer c.sessionLock.RUnlock()
+
+	// the probe itself never triggers reconnection
+	_, err := c.GetCurrentSessionInfoContext(withSessionSetup(ctx))
+	if err == nil {
+		return false
+	}
+	c.Debugf("probe session failed, err: %s\n", err)
+
+	var respErr *ResponseError
+	if errors.As(err, &respErr) {
+		return respErr.CompletionCode() == CompletionCodeCannotExecuteCommandSecurityRestrict
+	}
+	if errors.Is(err, ErrSessionInvalid) {
+		return true
+	}
+	return c.reconnectPolicy.ReconnectOnTimeout && isTimeoutError(err)
+}
+
+// reconnect re-establishes the session unless it was already re-established
+// by another goroutine since the session generation gen.
+func (c *Client) reconnect(ctx context.Context, gen uint64, cause error) error {
+	c.sessionLock.Lock()
+	defer c.sessionLock.Unlock()
+
+	if c.sessionGen != gen {
+		return nil
+	}
+
+	c.closeLostSession(ctx)
+	c.resetSession()
+
+	var err error
+	if c.v20 {
+		err = c.Connect20Context(ctx)
+	} else {
+		err = c.Connect15Context(ctx)
+	}
+	if err == nil {
+		c.sessionGen++
+	}
+	c.observeReconnect(err)
+
+	if c.reconnectPolicy.OnReconnect != nil {
+		c.reconnectPolicy.OnReconnect(cause, err)
+	}
+
+	return err
+}
+
+// closeLostSession tries to close the lost session before reconnecting, the errors are ignored.
+// The BMC may still hold the session, and the BMCs only support a few sessions,
+// so the abandoned sessions would prevent the new session from being activated.
+func (c *Client) closeLostSession(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(withSessionSetup(ctx), reconnectCloseSessionTimeout)
+	defer cancel()
+
+	var sessionID uint32
+	if c.v20 {
+		sessionID = c.session.v20.bmcSessionID
+	} else {
+		sessionID = c.session.v15.sessionID
+	}
+
+	if _, err := c.CloseSessionContext(ctx, &CloseSessionRequest{SessionID: sessionID}); err != nil {
+		c.Debugf("close the lost session failed but skipped, err: %s\n", err)
+	}
+}
+
+// resetSession clears the session states to pre-session,
+// the negotiated cipher suite and the BMC key are kept.
+func (c *Client) resetSession() {
+	c.lock()
+	defer c.unlock()
+
+	c.session.v20 = v20{
+		state:         SessionStatePreSession,
+		cipherSuiteID: c.session.v20.cipherSuiteID,
+		bmcKey:        c.session.v20.bmcKey,
+	}
+	c.session.v15 = v15{
+		active: false,
+	}
+}
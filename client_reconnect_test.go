@@ -0,0 +1,157 @@
+package ipmi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+var _ net.Error = timeoutError{}
+
+func Test_isSessionLost(t *testing.T) {
+	client, err := NewClient("127.0.0.1", 623, "user", "pass")
+	if err != nil {
+		t.Fatalf("new client failed, err: %s", err)
+	}
+	client.session.v20.state = SessionStateActive
+
+	privilegeErr := &ResponseError{completionCode: CompletionCodeCannotExecuteCommandSecurityRestrict}
+	busyErr := &ResponseError{completionCode: CompletionCodeNodeBusy}
+	timeoutErr := fmt.Errorf("client udp exchange msg failed, err: %w", timeoutError{})
+	invalidErr := fmt.Errorf("%w: the response is not authenticated", ErrSessionInvalid)
+
+	tests := []struct {
+		name     string
+		policy   *ReconnectPolicy
+		ctx      context.Context
+		err      error
+		expected bool
+	}{
+		{"nil error", &ReconnectPolicy{MaxAttempts: 1}, context.Background(), nil, false},
+		{"session invalid", &ReconnectPolicy{MaxAttempts: 1}, context.Background(), invalidErr, true},
+		{"privilege completion code", &ReconnectPolicy{MaxAttempts: 1}, context.Background(), privilegeErr, false},
+		{"other completion code", &ReconnectPolicy{MaxAttempts: 1}, context.Background(), busyErr, false},
+		{"custom completion code", &ReconnectPolicy{MaxAttempts: 1, CompletionCodes: []CompletionCode{CompletionCodeNodeBusy}}, context.Background(), busyErr, true},
+		{"timeout disabled", &ReconnectPolicy{MaxAttempts: 1}, context.Background(), timeoutErr, false},
+		{"timeout enabled", &ReconnectPolicy{MaxAttempts: 1, ReconnectOnTimeout: true}, context.Background(), timeoutErr, true},
+	}
+
+	for _, test := range tests {
+		client.WithReconnectPolicy(test.policy)
+		got := client.isSessionLost(test.ctx, test.err)
+		if got != test.expected {
+			t.Errorf("test %s not matched, got: %v, expected: %v", test.name, got, test.expected)
+		}
+	}
+
+	client.session.v20.state = SessionStatePreSession
+	if client.isSessionLost(context.Background(), invalidErr) {
+		t.Errorf("inactive session should not be treated as lost")
+	}
+}
+
+func Test_checkSession20(t *testing.T) {
+	client, err := NewClient("127.0.0.1", 623, "user", "pass")
+	if err != nil {
+		t.Fatalf("new client failed, err: %s", err)
+	}
+	client.session.v20.state = SessionStateActive
+	client.session.v20.consoleSessionID = 0x0a0b0c0d
+	client.session.v20.integrityAlg = IntegrityAlg_HMAC_SHA1_96
+
+	// a reply outside of the session without the authentication bit
+	outside := &Session20{SessionHeader20: &SessionHeader20{PayloadType: PayloadTypeIPMI}}
+	// a reply of another session
+	foreign := &Session20{SessionHeader20: &SessionHeader20{PayloadType: PayloadTypeIPMI, SessionID: 0x01020304}}
+
+	// the lenient replies are accepted unless the lost sessions are re-established
+	if err := client.checkSession20(outside.Pack(), outside); err != nil {
+		t.Errorf("expect the reply accepted without reconnect policy, got: %v", err)
+	}
+	if err := client.checkSession20(foreign.Pack(), foreign); err != errUnmatchedResponse {
+		t.Errorf("expect the reply of another session unmatched, got: %v", err)
+	}
+
+	client.WithReconnectPolicy(&ReconnectPolicy{MaxAttempts: 1})
+	if err := client.checkSession20(outside.Pack(), outside); !errors.Is(err, ErrSessionInvalid) {
+		t.Errorf("expect ErrSessionInvalid with reconnect policy, got: %v", err)
+	}
+	if err := client.checkSession20(foreign.Pack(), foreign); err != errUnmatchedResponse {
+		t.Errorf("expect the reply of another session unmatched, got: %v", err)
+	}
+}
+
+func Test_isSessionLost_PrivilegeProbe(t *testing.T) {
+	privilegeErr := &ResponseError{completionCode: CompletionCodeCannotExecuteCommandSecurityRestrict}
+	policy := &ReconnectPolicy{
+		MaxAttempts:     1,
+		CompletionCodes: []CompletionCode{CompletionCodeCannotExecuteCommandSecurityRestrict},
+	}
+
+	tests := []struct {
+		name     string
+		probe    []byte
+		expected bool
+	}{
+		{"session alive", []byte{0x00, 0x01, 0x04, 0x01}, false},
+		{"session lost", []byte{uint8(CompletionCodeCannotExecuteCommandSecurityRestrict)}, true},
+	}
+
+	for _, test := range tests {
+		server, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+		if err != nil {
+			t.Fatalf("listen udp failed, err: %s", err)
+		}
+
+		// the server replies the Get Session Info probe
+		probes := make(chan struct{}, 8)
+		probe := test.probe
+		go func() {
+			buf := make([]byte, 1024)
+			for {
+				n, addr, err := server.ReadFromUDP(buf)
+				if err != nil {
+					return
+				}
+				if buf[14+5] != CommandGetSessionInfo.ID {
+					continue
+				}
+				probes <- struct{}{}
+				rqSeq := buf[14+4] >> 2
+				res := buildLAN15Response(buf[:n], rqSeq, probe[1:])
+				// set the completion code, and fix the checksum
+				res[14+6] = probe[0]
+				res[len(res)-1] -= probe[0]
+				_, _ = server.WriteToUDP(res, addr)
+			}
+		}()
+
+		addr := server.LocalAddr().(*net.UDPAddr)
+		client, err := NewClient(addr.IP.String(), addr.Port, "user", "pass")
+		if err != nil {
+			t.Fatalf("new client failed, err: %s", err)
+		}
+		client.WithInterface(InterfaceLan).WithReconnectPolicy(policy)
+		client.v20 = false
+		client.session.v15.active = true
+
+		got := client.isSessionLost(context.Background(), privilegeErr)
+		if got != test.expected {
+			t.Errorf("test %s not matched, got: %v, expected: %v", test.name, got, test.expected)
+		}
+		if n := len(probes); n != 1 {
+			t.Errorf("test %s expect 1 probe, got: %d", test.name, n)
+		}
+
+		client.udpClient.Close()
+		server.Close()
+	}
+}
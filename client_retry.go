@@ -0,0 +1,141 @@
+package ipmi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultBusyCompletionCodes are the completion codes which are retried
+// if RetryPolicy.CompletionCodes is not set.
+var DefaultBusyCompletionCodes = []CompletionCode{
+	CompletionCodeNodeBusy,
+	CompletionCodeCannotProvideResponseSDRRInUpdate,
+	CompletionCodeCannotProvideResponseBMCInitialize,
+}
+
+// RetryPolicy controls how the Client retries the requests failed with transient completion codes,
+// see Client.WithRetryPolicy.
+type RetryPolicy struct {
+	// MaxAttempts is the number of times a request failed with a busy completion code is re-sent.
+	// Zero disables the retry.
+	MaxAttempts int
+
+	// CompletionCodes lists the busy completion codes to retry.
+	// If nil, DefaultBusyCompletionCodes is used.
+	CompletionCodes []CompletionCode
+
+	// Backoff is the delay before the first retry, it's doubled for each next retry
+	// but never exceeds MaxBackoff if MaxBackoff is not zero.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+
+	// MaxRestarts is the number of times a reader of the SDR Repository or the SEL
+	// takes a fresh reservation and restarts from the first record,
+	// after its reservation is cancelled (C5h), like by a new SEL entry or SDR update.
+	// Zero disables the restart, and the readers do not take reservations.
+	MaxRestarts int
+}
+
+// WithRetryPolicy enables the retry of the requests failed with busy completion codes,
+// and the restart of the readers whose reservation is cancelled.
+func (c *Client) WithRetryPolicy(policy *RetryPolicy) *Client {
+	c.retryPolicy = policy
+	return c
+}
+
+// isBusy reports whether the request failed with err should be retried.
+func (policy *RetryPolicy) isBusy(err error) bool {
+	var respErr *ResponseError
+	if !errors.As(err, &respErr) {
+		return false
+	}
+
+	codes := policy.CompletionCodes
+	if codes == nil {
+		codes = DefaultBusyCompletionCodes
+	}
+	for _, cc := range codes {
+		if respErr.CompletionCode() == cc {
+			return true
+		}
+	}
+	return false
+}
+
+// exchangeRetry calls exchange, and retries it with backoff if it fails with a busy completion code.
+func (c *Client) exchangeRetry(ctx context.Context, request Request, response Response, exchange func(ctx context.Context, request Request, response Response) error) error {
+	policy := c.retryPolicy
+	backoff := policy.Backoff
+
+	err := exchange(ctx, request, response)
+	for attempt := 1; attempt <= policy.MaxAttempts && policy.isBusy(err); attempt++ {
+		c.DebugfYellow("%s is busy, retry in %s (%d/%d), err: %s\n", request.Command().Name, backoff, attempt, policy.MaxAttempts, err)
+
+		if backoff > 0 {
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return fmt.Errorf("retry aborted, err: %w", ctx.Err())
+			case <-timer.C:
+			}
+		}
+		backoff *= 2
+		if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+
+		err = exchange(ctx, request, response)
+	}
+	return err
+}
+
+// withReservation calls read with a reservation ID taken by reserve. If read fails because the reservation
+// is cancelled, it's called again with a fresh reservation ID, see RetryPolicy.MaxRestarts.
+// read must discard what it has read before if it's called again.
+//
+// If restarting is disabled, read is called once with the reservation ID 0000h, which is accepted for full reads.
+func (c *Client) withReservation(ctx context.Context, what string, reserve func(ctx context.Context) (uint16, error), read func(reservationID uint16) error) error {
+	if c.retryPolicy == nil || c.retryPolicy.MaxRestarts <= 0 {
+		return read(0)
+	}
+
+	for restart := 0; ; restart++ {
+		reservationID, err := reserve(ctx)
+		if err != nil {
+			return fmt.Errorf("reserve %s failed, err: %w", what, err)
+		}
+
+		err = read(reservationID)
+		if !errors.Is(err, ErrReservationCanceled) || restart >= c.retryPolicy.MaxRestarts || ctx.Err() != nil {
+			return err
+		}
+		c.DebugfYellow("%s reservation cancelled, restart (%d/%d)\n", what, restart+1, c.retryPolicy.MaxRestarts)
+	}
+}
+
+func (c *Client) reserveSDRRepo(ctx context.Context) (uint16, error) {
+	res, err := c.ReserveSDRRepoContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return res.ReservationID, nil
+}
+
+func (c *Client) reserveDeviceSDRRepo(ctx context.Context) (uint16, error) {
+	res, err := c.ReserveDeviceSDRRepoContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return res.ReservationID, nil
+}
+
+func (c *Client) reserveSEL(ctx context.Context) (uint16, error) {
+	res, err := c.ReserveSELContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return res.ReservationID, nil
+}
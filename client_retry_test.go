@@ -0,0 +1,133 @@
+package ipmi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// scriptTransport replies the requests of each command in turn, the last reply is repeated.
+type scriptTransport struct {
+	rawTransport
+	scripts map[Command][][]byte
+	calls   map[Command]int
+}
+
+func (t *scriptTransport) Exchange(ctx context.Context, request Request, response Response) error {
+	cmd := request.Command()
+	key := scriptKey(cmd)
+
+	n := t.calls[key]
+	t.calls[key]++
+
+	script, ok := t.scripts[key]
+	if !ok {
+		return t.rawTransport.Exchange(ctx, request, response)
+	}
+	if n >= len(script) {
+		n = len(script) - 1
+	}
+	return UnpackResponse(script[n], response)
+}
+
+func newScriptTransport(scripts map[Command][][]byte) *scriptTransport {
+	return &scriptTransport{scripts: scripts, calls: map[Command]int{}}
+}
+
+func scriptKey(cmd Command) Command {
+	return Command{ID: cmd.ID, NetFn: cmd.NetFn}
+}
+
+func Test_WithRetryPolicy(t *testing.T) {
+	transport := newScriptTransport(map[Command][][]byte{
+		scriptKey(CommandGetDeviceID): {
+			{uint8(CompletionCodeNodeBusy)},
+			{uint8(CompletionCodeNodeBusy)},
+			{0x00, 0x20, 0x81, 0x01, 0x10, 0x02, 0xbf, 0x57, 0x01, 0x00, 0x34, 0x12},
+		},
+		scriptKey(CommandGetSELInfo): {
+			{uint8(CompletionCodeNodeBusy)},
+		},
+	})
+	client, err := NewClientWithTransport(transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.GetDeviceID(); !errors.Is(err, ErrNodeBusy) {
+		t.Fatalf("expect ErrNodeBusy without retry policy, got: %v", err)
+	}
+
+	transport.calls = map[Command]int{}
+	client.WithRetryPolicy(&RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond})
+
+	if _, err := client.GetDeviceID(); err != nil {
+		t.Fatalf("expect GetDeviceID succeeded after retry, got: %v", err)
+	}
+	if n := transport.calls[scriptKey(CommandGetDeviceID)]; n != 3 {
+		t.Errorf("expect 3 GetDeviceID requests, got: %d", n)
+	}
+
+	if _, err := client.GetSELInfo(); !errors.Is(err, ErrNodeBusy) {
+		t.Errorf("expect ErrNodeBusy after all retries, got: %v", err)
+	}
+	if n := transport.calls[scriptKey(CommandGetSELInfo)]; n != 4 {
+		t.Errorf("expect 4 GetSELInfo requests, got: %d", n)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client.WithRetryPolicy(&RetryPolicy{MaxAttempts: 3, Backoff: time.Hour})
+	if _, err := client.GetSELInfoContext(ctx); err == nil {
+		t.Errorf("expect GetSELInfo aborted")
+	}
+}
+
+func Test_WithRetryPolicy_Restart(t *testing.T) {
+	selInfo := []byte{0x00, 0x51, 0x02, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02}
+	entry := func(recordID uint16, nextRecordID uint16) []byte {
+		return []byte{
+			0x00, byte(nextRecordID), byte(nextRecordID >> 8),
+			byte(recordID), byte(recordID >> 8), 0x02, 0x00, 0x00, 0x00, 0x00, 0x20, 0x00, 0x04, 0x01, 0x30, 0x6f, 0x02, 0xff, 0xff,
+		}
+	}
+	transport := newScriptTransport(map[Command][][]byte{
+		scriptKey(CommandGetSELInfo): {selInfo},
+		scriptKey(CommandReserveSEL): {
+			{0x00, 0x01, 0x00},
+			{0x00, 0x02, 0x00},
+		},
+		scriptKey(CommandGetSELEntry): {
+			entry(1, 2),
+			{uint8(CompletionCodeReservationCanceled)},
+			entry(1, 2),
+			entry(2, 0xffff),
+		},
+	})
+	client, err := NewClientWithTransport(transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.WithRetryPolicy(&RetryPolicy{MaxRestarts: 1})
+
+	sels, err := client.GetSELEntries(0)
+	if err != nil {
+		t.Fatalf("expect GetSELEntries succeeded after restart, got: %v", err)
+	}
+	if len(sels) != 2 || sels[0].RecordID != 1 || sels[1].RecordID != 2 {
+		t.Errorf("expect SEL records 1 and 2, got: %v", sels)
+	}
+	if n := transport.calls[scriptKey(CommandReserveSEL)]; n != 2 {
+		t.Errorf("expect 2 ReserveSEL requests, got: %d", n)
+	}
+
+	transport.calls = map[Command]int{}
+	transport.scripts[scriptKey(CommandGetSELEntry)] = [][]byte{{uint8(CompletionCodeReservationCanceled)}}
+	if _, err := client.GetSELEntries(0); !errors.Is(err, ErrReservationCanceled) {
+		t.Errorf("expect ErrReservationCanceled after all restarts, got: %v", err)
+	}
+	if n := transport.calls[scriptKey(CommandReserveSEL)]; n != 2 {
+		t.Errorf("expect 2 ReserveSEL requests, got: %d", n)
+	}
+}
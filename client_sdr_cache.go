@@ -0,0 +1,263 @@
+package ipmi
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// SDRCache caches the records of the SDR Repository of the BMCs, so the readers like GetSensors
+// do not walk the whole SDR Repository on every call, but only check the SDR Repository Info.
+//
+// The records of a BMC are keyed by its device GUID together with the record count,
+// the most recent addition timestamp and the most recent erase timestamp in the SDR Repository Info,
+// so they are fetched again if the SDR Repository Info changes, and the BMCs sharing a GUID
+// (like the cloned or unset GUIDs) are not served the records of each other unless their SDR Repository Info match.
+// Only the latest entry of a GUID is kept, so the BMCs sharing a GUID replace the entries of each other.
+// The BMCs with an all-zero or all-FFh GUID are never cached.
+//
+// One SDRCache can be shared by the clients of many BMCs, and it can be saved to disk
+// and loaded on the next start.
+type SDRCache struct {
+	mu      sync.Mutex
+	entries map[string]*SDRCacheEntry
+}
+
+// SDRCacheEntry holds the cached SDR records of a BMC.
+type SDRCacheEntry struct {
+	// GUID is the device GUID of the BMC in hex.
+	GUID string `json:"guid"`
+
+	RecordCount            uint16    `json:"record_count"`
+	MostRecentAdditionTime time.Time `json:"most_recent_addition_time"`
+	MostRecentEraseTime    time.Time `json:"most_recent_erase_time"`
+
+	// Records holds the raw SDR records (with the 5 bytes header) in the order of the SDR Repository,
+	// they are concatenated like the file written by `ipmitool sdr dump`.
+	Records []byte `json:"records"`
+}
+
+// NewSDRCache creates an empty SDRCache.
+func NewSDRCache() *SDRCache {
+	return &SDRCache{
+		entries: make(map[string]*SDRCacheEntry),
+	}
+}
+
+// Entry returns the cached entry of the BMC with the device GUID and the SDR Repository Info, or nil if not cached.
+func (cache *SDRCache) Entry(guid [16]byte, info *GetSDRRepoInfoResponse) *SDRCacheEntry {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	return cache.entries[sdrCacheEntryKey(hex.EncodeToString(guid[:]), info.RecordCount, info.MostRecentAdditionTime, info.MostRecentEraseTime)]
+}
+
+// Invalidate removes the cached entries of the BMC with the device GUID.
+func (cache *SDRCache) Invalidate(guid [16]byte) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	for key, entry := range cache.entries {
+		if entry.GUID == hex.EncodeToString(guid[:]) {
+			delete(cache.entries, key)
+		}
+	}
+}
+
+// add adds the entry to the cache, the stale entries of the same device GUID are removed.
+func (cache *SDRCache) add(entry *SDRCacheEntry) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	for key, e := range cache.entries {
+		if e.GUID == entry.GUID {
+			delete(cache.entries, key)
+		}
+	}
+	cache.entries[entry.key()] = entry
+}
+
+// sdrCacheEntryKey returns the key of the cached entry of the device GUID and the SDR Repository Info.
+func sdrCacheEntryKey(guid string, recordCount uint16, additionTime time.Time, eraseTime time.Time) string {
+	return fmt.Sprintf("%s/%d/%d/%d", guid, recordCount, additionTime.Unix(), eraseTime.Unix())
+}
+
+func (entry *SDRCacheEntry) key() string {
+	return sdrCacheEntryKey(entry.GUID, entry.RecordCount, entry.MostRecentAdditionTime, entry.MostRecentEraseTime)
+}
+
+// isCacheableGUID reports whether the records of the BMC with the device GUID can be cached,
+// the all-zero and all-FFh GUIDs are reported by the BMCs whose GUID is not set.
+func isCacheableGUID(guid [16]byte) bool {
+	return guid != [16]byte{} && guid != [16]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+}
+
+// Save writes all the cached entries to w as JSON.
+func (cache *SDRCache) Save(w io.Writer) error {
+	cache.mu.Lock()
+	entries := make([]*SDRCacheEntry, 0, len(cache.entries))
+	for _, entry := range cache.entries {
+		entries = append(entries, entry)
+	}
+	cache.mu.Unlock()
+
+	if err := json.NewEncoder(w).Encode(entries); err != nil {
+		return fmt.Errorf("encode sdr cache failed, err: %w", err)
+	}
+	return nil
+}
+
+// Load reads the entries written by Save from r, and adds them to the cache.
+func (cache *SDRCache) Load(r io.Reader) error {
+	var entries []*SDRCacheEntry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return fmt.Errorf("decode sdr cache failed, err: %w", err)
+	}
+	for _, entry := range entries {
+		if _, err := splitSDRRecords(entry.Records); err != nil {
+			return fmt.Errorf("invalid records of guid (%s), err: %w", entry.GUID, err)
+		}
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	for _, entry := range entries {
+		cache.entries[entry.key()] = entry
+	}
+	return nil
+}
+
+// SaveFile writes all the cached entries to the file.
+func (cache *SDRCache) SaveFile(file string) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return fmt.Errorf("create sdr cache file (%s) failed, err: %w", file, err)
+	}
+	if err := cache.Save(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LoadFile reads the entries written by SaveFile from the file, and adds them to the cache.
+func (cache *SDRCache) LoadFile(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("open sdr cache file (%s) failed, err: %w", file, err)
+	}
+	defer f.Close()
+
+	return cache.Load(f)
+}
+
+// SDRs parses the cached records.
+func (entry *SDRCacheEntry) SDRs() ([]*SDR, error) {
+	return parseSDRRecords(entry.Records)
+}
+
+// parseSDRRecords parses the concatenated raw SDR records.
+// The NextRecordID of each SDR is set to the Record ID of the following record, and FFFFh for the last one.
+func parseSDRRecords(data []byte) ([]*SDR, error) {
+	records, err := splitSDRRecords(data)
+	if err != nil {
+		return nil, err
+	}
+
+	sdrs := make([]*SDR, 0, len(records))
+	for i, record := range records {
+		var nextRecordID uint16 = 0xffff
+		if i+1 < len(records) {
+			nextRecordID, _, _ = unpackUint16L(records[i+1], 0)
+		}
+		sdr, err := ParseSDR(record, nextRecordID)
+		if err != nil {
+			return nil, fmt.Errorf("record #%d: ParseSDR failed, err: %w", i, err)
+		}
+		sdrs = append(sdrs, sdr)
+	}
+	return sdrs, nil
+}
+
+// splitSDRRecords splits the concatenated raw SDR records by the Record Length in the record headers.
+func splitSDRRecords(data []byte) ([][]byte, error) {
+	records := make([][]byte, 0)
+	for offset := 0; offset < len(data); {
+		if len(data)-offset < sdrRecordHeaderSize {
+			return nil, fmt.Errorf("offset %d: %w", offset, ErrNotEnoughDataWith("sdr record header size", len(data)-offset, sdrRecordHeaderSize))
+		}
+		length := sdrRecordHeaderSize + int(data[offset+4])
+		if len(data)-offset < length {
+			return nil, fmt.Errorf("offset %d: %w", offset, ErrNotEnoughDataWith("sdr record size", len(data)-offset, length))
+		}
+		records = append(records, data[offset:offset+length:offset+length])
+		offset += length
+	}
+	return records, nil
+}
+
+// WithSDRCache makes the SDR Repository readers of the client use the cache,
+// like GetSDRs, GetSDRsMap and GetSensors.
+func (c *Client) WithSDRCache(cache *SDRCache) *Client {
+	c.sdrCache = cache
+	return c
+}
+
+// cachedSDRs returns the SDR records of the SDR Repository of the BMC with the device GUID from the cache.
+// The records are fetched and cached if they are not cached or changed.
+func (c *Client) cachedSDRs(ctx context.Context, guid [16]byte) ([]*SDR, error) {
+	info, err := c.GetSDRRepoInfoContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetSDRRepoInfo failed, err: %w", err)
+	}
+
+	if entry := c.sdrCache.Entry(guid, info); entry != nil {
+		return entry.SDRs()
+	}
+	c.Debugf("SDR cache of guid (%x) missed or changed, fetch the SDR repository\n", guid)
+
+	var records []byte
+	start := func() {
+		records = make([]byte, 0)
+	}
+	err = c.walkSDRs(ctx, start, func(data []byte, nextRecordID uint16) (bool, error) {
+		records = append(records, data...)
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &SDRCacheEntry{
+		GUID:                   hex.EncodeToString(guid[:]),
+		RecordCount:            info.RecordCount,
+		MostRecentAdditionTime: info.MostRecentAdditionTime,
+		MostRecentEraseTime:    info.MostRecentEraseTime,
+		Records:                records,
+	}
+	c.sdrCache.add(entry)
+
+	return entry.SDRs()
+}
+
+// sdrCacheGUID returns the device GUID of the BMC, it's only got once.
+func (c *Client) sdrCacheGUID(ctx context.Context) ([16]byte, error) {
+	c.sdrCacheLock.Lock()
+	defer c.sdrCacheLock.Unlock()
+
+	if c.sdrCacheKey != nil {
+		return *c.sdrCacheKey, nil
+	}
+
+	res, err := c.GetDeviceGUIDContext(ctx)
+	if err != nil {
+		return [16]byte{}, err
+	}
+	c.sdrCacheKey = &res.GUID
+	return res.GUID, nil
+}
@@ -0,0 +1,229 @@
+package ipmi
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+// sdrRepoTransport serves the SDR Repository of the records.
+type sdrRepoTransport struct {
+	rawTransport
+	records [][]byte
+	addTS   uint32
+	// guid is the device GUID, it's all ABh if nil.
+	guid []byte
+
+	getSDRCalls int
+}
+
+func (t *sdrRepoTransport) Exchange(ctx context.Context, request Request, response Response) error {
+	switch req := request.(type) {
+	case *GetDeviceGUIDRequest:
+		guid := t.guid
+		if guid == nil {
+			guid = bytes.Repeat([]byte{0xab}, 16)
+		}
+		return UnpackResponse(append([]byte{0x00}, guid...), response)
+
+	case *GetSDRRepoInfoRequest:
+		msg := []byte{0x00, 0x51, byte(len(t.records)), 0x00, 0x00, 0x10}
+		msg = append(msg, byte(t.addTS), byte(t.addTS>>8), byte(t.addTS>>16), byte(t.addTS>>24))
+		msg = append(msg, 0x00, 0x00, 0x00, 0x00, 0x02)
+		return UnpackResponse(msg, response)
+
+	case *GetSDRRequest:
+		t.getSDRCalls++
+		for i, record := range t.records {
+			recordID, _, _ := unpackUint16L(record, 0)
+			if recordID != req.RecordID && !(req.RecordID == 0 && i == 0) {
+				continue
+			}
+			var next uint16 = 0xffff
+			if i+1 < len(t.records) {
+				next, _, _ = unpackUint16L(t.records[i+1], 0)
+			}
+			return UnpackResponse(append([]byte{0x00, byte(next), byte(next >> 8)}, record...), response)
+		}
+		return UnpackResponse([]byte{uint8(CompletionCodeRequestedDataNotPresent)}, response)
+	}
+
+	return t.rawTransport.Exchange(ctx, request, response)
+}
+
+// oemSDR returns an OEM SDR record.
+func oemSDR(recordID uint16, data ...byte) []byte {
+	record := []byte{byte(recordID), byte(recordID >> 8), 0x51, uint8(SDRRecordTypeOEM), byte(3 + len(data)), 0x57, 0x01, 0x00}
+	return append(record, data...)
+}
+
+func Test_WithSDRCache(t *testing.T) {
+	transport := &sdrRepoTransport{
+		records: [][]byte{oemSDR(1, 0x01), oemSDR(2, 0x02, 0x03)},
+		addTS:   0x5f000000,
+	}
+	client, err := NewClientWithTransport(transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache := NewSDRCache()
+	client.WithSDRCache(cache)
+
+	for i := 0; i < 3; i++ {
+		sdrs, err := client.GetSDRs()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(sdrs) != 2 || sdrs[0].NextRecordID != 2 || sdrs[1].NextRecordID != 0xffff || !bytes.Equal(sdrs[1].OEM.OEMData, []byte{0x02, 0x03}) {
+			t.Fatalf("sdrs not match, got: %v", sdrs)
+		}
+	}
+	if transport.getSDRCalls != 2 {
+		t.Errorf("expect the SDR repository is walked once, got %d GetSDR requests", transport.getSDRCalls)
+	}
+
+	// a record is added
+	transport.records = append(transport.records, oemSDR(3, 0x04))
+	transport.addTS++
+	transport.getSDRCalls = 0
+	sdrs, err := client.GetSDRs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sdrs) != 3 || transport.getSDRCalls != 3 {
+		t.Errorf("expect the changed SDR repository is walked again, got %d records by %d GetSDR requests", len(sdrs), transport.getSDRCalls)
+	}
+	if len(cache.entries) != 1 {
+		t.Errorf("expect the stale entry removed, got %d entries", len(cache.entries))
+	}
+
+	// the saved cache is used by another client
+	buf := new(bytes.Buffer)
+	if err := cache.Save(buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded := NewSDRCache()
+	if err := loaded.Load(buf); err != nil {
+		t.Fatal(err)
+	}
+	var guid [16]byte
+	copy(guid[:], bytes.Repeat([]byte{0xab}, 16))
+	info, err := client.GetSDRRepoInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := loaded.Entry(guid, info)
+	if entry == nil || !bytes.Equal(entry.Records, bytes.Join(transport.records, nil)) {
+		t.Fatalf("loaded entry not match, got: %v", entry)
+	}
+
+	client2, err := NewClientWithTransport(transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client2.WithSDRCache(loaded)
+	transport.getSDRCalls = 0
+	if _, err := client2.GetSDRs(); err != nil {
+		t.Fatal(err)
+	}
+	if transport.getSDRCalls != 0 {
+		t.Errorf("expect the loaded cache is used, got %d GetSDR requests", transport.getSDRCalls)
+	}
+}
+
+// noGUIDTransport is a sdrRepoTransport of a BMC which does not support Get Device GUID.
+type noGUIDTransport struct {
+	sdrRepoTransport
+}
+
+func (t *noGUIDTransport) Exchange(ctx context.Context, request Request, response Response) error {
+	if _, ok := request.(*GetDeviceGUIDRequest); ok {
+		return UnpackResponse([]byte{uint8(CompletionCodeInvalidCommand)}, response)
+	}
+	return t.sdrRepoTransport.Exchange(ctx, request, response)
+}
+
+func Test_WithSDRCache_NoGUID(t *testing.T) {
+	transport := &noGUIDTransport{
+		sdrRepoTransport: sdrRepoTransport{
+			records: [][]byte{oemSDR(1, 0x01), oemSDR(2, 0x02, 0x03)},
+		},
+	}
+	client, err := NewClientWithTransport(transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache := NewSDRCache()
+	client.WithSDRCache(cache)
+
+	for i := 0; i < 2; i++ {
+		sdrs, err := client.GetSDRs()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(sdrs) != 2 {
+			t.Fatalf("expect 2 sdrs, got: %d", len(sdrs))
+		}
+	}
+	if transport.getSDRCalls != 4 {
+		t.Errorf("expect the SDR repository is walked each time, got %d GetSDR requests", transport.getSDRCalls)
+	}
+	if len(cache.entries) != 0 {
+		t.Errorf("expect nothing cached, got %d entries", len(cache.entries))
+	}
+}
+
+func Test_WithSDRCache_ZeroGUID(t *testing.T) {
+	for _, guid := range [][]byte{make([]byte, 16), bytes.Repeat([]byte{0xff}, 16)} {
+		transport := &sdrRepoTransport{
+			records: [][]byte{oemSDR(1, 0x01)},
+			guid:    guid,
+		}
+		client, err := NewClientWithTransport(transport)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cache := NewSDRCache()
+		client.WithSDRCache(cache)
+
+		for i := 0; i < 2; i++ {
+			if _, err := client.GetSDRs(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if transport.getSDRCalls != 2 {
+			t.Errorf("guid %x: expect the SDR repository is walked each time, got %d GetSDR requests", guid, transport.getSDRCalls)
+		}
+		if len(cache.entries) != 0 {
+			t.Errorf("guid %x: expect nothing cached, got %d entries", guid, len(cache.entries))
+		}
+	}
+}
+
+func Test_WithSDRCache_SharedGUID(t *testing.T) {
+	cache := NewSDRCache()
+	transports := []*sdrRepoTransport{
+		{records: [][]byte{oemSDR(1, 0x01)}, addTS: 0x5f000000},
+		{records: [][]byte{oemSDR(1, 0x02)}, addTS: 0x5f000001},
+	}
+	clients := make([]*Client, len(transports))
+	for i, transport := range transports {
+		client, err := NewClientWithTransport(transport)
+		if err != nil {
+			t.Fatal(err)
+		}
+		clients[i] = client.WithSDRCache(cache)
+	}
+
+	for round := 0; round < 2; round++ {
+		for i, client := range clients {
+			sdrs, err := client.GetSDRs()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(sdrs) != 1 || sdrs[0].OEM.OEMData[0] != uint8(i+1) {
+				t.Fatalf("client #%d: sdrs of another BMC served, got: %v", i, sdrs)
+			}
+		}
+	}
+}
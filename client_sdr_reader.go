@@ -0,0 +1,155 @@
+package ipmi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// sdrPartialRetries is the number of times the partial read or the partial add of a SDR record
+// is restarted with a fresh reservation if the reservation is cancelled.
+const sdrPartialRetries = 3
+
+// sdrRecordHeaderSize is the size of the SDR record header,
+// the Record ID, SDR Version, Record Type and Record Length fields.
+const sdrRecordHeaderSize = 5
+
+// sdrReader reads the SDR records of the SDR Repository or the Device SDR Repository.
+//
+// The full record is read at once (FFh) unless the BMC rejects it with CAh (cannot return number of
+// requested data bytes), then the record header is read first, and the record body is read in pieces.
+// The size of the pieces is halved each time the BMC rejects it, and is kept for the next records.
+type sdrReader struct {
+	// what is the name of the repository for the messages.
+	what string
+
+	reserve func(ctx context.Context) (uint16, error)
+
+	// read reads count bytes from offset of the record, count FFh means the entire record.
+	read func(ctx context.Context, reservationID uint16, recordID uint16, offset uint8, count uint8) (nextRecordID uint16, data []byte, err error)
+
+	// pieceSize is the max number of bytes of a partial read, 0 means the entire record can be read at once.
+	pieceSize *atomic.Uint32
+}
+
+// sdrReader returns the reader of the SDR Repository.
+func (c *Client) sdrReader() *sdrReader {
+	return &sdrReader{
+		what:    "SDR repository",
+		reserve: c.reserveSDRRepo,
+		read: func(ctx context.Context, reservationID uint16, recordID uint16, offset uint8, count uint8) (uint16, []byte, error) {
+			request := &GetSDRRequest{
+				ReservationID: reservationID,
+				RecordID:      recordID,
+				Offset:        offset,
+				Read:          count,
+			}
+			response := &GetSDRResponse{}
+			if err := c.ExchangeContext(ctx, request, response); err != nil {
+				return 0, nil, err
+			}
+			return response.NextRecordID, response.RecordData, nil
+		},
+		pieceSize: &c.sdrPieceSize,
+	}
+}
+
+// deviceSDRReader returns the reader of the Device SDR Repository.
+func (c *Client) deviceSDRReader() *sdrReader {
+	return &sdrReader{
+		what:    "device SDR repository",
+		reserve: c.reserveDeviceSDRRepo,
+		read: func(ctx context.Context, reservationID uint16, recordID uint16, offset uint8, count uint8) (uint16, []byte, error) {
+			request := &GetDeviceSDRRequest{
+				ReservationID: reservationID,
+				RecordID:      recordID,
+				ReadOffset:    offset,
+				ReadBytes:     count,
+			}
+			response := &GetDeviceSDRResponse{}
+			if err := c.ExchangeContext(ctx, request, response); err != nil {
+				return 0, nil, err
+			}
+			return response.NextRecordID, response.RecordData, nil
+		},
+		pieceSize: &c.deviceSDRPieceSize,
+	}
+}
+
+// readRecord reads the SDR record. If reservationID is 0000h, a reservation is taken for the partial reads,
+// and the record is read again with a fresh reservation if the reservation is cancelled.
+// Otherwise the error of the cancelled reservation is returned to the caller who owns the reservation.
+func (r *sdrReader) readRecord(ctx context.Context, c *Client, reservationID uint16, recordID uint16) (nextRecordID uint16, data []byte, err error) {
+	if r.pieceSize.Load() == 0 {
+		nextRecordID, data, err = r.read(ctx, reservationID, recordID, 0, 0xff)
+		if !errors.Is(err, ErrCannotReturnRequestedDataBytes) {
+			return nextRecordID, data, err
+		}
+		c.DebugfYellow("%s rejects reading entire record, read it partially\n", r.what)
+		r.pieceSize.CompareAndSwap(0, 0xff)
+	}
+
+	for attempt := 0; ; attempt++ {
+		id := reservationID
+		if id == 0 {
+			id, err = r.reserve(ctx)
+			if err != nil {
+				return 0, nil, fmt.Errorf("reserve %s failed, err: %w", r.what, err)
+			}
+		}
+
+		nextRecordID, data, err = r.readPieces(ctx, c, id, recordID)
+		if reservationID != 0 || !errors.Is(err, ErrReservationCanceled) || attempt >= sdrPartialRetries || ctx.Err() != nil {
+			return nextRecordID, data, err
+		}
+		c.DebugfYellow("%s reservation cancelled, read record (%#04x) again (%d/%d)\n", r.what, recordID, attempt+1, sdrPartialRetries)
+	}
+}
+
+// readPieces reads the record header, then reads the record body in pieces.
+func (r *sdrReader) readPieces(ctx context.Context, c *Client, reservationID uint16, recordID uint16) (uint16, []byte, error) {
+	nextRecordID, header, err := r.read(ctx, reservationID, recordID, 0, sdrRecordHeaderSize)
+	if err != nil {
+		return 0, nil, fmt.Errorf("read record header failed, err: %w", err)
+	}
+	if len(header) < sdrRecordHeaderSize {
+		return 0, nil, ErrNotEnoughDataWith("sdr record header size", len(header), sdrRecordHeaderSize)
+	}
+
+	// the Record ID 0000h means the first record, use the real one for the next pieces
+	recordID, _, _ = unpackUint16L(header, 0)
+	length := sdrRecordHeaderSize + int(header[4])
+
+	data := make([]byte, sdrRecordHeaderSize, length)
+	copy(data, header)
+	for len(data) < length {
+		if len(data) > 0xff {
+			return 0, nil, fmt.Errorf("record length (%d) exceeds the max offset of partial reads", length)
+		}
+
+		count := length - len(data)
+		if pieceSize := int(r.pieceSize.Load()); count > pieceSize {
+			count = pieceSize
+		}
+
+		_, piece, err := r.read(ctx, reservationID, recordID, uint8(len(data)), uint8(count))
+		if errors.Is(err, ErrCannotReturnRequestedDataBytes) && count > 1 {
+			c.DebugfYellow("%s rejects reading %d bytes, read %d bytes\n", r.what, count, count/2)
+			r.pieceSize.Store(uint32(count / 2))
+			continue
+		}
+		if err != nil {
+			return 0, nil, fmt.Errorf("read record at offset (%d) failed, err: %w", len(data), err)
+		}
+		if len(piece) == 0 {
+			return 0, nil, fmt.Errorf("read record at offset (%d) got no data", len(data))
+		}
+		if len(piece) > length-len(data) {
+			piece = piece[:length-len(data)]
+		}
+		data = append(data, piece...)
+	}
+
+	return nextRecordID, data, nil
+}
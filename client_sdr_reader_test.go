@@ -0,0 +1,54 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_sdrReader(t *testing.T) {
+	record := oemSDR(1, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a)
+	piece := func(offset int, count int) []byte {
+		return append([]byte{0x00, 0xff, 0xff}, record[offset:offset+count]...)
+	}
+
+	transport := newScriptTransport(map[Command][][]byte{
+		scriptKey(CommandReserveSDRRepo): {
+			{0x00, 0x01, 0x00},
+			{0x00, 0x02, 0x00},
+		},
+		scriptKey(CommandGetSDR): {
+			{uint8(CompletionCodeCannotReturnRequestedDataBytes)}, // entire record
+			piece(0, 5),
+			{uint8(CompletionCodeCannotReturnRequestedDataBytes)}, // 13 bytes
+			{uint8(CompletionCodeReservationCanceled)},
+			piece(0, 5),
+			piece(5, 6),
+			piece(11, 6),
+			piece(17, 1),
+		},
+	})
+	client, err := NewClientWithTransport(transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.GetSDR(0)
+	if err != nil {
+		t.Fatalf("GetSDR failed, err: %s", err)
+	}
+	if res.NextRecordID != 0xffff || !bytes.Equal(res.RecordData, record) {
+		t.Errorf("record not match, got: %x (next %#04x)", res.RecordData, res.NextRecordID)
+	}
+	if n := transport.calls[scriptKey(CommandGetSDR)]; n != 8 {
+		t.Errorf("expect 8 GetSDR requests, got: %d", n)
+	}
+	if n := transport.calls[scriptKey(CommandReserveSDRRepo)]; n != 2 {
+		t.Errorf("expect 2 ReserveSDRRepo requests, got: %d", n)
+	}
+	if n := client.sdrPieceSize.Load(); n != 6 {
+		t.Errorf("expect the piece size is kept as 6, got: %d", n)
+	}
+	if n := client.deviceSDRPieceSize.Load(); n != 0 {
+		t.Errorf("expect the device SDR piece size is not changed, got: %d", n)
+	}
+}
@@ -0,0 +1,155 @@
+package ipmi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// sdrPartialAddSize is the number of record bytes sent by each Partial Add SDR request.
+const sdrPartialAddSize = 16
+
+const (
+	// sdrEraseCheckInterval is the interval to check the erasure status after Clear SDR Repository.
+	sdrEraseCheckInterval = 100 * time.Millisecond
+	// sdrEraseMaxChecks is the max number of times to check the erasure status.
+	sdrEraseMaxChecks = 300
+)
+
+// LoadSDRRepository replaces all the records in the SDR Repository with the sdrs, like `ipmitool sdr fill`.
+//
+// The SDR Repository is cleared first, then the sdrs are added in order, and the Record IDs
+// are assigned by the BMC. The SDR Repository is put in update mode while loading if it only
+// supports modal update. The records are added in pieces by Partial Add SDR if the BMC supports it,
+// otherwise by Add SDR.
+func (c *Client) LoadSDRRepository(sdrs []*SDR) error {
+	return c.LoadSDRRepositoryContext(context.Background(), sdrs)
+}
+
+// LoadSDRRepositoryContext is like LoadSDRRepository but takes a context.
+func (c *Client) LoadSDRRepositoryContext(ctx context.Context, sdrs []*SDR) (err error) {
+	records := make([][]byte, 0, len(sdrs))
+	for i, sdr := range sdrs {
+		record, err := sdr.Pack()
+		if err != nil {
+			return fmt.Errorf("pack sdr (#%d) failed, err: %w", i, err)
+		}
+		records = append(records, record)
+	}
+
+	info, err := c.GetSDRRepoInfoContext(ctx)
+	if err != nil {
+		return fmt.Errorf("GetSDRRepoInfo failed, err: %w", err)
+	}
+	support := info.SDROperationSupport
+
+	if support.SupportModalSDRRepoUpdate && !support.SupportNonModalSDRRepoUpdate {
+		if err := c.EnterSDRRepoUpdateModeContext(ctx); err != nil {
+			return fmt.Errorf("EnterSDRRepoUpdateMode failed, err: %w", err)
+		}
+		defer func() {
+			if exitErr := c.ExitSDRRepoUpdateModeContext(ctx); exitErr != nil && err == nil {
+				err = fmt.Errorf("ExitSDRRepoUpdateMode failed, err: %w", exitErr)
+			}
+		}()
+	}
+
+	if err := c.clearSDRRepo(ctx); err != nil {
+		return err
+	}
+
+	for i, record := range records {
+		recordID, err := c.addSDRRecord(ctx, record, support.SupportPartialAddSDR)
+		if err != nil {
+			return fmt.Errorf("add sdr (#%d) failed, err: %w", i, err)
+		}
+		c.Debugf("sdr (#%d) added as record (%#04x)\n", i, recordID)
+	}
+
+	return nil
+}
+
+// clearSDRRepo clears the SDR Repository and waits until the erasure is completed.
+func (c *Client) clearSDRRepo(ctx context.Context) error {
+	reservationID, err := c.reserveSDRRepo(ctx)
+	if err != nil {
+		return fmt.Errorf("ReserveSDRRepo failed, err: %w", err)
+	}
+
+	res, err := c.ClearSDRRepoContext(ctx, reservationID)
+	if err != nil {
+		return fmt.Errorf("ClearSDRRepo failed, err: %w", err)
+	}
+
+	for i := 0; !res.ErasureCompleted(); i++ {
+		if i >= sdrEraseMaxChecks {
+			return fmt.Errorf("erasure of SDR repository not completed after %d checks", sdrEraseMaxChecks)
+		}
+
+		timer := time.NewTimer(sdrEraseCheckInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("wait erasure of SDR repository aborted, err: %w", ctx.Err())
+		case <-timer.C:
+		}
+
+		res, err = c.GetSDRRepoErasureStatusContext(ctx, reservationID)
+		if err != nil {
+			return fmt.Errorf("GetSDRRepoErasureStatus failed, err: %w", err)
+		}
+	}
+	return nil
+}
+
+// addSDRRecord adds the raw SDR record to the SDR Repository, and returns the assigned Record ID.
+// If partial is true, the record is added in pieces, and it's added again with a fresh reservation
+// if the reservation is cancelled.
+func (c *Client) addSDRRecord(ctx context.Context, record []byte, partial bool) (uint16, error) {
+	if !partial {
+		request := &AddSDRRequest{
+			RecordData: record,
+		}
+		response := &AddSDRResponse{}
+		if err := c.ExchangeContext(ctx, request, response); err != nil {
+			return 0, fmt.Errorf("AddSDR failed, err: %w", err)
+		}
+		return response.RecordID, nil
+	}
+
+	for attempt := 0; ; attempt++ {
+		recordID, err := c.partialAddSDRRecord(ctx, record)
+		if !errors.Is(err, ErrReservationCanceled) || attempt >= sdrPartialRetries || ctx.Err() != nil {
+			return recordID, err
+		}
+		c.DebugfYellow("SDR repository reservation cancelled, add record again (%d/%d)\n", attempt+1, sdrPartialRetries)
+	}
+}
+
+// partialAddSDRRecord adds the raw SDR record in pieces by Partial Add SDR.
+func (c *Client) partialAddSDRRecord(ctx context.Context, record []byte) (uint16, error) {
+	reservationID, err := c.reserveSDRRepo(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("ReserveSDRRepo failed, err: %w", err)
+	}
+
+	var recordID uint16
+	for offset := 0; offset < len(record); offset += sdrPartialAddSize {
+		if offset > 0xff {
+			return 0, fmt.Errorf("record length (%d) exceeds the max offset of partial add", len(record))
+		}
+
+		end := offset + sdrPartialAddSize
+		if end > len(record) {
+			end = len(record)
+		}
+
+		res, err := c.PartialAddSDRContext(ctx, reservationID, recordID, uint8(offset), end == len(record), record[offset:end])
+		if err != nil {
+			return 0, fmt.Errorf("PartialAddSDR at offset (%d) failed, err: %w", offset, err)
+		}
+		recordID = res.RecordID
+	}
+	return recordID, nil
+}
@@ -0,0 +1,463 @@
+package ipmi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultSOLRetryCount is the number of retransmissions of a SOL packet not acked by the BMC.
+	DefaultSOLRetryCount int = 7
+	// DefaultSOLRetryIntervalMillis is the milliseconds to wait for the ACK of a SOL packet before retransmission.
+	DefaultSOLRetryIntervalMillis int = 500
+
+	// solMaxBuffered is the max number of written characters waiting to be sent,
+	// Write blocks if more characters are buffered.
+	solMaxBuffered int = 4096
+)
+
+// ErrSOLDeactivated means the SOL payload is deactivated by the BMC.
+var ErrSOLDeactivated = errors.New("sol deactivated")
+
+// SOL is a Serial Over LAN console session, see OpenSOL.
+//
+// The characters received from the serial controller of the managed system are read by Read,
+// and the characters written by Write are sent to the serial controller.
+type SOL struct {
+	c        *Client
+	p        *pipeline
+	instance uint8
+	conn     net.Conn
+	recvCh   chan []byte
+	reader   *pipelineReader
+	// installed is true if OpenSOL installed the pipeline, it's removed by Close.
+	installed bool
+
+	// maxData is the max number of characters the BMC accepts in one SOL packet.
+	maxData       int
+	retryCount    int
+	retryInterval time.Duration
+
+	mu   sync.Mutex
+	cond *sync.Cond
+	// inbuf holds the received characters not yet read.
+	inbuf []byte
+	// outbuf holds the written characters not yet sent.
+	outbuf []byte
+	// ops holds the operations not yet sent.
+	ops SOLOperation
+	// err is set once the SOL is closed, deactivated or failed.
+	err error
+
+	wakeCh    chan struct{}
+	closeCh   chan struct{}
+	doneCh    chan struct{}
+	closeOnce sync.Once
+}
+
+// OpenSOL activates the SOL payload instance on the current lanplus session,
+// and returns the SOL console as an io.ReadWriteCloser.
+// Close deactivates the SOL payload.
+//
+// The SOL packets are received by the pipeline reader of the session, so the client
+// switches to pipelined exchanging (with window 1 if WithPipelineWindow is not called)
+// until the SOL is closed.
+// OpenSOL should not be called concurrently with other requests.
+func (c *Client) OpenSOL(ctx context.Context, instance uint8) (*SOL, error) {
+	if !c.v20 || c.session == nil || c.session.v20.state != SessionStateActive {
+		return nil, fmt.Errorf("sol is only supported on an active lanplus session")
+	}
+
+	p, installed := c.installPipeline()
+
+	conn, err := c.udpClient.getConn()
+	if err != nil {
+		c.uninstallPipeline(p, installed)
+		return nil, fmt.Errorf("init udp connection failed, err: %w", err)
+	}
+	reader := p.startReader(c, conn)
+
+	recvCh, err := p.registerBuffered(pipelineKeySOL, 64)
+	if err != nil {
+		c.uninstallPipeline(p, installed)
+		return nil, fmt.Errorf("sol already opened, err: %w", err)
+	}
+
+	request := &ActivatePayloadRequest{
+		PayloadType:              PayloadTypeSOL,
+		PayloadInstance:          instance,
+		EncryptionActivation:     c.session.v20.cryptAlg != CryptAlg_None,
+		AuthenticationActivation: c.session.v20.integrityAlg != IntegrityAlg_None,
+		SharedSerialAlert:        0x01, // deferred while SOL active
+	}
+	res, err := c.ActivatePayloadContext(ctx, request)
+	if err != nil {
+		p.unregister(pipelineKeySOL)
+		c.uninstallPipeline(p, installed)
+		return nil, fmt.Errorf("ActivatePayload failed, err: %w", err)
+	}
+	c.Debug("SOL activated", res)
+
+	if res.PayloadUDPPort != 0 && int(res.PayloadUDPPort) != c.Port {
+		p.unregister(pipelineKeySOL)
+		_, _ = c.DeactivatePayloadContext(ctx, PayloadTypeSOL, instance)
+		c.uninstallPipeline(p, installed)
+		return nil, fmt.Errorf("sol payload on another udp port (%d) is not supported", res.PayloadUDPPort)
+	}
+
+	maxData := int(res.InboundPayloadSize) - 4 // minus the 4 bytes SOL packet header
+	if maxData <= 0 || maxData > 255 {
+		// the Accepted Character Count field occupies only one byte
+		maxData = 255
+	}
+
+	s := &SOL{
+		c:             c,
+		p:             p,
+		instance:      instance,
+		conn:          conn,
+		recvCh:        recvCh,
+		reader:        reader,
+		installed:     installed,
+		maxData:       maxData,
+		retryCount:    DefaultSOLRetryCount,
+		retryInterval: time.Millisecond * time.Duration(DefaultSOLRetryIntervalMillis),
+
+		wakeCh:  make(chan struct{}, 1),
+		closeCh: make(chan struct{}),
+		doneCh:  make(chan struct{}),
+	}
+	s.cond = sync.NewCond(&s.mu)
+
+	go s.run()
+
+	return s, nil
+}
+
+// installPipeline returns the pipeline of the client, a pipeline with window 1 is installed
+// if pipelining is not enabled, and installed is true in that case.
+func (c *Client) installPipeline() (p *pipeline, installed bool) {
+	c.sessionLock.Lock()
+	defer c.sessionLock.Unlock()
+
+	if c.pipeline != nil {
+		return c.pipeline, false
+	}
+	c.pipeline = newPipeline(1)
+	return c.pipeline, true
+}
+
+// uninstallPipeline removes the pipeline installed by installPipeline,
+// so the client returns to the non-pipelined exchanging.
+func (c *Client) uninstallPipeline(p *pipeline, installed bool) {
+	if !installed {
+		return
+	}
+
+	c.sessionLock.Lock()
+	defer c.sessionLock.Unlock()
+
+	p.stop()
+	if c.pipeline == p {
+		c.pipeline = nil
+	}
+}
+
+// Read reads the characters received from the serial controller.
+// It returns io.EOF after the SOL is closed, or an error wrapping ErrSOLDeactivated
+// if the SOL is deactivated by the BMC.
+func (s *SOL) Read(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for len(s.inbuf) == 0 && s.err == nil {
+		s.cond.Wait()
+	}
+	if len(s.inbuf) > 0 {
+		n := copy(p, s.inbuf)
+		s.inbuf = s.inbuf[n:]
+		return n, nil
+	}
+	return 0, s.err
+}
+
+// Write queues the characters to be sent to the serial controller.
+// The characters written before the previous packet is acked are accumulated into the next packet.
+func (s *SOL) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	for len(s.outbuf) >= solMaxBuffered && s.err == nil {
+		s.cond.Wait()
+	}
+	if s.err != nil {
+		s.mu.Unlock()
+		return 0, s.err
+	}
+	s.outbuf = append(s.outbuf, p...)
+	s.mu.Unlock()
+
+	s.wake()
+	return len(p), nil
+}
+
+// Control sends the operations (like generating break or flushing) to the serial controller.
+func (s *SOL) Control(op SOLOperation) error {
+	s.mu.Lock()
+	if s.err != nil {
+		s.mu.Unlock()
+		return s.err
+	}
+	s.ops |= op &^ SOLOperationNACK
+	s.mu.Unlock()
+
+	s.wake()
+	return nil
+}
+
+// SendBreak generates a break condition on the serial controller.
+func (s *SOL) SendBreak() error {
+	return s.Control(SOLOperationGenerateBreak)
+}
+
+// Close deactivates the SOL payload.
+func (s *SOL) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		s.fail(io.EOF)
+		close(s.closeCh)
+		<-s.doneCh
+
+		s.p.unregister(pipelineKeySOL)
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.c.timeout)
+		defer cancel()
+		_, err = s.c.DeactivatePayloadContext(ctx, PayloadTypeSOL, s.instance)
+
+		var respErr *ResponseError
+		if errors.As(err, &respErr) && uint8(respErr.CompletionCode()) == 0x80 {
+			// payload already deactivated
+			err = nil
+		}
+		if err != nil {
+			err = fmt.Errorf("DeactivatePayload failed, err: %w", err)
+		}
+
+		s.c.uninstallPipeline(s.p, s.installed)
+	})
+	return err
+}
+
+func (s *SOL) wake() {
+	select {
+	case s.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+// fail stops the SOL with err, the characters already received can still be read.
+func (s *SOL) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == nil {
+		s.err = err
+	}
+	s.cond.Broadcast()
+}
+
+// run sends the queued characters and operations one packet at a time,
+// retransmits the packet not acked in time, and receives the packets from the BMC.
+func (s *SOL) run() {
+	defer close(s.doneCh)
+
+	var (
+		sendSeq     uint8
+		lastRecvSeq uint8
+		inflight    *SOLPacket
+		sentAt      time.Time
+		tries       int
+	)
+
+	tick := s.retryInterval / 4
+	if tick <= 0 {
+		tick = 10 * time.Millisecond
+	}
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		if inflight == nil {
+			if pkt := s.nextPacket(&sendSeq); pkt != nil {
+				if err := s.send(pkt); err != nil {
+					s.fail(err)
+					return
+				}
+				inflight, sentAt, tries = pkt, time.Now(), 0
+			}
+		}
+
+		select {
+		case <-s.closeCh:
+			return
+
+		case <-s.wakeCh:
+
+		case <-ticker.C:
+			if inflight == nil || time.Since(sentAt) < s.retryInterval {
+				continue
+			}
+			if tries >= s.retryCount {
+				s.fail(fmt.Errorf("sol packet (seq %d) not acked after %d retries", inflight.SequenceNumber, tries))
+				return
+			}
+			tries++
+			s.c.DebugfYellow("sol packet (seq %d) not acked, retransmit (%d/%d)\n", inflight.SequenceNumber, tries, s.retryCount)
+			if err := s.send(inflight); err != nil {
+				s.fail(err)
+				return
+			}
+			sentAt = time.Now()
+
+		case <-s.reader.done:
+			s.fail(fmt.Errorf("pipeline reader stopped, err: %w", s.reader.err))
+			return
+
+		case msg := <-s.recvCh:
+			pkt, err := s.c.parseSOLPacket(msg)
+			if err != nil {
+				s.c.DebugfRed("drop sol packet, err: %s\n", err)
+				continue
+			}
+			s.c.Debug("<< SOL Packet", pkt)
+
+			if pkt.AckSequenceNumber != 0 && inflight != nil && pkt.AckSequenceNumber == inflight.SequenceNumber {
+				if pkt.Status()&SOLStatusNACK != 0 {
+					// the BMC is not accepting characters for now, retry later
+					sentAt = time.Now()
+				} else {
+					if accepted := int(pkt.AcceptedCharacterCount); accepted < len(inflight.Data) {
+						s.requeue(inflight.Data[accepted:])
+					}
+					inflight = nil
+				}
+			}
+
+			if pkt.SequenceNumber != 0 {
+				// a retransmitted packet is acked again, but its characters are not duplicated
+				if pkt.SequenceNumber != lastRecvSeq {
+					lastRecvSeq = pkt.SequenceNumber
+					s.receive(pkt.Data)
+				}
+				ack := &SOLPacket{
+					AckSequenceNumber:      pkt.SequenceNumber,
+					AcceptedCharacterCount: uint8(len(pkt.Data)),
+				}
+				if err := s.send(ack); err != nil {
+					s.fail(err)
+					return
+				}
+			}
+
+			if pkt.Status()&SOLStatusDeactivating != 0 {
+				s.fail(fmt.Errorf("%w by the BMC", ErrSOLDeactivated))
+				return
+			}
+		}
+	}
+}
+
+// nextPacket takes the queued characters and operations into a new packet,
+// it returns nil if nothing is queued.
+func (s *SOL) nextPacket(sendSeq *uint8) *SOLPacket {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.outbuf) == 0 && s.ops == 0 {
+		return nil
+	}
+
+	n := len(s.outbuf)
+	if n > s.maxData {
+		n = s.maxData
+	}
+	data := make([]byte, n)
+	copy(data, s.outbuf[:n])
+	s.outbuf = s.outbuf[n:]
+
+	ops := s.ops
+	s.ops = 0
+	s.cond.Broadcast()
+
+	*sendSeq = *sendSeq%SOLPacketSequenceMax + 1
+	return &SOLPacket{
+		SequenceNumber:  *sendSeq,
+		OperationStatus: uint8(ops),
+		Data:            data,
+	}
+}
+
+// requeue puts the characters not accepted by the BMC back to the front of the queue.
+func (s *SOL) requeue(data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.outbuf = append(append([]byte{}, data...), s.outbuf...)
+}
+
+func (s *SOL) receive(data []byte) {
+	if len(data) == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inbuf = append(s.inbuf, data...)
+	s.cond.Broadcast()
+}
+
+func (s *SOL) send(pkt *SOLPacket) error {
+	s.c.Debug(">> SOL Packet", pkt)
+
+	s.p.sendMu.Lock()
+	defer s.p.sendMu.Unlock()
+
+	rmcp, err := s.c.buildRmcp(nil, PayloadTypeSOL, pkt.Pack())
+	if err != nil {
+		return fmt.Errorf("build RMCP+ sol msg failed, err: %w", err)
+	}
+	if _, err := s.conn.Write(rmcp.Pack()); err != nil {
+		return fmt.Errorf("write to conn failed, err: %w", err)
+	}
+	return nil
+}
+
+// parseSOLPacket validates and decrypts the received RMCP+ message of SOL payload.
+func (c *Client) parseSOLPacket(msg []byte) (*SOLPacket, error) {
+	rmcp := &Rmcp{}
+	if err := rmcp.Unpack(msg); err != nil {
+		return nil, fmt.Errorf("unpack rmcp failed, err: %w", err)
+	}
+	if rmcp.Session20 == nil || rmcp.Session20.SessionHeader20.PayloadType != PayloadTypeSOL {
+		return nil, fmt.Errorf("not a sol payload")
+	}
+
+	if err := c.checkSession20(msg, rmcp.Session20); err != nil {
+		return nil, err
+	}
+
+	payload := rmcp.Session20.SessionPayload
+	if rmcp.Session20.SessionHeader20.PayloadEncrypted {
+		d, err := c.decryptPayload(payload)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt session payload failed, err: %w", err)
+		}
+		payload = d
+	}
+
+	pkt := &SOLPacket{}
+	if err := pkt.Unpack(payload); err != nil {
+		return nil, fmt.Errorf("unpack sol packet failed, err: %w", err)
+	}
+	return pkt, nil
+}
@@ -0,0 +1,159 @@
+package ipmi
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+// buildLAN20Response builds an unauthenticated RMCP+ message of the session.
+func buildLAN20Response(sessionID uint32, payloadType PayloadType, payload []byte) []byte {
+	msg := []byte{0x06, 0x00, 0xff, 0x07, uint8(AuthTypeRMCPPlus), uint8(payloadType)}
+	msg = binary.LittleEndian.AppendUint32(msg, sessionID)
+	msg = binary.LittleEndian.AppendUint32(msg, 0)
+	msg = binary.LittleEndian.AppendUint16(msg, uint16(len(payload)))
+	return append(msg, payload...)
+}
+
+func Test_OpenSOL(t *testing.T) {
+	const consoleSessionID uint32 = 0x0a0b0c0d
+
+	server, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("listen udp failed, err: %s", err)
+	}
+	defer server.Close()
+
+	// The server accepts at most 4 characters per packet, and only 2 characters of the first packet.
+	// After the SOL is activated, it sends "hello" twice in the same packet to check the dedup.
+	inbound := make(chan []byte, 16)
+	operations := make(chan uint8, 16)
+	deactivated := make(chan struct{})
+	go func() {
+		buf := make([]byte, 1024)
+		firstData := true
+		for {
+			n, addr, err := server.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			msg := buf[:n]
+			payloadType := PayloadType(msg[5] & 0x3f)
+			payloadLen := int(binary.LittleEndian.Uint16(msg[14:16]))
+			payload := msg[16 : 16+payloadLen]
+
+			switch payloadType {
+			case PayloadTypeIPMI:
+				rqSeq := payload[4] >> 2
+				// reuse the lan builder by prepending a dummy IPMI v1.5 session header
+				recv := append(make([]byte, 14), payload...)
+				switch payload[5] {
+				case uint8(CommandActivatePayload.ID):
+					data := []byte{0x00, 0x00, 0x00, 0x00, 0x08, 0x00, 0x08, 0x00, 0x00, 0x00, 0xff, 0xff}
+					ipmiRes := buildLAN15Response(recv, rqSeq, data)[14:]
+					_, _ = server.WriteToUDP(buildLAN20Response(consoleSessionID, PayloadTypeIPMI, ipmiRes), addr)
+
+					hello := &SOLPacket{SequenceNumber: 1, Data: []byte("hello")}
+					for i := 0; i < 2; i++ {
+						_, _ = server.WriteToUDP(buildLAN20Response(consoleSessionID, PayloadTypeSOL, hello.Pack()), addr)
+					}
+				case uint8(CommandDeactivatePayload.ID):
+					ipmiRes := buildLAN15Response(recv, rqSeq, nil)[14:]
+					_, _ = server.WriteToUDP(buildLAN20Response(consoleSessionID, PayloadTypeIPMI, ipmiRes), addr)
+					close(deactivated)
+				}
+
+			case PayloadTypeSOL:
+				pkt := &SOLPacket{}
+				if err := pkt.Unpack(payload); err != nil || pkt.SequenceNumber == 0 {
+					continue
+				}
+				if len(pkt.Data) > 4 {
+					t.Errorf("sol packet carries %d characters, exceeds 4", len(pkt.Data))
+				}
+				accepted := len(pkt.Data)
+				if firstData && accepted > 2 {
+					firstData = false
+					accepted = 2
+				}
+				inbound <- append([]byte{}, pkt.Data[:accepted]...)
+				operations <- pkt.OperationStatus
+
+				ack := &SOLPacket{AckSequenceNumber: pkt.SequenceNumber, AcceptedCharacterCount: uint8(accepted)}
+				_, _ = server.WriteToUDP(buildLAN20Response(consoleSessionID, PayloadTypeSOL, ack.Pack()), addr)
+			}
+		}
+	}()
+
+	addr := server.LocalAddr().(*net.UDPAddr)
+	client, err := NewClient(addr.IP.String(), addr.Port, "user", "pass")
+	if err != nil {
+		t.Fatalf("new client failed, err: %s", err)
+	}
+	client.WithTimeout(5 * time.Second)
+	client.session.v20.state = SessionStateActive
+	client.session.v20.consoleSessionID = consoleSessionID
+	client.session.v20.bmcSessionID = 0x11223344
+	defer client.udpClient.Close()
+
+	sol, err := client.OpenSOL(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("OpenSOL failed, err: %s", err)
+	}
+
+	got := make([]byte, 5)
+	if _, err := io.ReadFull(sol, got); err != nil {
+		t.Fatalf("read sol failed, err: %s", err)
+	}
+	if string(got) != "hello" {
+		t.Errorf("read sol not match, expect: hello, got: %q", got)
+	}
+
+	if _, err := sol.Write([]byte("abcdefgh")); err != nil {
+		t.Fatalf("write sol failed, err: %s", err)
+	}
+	var sent []byte
+	for len(sent) < 8 {
+		select {
+		case data := <-inbound:
+			sent = append(sent, data...)
+			<-operations
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for sol characters, got: %q", sent)
+		}
+	}
+	if !bytes.Equal(sent, []byte("abcdefgh")) {
+		t.Errorf("sent characters not match, expect: abcdefgh, got: %q", sent)
+	}
+
+	if err := sol.SendBreak(); err != nil {
+		t.Fatalf("send break failed, err: %s", err)
+	}
+	select {
+	case op := <-operations:
+		if SOLOperation(op)&SOLOperationGenerateBreak == 0 {
+			t.Errorf("break not generated, operation: %#02x", op)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for break")
+	}
+
+	if err := sol.Close(); err != nil {
+		t.Fatalf("close sol failed, err: %s", err)
+	}
+	select {
+	case <-deactivated:
+	case <-time.After(5 * time.Second):
+		t.Fatal("sol payload not deactivated")
+	}
+	if _, err := sol.Read(got); err != io.EOF {
+		t.Errorf("read closed sol, expect io.EOF, got: %v", err)
+	}
+	if client.pipeline != nil {
+		t.Error("pipeline installed by OpenSOL not removed by Close")
+	}
+}
@@ -1,7 +1,9 @@
 package commands
 
 import (
+	"encoding/hex"
 	"fmt"
+	"strings"
 
 	"github.com/bougou/go-ipmi"
 	"github.com/spf13/cobra"
@@ -22,6 +24,7 @@ func NewCmdChannel() *cobra.Command {
 	}
 	cmd.AddCommand(NewCmdChannelInfo())
 	cmd.AddCommand(NewCmdChannelGetCiphers())
+	cmd.AddCommand(NewCmdChannelSetKG())
 
 	return cmd
 }
@@ -101,3 +104,44 @@ func NewCmdChannelGetCiphers() *cobra.Command {
 	}
 	return cmd
 }
+
+func NewCmdChannelSetKG() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "setkg",
+		Short: "setkg <hex|plain> <key> [channel]",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) < 2 {
+				CheckErr(fmt.Errorf("usage: setkg <hex|plain> <key> [channel]"))
+			}
+
+			var key []byte
+			switch args[0] {
+			case "hex":
+				b, err := hex.DecodeString(strings.TrimPrefix(args[1], "0x"))
+				if err != nil {
+					CheckErr(fmt.Errorf("invalid hex key, err: %s", err))
+				}
+				key = b
+			case "plain":
+				key = []byte(args[1])
+			default:
+				CheckErr(fmt.Errorf("unknown key format %s, should be hex or plain", args[0]))
+			}
+
+			var channelNumber uint8 = ipmi.ChannelNumberSelf
+			if len(args) >= 3 {
+				i, err := parseStringToInt64(args[2])
+				if err != nil {
+					CheckErr(fmt.Errorf("invalid channel number, err: %s", err))
+				}
+				channelNumber = uint8(i)
+			}
+
+			if _, err := client.SetBMCKey(channelNumber, key); err != nil {
+				CheckErr(fmt.Errorf("SetBMCKey failed, err: %s", err))
+			}
+			fmt.Println("Set Channel Security Keys command successful")
+		},
+	}
+	return cmd
+}
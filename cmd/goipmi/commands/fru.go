@@ -1,7 +1,10 @@
 package commands
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
 )
@@ -20,6 +23,7 @@ func NewCmdFRU() *cobra.Command {
 		},
 	}
 	cmd.AddCommand(NewCmdFRUPrint())
+	cmd.AddCommand(NewCmdFRURead())
 
 	return cmd
 }
@@ -57,3 +61,30 @@ func NewCmdFRUPrint() *cobra.Command {
 	}
 	return cmd
 }
+
+func NewCmdFRURead() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "read",
+		Short: "read raw FRU data to file",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) < 2 {
+				CheckErr(errors.New("no FRU Device ID or file supplied, usage: fru read <fruID> <file>"))
+			}
+			id, err := parseStringToInt64(args[0])
+			if err != nil {
+				CheckErr(fmt.Errorf("invalid FRU Device ID passed, err: %s", err))
+			}
+			fruID := uint8(id)
+
+			var buf bytes.Buffer
+			if err := client.DumpFRU(fruID, &buf); err != nil {
+				CheckErr(fmt.Errorf("DumpFRU failed, err: %s", err))
+			}
+			if err := os.WriteFile(args[1], buf.Bytes(), 0644); err != nil {
+				CheckErr(fmt.Errorf("write file failed, err: %s", err))
+			}
+			fmt.Printf("Read %d bytes of FRU data to '%s'\n", buf.Len(), args[1])
+		},
+	}
+	return cmd
+}
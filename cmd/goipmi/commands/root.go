@@ -1,9 +1,13 @@
 package commands
 
 import (
+	"encoding/hex"
+	"errors"
 	"flag"
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/bougou/go-ipmi"
 	"github.com/spf13/cobra"
@@ -22,7 +26,28 @@ var (
 	privilegeLevel string
 	showVersion    bool
 
-	client *ipmi.Client
+	retries        int
+	attemptTimeout int
+
+	bmcKey    string
+	bmcKeyHex string
+
+	toolPath string
+
+	siAddr  uint16
+	ssifBus int
+
+	targetChannel  uint8
+	targetAddr     uint8
+	transitChannel uint8
+	transitAddr    uint8
+
+	recordFile   string
+	sdrCacheFile string
+
+	client   *ipmi.Client
+	record   *os.File
+	sdrCache *ipmi.SDRCache
 )
 
 func initClient() error {
@@ -40,6 +65,25 @@ func initClient() error {
 		}
 		client = c
 
+	case "kcs":
+		c, err := ipmi.NewKCSClient(siAddr)
+		if err != nil {
+			return fmt.Errorf("create kcs client failed, err: %s", err)
+		}
+		client = c
+	case "bt":
+		c, err := ipmi.NewBTClient(siAddr)
+		if err != nil {
+			return fmt.Errorf("create bt client failed, err: %s", err)
+		}
+		client = c
+	case "ssif":
+		c, err := ipmi.NewSSIFClient(ssifBus, uint8(siAddr))
+		if err != nil {
+			return fmt.Errorf("create ssif client failed, err: %s", err)
+		}
+		client = c
+
 	case "lan", "lanplus":
 		c, err := ipmi.NewClient(host, port, username, password)
 		if err != nil {
@@ -47,15 +91,47 @@ func initClient() error {
 		}
 		client = c
 	case "tool":
-		c, err := ipmi.NewToolClient(host)
+		if toolPath == "" {
+			// for compatibility, the host is the path of ipmitool if --tool-path is not specified
+			c, err := ipmi.NewToolClient(host)
+			if err != nil {
+				return fmt.Errorf("create client based on ipmitool (%s) failed, err: %s", host, err)
+			}
+			client = c
+			break
+		}
+		if host == "" {
+			c, err := ipmi.NewToolClient(toolPath)
+			if err != nil {
+				return fmt.Errorf("create client based on ipmitool (%s) failed, err: %s", toolPath, err)
+			}
+			client = c
+			break
+		}
+		c, err := ipmi.NewToolLanplusClient(toolPath, host, port, username, password)
 		if err != nil {
-			return fmt.Errorf("create client based on ipmitool (%s) failed, err: %s", host, err)
+			return fmt.Errorf("create lanplus client based on ipmitool (%s) failed, err: %s", toolPath, err)
 		}
 		client = c
 	}
 
 	client.WithDebug(debug)
 	client.WithInterface(ipmi.Interface(intf))
+	client.WithRetries(retries)
+	if attemptTimeout > 0 {
+		client.WithAttemptTimeout(time.Duration(attemptTimeout) * time.Second)
+	}
+
+	if bmcKey != "" {
+		client.WithBMCKey([]byte(bmcKey))
+	}
+	if bmcKeyHex != "" {
+		key, err := hex.DecodeString(strings.TrimPrefix(bmcKeyHex, "0x"))
+		if err != nil {
+			return fmt.Errorf("invalid hex bmc key, err: %s", err)
+		}
+		client.WithBMCKey(key)
+	}
 
 	var privLevel ipmi.PrivilegeLevel = ipmi.PrivilegeLevelUnspecified
 	switch strings.ToUpper(privilegeLevel) {
@@ -73,6 +149,35 @@ func initClient() error {
 		client.WithMaxPrivilegeLevel(privLevel)
 	}
 
+	if targetAddr != 0 {
+		client.WithTarget(targetChannel, targetAddr, 0)
+	}
+	if transitAddr != 0 {
+		client.WithTransit(transitChannel, transitAddr)
+	}
+
+	if recordFile != "" {
+		f, err := os.OpenFile(recordFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return fmt.Errorf("open record file failed, err: %s", err)
+		}
+		transport, err := client.Transport()
+		if err != nil {
+			f.Close()
+			return err
+		}
+		client.WithTransport(ipmi.NewRecordTransport(transport, f))
+		record = f
+	}
+
+	if sdrCacheFile != "" {
+		sdrCache = ipmi.NewSDRCache()
+		if err := sdrCache.LoadFile(sdrCacheFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("load sdr cache failed, err: %s", err)
+		}
+		client.WithSDRCache(sdrCache)
+	}
+
 	if err := client.Connect(); err != nil {
 		return fmt.Errorf("client connect failed, err: %s", err)
 	}
@@ -83,6 +188,16 @@ func closeClient() error {
 	if err := client.Close(); err != nil {
 		return fmt.Errorf("close client failed, err: %s", err)
 	}
+	if record != nil {
+		if err := record.Close(); err != nil {
+			return fmt.Errorf("close record file failed, err: %s", err)
+		}
+	}
+	if sdrCache != nil {
+		if err := sdrCache.SaveFile(sdrCacheFile); err != nil {
+			return fmt.Errorf("save sdr cache failed, err: %s", err)
+		}
+	}
 	return nil
 }
 
@@ -107,11 +222,30 @@ func NewRootCommand() *cobra.Command {
 	rootCmd.PersistentFlags().IntVarP(&port, "port", "p", 623, "port")
 	rootCmd.PersistentFlags().StringVarP(&username, "user", "U", "", "username")
 	rootCmd.PersistentFlags().StringVarP(&password, "pass", "P", "", "password")
-	rootCmd.PersistentFlags().StringVarP(&intf, "interface", "I", "open", "interface, supported (open,lan,lanplus)")
+	rootCmd.PersistentFlags().StringVarP(&intf, "interface", "I", "open", "interface, supported (open,lan,lanplus,tool,kcs,bt,ssif)")
 	rootCmd.PersistentFlags().BoolVarP(&debug, "debug", "d", false, "debug")
 	rootCmd.PersistentFlags().BoolVarP(&showVersion, "version", "V", false, "version")
 	rootCmd.PersistentFlags().StringVarP(&privilegeLevel, "priv-level", "L", "ADMINISTRATOR", "Force session privilege level. Can be CALLBACK, USER, OPERATOR, ADMINISTRATOR.")
 
+	rootCmd.PersistentFlags().IntVarP(&retries, "retries", "R", 0, "Set the number of retries for lan/lanplus interface.")
+	rootCmd.PersistentFlags().IntVarP(&attemptTimeout, "attempt-timeout", "N", 0, "Specify nr of seconds between retransmissions for lan/lanplus interface.")
+
+	rootCmd.PersistentFlags().StringVarP(&bmcKey, "kg-key", "k", "", "Use Kg key for IPMIv2 authentication.")
+	rootCmd.PersistentFlags().StringVarP(&bmcKeyHex, "kg-hex", "y", "", "Use hexadecimal-encoded Kg key for IPMIv2 authentication.")
+
+	rootCmd.PersistentFlags().StringVar(&toolPath, "tool-path", "", "The path of ipmitool for tool interface, then the host, user and password are passed to ipmitool lanplus interface.")
+
+	rootCmd.PersistentFlags().Uint16Var(&siAddr, "si-addr", 0, "The I/O port base address for kcs/bt interface, or the SMBus slave address for ssif interface, 0 means the default.")
+	rootCmd.PersistentFlags().IntVar(&ssifBus, "ssif-bus", 0, "The i2c bus number (/dev/i2c-N) for ssif interface.")
+
+	rootCmd.PersistentFlags().Uint8VarP(&targetChannel, "target-channel", "b", 0, "Set destination channel for bridged request.")
+	rootCmd.PersistentFlags().Uint8VarP(&targetAddr, "target-addr", "t", 0, "Bridge request to remote target address.")
+	rootCmd.PersistentFlags().Uint8VarP(&transitChannel, "transit-channel", "B", 0, "Set transit channel for bridged request (dual bridge).")
+	rootCmd.PersistentFlags().Uint8VarP(&transitAddr, "transit-addr", "T", 0, "Set transit address for bridged request (dual bridge).")
+
+	rootCmd.PersistentFlags().StringVar(&recordFile, "record", "", "Append the request and response bytes of each exchange to the file, which can be replayed by ipmi.LoadReplayTransport.")
+	rootCmd.PersistentFlags().StringVar(&sdrCacheFile, "sdr-cache", "", "Cache the SDR records in the file, the SDR repository is only read again if it changes.")
+
 	rootCmd.Flags().AddGoFlagSet(flag.CommandLine)
 
 	rootCmd.AddCommand(NewCmdMC())
@@ -1,7 +1,9 @@
 package commands
 
 import (
+	"bytes"
 	"fmt"
+	"os"
 	"strconv"
 
 	"github.com/bougou/go-ipmi"
@@ -26,6 +28,7 @@ func NewCmdSDR() *cobra.Command {
 	cmd.AddCommand(NewCmdSDRGet())
 	cmd.AddCommand(NewCmdSDRList())
 	cmd.AddCommand(NewCmdSDRType())
+	cmd.AddCommand(NewCmdSDRDump())
 
 	return cmd
 }
@@ -215,3 +218,28 @@ func NewCmdSDRList() *cobra.Command {
 
 	return cmd
 }
+
+func NewCmdSDRDump() *cobra.Command {
+	usage := `sdr dump <file>`
+
+	cmd := &cobra.Command{
+		Use:   "dump",
+		Short: "dump raw SDR records to file",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) < 1 {
+				CheckErr(fmt.Errorf("no file supplied, usage: %s", usage))
+			}
+
+			var buf bytes.Buffer
+			if err := client.DumpSDRRepository(&buf); err != nil {
+				CheckErr(fmt.Errorf("DumpSDRRepository failed, err: %s", err))
+			}
+			if err := os.WriteFile(args[0], buf.Bytes(), 0644); err != nil {
+				CheckErr(fmt.Errorf("write file failed, err: %s", err))
+			}
+			fmt.Printf("Dumped Sensor Data Repository to '%s'\n", args[0])
+		},
+	}
+
+	return cmd
+}
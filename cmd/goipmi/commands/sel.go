@@ -1,8 +1,10 @@
 package commands
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"os"
 
 	"github.com/bougou/go-ipmi"
 	"github.com/spf13/cobra"
@@ -25,6 +27,7 @@ func NewCmdSEL() *cobra.Command {
 	cmd.AddCommand(NewCmdSELGet())
 	cmd.AddCommand(NewCmdSELList())
 	cmd.AddCommand(NewCmdSELElist())
+	cmd.AddCommand(NewCmdSELSave())
 
 	return cmd
 }
@@ -115,3 +118,25 @@ func NewCmdSELElist() *cobra.Command {
 	}
 	return cmd
 }
+
+func NewCmdSELSave() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "save",
+		Short: "save SEL records to file",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) < 1 {
+				CheckErr(errors.New("no file supplied, usage: sel save <file>"))
+			}
+
+			var buf bytes.Buffer
+			if err := client.SaveSEL(&buf); err != nil {
+				CheckErr(fmt.Errorf("SaveSEL failed, err: %s", err))
+			}
+			if err := os.WriteFile(args[0], buf.Bytes(), 0644); err != nil {
+				CheckErr(fmt.Errorf("write file failed, err: %s", err))
+			}
+			fmt.Printf("Saved SEL records to '%s'\n", args[0])
+		},
+	}
+	return cmd
+}
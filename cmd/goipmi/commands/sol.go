@@ -1,8 +1,15 @@
 package commands
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
 
+	"github.com/bougou/go-ipmi"
 	"github.com/spf13/cobra"
 )
 
@@ -20,6 +27,7 @@ func NewCmdSOL() *cobra.Command {
 		},
 	}
 	cmd.AddCommand(NewCmdSOLInfo())
+	cmd.AddCommand(NewCmdSOLActivate())
 
 	return cmd
 }
@@ -38,3 +46,149 @@ func NewCmdSOLInfo() *cobra.Command {
 	}
 	return cmd
 }
+
+func NewCmdSOLActivate() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "activate [instance]",
+		Short: "activate",
+		Long:  "Activate the SOL payload and attach it to the terminal, type '~.' at the beginning of a line to exit, '~B' to send a break.",
+		Run: func(cmd *cobra.Command, args []string) {
+			var instance uint8 = 1
+			if len(args) >= 1 {
+				id, err := parseStringToInt64(args[0])
+				if err != nil {
+					CheckErr(fmt.Errorf("invalid instance, err: %s", err))
+				}
+				instance = uint8(id)
+			}
+
+			ctx := context.Background()
+			sol, err := client.OpenSOL(ctx, instance)
+			if err != nil {
+				CheckErr(fmt.Errorf("OpenSOL failed, err: %s", err))
+			}
+
+			fmt.Fprintf(os.Stderr, "[SOL Session operational.  Use ~? for help]\n")
+
+			restore := setTerminalRaw()
+
+			errCh := make(chan error, 2)
+			go func() {
+				_, err := io.Copy(os.Stdout, sol)
+				errCh <- err
+			}()
+			go func() {
+				errCh <- copySOLInput(sol, os.Stdin)
+			}()
+
+			// CheckErr exits without running the deferred functions,
+			// so the terminal is restored and the SOL payload is deactivated before it.
+			err = <-errCh
+			restore()
+			closeErr := sol.Close()
+			if err != nil && err != io.EOF {
+				CheckErr(fmt.Errorf("SOL session failed, err: %s", err))
+			}
+			if closeErr != nil {
+				CheckErr(fmt.Errorf("close SOL failed, err: %s", closeErr))
+			}
+
+			fmt.Fprintf(os.Stderr, "\n[terminated goipmi]\n")
+		},
+	}
+	return cmd
+}
+
+// copySOLInput copies the input to the SOL until the escape sequence '~.' is
+// typed at the beginning of a line. '~B' sends a break, '~~' sends a '~'.
+func copySOLInput(sol *ipmi.SOL, in io.Reader) error {
+	const escapeChar = '~'
+
+	buf := make([]byte, 256)
+	lineStart := true
+	escaped := false
+
+	for {
+		n, err := in.Read(buf)
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		out := make([]byte, 0, n)
+		for _, b := range buf[:n] {
+			if escaped {
+				escaped = false
+				switch b {
+				case '.':
+					if _, err := sol.Write(out); err != nil {
+						return err
+					}
+					return nil
+				case 'B':
+					if err := sol.SendBreak(); err != nil {
+						return err
+					}
+					continue
+				case '?':
+					fmt.Fprintf(os.Stderr, "\r\nSupported escape sequences:\r\n"+
+						"  ~.  - terminate connection\r\n"+
+						"  ~B  - send a BREAK\r\n"+
+						"  ~?  - this message\r\n"+
+						"  ~~  - send the escape character by typing it twice\r\n")
+					continue
+				case escapeChar:
+					out = append(out, b)
+					lineStart = false
+					continue
+				default:
+					out = append(out, escapeChar)
+				}
+			} else if lineStart && b == escapeChar {
+				escaped = true
+				continue
+			}
+
+			out = append(out, b)
+			lineStart = b == '\r' || b == '\n'
+		}
+
+		if len(out) > 0 {
+			if _, err := sol.Write(out); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// setTerminalRaw puts the terminal into raw mode by stty, and returns the function to restore it.
+// Nothing is changed if the stdin is not a terminal.
+func setTerminalRaw() func() {
+	fi, err := os.Stdin.Stat()
+	if err != nil || fi.Mode()&os.ModeCharDevice == 0 {
+		return func() {}
+	}
+
+	stty := func(args ...string) ([]byte, error) {
+		c := exec.Command("stty", args...)
+		c.Stdin = os.Stdin
+		return c.Output()
+	}
+
+	state, err := stty("-g")
+	if err != nil {
+		return func() {}
+	}
+	if _, err := stty("raw", "-echo"); err != nil {
+		return func() {}
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			_, _ = stty(strings.TrimSpace(string(state)))
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package ipmi
+
+import (
+	"context"
+	"fmt"
+)
+
+// 24.1 Activate Payload Command
+type ActivatePayloadRequest struct {
+	PayloadType     PayloadType
+	PayloadInstance uint8
+
+	// The auxiliary request data for SOL payload (byte 3)
+	//
+	// [7] - Encryption Activation. 1b = activate encryption for payload, if supported
+	// [6] - Authentication Activation. 1b = activate authentication for payload, if supported
+	// [5] - Test mode.
+	// [3:2] - Shared serial alert behavior.
+	//         00b = Serial/modem alerts fail while SOL active
+	//         01b = Serial/modem alerts are deferred while SOL active
+	//         10b = Serial/modem alerts succeed while SOL active
+	// [1] - SOL startup handshake.
+	//         0b = BMC asserts CTS and DCD/DSR to baseboard upon activation
+	//         1b = CTS and DCD/DSR remain deasserted after activation
+	EncryptionActivation     bool
+	AuthenticationActivation bool
+	TestMode                 bool
+	SharedSerialAlert        uint8
+	DeassertHandshake        bool
+
+	// Auxiliary request data for the payload types other than SOL, 4 bytes.
+	AuxData []byte
+}
+
+type ActivatePayloadResponse struct {
+	AuxData []byte // 4 bytes
+
+	// Maximum size of payload data that BMC can accept from remote console, LS-byte first.
+	InboundPayloadSize uint16
+	// Maximum size of payload data that BMC can send to remote console, LS-byte first.
+	OutboundPayloadSize uint16
+
+	// the UDP port number that the remote console should use for the payload.
+	PayloadUDPPort uint16
+	// FFFFh if VLAN addressing is not used.
+	PayloadVLANNumber uint16
+}
+
+func (req *ActivatePayloadRequest) Command() Command {
+	return CommandActivatePayload
+}
+
+func (req *ActivatePayloadRequest) Pack() []byte {
+	out := make([]byte, 6)
+	packUint8(uint8(req.PayloadType)&0x3f, out, 0)
+	packUint8(req.PayloadInstance&0x0f, out, 1)
+
+	if req.PayloadType == PayloadTypeSOL {
+		var b uint8 = 0
+		if req.EncryptionActivation {
+			b = setBit7(b)
+		}
+		if req.AuthenticationActivation {
+			b = setBit6(b)
+		}
+		if req.TestMode {
+			b = setBit5(b)
+		}
+		b |= (req.SharedSerialAlert & 0x03) << 2
+		if req.DeassertHandshake {
+			b = setBit1(b)
+		}
+		packUint8(b, out, 2)
+		return out
+	}
+
+	packBytes(req.AuxData, out, 2)
+	return out
+}
+
+func (res *ActivatePayloadResponse) Unpack(msg []byte) error {
+	if len(msg) < 12 {
+		return ErrUnpackedDataTooShortWith(len(msg), 12)
+	}
+	res.AuxData, _, _ = unpackBytes(msg, 0, 4)
+	res.InboundPayloadSize, _, _ = unpackUint16L(msg, 4)
+	res.OutboundPayloadSize, _, _ = unpackUint16L(msg, 6)
+	res.PayloadUDPPort, _, _ = unpackUint16L(msg, 8)
+	res.PayloadVLANNumber, _, _ = unpackUint16L(msg, 10)
+	return nil
+}
+
+func (res *ActivatePayloadResponse) CompletionCodes() map[uint8]string {
+	return map[uint8]string{
+		0x80: "Payload already active on another session",
+		0x81: "Payload type is disabled",
+		0x82: "Payload activation limit reached",
+		0x83: "Cannot activate payload with encryption",
+		0x84: "Cannot activate payload without encryption",
+	}
+}
+
+func (res *ActivatePayloadResponse) Format() string {
+	return fmt.Sprintf(`Inbound Payload Size  : %d
+Outbound Payload Size : %d
+Payload UDP Port      : %d
+Payload VLAN Number   : %#04x`,
+		res.InboundPayloadSize,
+		res.OutboundPayloadSize,
+		res.PayloadUDPPort,
+		res.PayloadVLANNumber,
+	)
+}
+
+func (c *Client) ActivatePayload(request *ActivatePayloadRequest) (response *ActivatePayloadResponse, err error) {
+	return c.ActivatePayloadContext(context.Background(), request)
+}
+
+// ActivatePayloadContext is like ActivatePayload but takes a context.
+func (c *Client) ActivatePayloadContext(ctx context.Context, request *ActivatePayloadRequest) (response *ActivatePayloadResponse, err error) {
+	response = &ActivatePayloadResponse{}
+	err = c.ExchangeContext(ctx, request, response)
+	return
+}
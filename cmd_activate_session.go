@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -122,6 +123,11 @@ func (res *ActivateSessionResponse) Format() string {
 
 // ActivateSession is only used for IPMI v1.5
 func (c *Client) ActivateSession() (response *ActivateSessionResponse, err error) {
+	return c.ActivateSessionContext(context.Background())
+}
+
+// ActivateSessionContext is like ActivateSession but takes a context.
+func (c *Client) ActivateSessionContext(ctx context.Context) (response *ActivateSessionResponse, err error) {
 	request := &ActivateSessionRequest{
 		AuthTypeForSession: c.session.authType,
 		MaxPrivilegeLevel:  c.maxPrivilegeLevel,
@@ -137,7 +143,7 @@ func (c *Client) ActivateSession() (response *ActivateSessionResponse, err error
 	// The Activate Session packet is typically authenticated.
 	// We set session to active here to indicate this request should be authenticated
 	// but if ActivateSession Command failed, we should set session active to false
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,60 @@
+package ipmi
+
+import (
+	"context"
+	"fmt"
+)
+
+// 33.13 Add SDR Command
+type AddSDRRequest struct {
+	// RecordData is the raw SDR record (with the 5 bytes header), the Record ID in the header is ignored.
+	RecordData []byte
+}
+
+type AddSDRResponse struct {
+	RecordID uint16 // Record ID for added record, LS Byte first
+}
+
+func (req *AddSDRRequest) Command() Command {
+	return CommandAddSDR
+}
+
+func (req *AddSDRRequest) Pack() []byte {
+	return req.RecordData
+}
+
+func (res *AddSDRResponse) Unpack(msg []byte) error {
+	if len(msg) < 2 {
+		return ErrUnpackedDataTooShortWith(len(msg), 2)
+	}
+	res.RecordID, _, _ = unpackUint16L(msg, 0)
+	return nil
+}
+
+func (res *AddSDRResponse) CompletionCodes() map[uint8]string {
+	return map[uint8]string{}
+}
+
+func (res *AddSDRResponse) Format() string {
+	return fmt.Sprintf("Record ID : %d (%#02x)", res.RecordID, res.RecordID)
+}
+
+// AddSDR adds the SDR to the SDR Repository, the Record ID of the SDR is assigned by the BMC.
+func (c *Client) AddSDR(sdr *SDR) (response *AddSDRResponse, err error) {
+	return c.AddSDRContext(context.Background(), sdr)
+}
+
+// AddSDRContext is like AddSDR but takes a context.
+func (c *Client) AddSDRContext(ctx context.Context, sdr *SDR) (response *AddSDRResponse, err error) {
+	recordData, err := sdr.Pack()
+	if err != nil {
+		return nil, fmt.Errorf("pack sdr failed, err: %w", err)
+	}
+
+	request := &AddSDRRequest{
+		RecordData: recordData,
+	}
+	response = &AddSDRResponse{}
+	err = c.ExchangeContext(ctx, request, response)
+	return
+}
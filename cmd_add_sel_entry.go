@@ -1,6 +1,9 @@
 package ipmi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // 31.6 Add SEL Entry Command
 type AddSELEntryRequest struct {
@@ -39,10 +42,15 @@ func (res *AddSELEntryResponse) Format() string {
 }
 
 func (c *Client) AddSELEntry(sel *SEL) (response *AddSELEntryResponse, err error) {
+	return c.AddSELEntryContext(context.Background(), sel)
+}
+
+// AddSELEntryContext is like AddSELEntry but takes a context.
+func (c *Client) AddSELEntryContext(ctx context.Context, sel *SEL) (response *AddSELEntryResponse, err error) {
 	request := &AddSELEntryRequest{
 		SEL: sel,
 	}
 	response = &AddSELEntryResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
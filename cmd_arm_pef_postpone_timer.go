@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -59,8 +60,13 @@ func (res *ArmPEFPostponeTimerResponse) Format() string {
 }
 
 func (c *Client) ArmPEFPostponeTimer() (response *ArmPEFPostponeTimerResponse, err error) {
+	return c.ArmPEFPostponeTimerContext(context.Background())
+}
+
+// ArmPEFPostponeTimerContext is like ArmPEFPostponeTimer but takes a context.
+func (c *Client) ArmPEFPostponeTimerContext(ctx context.Context) (response *ArmPEFPostponeTimerResponse, err error) {
 	request := &ArmPEFPostponeTimerRequest{}
 	response = &ArmPEFPostponeTimerResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 type ChassisControl uint8
 
 const (
@@ -42,10 +44,15 @@ func (res *ChassisControlResponse) Format() string {
 }
 
 func (c *Client) ChassisControl(control ChassisControl) (response *ChassisControlResponse, err error) {
+	return c.ChassisControlContext(context.Background(), control)
+}
+
+// ChassisControlContext is like ChassisControl but takes a context.
+func (c *Client) ChassisControlContext(ctx context.Context, control ChassisControl) (response *ChassisControlResponse, err error) {
 	request := &ChassisControlRequest{
 		ChassisControl: control,
 	}
 	response = &ChassisControlResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 28.5 Chassis Identify Command
 // 用来定位设备，机箱定位 （机箱定位灯默认亮 interval 秒）
 type ChassisIdentifyRequest struct {
@@ -43,11 +45,16 @@ func (res *ChassisIdentifyResponse) Format() string {
 // chosen by the system implementation; such as turning on blinking user-visible lights
 // or emitting beeps via a speaker, LCD panel, etc.
 func (c *Client) ChassisIdentify(interval uint8, force bool) (response *ChassisIdentifyResponse, err error) {
+	return c.ChassisIdentifyContext(context.Background(), interval, force)
+}
+
+// ChassisIdentifyContext is like ChassisIdentify but takes a context.
+func (c *Client) ChassisIdentifyContext(ctx context.Context, interval uint8, force bool) (response *ChassisIdentifyResponse, err error) {
 	request := &ChassisIdentifyRequest{
 		IdentifyInterval: interval,
 		ForceIdentifyOn:  force,
 	}
 	response = &ChassisIdentifyResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
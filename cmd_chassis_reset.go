@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 28.4 Chassis Reset Command
 type ChassisResetRequest struct {
 	// empty
@@ -33,8 +35,13 @@ func (res *ChassisResetResponse) Format() string {
 // It has been superseded by the Chassis Control command
 // For host systems, this corresponds to a system hard reset.
 func (c *Client) ChassisReset() (response *ChassisResetResponse, err error) {
+	return c.ChassisResetContext(context.Background())
+}
+
+// ChassisResetContext is like ChassisReset but takes a context.
+func (c *Client) ChassisResetContext(ctx context.Context) (response *ChassisResetResponse, err error) {
 	request := &ChassisResetRequest{}
 	response = &ChassisResetResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 22.3 Clear Message Flags Command
 type ClearMessageFlagsRequest struct {
 	ClearOEM2                            bool
@@ -56,8 +58,13 @@ func (res *ClearMessageFlagsResponse) Format() string {
 }
 
 func (c *Client) ClearMessageFlags() (response *ClearMessageFlagsResponse, err error) {
+	return c.ClearMessageFlagsContext(context.Background())
+}
+
+// ClearMessageFlagsContext is like ClearMessageFlags but takes a context.
+func (c *Client) ClearMessageFlagsContext(ctx context.Context) (response *ClearMessageFlagsResponse, err error) {
 	request := &ClearMessageFlagsRequest{}
 	response = &ClearMessageFlagsResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -0,0 +1,89 @@
+package ipmi
+
+import (
+	"context"
+	"fmt"
+)
+
+// 33.16 Clear SDR Repository Command
+type ClearSDRRepoRequest struct {
+	ReservationID        uint16 // LS Byte first
+	GetErasureStatusFlag bool
+}
+
+type ClearSDRRepoResponse struct {
+	ErasureProgressStatus uint8
+}
+
+func (req *ClearSDRRepoRequest) Pack() []byte {
+	var out = make([]byte, 6)
+	packUint16L(req.ReservationID, out, 0)
+	packUint8('C', out, 2) // fixed 'C' char
+	packUint8('L', out, 3) // fixed 'L' char
+	packUint8('R', out, 4) // fixed 'R' char
+	if req.GetErasureStatusFlag {
+		packUint8(0x00, out, 5) //  get erasure status
+	} else {
+		packUint8(0xaa, out, 5) //  initiate erase
+	}
+	return out
+}
+
+func (req *ClearSDRRepoRequest) Command() Command {
+	return CommandClearSDRRepo
+}
+
+func (res *ClearSDRRepoResponse) Unpack(msg []byte) error {
+	if len(msg) < 1 {
+		return ErrUnpackedDataTooShortWith(len(msg), 1)
+	}
+
+	res.ErasureProgressStatus, _, _ = unpackUint8(msg, 0)
+	return nil
+}
+
+// ErasureCompleted reports whether the erasure of the SDR Repository is completed.
+func (res *ClearSDRRepoResponse) ErasureCompleted() bool {
+	return res.ErasureProgressStatus&0x0f == 0x01
+}
+
+func (res *ClearSDRRepoResponse) CompletionCodes() map[uint8]string {
+	// no command-specific cc
+	return map[uint8]string{}
+}
+
+func (res *ClearSDRRepoResponse) Format() string {
+	return fmt.Sprintf("Erasure completed : %v", res.ErasureCompleted())
+}
+
+// ClearSDRRepo initiates the erasure of all the records in the SDR Repository.
+func (c *Client) ClearSDRRepo(reservationID uint16) (response *ClearSDRRepoResponse, err error) {
+	return c.ClearSDRRepoContext(context.Background(), reservationID)
+}
+
+// ClearSDRRepoContext is like ClearSDRRepo but takes a context.
+func (c *Client) ClearSDRRepoContext(ctx context.Context, reservationID uint16) (response *ClearSDRRepoResponse, err error) {
+	request := &ClearSDRRepoRequest{
+		ReservationID:        reservationID,
+		GetErasureStatusFlag: false,
+	}
+	response = &ClearSDRRepoResponse{}
+	err = c.ExchangeContext(ctx, request, response)
+	return
+}
+
+// GetSDRRepoErasureStatus gets the erasure status of the SDR Repository after ClearSDRRepo.
+func (c *Client) GetSDRRepoErasureStatus(reservationID uint16) (response *ClearSDRRepoResponse, err error) {
+	return c.GetSDRRepoErasureStatusContext(context.Background(), reservationID)
+}
+
+// GetSDRRepoErasureStatusContext is like GetSDRRepoErasureStatus but takes a context.
+func (c *Client) GetSDRRepoErasureStatusContext(ctx context.Context, reservationID uint16) (response *ClearSDRRepoResponse, err error) {
+	request := &ClearSDRRepoRequest{
+		ReservationID:        reservationID,
+		GetErasureStatusFlag: true,
+	}
+	response = &ClearSDRRepoResponse{}
+	err = c.ExchangeContext(ctx, request, response)
+	return
+}
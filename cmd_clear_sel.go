@@ -1,6 +1,9 @@
 package ipmi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // 31.9 Clear SEL Command
 type ClearSELRequest struct {
@@ -49,11 +52,16 @@ func (res *ClearSELResponse) Format() string {
 }
 
 func (c *Client) ClearSEL(reservationID uint16) (response *ClearSELResponse, err error) {
+	return c.ClearSELContext(context.Background(), reservationID)
+}
+
+// ClearSELContext is like ClearSEL but takes a context.
+func (c *Client) ClearSELContext(ctx context.Context, reservationID uint16) (response *ClearSELResponse, err error) {
 	request := &ClearSELRequest{
 		ReservationID:        reservationID,
 		GetErasureStatusFlag: false,
 	}
 	response = &ClearSELResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
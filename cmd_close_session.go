@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 22.19
 type CloseSessionRequest struct {
 	// For IPMI v2.0/RMCP+ this is the Managed System Session ID value that was generated by the BMC, not the ID from the remote console. If Session ID = 0000_0000h then an implementation can optionally enable this command to take an additional byte of parameter data that allows a session handle to be used to close a session.
@@ -42,7 +44,12 @@ func (res *CloseSessionResponse) Format() string {
 }
 
 func (c *Client) CloseSession(request *CloseSessionRequest) (response *CloseSessionResponse, err error) {
+	return c.CloseSessionContext(context.Background(), request)
+}
+
+// CloseSessionContext is like CloseSession but takes a context.
+func (c *Client) CloseSessionContext(ctx context.Context, request *CloseSessionRequest) (response *CloseSessionResponse, err error) {
 	response = &CloseSessionResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
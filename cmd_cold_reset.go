@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 20.2 Cold Reset Command
 type ColdResetRequest struct {
 	// empty
@@ -29,8 +31,13 @@ func (res *ColdResetResponse) Format() string {
 }
 
 func (c *Client) ColdReset() (err error) {
+	return c.ColdResetContext(context.Background())
+}
+
+// ColdResetContext is like ColdReset but takes a context.
+func (c *Client) ColdResetContext(ctx context.Context) (err error) {
 	request := &ColdResetRequest{}
 	response := &ColdResetResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -0,0 +1,59 @@
+package ipmi
+
+import (
+	"context"
+)
+
+// 24.2 Deactivate Payload Command
+type DeactivatePayloadRequest struct {
+	PayloadType     PayloadType
+	PayloadInstance uint8
+
+	// Payload Auxiliary Data, 4 bytes. For SOL, all bytes are reserved.
+	AuxData []byte
+}
+
+type DeactivatePayloadResponse struct {
+}
+
+func (req *DeactivatePayloadRequest) Command() Command {
+	return CommandDeactivatePayload
+}
+
+func (req *DeactivatePayloadRequest) Pack() []byte {
+	out := make([]byte, 6)
+	packUint8(uint8(req.PayloadType)&0x3f, out, 0)
+	packUint8(req.PayloadInstance&0x0f, out, 1)
+	packBytes(req.AuxData, out, 2)
+	return out
+}
+
+func (res *DeactivatePayloadResponse) Unpack(msg []byte) error {
+	return nil
+}
+
+func (res *DeactivatePayloadResponse) CompletionCodes() map[uint8]string {
+	return map[uint8]string{
+		0x80: "Payload already deactivated",
+		0x81: "Payload type is disabled",
+	}
+}
+
+func (res *DeactivatePayloadResponse) Format() string {
+	return ""
+}
+
+func (c *Client) DeactivatePayload(payloadType PayloadType, payloadInstance uint8) (response *DeactivatePayloadResponse, err error) {
+	return c.DeactivatePayloadContext(context.Background(), payloadType, payloadInstance)
+}
+
+// DeactivatePayloadContext is like DeactivatePayload but takes a context.
+func (c *Client) DeactivatePayloadContext(ctx context.Context, payloadType PayloadType, payloadInstance uint8) (response *DeactivatePayloadResponse, err error) {
+	request := &DeactivatePayloadRequest{
+		PayloadType:     payloadType,
+		PayloadInstance: payloadInstance,
+	}
+	response = &DeactivatePayloadResponse{}
+	err = c.ExchangeContext(ctx, request, response)
+	return
+}
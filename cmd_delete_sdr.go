@@ -0,0 +1,59 @@
+package ipmi
+
+import (
+	"context"
+	"fmt"
+)
+
+// 33.15 Delete SDR Command
+type DeleteSDRRequest struct {
+	ReservationID uint16
+	RecordID      uint16
+}
+
+type DeleteSDRResponse struct {
+	RecordID uint16 // Record ID for deleted record, LS Byte first
+}
+
+func (req *DeleteSDRRequest) Command() Command {
+	return CommandDeleteSDR
+}
+
+func (req *DeleteSDRRequest) Pack() []byte {
+	out := make([]byte, 4)
+	packUint16L(req.ReservationID, out, 0)
+	packUint16L(req.RecordID, out, 2)
+	return out
+}
+
+func (res *DeleteSDRResponse) Unpack(msg []byte) error {
+	if len(msg) < 2 {
+		return ErrUnpackedDataTooShortWith(len(msg), 2)
+	}
+	res.RecordID, _, _ = unpackUint16L(msg, 0)
+	return nil
+}
+
+func (res *DeleteSDRResponse) CompletionCodes() map[uint8]string {
+	return map[uint8]string{}
+}
+
+func (res *DeleteSDRResponse) Format() string {
+	return fmt.Sprintf("Record ID : %d (%#02x)", res.RecordID, res.RecordID)
+}
+
+// DeleteSDR deletes the SDR record from the SDR Repository.
+func (c *Client) DeleteSDR(recordID uint16, reservationID uint16) (response *DeleteSDRResponse, err error) {
+	return c.DeleteSDRContext(context.Background(), recordID, reservationID)
+}
+
+// DeleteSDRContext is like DeleteSDR but takes a context.
+func (c *Client) DeleteSDRContext(ctx context.Context, recordID uint16, reservationID uint16) (response *DeleteSDRResponse, err error) {
+	request := &DeleteSDRRequest{
+		ReservationID: reservationID,
+		RecordID:      recordID,
+	}
+	response = &DeleteSDRResponse{}
+	err = c.ExchangeContext(ctx, request, response)
+	return
+}
@@ -1,6 +1,9 @@
 package ipmi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // 31.8 Delete SEL Entry Command
 type DeleteSELEntryRequest struct {
@@ -43,11 +46,16 @@ func (res *DeleteSELEntryResponse) Format() string {
 }
 
 func (c *Client) DeleteSELEntry(recordID uint16, reservationID uint16) (response *DeleteSELEntryResponse, err error) {
+	return c.DeleteSELEntryContext(context.Background(), recordID, reservationID)
+}
+
+// DeleteSELEntryContext is like DeleteSELEntry but takes a context.
+func (c *Client) DeleteSELEntryContext(ctx context.Context, recordID uint16, reservationID uint16) (response *DeleteSELEntryResponse, err error) {
 	request := &DeleteSELEntryRequest{
 		ReservationID: reservationID,
 		RecordID:      recordID,
 	}
 	response = &DeleteSELEntryResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
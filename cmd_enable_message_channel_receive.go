@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 22.5 Enable Message Channel Receive Command
 type EnableMessageChannelReceiveRequest struct {
 	ChannelNumber uint8
@@ -52,8 +54,13 @@ func (res *EnableMessageChannelReceiveResponse) Format() string {
 }
 
 func (c *Client) EnableMessageChannelReceive() (response *EnableMessageChannelReceiveResponse, err error) {
+	return c.EnableMessageChannelReceiveContext(context.Background())
+}
+
+// EnableMessageChannelReceiveContext is like EnableMessageChannelReceive but takes a context.
+func (c *Client) EnableMessageChannelReceiveContext(ctx context.Context) (response *EnableMessageChannelReceiveResponse, err error) {
 	request := &EnableMessageChannelReceiveRequest{}
 	response = &EnableMessageChannelReceiveResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
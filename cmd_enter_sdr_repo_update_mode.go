@@ -0,0 +1,44 @@
+package ipmi
+
+import "context"
+
+// 33.19 Enter SDR Repository Update Mode Command
+type EnterSDRRepoUpdateModeRequest struct {
+	// empty
+}
+
+type EnterSDRRepoUpdateModeResponse struct {
+}
+
+func (req *EnterSDRRepoUpdateModeRequest) Command() Command {
+	return CommandEnterSDRRepoUpdateMode
+}
+
+func (req *EnterSDRRepoUpdateModeRequest) Pack() []byte {
+	return []byte{}
+}
+
+func (res *EnterSDRRepoUpdateModeResponse) CompletionCodes() map[uint8]string {
+	return map[uint8]string{}
+}
+
+func (res *EnterSDRRepoUpdateModeResponse) Unpack(msg []byte) error {
+	return nil
+}
+
+func (res *EnterSDRRepoUpdateModeResponse) Format() string {
+	return ""
+}
+
+// EnterSDRRepoUpdateMode enters the SDR Repository update mode, the SDR Repository of modal update can only be written in the update mode.
+func (c *Client) EnterSDRRepoUpdateMode() (err error) {
+	return c.EnterSDRRepoUpdateModeContext(context.Background())
+}
+
+// EnterSDRRepoUpdateModeContext is like EnterSDRRepoUpdateMode but takes a context.
+func (c *Client) EnterSDRRepoUpdateModeContext(ctx context.Context) (err error) {
+	request := &EnterSDRRepoUpdateModeRequest{}
+	response := &EnterSDRRepoUpdateModeResponse{}
+	err = c.ExchangeContext(ctx, request, response)
+	return
+}
@@ -0,0 +1,44 @@
+package ipmi
+
+import "context"
+
+// 33.20 Exit SDR Repository Update Mode Command
+type ExitSDRRepoUpdateModeRequest struct {
+	// empty
+}
+
+type ExitSDRRepoUpdateModeResponse struct {
+}
+
+func (req *ExitSDRRepoUpdateModeRequest) Command() Command {
+	return CommandExitSDRRepoUpdateMode
+}
+
+func (req *ExitSDRRepoUpdateModeRequest) Pack() []byte {
+	return []byte{}
+}
+
+func (res *ExitSDRRepoUpdateModeResponse) CompletionCodes() map[uint8]string {
+	return map[uint8]string{}
+}
+
+func (res *ExitSDRRepoUpdateModeResponse) Unpack(msg []byte) error {
+	return nil
+}
+
+func (res *ExitSDRRepoUpdateModeResponse) Format() string {
+	return ""
+}
+
+// ExitSDRRepoUpdateMode exits the SDR Repository update mode.
+func (c *Client) ExitSDRRepoUpdateMode() (err error) {
+	return c.ExitSDRRepoUpdateModeContext(context.Background())
+}
+
+// ExitSDRRepoUpdateModeContext is like ExitSDRRepoUpdateMode but takes a context.
+func (c *Client) ExitSDRRepoUpdateModeContext(ctx context.Context) (err error) {
+	request := &ExitSDRRepoUpdateModeRequest{}
+	response := &ExitSDRRepoUpdateModeResponse{}
+	err = c.ExchangeContext(ctx, request, response)
+	return
+}
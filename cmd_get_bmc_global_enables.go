@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 22.2 Get BMC Global Enables Command
 type GetBMCGlobalEnablesRequest struct {
 	// empty
@@ -51,8 +53,13 @@ func (res *GetBMCGlobalEnablesResponse) Format() string {
 }
 
 func (c *Client) GetBMCGlobalEnables() (response *GetBMCGlobalEnablesResponse, err error) {
+	return c.GetBMCGlobalEnablesContext(context.Background())
+}
+
+// GetBMCGlobalEnablesContext is like GetBMCGlobalEnables but takes a context.
+func (c *Client) GetBMCGlobalEnablesContext(ctx context.Context) (response *GetBMCGlobalEnablesResponse, err error) {
 	request := &GetBMCGlobalEnablesRequest{}
 	response = &GetBMCGlobalEnablesResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
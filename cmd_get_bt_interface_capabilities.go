@@ -1,5 +1,10 @@
 package ipmi
 
+import (
+	"context"
+	"fmt"
+)
+
 // 22.10 Get BT Interface Capabilities Command
 type GetBTInterfaceCapabilitiesRequest struct {
 }
@@ -21,16 +26,15 @@ func (req *GetBTInterfaceCapabilitiesRequest) Pack() []byte {
 }
 
 func (res *GetBTInterfaceCapabilitiesResponse) Unpack(msg []byte) error {
-	// at least 3 bytes
 	if len(msg) < 5 {
 		return ErrUnpackedDataTooShortWith(len(msg), 5)
 	}
 
 	res.NumberOfOutstandingRequestsSupported, _, _ = unpackUint8(msg, 0)
-	res.InputBufferMessageSizeBytes, _, _ = unpackUint8(msg, 0)
-	res.OutputBufferMessageSizeBytes, _, _ = unpackUint8(msg, 0)
-	res.BMCRequestToResponseTimeSec, _, _ = unpackUint8(msg, 0)
-	res.RecommendedRetries, _, _ = unpackUint8(msg, 0)
+	res.InputBufferMessageSizeBytes, _, _ = unpackUint8(msg, 1)
+	res.OutputBufferMessageSizeBytes, _, _ = unpackUint8(msg, 2)
+	res.BMCRequestToResponseTimeSec, _, _ = unpackUint8(msg, 3)
+	res.RecommendedRetries, _, _ = unpackUint8(msg, 4)
 	return nil
 }
 
@@ -40,12 +44,22 @@ func (*GetBTInterfaceCapabilitiesResponse) CompletionCodes() map[uint8]string {
 }
 
 func (res *GetBTInterfaceCapabilitiesResponse) Format() string {
-	return ""
+	return "" +
+		fmt.Sprintf("Number of outstanding requests supported : %d\n", res.NumberOfOutstandingRequestsSupported) +
+		fmt.Sprintf("Input buffer message size (bytes)        : %d\n", res.InputBufferMessageSizeBytes) +
+		fmt.Sprintf("Output buffer message size (bytes)       : %d\n", res.OutputBufferMessageSizeBytes) +
+		fmt.Sprintf("BMC request to response time (seconds)   : %d\n", res.BMCRequestToResponseTimeSec) +
+		fmt.Sprintf("Recommended retries                      : %d", res.RecommendedRetries)
 }
 
 func (c *Client) GetBTInterfaceCapabilities() (response *GetBTInterfaceCapabilitiesResponse, err error) {
+	return c.GetBTInterfaceCapabilitiesContext(context.Background())
+}
+
+// GetBTInterfaceCapabilitiesContext is like GetBTInterfaceCapabilities but takes a context.
+func (c *Client) GetBTInterfaceCapabilitiesContext(ctx context.Context) (response *GetBTInterfaceCapabilitiesResponse, err error) {
 	request := &GetBTInterfaceCapabilitiesRequest{}
 	response = &GetBTInterfaceCapabilitiesResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,6 +1,9 @@
 package ipmi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // 22.23 Get Channel Access Command
 type GetChannelAccessRequest struct {
@@ -70,11 +73,16 @@ func (res *GetChannelAccessResponse) Format() string {
 }
 
 func (c *Client) GetChannelAccess(channelNumber uint8, accessOption ChannelAccessOption) (response *GetChannelAccessResponse, err error) {
+	return c.GetChannelAccessContext(context.Background(), channelNumber, accessOption)
+}
+
+// GetChannelAccessContext is like GetChannelAccess but takes a context.
+func (c *Client) GetChannelAccessContext(ctx context.Context, channelNumber uint8, accessOption ChannelAccessOption) (response *GetChannelAccessResponse, err error) {
 	request := &GetChannelAccessRequest{
 		ChannelNumber: channelNumber,
 		AccessOption:  accessOption,
 	}
 	response = &GetChannelAccessResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,6 +1,9 @@
 package ipmi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // 13.14
 // 22.13
@@ -174,6 +177,11 @@ func (res *GetChannelAuthenticationCapabilitiesResponse) Format() string {
 // normally be the same Requested Maximum Privilege level that will be used
 // for a subsequent Activate Session command.
 func (c *Client) GetChannelAuthenticationCapabilities(channelNumber uint8, privilegeLevel PrivilegeLevel) (response *GetChannelAuthenticationCapabilitiesResponse, err error) {
+	return c.GetChannelAuthenticationCapabilitiesContext(context.Background(), channelNumber, privilegeLevel)
+}
+
+// GetChannelAuthenticationCapabilitiesContext is like GetChannelAuthenticationCapabilities but takes a context.
+func (c *Client) GetChannelAuthenticationCapabilitiesContext(ctx context.Context, channelNumber uint8, privilegeLevel PrivilegeLevel) (response *GetChannelAuthenticationCapabilitiesResponse, err error) {
 	request := &GetChannelAuthenticationCapabilitiesRequest{
 		IPMIv20Extended:       true,
 		ChannelNumber:         channelNumber,
@@ -181,7 +189,7 @@ func (c *Client) GetChannelAuthenticationCapabilities(channelNumber uint8, privi
 	}
 
 	response = &GetChannelAuthenticationCapabilitiesResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	if err != nil {
 		return
 	}
@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -59,23 +60,33 @@ func (res *GetChannelCipherSuitesResponse) Format() string {
 // The algorithms are used in combination as 'Cipher Suites'.
 // This command only applies to implementations that support IPMI v2.0/RMCP+ sessions.
 func (c *Client) GetChannelCipherSuites(channelNumber uint8, index uint8) (response *GetChannelCipherSuitesResponse, err error) {
+	return c.GetChannelCipherSuitesContext(context.Background(), channelNumber, index)
+}
+
+// GetChannelCipherSuitesContext is like GetChannelCipherSuites but takes a context.
+func (c *Client) GetChannelCipherSuitesContext(ctx context.Context, channelNumber uint8, index uint8) (response *GetChannelCipherSuitesResponse, err error) {
 	request := &GetChannelCipherSuitesRequest{
 		ChannelNumber: channelNumber,
 		PayloadType:   PayloadTypeIPMI,
 		ListIndex:     index,
 	}
 	response = &GetChannelCipherSuitesResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
 
 func (c *Client) GetAllChannelCipherSuites(channelNumber uint8) ([]CipherSuiteRecord, error) {
+	return c.GetAllChannelCipherSuitesContext(context.Background(), channelNumber)
+}
+
+// GetAllChannelCipherSuitesContext is like GetAllChannelCipherSuites but takes a context.
+func (c *Client) GetAllChannelCipherSuitesContext(ctx context.Context, channelNumber uint8) ([]CipherSuiteRecord, error) {
 	var index uint8 = 0
 	var cipherSuitesData = make([]byte, 0)
 	for ; index < MaxCipherSuiteListIndex; index++ {
-		res, err := c.GetChannelCipherSuites(channelNumber, index)
+		res, err := c.GetChannelCipherSuitesContext(ctx, channelNumber, index)
 		if err != nil {
-			return nil, fmt.Errorf("cmd GetChannelCipherSuites failed, err: %s", err)
+			return nil, fmt.Errorf("cmd GetChannelCipherSuites failed, err: %w", err)
 		}
 		cipherSuitesData = append(cipherSuitesData, res.CipherSuiteRecords...)
 		if len(res.CipherSuiteRecords) < 16 {
@@ -1,6 +1,9 @@
 package ipmi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // 22.24 Get Channel Info Command
 type GetChannelInfoRequest struct {
@@ -104,10 +107,15 @@ func (res *GetChannelInfoResponse) Format() string {
 }
 
 func (c *Client) GetChannelInfo(channelNumber uint8) (response *GetChannelInfoResponse, err error) {
+	return c.GetChannelInfoContext(context.Background(), channelNumber)
+}
+
+// GetChannelInfoContext is like GetChannelInfo but takes a context.
+func (c *Client) GetChannelInfoContext(ctx context.Context, channelNumber uint8) (response *GetChannelInfoResponse, err error) {
 	request := &GetChannelInfoRequest{
 		ChannelNumber: channelNumber,
 	}
 	response = &GetChannelInfoResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
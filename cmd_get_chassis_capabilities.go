@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 28.1 Get Chassis Capabilities Command
 type GetChassisCapabilitiesRequest struct {
 	// no request data
@@ -63,8 +65,13 @@ func (res *GetChassisCapabilitiesResponse) Format() string {
 }
 
 func (c *Client) GetChassisCapabilities() (response *GetChassisCapabilitiesResponse, err error) {
+	return c.GetChassisCapabilitiesContext(context.Background())
+}
+
+// GetChassisCapabilitiesContext is like GetChassisCapabilities but takes a context.
+func (c *Client) GetChassisCapabilitiesContext(ctx context.Context) (response *GetChassisCapabilitiesResponse, err error) {
 	request := &GetChassisCapabilitiesRequest{}
 	response = &GetChassisCapabilitiesResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
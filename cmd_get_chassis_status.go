@@ -1,6 +1,9 @@
 package ipmi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // 28.2 Get Chassis Status Command
 type GetChassisStatusRequest struct {
@@ -171,8 +174,13 @@ func (res *GetChassisStatusResponse) Format() string {
 }
 
 func (c *Client) GetChassisStatus() (response *GetChassisStatusResponse, err error) {
+	return c.GetChassisStatusContext(context.Background())
+}
+
+// GetChassisStatusContext is like GetChassisStatus but takes a context.
+func (c *Client) GetChassisStatusContext(ctx context.Context) (response *GetChassisStatusResponse, err error) {
 	request := &GetChassisStatusRequest{}
 	response = &GetChassisStatusResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
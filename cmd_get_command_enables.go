@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 21.8 Get Command Enables Command
 type GetCommandEnablesRequest struct {
 	ChannelNumber uint8
@@ -63,6 +65,11 @@ func (res *GetCommandEnablesResponse) Format() string {
 }
 
 func (c *Client) GetCommandEnables(channelNumber uint8, commandRangeMask CommandRangeMask, netFn NetFn, lun uint8, code uint8, oemIANA uint32) (response *GetCommandEnablesResponse, err error) {
+	return c.GetCommandEnablesContext(context.Background(), channelNumber, commandRangeMask, netFn, lun, code, oemIANA)
+}
+
+// GetCommandEnablesContext is like GetCommandEnables but takes a context.
+func (c *Client) GetCommandEnablesContext(ctx context.Context, channelNumber uint8, commandRangeMask CommandRangeMask, netFn NetFn, lun uint8, code uint8, oemIANA uint32) (response *GetCommandEnablesResponse, err error) {
 	request := &GetCommandEnablesRequest{
 		ChannelNumber:    channelNumber,
 		CommandRangeMask: commandRangeMask,
@@ -72,6 +79,6 @@ func (c *Client) GetCommandEnables(channelNumber uint8, commandRangeMask Command
 		OEM_IANA:         oemIANA,
 	}
 	response = &GetCommandEnablesResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 21.4 Get Command Sub-function Support Command
 type GetCommandSubfunctionSupportRequest struct {
 	ChannelNumber uint8
@@ -76,6 +78,11 @@ func (res *GetCommandSubfunctionSupportResponse) Format() string {
 }
 
 func (c *Client) GetCommandSubfunctionSupport(channelNumber uint8, netFn NetFn, lun uint8, code uint8, oemIANA uint32) (response *GetCommandSubfunctionSupportResponse, err error) {
+	return c.GetCommandSubfunctionSupportContext(context.Background(), channelNumber, netFn, lun, code, oemIANA)
+}
+
+// GetCommandSubfunctionSupportContext is like GetCommandSubfunctionSupport but takes a context.
+func (c *Client) GetCommandSubfunctionSupportContext(ctx context.Context, channelNumber uint8, netFn NetFn, lun uint8, code uint8, oemIANA uint32) (response *GetCommandSubfunctionSupportResponse, err error) {
 	request := &GetCommandSubfunctionSupportRequest{
 		ChannelNumber:  channelNumber,
 		NetFn:          netFn,
@@ -84,6 +91,6 @@ func (c *Client) GetCommandSubfunctionSupport(channelNumber uint8, netFn NetFn,
 		OEM_IANA:       oemIANA,
 	}
 	response = &GetCommandSubfunctionSupportResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
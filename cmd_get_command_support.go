@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 21.3 Get Command Support Command
 type GetCommandSupportRequest struct {
 	ChannelNumber uint8
@@ -70,6 +72,11 @@ func (res *GetCommandSupportResponse) Format() string {
 }
 
 func (c *Client) GetCommandSupport(channelNumber uint8, commandRangeMask CommandRangeMask, netFn NetFn, lun uint8, code uint8, oemIANA uint32) (response *GetCommandSupportResponse, err error) {
+	return c.GetCommandSupportContext(context.Background(), channelNumber, commandRangeMask, netFn, lun, code, oemIANA)
+}
+
+// GetCommandSupportContext is like GetCommandSupport but takes a context.
+func (c *Client) GetCommandSupportContext(ctx context.Context, channelNumber uint8, commandRangeMask CommandRangeMask, netFn NetFn, lun uint8, code uint8, oemIANA uint32) (response *GetCommandSupportResponse, err error) {
 	request := &GetCommandSupportRequest{
 		ChannelNumber:    channelNumber,
 		CommandRangeMask: commandRangeMask,
@@ -79,6 +86,6 @@ func (c *Client) GetCommandSupport(channelNumber uint8, commandRangeMask Command
 		OEM_IANA:         oemIANA,
 	}
 	response = &GetCommandSupportResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 21.5 Get Configurable Commands Command
 type GetConfigurableCommandsRequest struct {
 	ChannelNumber uint8
@@ -63,6 +65,11 @@ func (res *GetConfigurableCommandsResponse) Format() string {
 }
 
 func (c *Client) GetConfigurableCommands(channelNumber uint8, commandRangeMask CommandRangeMask, netFn NetFn, lun uint8, code uint8, oemIANA uint32) (response *GetConfigurableCommandsResponse, err error) {
+	return c.GetConfigurableCommandsContext(context.Background(), channelNumber, commandRangeMask, netFn, lun, code, oemIANA)
+}
+
+// GetConfigurableCommandsContext is like GetConfigurableCommands but takes a context.
+func (c *Client) GetConfigurableCommandsContext(ctx context.Context, channelNumber uint8, commandRangeMask CommandRangeMask, netFn NetFn, lun uint8, code uint8, oemIANA uint32) (response *GetConfigurableCommandsResponse, err error) {
 	request := &GetConfigurableCommandsRequest{
 		ChannelNumber:    channelNumber,
 		CommandRangeMask: commandRangeMask,
@@ -72,6 +79,6 @@ func (c *Client) GetConfigurableCommands(channelNumber uint8, commandRangeMask C
 		OEM_IANA:         oemIANA,
 	}
 	response = &GetConfigurableCommandsResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -92,8 +93,13 @@ func (res *GetDCMIPowerReadingResponse) Format() string {
 // GetDCMIPowerReading sends a DCMI "Get Power Reading" command.
 // See [GetDCMIPowerReadingRequest] for details.
 func (c *Client) GetDCMIPowerReading() (response *GetDCMIPowerReadingResponse, err error) {
+	return c.GetDCMIPowerReadingContext(context.Background())
+}
+
+// GetDCMIPowerReadingContext is like GetDCMIPowerReading but takes a context.
+func (c *Client) GetDCMIPowerReadingContext(ctx context.Context) (response *GetDCMIPowerReadingResponse, err error) {
 	request := &GetDCMIPowerReadingRequest{}
 	response = &GetDCMIPowerReadingResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
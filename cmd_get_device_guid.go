@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -46,8 +47,13 @@ func (res *GetDeviceGUIDResponse) Format() string {
 }
 
 func (c *Client) GetDeviceGUID() (response *GetDeviceGUIDResponse, err error) {
+	return c.GetDeviceGUIDContext(context.Background())
+}
+
+// GetDeviceGUIDContext is like GetDeviceGUID but takes a context.
+func (c *Client) GetDeviceGUIDContext(ctx context.Context) (response *GetDeviceGUIDResponse, err error) {
 	request := &GetDeviceGUIDRequest{}
 	response = &GetDeviceGUIDResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
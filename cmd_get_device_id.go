@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
@@ -213,8 +214,13 @@ Aux Firmware Rev Info     :
 }
 
 func (c *Client) GetDeviceID() (response *GetDeviceIDResponse, err error) {
+	return c.GetDeviceIDContext(context.Background())
+}
+
+// GetDeviceIDContext is like GetDeviceID but takes a context.
+func (c *Client) GetDeviceIDContext(ctx context.Context) (response *GetDeviceIDResponse, err error) {
 	request := &GetDeviceIDRequest{}
 	response = &GetDeviceIDResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
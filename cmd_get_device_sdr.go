@@ -1,6 +1,9 @@
 package ipmi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // 35.3 Get Device SDR Command
 type GetDeviceSDRRequest struct {
@@ -50,60 +53,92 @@ func (res *GetDeviceSDRResponse) Format() string {
 
 // This command returns general information about the collection of sensors in a Dynamic Sensor Device.
 func (c *Client) GetDeviceSDR(recordID uint16) (response *GetDeviceSDRResponse, err error) {
-	request := &GetDeviceSDRRequest{
-		ReservationID: 0,
-		RecordID:      recordID,
-		ReadOffset:    0,
-		ReadBytes:     0xff,
-	}
-	response = &GetDeviceSDRResponse{}
-	err = c.Exchange(request, response)
-	return
+	return c.GetDeviceSDRContext(context.Background(), recordID)
 }
 
-func (c *Client) GetDeviceSDRBySensorID(sensorNumber uint8) (*SDR, error) {
-	if SensorNumber(sensorNumber) == SensorNumberReserved {
-		return nil, fmt.Errorf("not valid sensorNumber, %#0x is reserved", sensorNumber)
+// GetDeviceSDRContext is like GetDeviceSDR but takes a context.
+func (c *Client) GetDeviceSDRContext(ctx context.Context, recordID uint16) (response *GetDeviceSDRResponse, err error) {
+	return c.getDeviceSDR(ctx, 0, recordID)
+}
+
+func (c *Client) getDeviceSDR(ctx context.Context, reservationID uint16, recordID uint16) (response *GetDeviceSDRResponse, err error) {
+	nextRecordID, data, err := c.deviceSDRReader().readRecord(ctx, c, reservationID, recordID)
+	if err != nil {
+		return nil, err
 	}
+	return &GetDeviceSDRResponse{NextRecordID: nextRecordID, RecordData: data}, nil
+}
 
-	var recordID uint16 = 0
-	for {
-		res, err := c.GetDeviceSDR(recordID)
-		if err != nil {
-			return nil, fmt.Errorf("GetDeviceSDR for recordID (%#0x) failed, err: %s", recordID, err)
+// iterateDeviceSDRs is like iterateSDRs but for the Device SDR Repository.
+func (c *Client) iterateDeviceSDRs(ctx context.Context, start func(), fn func(sdr *SDR) (done bool, err error)) error {
+	return c.withReservation(ctx, "device SDR repository", c.reserveDeviceSDRRepo, func(reservationID uint16) error {
+		if start != nil {
+			start()
 		}
 
-		sdr, err := ParseSDR(res.RecordData, res.NextRecordID)
-		if err != nil {
-			return nil, fmt.Errorf("ParseSDR for recordID (%#0x) failed, err: %s", recordID, err)
-		}
-		if uint8(sdr.SensorNumber()) == sensorNumber {
-			return sdr, nil
-		}
+		var recordID uint16 = 0
+		for {
+			res, err := c.getDeviceSDR(ctx, reservationID, recordID)
+			if err != nil {
+				return fmt.Errorf("GetDeviceSDR for recordID (%#0x) failed, err: %w", recordID, err)
+			}
+
+			sdr, err := ParseSDR(res.RecordData, res.NextRecordID)
+			if err != nil {
+				return fmt.Errorf("ParseSDR for recordID (%#0x) failed, err: %w", recordID, err)
+			}
 
-		recordID = res.NextRecordID
-		if recordID == 0xffff {
-			break
+			done, err := fn(sdr)
+			if err != nil || done {
+				return err
+			}
+
+			recordID = res.NextRecordID
+			if recordID == 0xffff {
+				return nil
+			}
 		}
-	}
+	})
+}
 
-	return nil, fmt.Errorf("not found SDR for sensor id (%#0x)", sensorNumber)
+func (c *Client) GetDeviceSDRBySensorID(sensorNumber uint8) (*SDR, error) {
+	return c.GetDeviceSDRBySensorIDContext(context.Background(), sensorNumber)
 }
 
-func (c *Client) GetDeviceSDRs(recordTypes ...SDRRecordType) ([]*SDR, error) {
-	var out = make([]*SDR, 0)
-	var recordID uint16 = 0
-	for {
-		res, err := c.GetDeviceSDR(recordID)
-		if err != nil {
-			return nil, fmt.Errorf("GetDeviceSDR for recordID (%#0x) failed, err: %s", recordID, err)
-		}
+// GetDeviceSDRBySensorIDContext is like GetDeviceSDRBySensorID but takes a context.
+func (c *Client) GetDeviceSDRBySensorIDContext(ctx context.Context, sensorNumber uint8) (*SDR, error) {
+	if SensorNumber(sensorNumber) == SensorNumberReserved {
+		return nil, fmt.Errorf("not valid sensorNumber, %#0x is reserved", sensorNumber)
+	}
 
-		sdr, err := ParseSDR(res.RecordData, res.NextRecordID)
-		if err != nil {
-			return nil, fmt.Errorf("ParseSDR for recordID (%#0x) failed, err: %s", recordID, err)
+	var found *SDR
+	err := c.iterateDeviceSDRs(ctx, nil, func(sdr *SDR) (bool, error) {
+		if uint8(sdr.SensorNumber()) != sensorNumber {
+			return false, nil
 		}
+		found = sdr
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, fmt.Errorf("not found SDR for sensor id (%#0x)", sensorNumber)
+	}
+	return found, nil
+}
+
+func (c *Client) GetDeviceSDRs(recordTypes ...SDRRecordType) ([]*SDR, error) {
+	return c.GetDeviceSDRsContext(context.Background(), recordTypes...)
+}
 
+// GetDeviceSDRsContext is like GetDeviceSDRs but takes a context.
+func (c *Client) GetDeviceSDRsContext(ctx context.Context, recordTypes ...SDRRecordType) ([]*SDR, error) {
+	var out []*SDR
+	start := func() {
+		out = make([]*SDR, 0)
+	}
+	err := c.iterateDeviceSDRs(ctx, start, func(sdr *SDR) (bool, error) {
 		if len(recordTypes) == 0 {
 			out = append(out, sdr)
 		} else {
@@ -114,11 +149,10 @@ func (c *Client) GetDeviceSDRs(recordTypes ...SDRRecordType) ([]*SDR, error) {
 				}
 			}
 		}
-
-		recordID = res.NextRecordID
-		if recordID == 0xffff {
-			break
-		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return out, nil
 }
@@ -1,6 +1,9 @@
 package ipmi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // 35.2 Get Device SDR Info Command
 type GetDeviceSDRInfoRequest struct {
@@ -93,10 +96,15 @@ LUN 3 has sensors  : %v
 
 // This command returns general information about the collection of sensors in a Dynamic Sensor Device.
 func (c *Client) GetDeviceSDRInfo(getSDRCount bool) (response *GetDeviceSDRInfoResponse, err error) {
+	return c.GetDeviceSDRInfoContext(context.Background(), getSDRCount)
+}
+
+// GetDeviceSDRInfoContext is like GetDeviceSDRInfo but takes a context.
+func (c *Client) GetDeviceSDRInfoContext(ctx context.Context, getSDRCount bool) (response *GetDeviceSDRInfoResponse, err error) {
 	request := &GetDeviceSDRInfoRequest{
 		GetSDRCount: getSDRCount,
 	}
 	response = &GetDeviceSDRInfoResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 29.2 Get Event Receiver Command
 type GetEventReceiverRequest struct {
 }
@@ -35,8 +37,13 @@ func (res *GetEventReceiverResponse) Format() string {
 }
 
 func (c *Client) GetEventReceiver() (response *GetEventReceiverResponse, err error) {
+	return c.GetEventReceiverContext(context.Background())
+}
+
+// GetEventReceiverContext is like GetEventReceiver but takes a context.
+func (c *Client) GetEventReceiverContext(ctx context.Context) (response *GetEventReceiverResponse, err error) {
 	request := &GetEventReceiverRequest{}
 	response = &GetEventReceiverResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -46,10 +47,15 @@ func (res *GetFRUInventoryAreaInfoResponse) Format() string {
 
 // This command returns overall the size of the FRU Inventory Area in this device, in bytes.
 func (c *Client) GetFRUInventoryAreaInfo(fruDeviceID uint8) (response *GetFRUInventoryAreaInfoResponse, err error) {
+	return c.GetFRUInventoryAreaInfoContext(context.Background(), fruDeviceID)
+}
+
+// GetFRUInventoryAreaInfoContext is like GetFRUInventoryAreaInfo but takes a context.
+func (c *Client) GetFRUInventoryAreaInfoContext(ctx context.Context, fruDeviceID uint8) (response *GetFRUInventoryAreaInfoResponse, err error) {
 	request := &GetFRUInventoryAreaInfoRequest{
 		FRUDeviceID: fruDeviceID,
 	}
 	response = &GetFRUInventoryAreaInfoResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,15 +1,22 @@
 package ipmi
 
 import (
+	"context"
+	"errors"
 	"fmt"
 )
 
 // GetFRUData return all data bytes, the data size is firstly determined by
 // GetFRUInventoryAreaInfoResponse.AreaSizeBytes
 func (c *Client) GetFRUData(deviceID uint8) ([]byte, error) {
-	fruAreaInfoRes, err := c.GetFRUInventoryAreaInfo(deviceID)
+	return c.GetFRUDataContext(context.Background(), deviceID)
+}
+
+// GetFRUDataContext is like GetFRUData but takes a context.
+func (c *Client) GetFRUDataContext(ctx context.Context, deviceID uint8) ([]byte, error) {
+	fruAreaInfoRes, err := c.GetFRUInventoryAreaInfoContext(ctx, deviceID)
 	if err != nil {
-		return nil, fmt.Errorf("GetFRUInventoryAreaInfo failed, err: %s", err)
+		return nil, fmt.Errorf("GetFRUInventoryAreaInfo failed, err: %w", err)
 	}
 
 	c.Debug("", fruAreaInfoRes.Format())
@@ -17,9 +24,9 @@ func (c *Client) GetFRUData(deviceID uint8) ([]byte, error) {
 		return nil, fmt.Errorf("invalid FRU size %d", fruAreaInfoRes.AreaSizeBytes)
 	}
 
-	data, err := c.readFRUDataByLength(deviceID, 0, fruAreaInfoRes.AreaSizeBytes)
+	data, err := c.readFRUDataByLength(ctx, deviceID, 0, fruAreaInfoRes.AreaSizeBytes)
 	if err != nil {
-		return nil, fmt.Errorf("ReadFRUDataAll failed, err: %s", err)
+		return nil, fmt.Errorf("ReadFRUDataAll failed, err: %w", err)
 	}
 	c.Debugf("Got %d fru data\n", len(data))
 
@@ -29,6 +36,11 @@ func (c *Client) GetFRUData(deviceID uint8) ([]byte, error) {
 // GetFRU return FRU for the specified deviceID.
 // The deviceName is not a must, pass empty string if not known.
 func (c *Client) GetFRU(deviceID uint8, deviceName string) (*FRU, error) {
+	return c.GetFRUContext(context.Background(), deviceID, deviceName)
+}
+
+// GetFRUContext is like GetFRU but takes a context.
+func (c *Client) GetFRUContext(ctx context.Context, deviceID uint8, deviceName string) (*FRU, error) {
 	c.Debugf("GetFRU device name (%s) id (%#02x)\n", deviceName, deviceID)
 
 	fru := &FRU{
@@ -36,16 +48,14 @@ func (c *Client) GetFRU(deviceID uint8, deviceName string) (*FRU, error) {
 		deviceName: deviceName,
 	}
 
-	fruAreaInfoRes, err := c.GetFRUInventoryAreaInfo(deviceID)
+	fruAreaInfoRes, err := c.GetFRUInventoryAreaInfoContext(ctx, deviceID)
 	if err != nil {
-		if resErr, ok := err.(*ResponseError); ok {
-			if resErr.CompletionCode() == CompletionCodeRequestedDataNotPresent {
-				fru.deviceNotPresent = true
-				fru.deviceNotPresentReason = "InventoryRecordNotExist"
-				return fru, nil
-			}
+		if errors.Is(err, ErrRequestedDataNotPresent) {
+			fru.deviceNotPresent = true
+			fru.deviceNotPresentReason = "InventoryRecordNotExist"
+			return fru, nil
 		}
-		return nil, fmt.Errorf("GetFRUInventoryAreaInfo failed, err: %s", err)
+		return nil, fmt.Errorf("GetFRUInventoryAreaInfo failed, err: %w", err)
 	}
 
 	c.Debug("", fruAreaInfoRes.Format())
@@ -54,9 +64,10 @@ func (c *Client) GetFRU(deviceID uint8, deviceName string) (*FRU, error) {
 	}
 
 	// retrieve the FRU header, just fetch FRUCommonHeaderSize bytes to construct a FRU Header
-	readFRURes, err := c.ReadFRUData(deviceID, 0, FRUCommonHeaderSize)
+	readFRURes, err := c.ReadFRUDataContext(ctx, deviceID, 0, FRUCommonHeaderSize)
 	if err != nil {
-		if resErr, ok := err.(*ResponseError); ok {
+		var resErr *ResponseError
+		if errors.As(err, &resErr) {
 			switch resErr.CompletionCode() {
 			case CompletionCodeRequestedDataNotPresent:
 				fru.deviceNotPresent = true
@@ -68,12 +79,12 @@ func (c *Client) GetFRU(deviceID uint8, deviceName string) (*FRU, error) {
 				return fru, nil
 			}
 		}
-		return nil, fmt.Errorf("ReadFRUData failed, err: %s", err)
+		return nil, fmt.Errorf("ReadFRUData failed, err: %w", err)
 	}
 
 	fruHeader := &FRUCommonHeader{}
 	if err := fruHeader.Unpack(readFRURes.Data); err != nil {
-		return nil, fmt.Errorf("unpack fru data failed, err: %s", err)
+		return nil, fmt.Errorf("unpack fru data failed, err: %w", err)
 	}
 	if fruHeader.FormatVersion != FRUFormatVersion {
 		return nil, fmt.Errorf("unknown FRU header version %#02x", fruHeader.FormatVersion)
@@ -84,9 +95,9 @@ func (c *Client) GetFRU(deviceID uint8, deviceName string) (*FRU, error) {
 
 	if offset := uint16(fruHeader.ChassisOffset8B) * 8; offset > 0 && offset < fruAreaInfoRes.AreaSizeBytes {
 		c.Debugf("Get FRU Area Chassis, offset (%d)\n", offset)
-		fruChassis, err := c.GetFRUAreaChassis(deviceID, offset)
+		fruChassis, err := c.GetFRUAreaChassisContext(ctx, deviceID, offset)
 		if err != nil {
-			return nil, fmt.Errorf("GetFRUAreaChassis failed, err: %s", err)
+			return nil, fmt.Errorf("GetFRUAreaChassis failed, err: %w", err)
 		}
 
 		c.Debug("FRU Area Chassis", fruChassis)
@@ -95,9 +106,9 @@ func (c *Client) GetFRU(deviceID uint8, deviceName string) (*FRU, error) {
 
 	if offset := uint16(fruHeader.BoardOffset8B) * 8; offset > 0 && offset < fruAreaInfoRes.AreaSizeBytes {
 		c.Debugf("Get FRU Area Board, offset (%d)\n", offset)
-		fruBoard, err := c.GetFRUAreaBoard(deviceID, offset)
+		fruBoard, err := c.GetFRUAreaBoardContext(ctx, deviceID, offset)
 		if err != nil {
-			return nil, fmt.Errorf("GetFRUAreaBoard failed, err: %s", err)
+			return nil, fmt.Errorf("GetFRUAreaBoard failed, err: %w", err)
 		}
 		c.Debug("FRU Area Board", fruBoard)
 		fru.BoardInfoArea = fruBoard
@@ -105,9 +116,9 @@ func (c *Client) GetFRU(deviceID uint8, deviceName string) (*FRU, error) {
 
 	if offset := uint16(fruHeader.ProductOffset8B) * 8; offset > 0 && offset < fruAreaInfoRes.AreaSizeBytes {
 		c.Debugf("Get FRU Area Product, offset (%d)\n", offset)
-		fruProduct, err := c.GetFRUAreaProduct(deviceID, offset)
+		fruProduct, err := c.GetFRUAreaProductContext(ctx, deviceID, offset)
 		if err != nil {
-			return nil, fmt.Errorf("GetFRUAreaProduct failed, err: %s", err)
+			return nil, fmt.Errorf("GetFRUAreaProduct failed, err: %w", err)
 		}
 		c.Debug("FRU Area Product", fruProduct)
 		fru.ProductInfoArea = fruProduct
@@ -115,9 +126,9 @@ func (c *Client) GetFRU(deviceID uint8, deviceName string) (*FRU, error) {
 
 	if offset := uint16(fruHeader.MultiRecordsOffset8B) * 8; offset > 0 && offset < fruAreaInfoRes.AreaSizeBytes {
 		c.Debugf("Get FRU Area Multi Records, offset (%d)\n", offset)
-		fruMultiRecords, err := c.GetFRUAreaMultiRecords(deviceID, offset)
+		fruMultiRecords, err := c.GetFRUAreaMultiRecordsContext(ctx, deviceID, offset)
 		if err != nil {
-			return nil, fmt.Errorf("GetFRUAreaMultiRecord failed, err: %s", err)
+			return nil, fmt.Errorf("GetFRUAreaMultiRecord failed, err: %w", err)
 		}
 		c.Debug("FRU Area MultiRecords", fruMultiRecords)
 		fru.MultiRecords = fruMultiRecords
@@ -128,12 +139,17 @@ func (c *Client) GetFRU(deviceID uint8, deviceName string) (*FRU, error) {
 }
 
 func (c *Client) GetFRUs() ([]*FRU, error) {
+	return c.GetFRUsContext(context.Background())
+}
+
+// GetFRUsContext is like GetFRUs but takes a context.
+func (c *Client) GetFRUsContext(ctx context.Context) ([]*FRU, error) {
 	var frus = make([]*FRU, 0)
 
 	// Do a Get Device ID command to determine device support
-	deviceRes, err := c.GetDeviceID()
+	deviceRes, err := c.GetDeviceIDContext(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("GetDeviceID failed, err: %s", err)
+		return nil, fmt.Errorf("GetDeviceID failed, err: %w", err)
 	}
 
 	c.Debug("deviceRes", deviceRes)
@@ -142,9 +158,9 @@ func (c *Client) GetFRUs() ([]*FRU, error) {
 		// FRU Device ID #00 at LUN 00b is predefined as being the FRU Device
 		// for the FRU that the management controller is located on.
 		var deviceID uint8 = 0x00
-		fru, err := c.GetFRU(deviceID, "Builtin FRU")
+		fru, err := c.GetFRUContext(ctx, deviceID, "Builtin FRU")
 		if err != nil {
-			return nil, fmt.Errorf("GetFRU device id (%#02x) failed, err: %s", deviceID, err)
+			return nil, fmt.Errorf("GetFRU device id (%#02x) failed, err: %w", deviceID, err)
 		}
 		frus = append(frus, fru)
 	}
@@ -152,9 +168,9 @@ func (c *Client) GetFRUs() ([]*FRU, error) {
 	// Walk the SDRs to look for FRU Devices and Management Controller Devices.
 	// For FRU devices, print the FRU from the SDR locator record.
 	// For MC devices, issue FRU commands to the satellite controller to print FRU data.
-	sdrs, err := c.GetSDRs(SDRRecordTypeFRUDeviceLocator, SDRRecordTypeManagementControllerDeviceLocator)
+	sdrs, err := c.GetSDRsContext(ctx, SDRRecordTypeFRUDeviceLocator, SDRRecordTypeManagementControllerDeviceLocator)
 	if err != nil {
-		return nil, fmt.Errorf("GetSDRS failed, err: %s", err)
+		return nil, fmt.Errorf("GetSDRS failed, err: %w", err)
 	}
 
 	for _, sdr := range sdrs {
@@ -188,9 +204,9 @@ func (c *Client) GetFRUs() ([]*FRU, error) {
 				}
 
 				// Todo, accessed using Read/Write FRU commands at LUN other than 00b
-				fru, err := c.GetFRU(deviceIDOrSlaveAddress, deviceName)
+				fru, err := c.GetFRUContext(ctx, deviceIDOrSlaveAddress, deviceName)
 				if err != nil {
-					return nil, fmt.Errorf("GetFRU sdr device id (%#02x) failed, err: %s", deviceIDOrSlaveAddress, err)
+					return nil, fmt.Errorf("GetFRU sdr device id (%#02x) failed, err: %w", deviceIDOrSlaveAddress, err)
 				}
 				frus = append(frus, fru)
 
@@ -232,75 +248,95 @@ func (c *Client) GetFRUs() ([]*FRU, error) {
 }
 
 func (c *Client) GetFRUAreaChassis(deviceID uint8, offset uint16) (*FRUChassisInfoArea, error) {
+	return c.GetFRUAreaChassisContext(context.Background(), deviceID, offset)
+}
+
+// GetFRUAreaChassisContext is like GetFRUAreaChassis but takes a context.
+func (c *Client) GetFRUAreaChassisContext(ctx context.Context, deviceID uint8, offset uint16) (*FRUChassisInfoArea, error) {
 	// read enough (2 bytes) to check the length field
-	res, err := c.ReadFRUData(deviceID, offset, 2)
+	res, err := c.ReadFRUDataContext(ctx, deviceID, offset, 2)
 	if err != nil {
-		return nil, fmt.Errorf("ReadFRUData failed, err: %s", err)
+		return nil, fmt.Errorf("ReadFRUData failed, err: %w", err)
 	}
 	length := uint16(res.Data[1]) * 8 // in multiples of 8 bytes
 
 	// now read full area data
-	data, err := c.readFRUDataByLength(deviceID, offset, length)
+	data, err := c.readFRUDataByLength(ctx, deviceID, offset, length)
 	if err != nil {
-		return nil, fmt.Errorf("ReadFRUDataAll failed, err: %s", err)
+		return nil, fmt.Errorf("ReadFRUDataAll failed, err: %w", err)
 	}
 	c.Debugf("Got %d fru data\n", len(data))
 
 	fruChassis := &FRUChassisInfoArea{}
 	if err := fruChassis.Unpack(data); err != nil {
-		return nil, fmt.Errorf("unpack fru chassis failed, err: %s", err)
+		return nil, fmt.Errorf("unpack fru chassis failed, err: %w", err)
 	}
 
 	return fruChassis, nil
 }
 
 func (c *Client) GetFRUAreaBoard(deviceID uint8, offset uint16) (*FRUBoardInfoArea, error) {
+	return c.GetFRUAreaBoardContext(context.Background(), deviceID, offset)
+}
+
+// GetFRUAreaBoardContext is like GetFRUAreaBoard but takes a context.
+func (c *Client) GetFRUAreaBoardContext(ctx context.Context, deviceID uint8, offset uint16) (*FRUBoardInfoArea, error) {
 	// read enough (2 bytes) to check the length field
-	res, err := c.ReadFRUData(deviceID, offset, 2)
+	res, err := c.ReadFRUDataContext(ctx, deviceID, offset, 2)
 	if err != nil {
-		return nil, fmt.Errorf("ReadFRUData failed, err: %s", err)
+		return nil, fmt.Errorf("ReadFRUData failed, err: %w", err)
 	}
 	length := uint16(res.Data[1]) * 8 // in multiples of 8 bytes
 
 	// now read full area data
-	data, err := c.readFRUDataByLength(deviceID, offset, length)
+	data, err := c.readFRUDataByLength(ctx, deviceID, offset, length)
 	if err != nil {
-		return nil, fmt.Errorf("ReadFRUDataAll failed, err: %s", err)
+		return nil, fmt.Errorf("ReadFRUDataAll failed, err: %w", err)
 	}
 	c.Debugf("Got %d fru data\n", len(data))
 
 	fruBoard := &FRUBoardInfoArea{}
 	if err := fruBoard.Unpack(data); err != nil {
-		return nil, fmt.Errorf("unpack fru board failed, err: %s", err)
+		return nil, fmt.Errorf("unpack fru board failed, err: %w", err)
 	}
 
 	return fruBoard, nil
 }
 
 func (c *Client) GetFRUAreaProduct(deviceID uint8, offset uint16) (*FRUProductInfoArea, error) {
+	return c.GetFRUAreaProductContext(context.Background(), deviceID, offset)
+}
+
+// GetFRUAreaProductContext is like GetFRUAreaProduct but takes a context.
+func (c *Client) GetFRUAreaProductContext(ctx context.Context, deviceID uint8, offset uint16) (*FRUProductInfoArea, error) {
 	// read enough (2 bytes) to check the length field
-	res, err := c.ReadFRUData(deviceID, offset, 2)
+	res, err := c.ReadFRUDataContext(ctx, deviceID, offset, 2)
 	if err != nil {
-		return nil, fmt.Errorf("ReadFRUData failed, err: %s", err)
+		return nil, fmt.Errorf("ReadFRUData failed, err: %w", err)
 	}
 	length := uint16(res.Data[1]) * 8 // in multiples of 8 bytes
 
 	// now read full area data
-	data, err := c.readFRUDataByLength(deviceID, offset, length)
+	data, err := c.readFRUDataByLength(ctx, deviceID, offset, length)
 	if err != nil {
-		return nil, fmt.Errorf("ReadFRUDataAll failed, err: %s", err)
+		return nil, fmt.Errorf("ReadFRUDataAll failed, err: %w", err)
 	}
 	c.Debugf("Got %d fru data\n", len(data))
 
 	fruProduct := &FRUProductInfoArea{}
 	if err := fruProduct.Unpack(data); err != nil {
-		return nil, fmt.Errorf("unpack fru board failed, err: %s", err)
+		return nil, fmt.Errorf("unpack fru board failed, err: %w", err)
 	}
 
 	return fruProduct, nil
 }
 
 func (c *Client) GetFRUAreaMultiRecords(deviceID uint8, offset uint16) ([]*FRUMultiRecord, error) {
+	return c.GetFRUAreaMultiRecordsContext(context.Background(), deviceID, offset)
+}
+
+// GetFRUAreaMultiRecordsContext is like GetFRUAreaMultiRecords but takes a context.
+func (c *Client) GetFRUAreaMultiRecordsContext(ctx context.Context, deviceID uint8, offset uint16) ([]*FRUMultiRecord, error) {
 	records := make([]*FRUMultiRecord, 0)
 
 	for {
@@ -309,23 +345,23 @@ func (c *Client) GetFRUAreaMultiRecords(deviceID uint8, offset uint16) ([]*FRUMu
 		// and the third byte holds the data length.
 		//
 		// see: FRU/16.1 Record Header
-		res, err := c.ReadFRUData(deviceID, offset, 5)
+		res, err := c.ReadFRUDataContext(ctx, deviceID, offset, 5)
 		if err != nil {
-			return nil, fmt.Errorf("ReadFRUData failed, err: %s", err)
+			return nil, fmt.Errorf("ReadFRUData failed, err: %w", err)
 		}
 		length := uint16(res.Data[2])
 
 		// now read full data for this record
 		recordSize := 5 + length // Record Header + Data Length
-		data, err := c.readFRUDataByLength(deviceID, offset, recordSize)
+		data, err := c.readFRUDataByLength(ctx, deviceID, offset, recordSize)
 		if err != nil {
-			return nil, fmt.Errorf("ReadFRUDataAll failed, err: %s", err)
+			return nil, fmt.Errorf("ReadFRUDataAll failed, err: %w", err)
 		}
 		c.Debugf("Got %d fru data\n", len(data))
 
 		record := &FRUMultiRecord{}
 		if err := record.Unpack(data); err != nil {
-			return nil, fmt.Errorf("unpack fru multi record failed, err: %s", err)
+			return nil, fmt.Errorf("unpack fru multi record failed, err: %w", err)
 		}
 		c.Debug("Multi record", record)
 		records = append(records, record)
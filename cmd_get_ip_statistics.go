@@ -1,6 +1,9 @@
 package ipmi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // 23.4 Get IP/UDP/RMCP Statistics Command
 type GetIPStatisticsRequest struct {
@@ -83,11 +86,16 @@ UDP Proxy Packet Dropped  : %d`,
 }
 
 func (c *Client) GetIPStatistics(channelNumber uint8, clearAllStatistics bool) (response *GetIPStatisticsResponse, err error) {
+	return c.GetIPStatisticsContext(context.Background(), channelNumber, clearAllStatistics)
+}
+
+// GetIPStatisticsContext is like GetIPStatistics but takes a context.
+func (c *Client) GetIPStatisticsContext(ctx context.Context, channelNumber uint8, clearAllStatistics bool) (response *GetIPStatisticsResponse, err error) {
 	request := &GetIPStatisticsRequest{
 		ChannelNumber:      channelNumber,
 		ClearAllStatistics: clearAllStatistics,
 	}
 	response = &GetIPStatisticsResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
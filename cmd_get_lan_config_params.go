@@ -1,6 +1,8 @@
 package ipmi
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net"
 )
@@ -57,27 +59,37 @@ Length of Config Data: %d
 }
 
 func (c *Client) GetLanConfigParams(channelNumber uint8, paramSelector LanParamSelector) (response *GetLanConfigParamsResponse, err error) {
+	return c.GetLanConfigParamsContext(context.Background(), channelNumber, paramSelector)
+}
+
+// GetLanConfigParamsContext is like GetLanConfigParams but takes a context.
+func (c *Client) GetLanConfigParamsContext(ctx context.Context, channelNumber uint8, paramSelector LanParamSelector) (response *GetLanConfigParamsResponse, err error) {
 	request := &GetLanConfigParamsRequest{
 		ChannelNumber: channelNumber,
 		ParamSelector: paramSelector,
 	}
 	response = &GetLanConfigParamsResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
 
 // GetLanConfig will fetch all Lan information.
 func (c *Client) GetLanConfig(channelNumber uint8) (*LanConfig, error) {
+	return c.GetLanConfigContext(context.Background(), channelNumber)
+}
+
+// GetLanConfigContext is like GetLanConfig but takes a context.
+func (c *Client) GetLanConfigContext(ctx context.Context, channelNumber uint8) (*LanConfig, error) {
 	lanConfig := &LanConfig{}
 
 	for _, lanParam := range LanParams {
 		paramSelector := LanParamSelector(lanParam.Selector)
 
-		res, err := c.GetLanConfigParams(channelNumber, paramSelector)
+		res, err := c.GetLanConfigParamsContext(ctx, channelNumber, paramSelector)
 		if err != nil {
-			resErr, ok := err.(*ResponseError)
-			if !ok {
-				return nil, fmt.Errorf("not ResponseError")
+			var resErr *ResponseError
+			if !errors.As(err, &resErr) {
+				return nil, fmt.Errorf("get lan config param (%s) failed, err: %w", paramSelector, err)
 			}
 
 			cc := resErr.CompletionCode()
@@ -92,12 +104,12 @@ func (c *Client) GetLanConfig(channelNumber uint8) (*LanConfig, error) {
 			default:
 				// other completion codes are treated as error.
 				// including 0x00 which means cc is successful, but other part failed
-				return nil, fmt.Errorf("get lan config param (%s) failed, err: %s", paramSelector, err)
+				return nil, fmt.Errorf("get lan config param (%s) failed, err: %w", paramSelector, err)
 			}
 		}
 
 		if err := FillLanConfig(lanConfig, paramSelector, res.ConfigData); err != nil {
-			return nil, fmt.Errorf("get lan config param (%s) failed, err: %s", paramSelector, err)
+			return nil, fmt.Errorf("get lan config param (%s) failed, err: %w", paramSelector, err)
 		}
 	}
 
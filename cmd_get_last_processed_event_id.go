@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -59,8 +60,13 @@ LastBMCProcessedEventRecordID      : %#04x (%d)
 }
 
 func (c *Client) GetLastProcessedEventId() (response *GetLastProcessedEventIdResponse, err error) {
+	return c.GetLastProcessedEventIdContext(context.Background())
+}
+
+// GetLastProcessedEventIdContext is like GetLastProcessedEventId but takes a context.
+func (c *Client) GetLastProcessedEventIdContext(ctx context.Context) (response *GetLastProcessedEventIdResponse, err error) {
 	request := &GetLastProcessedEventIdRequest{}
 	response = &GetLastProcessedEventIdResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 22.6 Get Message Command
 type GetMessageRequest struct {
 	// empty
@@ -38,8 +40,13 @@ func (res *GetMessageResponse) Format() string {
 }
 
 func (c *Client) GetMessage() (response *GetMessageResponse, err error) {
+	return c.GetMessageContext(context.Background())
+}
+
+// GetMessageContext is like GetMessage but takes a context.
+func (c *Client) GetMessageContext(ctx context.Context) (response *GetMessageResponse, err error) {
 	request := &GetMessageRequest{}
 	response = &GetMessageResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 22.4 Get Message Flags Command
 type GetMessageFlagsRequest struct {
 }
@@ -47,8 +49,13 @@ func (res *GetMessageFlagsResponse) Format() string {
 }
 
 func (c *Client) GetMessageFlags() (response *GetMessageFlagsResponse, err error) {
+	return c.GetMessageFlagsContext(context.Background())
+}
+
+// GetMessageFlagsContext is like GetMessageFlags but takes a context.
+func (c *Client) GetMessageFlagsContext(ctx context.Context) (response *GetMessageFlagsResponse, err error) {
 	request := &GetMessageFlagsRequest{}
 	response = &GetMessageFlagsResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 21.2 Get NetFn Support Command
 type GetNetFnSupportRequest struct {
 	ChannelNumber uint8
@@ -64,10 +66,15 @@ func (res *GetNetFnSupportResponse) Format() string {
 }
 
 func (c *Client) GetNetFnSupport(channelNumber uint8) (response *GetNetFnSupportResponse, err error) {
+	return c.GetNetFnSupportContext(context.Background(), channelNumber)
+}
+
+// GetNetFnSupportContext is like GetNetFnSupport but takes a context.
+func (c *Client) GetNetFnSupportContext(ctx context.Context, channelNumber uint8) (response *GetNetFnSupportResponse, err error) {
 	request := &GetNetFnSupportRequest{
 		ChannelNumber: channelNumber,
 	}
 	response = &GetNetFnSupportResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,6 +1,9 @@
 package ipmi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // 24.4 Get Payload Activation Status Command
 type GetPayloadActivationStatusRequest struct {
@@ -113,11 +116,16 @@ func (res *GetPayloadActivationStatusResponse) Format() string {
 }
 
 func (c *Client) GetPayloadActivationStatus(payloadType PayloadType) (response *GetPayloadActivationStatusResponse, err error) {
+	return c.GetPayloadActivationStatusContext(context.Background(), payloadType)
+}
+
+// GetPayloadActivationStatusContext is like GetPayloadActivationStatus but takes a context.
+func (c *Client) GetPayloadActivationStatusContext(ctx context.Context, payloadType PayloadType) (response *GetPayloadActivationStatusResponse, err error) {
 	request := &GetPayloadActivationStatusRequest{
 		PayloadType: payloadType,
 	}
 	response = &GetPayloadActivationStatusResponse{}
 	response.PayloadType = request.PayloadType
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
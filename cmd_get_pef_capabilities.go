@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -79,8 +80,13 @@ Support Alert                : %s`,
 }
 
 func (c *Client) GetPEFCapabilities() (response *GetPEFCapabilitiesResponse, err error) {
+	return c.GetPEFCapabilitiesContext(context.Background())
+}
+
+// GetPEFCapabilitiesContext is like GetPEFCapabilities but takes a context.
+func (c *Client) GetPEFCapabilitiesContext(ctx context.Context) (response *GetPEFCapabilitiesResponse, err error) {
 	request := &GetPEFCapabilitiesRequest{}
 	response = &GetPEFCapabilitiesResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -103,6 +104,11 @@ Configuration Parameter Data : %# 02x`,
 }
 
 func (c *Client) GetPEFConfigParameters(getRevisionOnly bool, paramSelector PEFConfigParamSelector, setSelector uint8, blockSelector uint8) (response *GetPEFConfigParametersResponse, err error) {
+	return c.GetPEFConfigParametersContext(context.Background(), getRevisionOnly, paramSelector, setSelector, blockSelector)
+}
+
+// GetPEFConfigParametersContext is like GetPEFConfigParameters but takes a context.
+func (c *Client) GetPEFConfigParametersContext(ctx context.Context, getRevisionOnly bool, paramSelector PEFConfigParamSelector, setSelector uint8, blockSelector uint8) (response *GetPEFConfigParametersResponse, err error) {
 	request := &GetPEFConfigParametersRequest{
 		GetRevisionOnly: getRevisionOnly,
 		ParamSelector:   paramSelector,
@@ -110,14 +116,19 @@ func (c *Client) GetPEFConfigParameters(getRevisionOnly bool, paramSelector PEFC
 		BlockSelector:   blockSelector,
 	}
 	response = &GetPEFConfigParametersResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
 
 func (c *Client) GetPEFConfigParameters_SystemUUID() (param *PEFConfigParam_SystemUUID, err error) {
-	res, err := c.GetPEFConfigParameters(false, PEFConfigParamSelector_SystemGUID, 0, 0)
+	return c.GetPEFConfigParameters_SystemUUIDContext(context.Background())
+}
+
+// GetPEFConfigParameters_SystemUUIDContext is like GetPEFConfigParameters_SystemUUID but takes a context.
+func (c *Client) GetPEFConfigParameters_SystemUUIDContext(ctx context.Context) (param *PEFConfigParam_SystemUUID, err error) {
+	res, err := c.GetPEFConfigParametersContext(ctx, false, PEFConfigParamSelector_SystemGUID, 0, 0)
 	if err != nil {
-		return nil, fmt.Errorf("GetPEFConfigParameters failed, err: %s", err)
+		return nil, fmt.Errorf("GetPEFConfigParameters failed, err: %w", err)
 	}
 
 	param = &PEFConfigParam_SystemUUID{}
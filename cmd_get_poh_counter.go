@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -58,8 +59,13 @@ Counter reading   : %d`,
 
 // This command returns the present reading of the POH (Power-On Hours) counter, plus the number of counts per hour.
 func (c *Client) GetPOHCounter() (response *GetPOHCounterResponse, err error) {
+	return c.GetPOHCounterContext(context.Background())
+}
+
+// GetPOHCounterContext is like GetPOHCounter but takes a context.
+func (c *Client) GetPOHCounterContext(ctx context.Context) (response *GetPOHCounterResponse, err error) {
 	request := &GetPOHCounterRequest{}
 	response = &GetPOHCounterResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
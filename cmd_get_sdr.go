@@ -1,6 +1,9 @@
 package ipmi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // 33.12 Get SDR Command
 type GetSDRRequest struct {
@@ -48,102 +51,162 @@ func (res *GetSDRResponse) Format() string {
 
 // GetSDR returns raw SDR record.
 func (c *Client) GetSDR(recordID uint16) (response *GetSDRResponse, err error) {
-	request := &GetSDRRequest{
-		ReservationID: 0,
-		RecordID:      recordID,
-		Offset:        0,
-		Read:          0xff,
-	}
-	response = &GetSDRResponse{}
-	err = c.Exchange(request, response)
+	return c.GetSDRContext(context.Background(), recordID)
+}
 
-	// Todo, try read partial data if err (ResponseError and CompletionCode) indicate
-	// reading full data (0xff) exceeds the maximum transfer length for the interface
-	// if resErr, ok := err.(*ResponseError); ok {
-	// 	if resErr.CompletionCode() == CompletionCodeCannotReturnRequestedDataBytes {
-	// 	}
-	// }
+// GetSDRContext is like GetSDR but takes a context.
+func (c *Client) GetSDRContext(ctx context.Context, recordID uint16) (response *GetSDRResponse, err error) {
+	return c.getSDR(ctx, 0, recordID)
+}
 
-	return
+func (c *Client) getSDR(ctx context.Context, reservationID uint16, recordID uint16) (response *GetSDRResponse, err error) {
+	nextRecordID, data, err := c.sdrReader().readRecord(ctx, c, reservationID, recordID)
+	if err != nil {
+		return nil, err
+	}
+	return &GetSDRResponse{NextRecordID: nextRecordID, RecordData: data}, nil
 }
 
-func (c *Client) GetSDRBySensorID(sensorNumber uint8) (*SDR, error) {
-	if SensorNumber(sensorNumber) == SensorNumberReserved {
-		return nil, fmt.Errorf("not valid sensorNumber, %#0x is reserved", sensorNumber)
+// iterateSDRs calls fn with the SDR records in the SDR Repository in order,
+// until fn returns done or the last record is reached.
+// The records are got from the SDR cache if the client has one, see WithSDRCache.
+// They are read from the SDR Repository without the cache if the device GUID of the BMC is not available or not set.
+//
+// If the reservation is cancelled while iterating, the iteration restarts from the
+// first record with a fresh reservation, and start is called before each iteration.
+func (c *Client) iterateSDRs(ctx context.Context, start func(), fn func(sdr *SDR) (done bool, err error)) error {
+	if c.sdrCache != nil {
+		guid, err := c.sdrCacheGUID(ctx)
+		switch {
+		case err != nil:
+			c.DebugfYellow("GetDeviceGUID failed, read the SDR repository without the cache, err: %s\n", err)
+		case !isCacheableGUID(guid):
+			c.DebugfYellow("device GUID (%x) not set, read the SDR repository without the cache\n", guid)
+		default:
+			sdrs, err := c.cachedSDRs(ctx, guid)
+			if err != nil {
+				return err
+			}
+			if start != nil {
+				start()
+			}
+			for _, sdr := range sdrs {
+				if done, err := fn(sdr); err != nil || done {
+					return err
+				}
+			}
+			return nil
+		}
 	}
 
-	var recordID uint16 = 0
-	for {
-		res, err := c.GetSDR(recordID)
+	return c.walkSDRs(ctx, start, func(data []byte, nextRecordID uint16) (bool, error) {
+		sdr, err := ParseSDR(data, nextRecordID)
 		if err != nil {
-			return nil, fmt.Errorf("GetSDR failed for recordID (%#02x), err: %s", recordID, err)
+			return false, fmt.Errorf("ParseSDR failed, err: %w", err)
 		}
-		sdr, err := ParseSDR(res.RecordData, res.NextRecordID)
-		if err != nil {
-			return nil, fmt.Errorf("ParseSDR failed, err: %s", err)
+		return fn(sdr)
+	})
+}
+
+// walkSDRs is like iterateSDRs, but it always reads the raw SDR records from the SDR Repository.
+func (c *Client) walkSDRs(ctx context.Context, start func(), fn func(data []byte, nextRecordID uint16) (done bool, err error)) error {
+	return c.withReservation(ctx, "SDR repository", c.reserveSDRRepo, func(reservationID uint16) error {
+		if start != nil {
+			start()
 		}
-		if uint8(sdr.SensorNumber()) != sensorNumber {
-			recordID = sdr.NextRecordID
+
+		var recordID uint16 = 0
+		for {
+			res, err := c.getSDR(ctx, reservationID, recordID)
+			if err != nil {
+				return fmt.Errorf("GetSDR for recordID (%#0x) failed, err: %w", recordID, err)
+			}
+
+			done, err := fn(res.RecordData, res.NextRecordID)
+			if err != nil || done {
+				return err
+			}
+
+			recordID = res.NextRecordID
 			if recordID == 0xffff {
-				break
+				return nil
 			}
-			continue
 		}
+	})
+}
 
-		if err := c.enhanceSDR(sdr); err != nil {
-			return sdr, fmt.Errorf("enhanceSDR failed, err: %s", err)
+func (c *Client) GetSDRBySensorID(sensorNumber uint8) (*SDR, error) {
+	return c.GetSDRBySensorIDContext(context.Background(), sensorNumber)
+}
+
+// GetSDRBySensorIDContext is like GetSDRBySensorID but takes a context.
+func (c *Client) GetSDRBySensorIDContext(ctx context.Context, sensorNumber uint8) (*SDR, error) {
+	if SensorNumber(sensorNumber) == SensorNumberReserved {
+		return nil, fmt.Errorf("not valid sensorNumber, %#0x is reserved", sensorNumber)
+	}
+
+	var found *SDR
+	err := c.iterateSDRs(ctx, nil, func(sdr *SDR) (bool, error) {
+		if uint8(sdr.SensorNumber()) != sensorNumber {
+			return false, nil
 		}
-		return sdr, nil
+		found = sdr
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, fmt.Errorf("not found SDR for sensor id (%#0x)", sensorNumber)
 	}
 
-	return nil, fmt.Errorf("not found SDR for sensor id (%#0x)", sensorNumber)
+	if err := c.enhanceSDR(ctx, found); err != nil {
+		return found, fmt.Errorf("enhanceSDR failed, err: %w", err)
+	}
+	return found, nil
 }
 
 func (c *Client) GetSDRBySensorName(sensorName string) (*SDR, error) {
-	var recordID uint16 = 0
-	for {
-		res, err := c.GetSDR(recordID)
-		if err != nil {
-			return nil, fmt.Errorf("GetSDR failed for recordID (%#02x), err: %s", recordID, err)
-		}
-		sdr, err := ParseSDR(res.RecordData, res.NextRecordID)
-		if err != nil {
-			return nil, fmt.Errorf("ParseSDR failed, err: %s", err)
-		}
+	return c.GetSDRBySensorNameContext(context.Background(), sensorName)
+}
 
+// GetSDRBySensorNameContext is like GetSDRBySensorName but takes a context.
+func (c *Client) GetSDRBySensorNameContext(ctx context.Context, sensorName string) (*SDR, error) {
+	var found *SDR
+	err := c.iterateSDRs(ctx, nil, func(sdr *SDR) (bool, error) {
 		if sdr.SensorName() != sensorName {
-			recordID = sdr.NextRecordID
-			if recordID == 0xffff {
-				break
-			}
-			continue
-		}
-
-		if err := c.enhanceSDR(sdr); err != nil {
-			return sdr, fmt.Errorf("enhanceSDR failed, err: %s", err)
+			return false, nil
 		}
-		return sdr, nil
+		found = sdr
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, fmt.Errorf("not found SDR for sensor name (%s)", sensorName)
 	}
 
-	return nil, fmt.Errorf("not found SDR for sensor name (%s)", sensorName)
+	if err := c.enhanceSDR(ctx, found); err != nil {
+		return found, fmt.Errorf("enhanceSDR failed, err: %w", err)
+	}
+	return found, nil
 }
 
 // GetSDRs fetches the SDR records with the specified RecordTypes.
 // The parameter is a slice of SDRRecordType used as filter.
 // Empty means to get all SDR records.
 func (c *Client) GetSDRs(recordTypes ...SDRRecordType) ([]*SDR, error) {
-	var recordID uint16 = 0
-	var out = make([]*SDR, 0)
-	for {
-		res, err := c.GetSDR(recordID)
-		if err != nil {
-			return nil, fmt.Errorf("GetSDR for recordID (%#0x) failed, err: %s", recordID, err)
-		}
-		sdr, err := ParseSDR(res.RecordData, res.NextRecordID)
-		if err != nil {
-			return nil, fmt.Errorf("ParseSDR failed, err: %s", err)
-		}
+	return c.GetSDRsContext(context.Background(), recordTypes...)
+}
 
+// GetSDRsContext is like GetSDRs but takes a context.
+func (c *Client) GetSDRsContext(ctx context.Context, recordTypes ...SDRRecordType) ([]*SDR, error) {
+	var out []*SDR
+	start := func() {
+		out = make([]*SDR, 0)
+	}
+	err := c.iterateSDRs(ctx, start, func(sdr *SDR) (bool, error) {
 		if len(recordTypes) == 0 {
 			out = append(out, sdr)
 		} else {
@@ -154,11 +217,10 @@ func (c *Client) GetSDRs(recordTypes ...SDRRecordType) ([]*SDR, error) {
 				}
 			}
 		}
-
-		recordID = sdr.NextRecordID
-		if recordID == 0xffff {
-			break
-		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return out, nil
@@ -168,19 +230,16 @@ func (c *Client) GetSDRs(recordTypes ...SDRRecordType) ([]*SDR, error) {
 // The sensor name can only be got from SDR record.
 // So use this method to construct a map from which you can get sensor name.
 func (c *Client) GetSDRsMap() (SDRMapBySensorNumber, error) {
-	var out = make(map[GeneratorID]map[SensorNumber]*SDR)
-
-	var recordID uint16 = 0
-	for {
-		res, err := c.GetSDR(recordID)
-		if err != nil {
-			return nil, fmt.Errorf("GetSDR for recordID (%#0x) failed, err: %s", recordID, err)
-		}
-		sdr, err := ParseSDR(res.RecordData, res.NextRecordID)
-		if err != nil {
-			return nil, fmt.Errorf("ParseSDR failed, err: %s", err)
-		}
+	return c.GetSDRsMapContext(context.Background())
+}
 
+// GetSDRsMapContext is like GetSDRsMap but takes a context.
+func (c *Client) GetSDRsMapContext(ctx context.Context) (SDRMapBySensorNumber, error) {
+	var out map[GeneratorID]map[SensorNumber]*SDR
+	start := func() {
+		out = make(map[GeneratorID]map[SensorNumber]*SDR)
+	}
+	err := c.iterateSDRs(ctx, start, func(sdr *SDR) (bool, error) {
 		var generatorID GeneratorID
 		var sensorNumber SensorNumber
 
@@ -200,11 +259,10 @@ func (c *Client) GetSDRsMap() (SDRMapBySensorNumber, error) {
 			}
 			out[generatorID][sensorNumber] = sdr
 		}
-
-		recordID = sdr.NextRecordID
-		if recordID == 0xffff {
-			break
-		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return out, nil
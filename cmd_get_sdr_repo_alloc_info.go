@@ -1,6 +1,9 @@
 package ipmi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // 33.10 Get SDR Repository Allocation Info Command
 type GetSDRRepoAllocInfoRequest struct {
@@ -44,8 +47,13 @@ func (res *GetSDRRepoAllocInfoResponse) Format() string {
 }
 
 func (c *Client) GetSDRRepoAllocInfo() (response *GetSDRRepoAllocInfoResponse, err error) {
+	return c.GetSDRRepoAllocInfoContext(context.Background())
+}
+
+// GetSDRRepoAllocInfoContext is like GetSDRRepoAllocInfo but takes a context.
+func (c *Client) GetSDRRepoAllocInfoContext(ctx context.Context) (response *GetSDRRepoAllocInfoResponse, err error) {
 	request := &GetSDRRepoAllocInfoRequest{}
 	response = &GetSDRRepoAllocInfoResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -115,8 +116,13 @@ func (res *GetSDRRepoInfoResponse) CompletionCodes() map[uint8]string {
 }
 
 func (c *Client) GetSDRRepoInfo() (response *GetSDRRepoInfoResponse, err error) {
+	return c.GetSDRRepoInfoContext(context.Background())
+}
+
+// GetSDRRepoInfoContext is like GetSDRRepoInfo but takes a context.
+func (c *Client) GetSDRRepoInfoContext(ctx context.Context) (response *GetSDRRepoInfoResponse, err error) {
 	request := &GetSDRRepoInfoRequest{}
 	response = &GetSDRRepoInfoResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,6 +1,9 @@
 package ipmi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 type GetSELAllocInfoRequest struct {
 	// empty
@@ -53,8 +56,13 @@ Max Record Size              : %d`,
 }
 
 func (c *Client) GetSELAllocInfo() (response *GetSELAllocInfoResponse, err error) {
+	return c.GetSELAllocInfoContext(context.Background())
+}
+
+// GetSELAllocInfoContext is like GetSELAllocInfo but takes a context.
+func (c *Client) GetSELAllocInfoContext(ctx context.Context) (response *GetSELAllocInfoResponse, err error) {
 	request := &GetSELAllocInfoRequest{}
 	response = &GetSELAllocInfoResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
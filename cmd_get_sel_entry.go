@@ -1,6 +1,9 @@
 package ipmi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // 31.5 Get SEL Entry Command
 type GetSELEntryRequest struct {
@@ -58,8 +61,13 @@ func (res *GetSELEntryResponse) Format() string {
 
 // The reservationID is only required for partial Get, use 0000h otherwise.
 func (c *Client) GetSELEntry(reservationID uint16, recordID uint16) (response *GetSELEntryResponse, err error) {
-	if _, err := c.GetSELInfo(); err != nil {
-		return nil, fmt.Errorf("GetSELInfo failed, err: %s", err)
+	return c.GetSELEntryContext(context.Background(), reservationID, recordID)
+}
+
+// GetSELEntryContext is like GetSELEntry but takes a context.
+func (c *Client) GetSELEntryContext(ctx context.Context, reservationID uint16, recordID uint16) (response *GetSELEntryResponse, err error) {
+	if _, err := c.GetSELInfoContext(ctx); err != nil {
+		return nil, fmt.Errorf("GetSELInfo failed, err: %w", err)
 	}
 
 	request := &GetSELEntryRequest{
@@ -69,13 +77,18 @@ func (c *Client) GetSELEntry(reservationID uint16, recordID uint16) (response *G
 		ReadBytes:     0xff,
 	}
 	response = &GetSELEntryResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
 
 // GetSELEntries return all SEL records starting from the specified recordID.
 // Pass 0 means retrieve all SEL entries starting from the first record.
 func (c *Client) GetSELEntries(startRecordID uint16) ([]*SEL, error) {
+	return c.GetSELEntriesContext(context.Background(), startRecordID)
+}
+
+// GetSELEntriesContext is like GetSELEntries but takes a context.
+func (c *Client) GetSELEntriesContext(ctx context.Context, startRecordID uint16) ([]*SEL, error) {
 	// Todo
 	// Notice, this extra GetSELInfo call is used to make sure the GetSELEntry works properly.
 	// On Huawei TaiShan 200 (Model 2280), the NextRecordID (0xffff) in GetSELEntryResponse is NOT right occasionally.
@@ -88,29 +101,36 @@ func (c *Client) GetSELEntries(startRecordID uint16) ([]*SEL, error) {
 	// ff ff
 	//
 	// This extra GetSELInfo can avoid it. (I don't known why!)
-	if _, err := c.GetSELInfo(); err != nil {
-		return nil, fmt.Errorf("GetSELInfo failed, err: %s", err)
+	if _, err := c.GetSELInfoContext(ctx); err != nil {
+		return nil, fmt.Errorf("GetSELInfo failed, err: %w", err)
 	}
 
-	var out = make([]*SEL, 0)
-	var recordID uint16 = startRecordID
-	for {
-		selEntry, err := c.GetSELEntry(0, recordID)
-		if err != nil {
-			return nil, fmt.Errorf("GetSELEntry failed, err: %s", err)
-		}
-		c.DebugBytes("sel entry record data", selEntry.Data, 16)
-
-		sel, err := ParseSEL(selEntry.Data)
-		if err != nil {
-			return nil, fmt.Errorf("unpackSEL record failed, err: %s", err)
-		}
-		out = append(out, sel)
-
-		recordID = selEntry.NextRecordID
-		if recordID == 0xffff {
-			break
+	var out []*SEL
+	err := c.withReservation(ctx, "SEL", c.reserveSEL, func(reservationID uint16) error {
+		out = make([]*SEL, 0)
+
+		var recordID uint16 = startRecordID
+		for {
+			selEntry, err := c.GetSELEntryContext(ctx, reservationID, recordID)
+			if err != nil {
+				return fmt.Errorf("GetSELEntry failed, err: %w", err)
+			}
+			c.DebugBytes("sel entry record data", selEntry.Data, 16)
+
+			sel, err := ParseSEL(selEntry.Data)
+			if err != nil {
+				return fmt.Errorf("unpackSEL record failed, err: %w", err)
+			}
+			out = append(out, sel)
+
+			recordID = selEntry.NextRecordID
+			if recordID == 0xffff {
+				return nil
+			}
 		}
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return out, nil
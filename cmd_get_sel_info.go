@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -105,8 +106,13 @@ Get SEL Alloc Info supported : %v`,
 }
 
 func (c *Client) GetSELInfo() (response *GetSELInfoResponse, err error) {
+	return c.GetSELInfoContext(context.Background())
+}
+
+// GetSELInfoContext is like GetSELInfo but takes a context.
+func (c *Client) GetSELInfoContext(ctx context.Context) (response *GetSELInfoResponse, err error) {
 	request := &GetSELInfoRequest{}
 	response = &GetSELInfoResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
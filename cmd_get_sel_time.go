@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -42,8 +43,13 @@ func (res *GetSELTimeResponse) Format() string {
 }
 
 func (c *Client) GetSELTime() (response *GetSELTimeResponse, err error) {
+	return c.GetSELTimeContext(context.Background())
+}
+
+// GetSELTimeContext is like GetSELTime but takes a context.
+func (c *Client) GetSELTimeContext(ctx context.Context) (response *GetSELTimeResponse, err error) {
 	request := &GetSELTimeRequest{}
 	response = &GetSELTimeResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
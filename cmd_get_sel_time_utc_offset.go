@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -43,8 +44,13 @@ func (res *GetSELTimeUTCOffsetResponse) Format() string {
 
 // GetSELTimeUTCOffset is used to retrieve the SEL Time UTC Offset (timezone)
 func (c *Client) GetSELTimeUTCOffset() (response *GetSELTimeUTCOffsetResponse, err error) {
+	return c.GetSELTimeUTCOffsetContext(context.Background())
+}
+
+// GetSELTimeUTCOffsetContext is like GetSELTimeUTCOffset but takes a context.
+func (c *Client) GetSELTimeUTCOffsetContext(ctx context.Context) (response *GetSELTimeUTCOffsetResponse, err error) {
 	request := &GetSELTimeUTCOffsetRequest{}
 	response = &GetSELTimeUTCOffsetResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
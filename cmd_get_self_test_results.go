@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 20.4 Get Self Test Results Command
 type GetSelfTestResultsRequest struct {
 	// empty
@@ -37,8 +39,13 @@ func (res *GetSelfTestResultsResponse) Format() string {
 }
 
 func (c *Client) GetSelfTestResults() (response *GetSelfTestResultsResponse, err error) {
+	return c.GetSelfTestResultsContext(context.Background())
+}
+
+// GetSelfTestResultsContext is like GetSelfTestResults but takes a context.
+func (c *Client) GetSelfTestResultsContext(ctx context.Context) (response *GetSelfTestResultsResponse, err error) {
 	request := &GetSelfTestResultsRequest{}
 	response = &GetSelfTestResultsResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
@@ -140,10 +141,15 @@ Enabled Deassert Event    : %s`,
 }
 
 func (c *Client) GetSensorEventEnable(sensorNumber uint8) (response *GetSensorEventEnableResponse, err error) {
+	return c.GetSensorEventEnableContext(context.Background(), sensorNumber)
+}
+
+// GetSensorEventEnableContext is like GetSensorEventEnable but takes a context.
+func (c *Client) GetSensorEventEnableContext(ctx context.Context, sensorNumber uint8) (response *GetSensorEventEnableResponse, err error) {
 	request := &GetSensorEventEnableRequest{
 		SensorNumber: sensorNumber,
 	}
 	response = &GetSensorEventEnableResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
@@ -145,10 +146,15 @@ Occurred Deassert Event  : %s`,
 }
 
 func (c *Client) GetSensorEventStatus(sensorNumber uint8) (response *GetSensorEventStatusResponse, err error) {
+	return c.GetSensorEventStatusContext(context.Background(), sensorNumber)
+}
+
+// GetSensorEventStatusContext is like GetSensorEventStatus but takes a context.
+func (c *Client) GetSensorEventStatusContext(ctx context.Context, sensorNumber uint8) (response *GetSensorEventStatusResponse, err error) {
 	request := &GetSensorEventStatusRequest{
 		SensorNumber: sensorNumber,
 	}
 	response = &GetSensorEventStatusResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,6 +1,9 @@
 package ipmi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // 35.7 Get Sensor Hysteresis Command
 type GetSensorHysteresisRequest struct {
@@ -47,10 +50,15 @@ Negative Hysteresis : %d`,
 // This command retrieves the present hysteresis values for the specified sensor.
 // If the sensor hysteresis values are "fixed", then the hysteresis values can be obtained from the SDR for the sensor.
 func (c *Client) GetSensorHysteresis(sensorNumber uint8) (response *GetSensorHysteresisResponse, err error) {
+	return c.GetSensorHysteresisContext(context.Background(), sensorNumber)
+}
+
+// GetSensorHysteresisContext is like GetSensorHysteresis but takes a context.
+func (c *Client) GetSensorHysteresisContext(ctx context.Context, sensorNumber uint8) (response *GetSensorHysteresisResponse, err error) {
 	request := &GetSensorHysteresisRequest{
 		SensorNumber: sensorNumber,
 	}
 	response = &GetSensorHysteresisResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -149,10 +150,15 @@ Discrete Events        : %v
 }
 
 func (c *Client) GetSensorReading(sensorNumber uint8) (response *GetSensorReadingResponse, err error) {
+	return c.GetSensorReadingContext(context.Background(), sensorNumber)
+}
+
+// GetSensorReadingContext is like GetSensorReading but takes a context.
+func (c *Client) GetSensorReadingContext(ctx context.Context, sensorNumber uint8) (response *GetSensorReadingResponse, err error) {
 	request := &GetSensorReadingRequest{
 		SensorNumber: sensorNumber,
 	}
 	response = &GetSensorReadingResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,6 +1,9 @@
 package ipmi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // 35.9 Get Sensor Thresholds Command
 type GetSensorThresholdsRequest struct {
@@ -79,10 +82,15 @@ LNC Readable : %v%s`,
 
 // This command retrieves the threshold for the given sensor.
 func (c *Client) GetSensorThresholds(sensorNumber uint8) (response *GetSensorThresholdsResponse, err error) {
+	return c.GetSensorThresholdsContext(context.Background(), sensorNumber)
+}
+
+// GetSensorThresholdsContext is like GetSensorThresholds but takes a context.
+func (c *Client) GetSensorThresholdsContext(ctx context.Context, sensorNumber uint8) (response *GetSensorThresholdsResponse, err error) {
 	request := &GetSensorThresholdsRequest{
 		SensorNumber: sensorNumber,
 	}
 	response = &GetSensorThresholdsResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -48,10 +49,15 @@ Event/Reading Type          : %#02x (%s)`,
 }
 
 func (c *Client) GetSensorType(sensorNumber uint8) (response *GetSensorTypeResponse, err error) {
+	return c.GetSensorTypeContext(context.Background(), sensorNumber)
+}
+
+// GetSensorTypeContext is like GetSensorType but takes a context.
+func (c *Client) GetSensorTypeContext(ctx context.Context, sensorNumber uint8) (response *GetSensorTypeResponse, err error) {
 	request := &GetSensorTypeRequest{
 		SensorNumber: sensorNumber,
 	}
 	response = &GetSensorTypeResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
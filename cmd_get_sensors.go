@@ -1,8 +1,11 @@
 package ipmi
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
 )
 
 type SensorFilterOption func(sensor *Sensor) bool
@@ -36,18 +39,24 @@ func SensorFilterOptionIsSensorType(sensorTypes ...SensorType) func(sensor *Sens
 //
 // If you want the filter options are logically ORed, use `GetSensorsAny`
 func (c *Client) GetSensors(filterOptions ...SensorFilterOption) ([]*Sensor, error) {
+	return c.GetSensorsContext(context.Background(), filterOptions...)
+}
+
+// GetSensorsContext is like GetSensors but takes a context.
+func (c *Client) GetSensorsContext(ctx context.Context, filterOptions ...SensorFilterOption) ([]*Sensor, error) {
 	var out = make([]*Sensor, 0)
 
-	sdrs, err := c.GetSDRs(SDRRecordTypeFullSensor, SDRRecordTypeCompactSensor)
+	sdrs, err := c.GetSDRsContext(ctx, SDRRecordTypeFullSensor, SDRRecordTypeCompactSensor)
 	if err != nil {
-		return nil, fmt.Errorf("GetSDRs failed, err: %s", err)
+		return nil, fmt.Errorf("GetSDRs failed, err: %w", err)
 	}
 
-	for _, sdr := range sdrs {
-		sensor, err := c.sdrToSensor(sdr)
-		if err != nil {
-			return nil, fmt.Errorf("sdrToSensor failed, err: %s", err)
-		}
+	sensors, err := c.sdrsToSensors(ctx, sdrs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sensor := range sensors {
 
 		var choose bool = true
 		for _, filterOption := range filterOptions {
@@ -74,18 +83,24 @@ func (c *Client) GetSensors(filterOptions ...SensorFilterOption) ([]*Sensor, err
 //
 // If you want the filter options are logically ANDed, use `GetSensors`.
 func (c *Client) GetSensorsAny(filterOptions ...SensorFilterOption) ([]*Sensor, error) {
+	return c.GetSensorsAnyContext(context.Background(), filterOptions...)
+}
+
+// GetSensorsAnyContext is like GetSensorsAny but takes a context.
+func (c *Client) GetSensorsAnyContext(ctx context.Context, filterOptions ...SensorFilterOption) ([]*Sensor, error) {
 	var out = make([]*Sensor, 0)
 
-	sdrs, err := c.GetSDRs(SDRRecordTypeFullSensor, SDRRecordTypeCompactSensor)
+	sdrs, err := c.GetSDRsContext(ctx, SDRRecordTypeFullSensor, SDRRecordTypeCompactSensor)
 	if err != nil {
-		return nil, fmt.Errorf("GetSDRs failed, err: %s", err)
+		return nil, fmt.Errorf("GetSDRs failed, err: %w", err)
 	}
 
-	for _, sdr := range sdrs {
-		sensor, err := c.sdrToSensor(sdr)
-		if err != nil {
-			return nil, fmt.Errorf("sdrToSensor failed, err: %s", err)
-		}
+	sensors, err := c.sdrsToSensors(ctx, sdrs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sensor := range sensors {
 
 		var choose bool = false
 		for _, filterOption := range filterOptions {
@@ -105,14 +120,19 @@ func (c *Client) GetSensorsAny(filterOptions ...SensorFilterOption) ([]*Sensor,
 
 // GetSensorByID returns the sensor with current reading and status by specified sensor number.
 func (c *Client) GetSensorByID(sensorNumber uint8) (*Sensor, error) {
-	sdr, err := c.GetSDRBySensorID(sensorNumber)
+	return c.GetSensorByIDContext(context.Background(), sensorNumber)
+}
+
+// GetSensorByIDContext is like GetSensorByID but takes a context.
+func (c *Client) GetSensorByIDContext(ctx context.Context, sensorNumber uint8) (*Sensor, error) {
+	sdr, err := c.GetSDRBySensorIDContext(ctx, sensorNumber)
 	if err != nil {
-		return nil, fmt.Errorf("GetSDRBySensorID failed, err: %s", err)
+		return nil, fmt.Errorf("GetSDRBySensorID failed, err: %w", err)
 	}
 
-	sensor, err := c.sdrToSensor(sdr)
+	sensor, err := c.sdrToSensor(ctx, sdr)
 	if err != nil {
-		return nil, fmt.Errorf("GetSensorFromSDR failed, err: %s", err)
+		return nil, fmt.Errorf("GetSensorFromSDR failed, err: %w", err)
 	}
 
 	return sensor, nil
@@ -120,25 +140,98 @@ func (c *Client) GetSensorByID(sensorNumber uint8) (*Sensor, error) {
 
 // GetSensorByName returns the sensor with current reading and status by specified sensor name.
 func (c *Client) GetSensorByName(sensorName string) (*Sensor, error) {
-	sdr, err := c.GetSDRBySensorName(sensorName)
+	return c.GetSensorByNameContext(context.Background(), sensorName)
+}
+
+// GetSensorByNameContext is like GetSensorByName but takes a context.
+func (c *Client) GetSensorByNameContext(ctx context.Context, sensorName string) (*Sensor, error) {
+	sdr, err := c.GetSDRBySensorNameContext(ctx, sensorName)
 	if err != nil {
-		return nil, fmt.Errorf("GetSDRBySensorName failed, err: %s", err)
+		return nil, fmt.Errorf("GetSDRBySensorName failed, err: %w", err)
 	}
 
-	sensor, err := c.sdrToSensor(sdr)
+	sensor, err := c.sdrToSensor(ctx, sdr)
 	if err != nil {
-		return nil, fmt.Errorf("GetSensorFromSDR failed, err: %s", err)
+		return nil, fmt.Errorf("GetSensorFromSDR failed, err: %w", err)
 	}
 
 	return sensor, nil
 }
 
+// sdrsToSensors converts the SDR records to Sensors in the same order.
+// The sensors are read concurrently if pipelining is enabled, see WithPipelineWindow.
+// It stops at the first error, the sensors not read yet are skipped.
+func (c *Client) sdrsToSensors(ctx context.Context, sdrs []*SDR) ([]*Sensor, error) {
+	sensors := make([]*Sensor, len(sdrs))
+
+	window := c.pipelineWindow()
+	if window == 1 {
+		for i, sdr := range sdrs {
+			sensor, err := c.sdrToSensor(ctx, sdr)
+			if err != nil {
+				return nil, fmt.Errorf("sdrToSensor failed, err: %w", err)
+			}
+			sensors[i] = sensor
+		}
+		return sensors, nil
+	}
+
+	// the in-flight reads are aborted once one of them fails
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var firstErr error
+	setErr := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	sem := make(chan struct{}, window)
+	for i, sdr := range sdrs {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, sdr *SDR) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			sensor, err := c.sdrToSensor(ctx, sdr)
+			if err != nil {
+				setErr(err)
+				return
+			}
+			sensors[i] = sensor
+		}(i, sdr)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, fmt.Errorf("sdrToSensor failed, err: %w", firstErr)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("sdrToSensor aborted, err: %w", err)
+	}
+	return sensors, nil
+}
+
 // sdrToSensor convert SDR record to Sensor struct.
 //
 // Only Full and Compact SDR records are meaningful here. Pass SDRs with other record types will return error.
 //
 // This function will fetch other sensor-related values which are not stored in SDR by other IPMI commands.
-func (c *Client) sdrToSensor(sdr *SDR) (*Sensor, error) {
+func (c *Client) sdrToSensor(ctx context.Context, sdr *SDR) (*Sensor, error) {
 	if sdr == nil {
 		return nil, fmt.Errorf("nil sdr parameter")
 	}
@@ -181,8 +274,8 @@ func (c *Client) sdrToSensor(sdr *SDR) (*Sensor, error) {
 	c.Debug("Sensor:", sensor)
 	c.Debug("Get Sensor", fmt.Sprintf("Sensor Name: %s, Sensor Number: %#02x\n", sensor.Name, sensor.Number))
 
-	if err := c.fillSensorReading(sensor); err != nil {
-		return nil, fmt.Errorf("fillSensorReading failed, err: %s", err)
+	if err := c.fillSensorReading(ctx, sensor); err != nil {
+		return nil, fmt.Errorf("fillSensorReading failed, err: %w", err)
 	}
 
 	// scanningDisabled is filled/set by fillSensorReading
@@ -193,34 +286,45 @@ func (c *Client) sdrToSensor(sdr *SDR) (*Sensor, error) {
 	}
 
 	if !sensor.EventReadingType.IsThreshold() || !sensor.SensorUnit.IsAnalog() {
-		if err := c.fillSensorDiscrete(sensor); err != nil {
-			return nil, fmt.Errorf("fillSensorDiscrete failed, err: %s", err)
+		if err := c.fillSensorDiscrete(ctx, sensor); err != nil {
+			return nil, fmt.Errorf("fillSensorDiscrete failed, err: %w", err)
 		}
 	} else {
-		if err := c.fillSensorThreshold(sensor); err != nil {
-			return nil, fmt.Errorf("fillSensorThreshold failed, err: %s", err)
+		if err := c.fillSensorThreshold(ctx, sensor); err != nil {
+			return nil, fmt.Errorf("fillSensorThreshold failed, err: %w", err)
 		}
 	}
 
 	return sensor, nil
 }
 
-func (c *Client) fillSensorReading(sensor *Sensor) error {
+func (c *Client) fillSensorReading(ctx context.Context, sensor *Sensor) error {
 
-	readingRes, err := c.GetSensorReading(sensor.Number)
+	readingRes, err := c.GetSensorReadingContext(ctx, sensor.Number)
 	if err != nil {
 		if _canSafelyIgnoredResponseError(err) {
 			c.Debug(fmt.Sprintf("GetSensorReading for sensor %#02x failed but skipped", sensor.Number), err)
 			return nil
 		}
-		return fmt.Errorf("GetSensorReading for sensor %#02x failed, err: %s", sensor.Number, err)
+		return fmt.Errorf("GetSensorReading for sensor %#02x failed, err: %w", sensor.Number, err)
 	}
 
 	sensor.Raw = readingRes.Reading
-	sensor.Value = sensor.ConvertReading(readingRes.Reading)
-
 	sensor.scanningDisabled = readingRes.SensorScanningDisabled
 	sensor.readingAvailable = !readingRes.ReadingUnavailable
+
+	sensor.Value = sensor.ConvertReading(sensor.Raw)
+	if !sensor.scanningDisabled && sensor.readingAvailable {
+		// the factors for the reading are only used to convert it,
+		// the SDR factors are kept for the thresholds and hysteresis
+		factors, err := c.nonLinearReadingFactors(ctx, sensor, sensor.Raw)
+		if err != nil {
+			return err
+		}
+		if f, ok := factors[sensor.Raw]; ok {
+			sensor.Value = ConvertReading(sensor.Raw, sensor.SensorUnit.AnalogDataFormat, f, sensor.Threshold.LinearizationFunc)
+		}
+	}
 	sensor.Threshold.ThresholdStatus = readingRes.ThresholdStatus()
 
 	sensor.Discrete.ActiveStates = readingRes.ActiveStates
@@ -231,46 +335,32 @@ func (c *Client) fillSensorReading(sensor *Sensor) error {
 }
 
 // fillSensorDiscrete retrieves and fills extra sensor attributes for given discrete sensor.
-func (c *Client) fillSensorDiscrete(sensor *Sensor) error {
-	statusRes, err := c.GetSensorEventStatus(sensor.Number)
+func (c *Client) fillSensorDiscrete(ctx context.Context, sensor *Sensor) error {
+	statusRes, err := c.GetSensorEventStatusContext(ctx, sensor.Number)
 	if err != nil {
 		if _canSafelyIgnoredResponseError(err) {
 			c.Debug(fmt.Sprintf("GetSensorEventStatus for sensor %#02x failed but skipped", sensor.Number), err)
 			return nil
 		}
-		return fmt.Errorf("GetSensorEventStatus for sensor %#02x failed, err: %s", sensor.Number, err)
+		return fmt.Errorf("GetSensorEventStatus for sensor %#02x failed, err: %w", sensor.Number, err)
 	}
 	sensor.OccurredEvents = statusRes.SensorEventFlag.TrueEvents()
 	return nil
 }
 
 // fillSensorThreshold retrieves and fills sensor attributes for given threshold sensor.
-func (c *Client) fillSensorThreshold(sensor *Sensor) error {
+func (c *Client) fillSensorThreshold(ctx context.Context, sensor *Sensor) error {
 	if sensor.SDRRecordType != SDRRecordTypeFullSensor {
 		return nil
 	}
 
-	// If Non Linear, should update the ReadingFactors
-	// see 36.2 Non-Linear Sensors
-	if sensor.Threshold.LinearizationFunc.IsNonLinear() {
-		factorsRes, err := c.GetSensorReadingFactors(sensor.Number, sensor.Raw)
-		if err != nil {
-			if _canSafelyIgnoredResponseError(err) {
-				c.Debug(fmt.Sprintf("GetSensorReadingFactors for sensor %#02x failed but skipped", sensor.Number), err)
-				return nil
-			}
-			return fmt.Errorf("GetSensorReadingFactors for sensor %#02x failed, err: %s", sensor.Number, err)
-		}
-		sensor.Threshold.ReadingFactors = factorsRes.ReadingFactors
-	}
-
-	thresholdRes, err := c.GetSensorThresholds(sensor.Number)
+	thresholdRes, err := c.GetSensorThresholdsContext(ctx, sensor.Number)
 	if err != nil {
 		if _canSafelyIgnoredResponseError(err) {
 			c.Debug(fmt.Sprintf("GetSensorThresholds for sensor %#02x failed but skipped", sensor.Number), err)
 			return nil
 		}
-		return fmt.Errorf("GetSensorThresholds for sensor %#02x failed, err: %s", sensor.Number, err)
+		return fmt.Errorf("GetSensorThresholds for sensor %#02x failed, err: %w", sensor.Number, err)
 	}
 	sensor.Threshold.Mask.UNR.Readable = thresholdRes.UNR_Readable
 	sensor.Threshold.Mask.UCR.Readable = thresholdRes.UCR_Readable
@@ -284,20 +374,49 @@ func (c *Client) fillSensorThreshold(sensor *Sensor) error {
 	sensor.Threshold.UNC_Raw = thresholdRes.UNC_Raw
 	sensor.Threshold.UCR_Raw = thresholdRes.UCR_Raw
 	sensor.Threshold.UNR_Raw = thresholdRes.UNR_Raw
-	sensor.Threshold.LNC = sensor.ConvertReading(thresholdRes.LNC_Raw)
-	sensor.Threshold.LCR = sensor.ConvertReading(thresholdRes.LCR_Raw)
-	sensor.Threshold.LNR = sensor.ConvertReading(thresholdRes.LNR_Raw)
-	sensor.Threshold.UNC = sensor.ConvertReading(thresholdRes.UNC_Raw)
-	sensor.Threshold.UCR = sensor.ConvertReading(thresholdRes.UCR_Raw)
-	sensor.Threshold.UNR = sensor.ConvertReading(thresholdRes.UNR_Raw)
-
-	hysteresisRes, err := c.GetSensorHysteresis(sensor.Number)
+
+	// the thresholds of non-linear sensors are converted by the reading factors for their own raw values
+	var readableRaws []uint8
+	for _, v := range []struct {
+		readable bool
+		raw      uint8
+	}{
+		{thresholdRes.LNC_Readable, thresholdRes.LNC_Raw},
+		{thresholdRes.LCR_Readable, thresholdRes.LCR_Raw},
+		{thresholdRes.LNR_Readable, thresholdRes.LNR_Raw},
+		{thresholdRes.UNC_Readable, thresholdRes.UNC_Raw},
+		{thresholdRes.UCR_Readable, thresholdRes.UCR_Raw},
+		{thresholdRes.UNR_Readable, thresholdRes.UNR_Raw},
+	} {
+		if v.readable {
+			readableRaws = append(readableRaws, v.raw)
+		}
+	}
+	factors, err := c.nonLinearReadingFactors(ctx, sensor, readableRaws...)
+	if err != nil {
+		return err
+	}
+	convert := func(raw uint8) float64 {
+		f, ok := factors[raw]
+		if !ok {
+			return sensor.ConvertReading(raw)
+		}
+		return ConvertReading(raw, sensor.SensorUnit.AnalogDataFormat, f, sensor.Threshold.LinearizationFunc)
+	}
+	sensor.Threshold.LNC = convert(thresholdRes.LNC_Raw)
+	sensor.Threshold.LCR = convert(thresholdRes.LCR_Raw)
+	sensor.Threshold.LNR = convert(thresholdRes.LNR_Raw)
+	sensor.Threshold.UNC = convert(thresholdRes.UNC_Raw)
+	sensor.Threshold.UCR = convert(thresholdRes.UCR_Raw)
+	sensor.Threshold.UNR = convert(thresholdRes.UNR_Raw)
+
+	hysteresisRes, err := c.GetSensorHysteresisContext(ctx, sensor.Number)
 	if err != nil {
 		if _canSafelyIgnoredResponseError(err) {
 			c.Debug(fmt.Sprintf("GetSensorHysteresis for sensor %#02x failed but skipped", sensor.Number), err)
 			return nil
 		}
-		return fmt.Errorf("GetSensorHysteresis for sensor %#02x failed, err: %s", sensor.Number, err)
+		return fmt.Errorf("GetSensorHysteresis for sensor %#02x failed, err: %w", sensor.Number, err)
 	}
 	sensor.Threshold.PositiveHysteresisRaw = hysteresisRes.PositiveRaw
 	sensor.Threshold.NegativeHysteresisRaw = hysteresisRes.NegativeRaw
@@ -310,13 +429,38 @@ func (c *Client) fillSensorThreshold(sensor *Sensor) error {
 // If the err is a ResponseError and the completion code wrapped
 // in ResponseError can be safely ignored
 func _canSafelyIgnoredResponseError(err error) bool {
-	if respErr, ok := err.(*ResponseError); ok {
-		cc := respErr.CompletionCode()
-		if cc == CompletionCodeRequestedDataNotPresent || cc == CompletionCodeIllegalCommand || cc == CompletionCodeInvalidCommand {
-			// above completion codes CAN be ignored
-			// it normally means the sensor device does not exist or the sensor device does not recognize the IPMI command
-			return true
+	// these completion codes CAN be ignored
+	// it normally means the sensor device does not exist or the sensor device does not recognize the IPMI command
+	return errors.Is(err, ErrRequestedDataNotPresent) ||
+		errors.Is(err, ErrIllegalCommand) ||
+		errors.Is(err, ErrInvalidCommand)
+}
+
+// nonLinearReadingFactors returns the reading factors of the non-linear sensor for the raw values.
+// The reading factors of non-linear sensors vary with the reading, and the factors in the SDR
+// should not be used, so they are got by GetSensorReadingFactors, see 36.2 Non-Linear Sensors.
+//
+// It returns nil if the sensor is not a non-linear analog sensor.
+// The raw values whose factors can't be got are absent in the returned map.
+func (c *Client) nonLinearReadingFactors(ctx context.Context, sensor *Sensor, raws ...uint8) (map[uint8]ReadingFactors, error) {
+	if !sensor.HasAnalogReading || !sensor.Threshold.LinearizationFunc.IsNonLinear() {
+		return nil, nil
+	}
+
+	out := make(map[uint8]ReadingFactors, len(raws))
+	for _, raw := range raws {
+		if _, ok := out[raw]; ok {
+			continue
+		}
+		factorsRes, err := c.GetSensorReadingFactorsContext(ctx, sensor.Number, raw)
+		if err != nil {
+			if _canSafelyIgnoredResponseError(err) {
+				c.Debug(fmt.Sprintf("GetSensorReadingFactors for sensor %#02x failed but skipped", sensor.Number), err)
+				return out, nil
+			}
+			return nil, fmt.Errorf("GetSensorReadingFactors for sensor %#02x failed, err: %w", sensor.Number, err)
 		}
+		out[raw] = factorsRes.ReadingFactors
 	}
-	return false
+	return out, nil
 }
@@ -1,6 +1,9 @@
 package ipmi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // 22.16
 type GetSessionChallengeRequest struct {
@@ -52,6 +55,11 @@ func (res *GetSessionChallengeResponse) Format() string {
 // The command selects which of the BMC-supported authentication types the Remote Console would like to use,
 // and a username that selects which set of user information should be used for the session
 func (c *Client) GetSessionChallenge() (response *GetSessionChallengeResponse, err error) {
+	return c.GetSessionChallengeContext(context.Background())
+}
+
+// GetSessionChallengeContext is like GetSessionChallenge but takes a context.
+func (c *Client) GetSessionChallengeContext(ctx context.Context) (response *GetSessionChallengeResponse, err error) {
 	username := padBytes(c.Username, 16, 0x00)
 	request := &GetSessionChallengeRequest{
 		AuthType: c.session.authType,
@@ -59,7 +67,7 @@ func (c *Client) GetSessionChallenge() (response *GetSessionChallengeResponse, e
 	}
 
 	response = &GetSessionChallengeResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	if err != nil {
 		return
 	}
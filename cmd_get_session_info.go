@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"context"
 	"fmt"
 	"net"
 )
@@ -147,16 +148,26 @@ console port                  : %d
 }
 
 func (c *Client) GetSessionInfo(request *GetSessionInfoRequest) (response *GetSessionInfoResponse, err error) {
+	return c.GetSessionInfoContext(context.Background(), request)
+}
+
+// GetSessionInfoContext is like GetSessionInfo but takes a context.
+func (c *Client) GetSessionInfoContext(ctx context.Context, request *GetSessionInfoRequest) (response *GetSessionInfoResponse, err error) {
 	response = &GetSessionInfoResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
 
 func (c *Client) GetCurrentSessionInfo() (response *GetSessionInfoResponse, err error) {
+	return c.GetCurrentSessionInfoContext(context.Background())
+}
+
+// GetCurrentSessionInfoContext is like GetCurrentSessionInfo but takes a context.
+func (c *Client) GetCurrentSessionInfoContext(ctx context.Context) (response *GetSessionInfoResponse, err error) {
 	request := &GetSessionInfoRequest{
 		SessionIndex: 0x00,
 	}
 	response = &GetSessionInfoResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
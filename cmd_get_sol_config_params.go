@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 26.3 Get SOL Configuration Parameters Command
 type GetSOLConfigParamsRequest struct {
 	GetParameterRevisionOnly bool
@@ -51,6 +53,11 @@ func (res *GetSOLConfigParamsResponse) Format() string {
 }
 
 func (c *Client) GetSOLConfigParams(channelNumber uint8, paramSelector SOLConfigParamSelector) (response *GetSOLConfigParamsResponse, err error) {
+	return c.GetSOLConfigParamsContext(context.Background(), channelNumber, paramSelector)
+}
+
+// GetSOLConfigParamsContext is like GetSOLConfigParams but takes a context.
+func (c *Client) GetSOLConfigParamsContext(ctx context.Context, channelNumber uint8, paramSelector SOLConfigParamSelector) (response *GetSOLConfigParamsResponse, err error) {
 	request := &GetSOLConfigParamsRequest{
 		ChannelNumber:     channelNumber,
 		ParameterSelector: paramSelector,
@@ -58,6 +65,6 @@ func (c *Client) GetSOLConfigParams(channelNumber uint8, paramSelector SOLConfig
 		BlockSelector:     0x00,
 	}
 	response = &GetSOLConfigParamsResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
@@ -37,8 +38,13 @@ func (res *CommandGetSupermicroBiosVersionResponse) Format() string {
 }
 
 func (c *Client) GetSupermicroBiosVersion() (response *CommandGetSupermicroBiosVersionResponse, err error) {
+	return c.GetSupermicroBiosVersionContext(context.Background())
+}
+
+// GetSupermicroBiosVersionContext is like GetSupermicroBiosVersion but takes a context.
+func (c *Client) GetSupermicroBiosVersionContext(ctx context.Context) (response *CommandGetSupermicroBiosVersionResponse, err error) {
 	request := &CommandGetSupermicroBiosVersionRequest{}
 	response = &CommandGetSupermicroBiosVersionResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,6 +1,9 @@
 package ipmi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // 28.13 Get System Boot Options Command
 type GetSystemBootOptionsRequest struct {
@@ -59,7 +62,7 @@ func (res *GetSystemBootOptionsResponse) Unpack(msg []byte) error {
 
 		bop, err := ParseBootOptionParameterData(res.ParameterSelector, parameterData)
 		if err != nil {
-			return fmt.Errorf("parse ParameterData failed, err: %s", err)
+			return fmt.Errorf("parse ParameterData failed, err: %w", err)
 		}
 		res.BootOptionParameter = bop
 	}
@@ -82,12 +85,17 @@ Boot parameter data: %02x
 // The boot flags only apply for one system restart. It is the responsibility of the system BIOS
 // to read these settings from the BMC and then clear the boot flags
 func (c *Client) GetSystemBootOptions(parameterSelector BootOptionParameterSelector) (response *GetSystemBootOptionsResponse, err error) {
+	return c.GetSystemBootOptionsContext(context.Background(), parameterSelector)
+}
+
+// GetSystemBootOptionsContext is like GetSystemBootOptions but takes a context.
+func (c *Client) GetSystemBootOptionsContext(ctx context.Context, parameterSelector BootOptionParameterSelector) (response *GetSystemBootOptionsResponse, err error) {
 	request := &GetSystemBootOptionsRequest{
 		ParameterSelector: parameterSelector,
 		SetSelector:       0x00,
 		BlockSelector:     0x00,
 	}
 	response = &GetSystemBootOptionsResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
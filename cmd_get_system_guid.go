@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -55,8 +56,13 @@ func (res *GetSystemGUIDResponse) Format() string {
 }
 
 func (c *Client) GetSystemGUID() (response *GetSystemGUIDResponse, err error) {
+	return c.GetSystemGUIDContext(context.Background())
+}
+
+// GetSystemGUIDContext is like GetSystemGUID but takes a context.
+func (c *Client) GetSystemGUIDContext(ctx context.Context) (response *GetSystemGUIDResponse, err error) {
 	request := &GetSystemGUIDRequest{}
 	response = &GetSystemGUIDResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 22.9 Get System Interface Capabilities Command
 type GetSystemInterfaceCapabilitiesRequest struct {
 	SystemInterfaceType SystemInterfaceType
@@ -67,10 +69,15 @@ func (res *GetSystemInterfaceCapabilitiesResponse) Format() string {
 }
 
 func (c *Client) GetSystemInterfaceCapabilities(interfaceType SystemInterfaceType) (response *GetSystemInterfaceCapabilitiesResponse, err error) {
+	return c.GetSystemInterfaceCapabilitiesContext(context.Background(), interfaceType)
+}
+
+// GetSystemInterfaceCapabilitiesContext is like GetSystemInterfaceCapabilities but takes a context.
+func (c *Client) GetSystemInterfaceCapabilitiesContext(ctx context.Context, interfaceType SystemInterfaceType) (response *GetSystemInterfaceCapabilitiesResponse, err error) {
 	request := &GetSystemInterfaceCapabilitiesRequest{
 		SystemInterfaceType: interfaceType,
 	}
 	response = &GetSystemInterfaceCapabilitiesResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,6 +1,9 @@
 package ipmi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // 28.11 Get System Restart Cause Command
 type GetSystemRestartCauseRequest struct {
@@ -65,8 +68,13 @@ func (res *GetSystemRestartCauseResponse) Format() string {
 }
 
 func (c *Client) GetSystemRestartCause() (response *GetSystemRestartCauseResponse, err error) {
+	return c.GetSystemRestartCauseContext(context.Background())
+}
+
+// GetSystemRestartCauseContext is like GetSystemRestartCause but takes a context.
+func (c *Client) GetSystemRestartCauseContext(ctx context.Context) (response *GetSystemRestartCauseResponse, err error) {
 	request := &GetSystemRestartCauseRequest{}
 	response = &GetSystemRestartCauseResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
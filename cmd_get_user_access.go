@@ -2,6 +2,8 @@ package ipmi
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 
 	"github.com/olekukonko/tablewriter"
@@ -99,31 +101,40 @@ func (res *GetUserAccessResponse) Format() string {
 }
 
 func (c *Client) GetUserAccess(channelNumber uint8, userID uint8) (response *GetUserAccessResponse, err error) {
+	return c.GetUserAccessContext(context.Background(), channelNumber, userID)
+}
+
+// GetUserAccessContext is like GetUserAccess but takes a context.
+func (c *Client) GetUserAccessContext(ctx context.Context, channelNumber uint8, userID uint8) (response *GetUserAccessResponse, err error) {
 	request := &GetUserAccessRequest{
 		ChannelNumber: channelNumber,
 		UserID:        userID,
 	}
 	response = &GetUserAccessResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
 
 func (c *Client) ListUser(channelNumber uint8) ([]*User, error) {
+	return c.ListUserContext(context.Background(), channelNumber)
+}
+
+// ListUserContext is like ListUser but takes a context.
+func (c *Client) ListUserContext(ctx context.Context, channelNumber uint8) ([]*User, error) {
 	var users = make([]*User, 0)
 
 	var userID uint8 = 1
 	var username string
 	for {
-		res, err := c.GetUserAccess(channelNumber, userID)
+		res, err := c.GetUserAccessContext(ctx, channelNumber, userID)
 		if err != nil {
-			return nil, fmt.Errorf("get user for userID %d failed, err: %s", userID, err)
+			return nil, fmt.Errorf("get user for userID %d failed, err: %w", userID, err)
 		}
 
-		res2, err := c.GetUsername(userID)
+		res2, err := c.GetUsernameContext(ctx, userID)
 		if err != nil {
-			respErr, ok := err.(*ResponseError)
-			if !ok || uint8(respErr.CompletionCode()) != 0xcc {
-				return nil, fmt.Errorf("get user name for userID %d failed, err: %s", userID, err)
+			if !errors.Is(err, ErrRequestDataFieldInvalid) {
+				return nil, fmt.Errorf("get user name for userID %d failed, err: %w", userID, err)
 			}
 
 			// Completion Code is 0xcc, means this UserID is not set.
@@ -1,6 +1,9 @@
 package ipmi
 
-import "bytes"
+import (
+	"bytes"
+	"context"
+)
 
 // 22.29 Get User Name Command
 type GetUsernameRequest struct {
@@ -38,10 +41,15 @@ func (res *GetUsernameResponse) Format() string {
 }
 
 func (c *Client) GetUsername(userID uint8) (response *GetUsernameResponse, err error) {
+	return c.GetUsernameContext(context.Background(), userID)
+}
+
+// GetUsernameContext is like GetUsername but takes a context.
+func (c *Client) GetUsernameContext(ctx context.Context, userID uint8) (response *GetUsernameResponse, err error) {
 	request := &GetUsernameRequest{
 		UserID: userID,
 	}
 	response = &GetUsernameResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,6 +1,9 @@
 package ipmi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // 27.7 Get Watchdog Timer Command
 type GetWatchdogTimerRequest struct {
@@ -71,9 +74,14 @@ Present Countdown:      %d sec`,
 }
 
 func (c *Client) GetWatchdogTimer() (response *GetWatchdogTimerResponse, err error) {
+	return c.GetWatchdogTimerContext(context.Background())
+}
+
+// GetWatchdogTimerContext is like GetWatchdogTimer but takes a context.
+func (c *Client) GetWatchdogTimerContext(ctx context.Context) (response *GetWatchdogTimerResponse, err error) {
 	request := &GetWatchdogTimerRequest{}
 	response = &GetWatchdogTimerResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
 
@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 20.4 20.5 Manufacturing Test On Command
 type ManufacturingTestOnRequest struct {
 	// empty
@@ -32,8 +34,13 @@ func (res *ManufacturingTestOnResponse) Format() string {
 
 // If the device supports a "manufacturing test mode", this command is reserved to turn that mode on.
 func (c *Client) ManufacturingTestOn() (response *ManufacturingTestOnResponse, err error) {
+	return c.ManufacturingTestOnContext(context.Background())
+}
+
+// ManufacturingTestOnContext is like ManufacturingTestOn but takes a context.
+func (c *Client) ManufacturingTestOnContext(ctx context.Context) (response *ManufacturingTestOnResponse, err error) {
 	request := &ManufacturingTestOnRequest{}
 	response = &ManufacturingTestOnResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
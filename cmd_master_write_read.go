@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 22.11 Master Write-Read Command
 type MasterWriteReadRequest struct {
 	ChannelNumber    uint8
@@ -54,7 +56,12 @@ func (res *MasterWriteReadResponse) Format() string {
 }
 
 func (c *Client) MasterWriteRead(request *MasterWriteReadRequest) (*MasterWriteReadResponse, error) {
+	return c.MasterWriteReadContext(context.Background(), request)
+}
+
+// MasterWriteReadContext is like MasterWriteRead but takes a context.
+func (c *Client) MasterWriteReadContext(ctx context.Context, request *MasterWriteReadRequest) (*MasterWriteReadResponse, error) {
 	response := &MasterWriteReadResponse{}
-	err := c.Exchange(request, response)
+	err := c.ExchangeContext(ctx, request, response)
 	return response, err
 }
@@ -1,6 +1,9 @@
 package ipmi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // 13.17 RMCP+ Open Session Request
 type OpenSessionRequest struct {
@@ -70,6 +73,48 @@ func (req *OpenSessionRequest) Pack() []byte {
 	return out
 }
 
+// Unpack is used by the managed system (like the simulator) to decode the received request.
+func (req *OpenSessionRequest) Unpack(data []byte) error {
+	if len(data) < RmcpOpenSessionRequestSize {
+		return ErrUnpackedDataTooShortWith(len(data), RmcpOpenSessionRequestSize)
+	}
+
+	req.MessageTag, _, _ = unpackUint8(data, 0)
+	b1, _, _ := unpackUint8(data, 1)
+	req.RequestedMaximumPrivilegeLevel = PrivilegeLevel(b1 & 0x0f)
+	// 2 bytes reserved
+	req.RemoteConsoleSessionID, _, _ = unpackUint32L(data, 4)
+	req.AuthenticationPayload.Unpack(data[8:16])
+	req.IntegrityPayload.Unpack(data[16:24])
+	req.ConfidentialityPayload.Unpack(data[24:32])
+	return nil
+}
+
+// Pack is used by the managed system (like the simulator) to encode the response.
+// The algorithm payloads are omitted if the RmcpStatusCode is not RmcpStatusCodeNoErrors.
+func (res *OpenSessionResponse) Pack() []byte {
+	if res.RmcpStatusCode != RmcpStatusCodeNoErrors {
+		out := make([]byte, RmcpOpenSessionResponseMinSize)
+		packUint8(res.MessageTag, out, 0)
+		packUint8(uint8(res.RmcpStatusCode), out, 1)
+		packUint8(res.MaximumPrivilegeLevel, out, 2)
+		packUint32L(res.RemoteConsoleSessionID, out, 4)
+		return out
+	}
+
+	out := make([]byte, RmcpOpenSessionResponseSize)
+	packUint8(res.MessageTag, out, 0)
+	packUint8(uint8(res.RmcpStatusCode), out, 1)
+	packUint8(res.MaximumPrivilegeLevel, out, 2)
+	// 1 byte reserved
+	packUint32L(res.RemoteConsoleSessionID, out, 4)
+	packUint32L(res.ManagedSystemSessionID, out, 8)
+	packBytes(res.AuthenticationPayload.Pack(), out, 12)
+	packBytes(res.IntegrityPayload.Pack(), out, 20)
+	packBytes(res.ConfidentialityPayload.Pack(), out, 28)
+	return out
+}
+
 func (res *OpenSessionResponse) Unpack(data []byte) error {
 	if len(data) < RmcpOpenSessionResponseMinSize {
 		return ErrUnpackedDataTooShortWith(len(data), RmcpOpenSessionResponseMinSize)
@@ -129,11 +174,16 @@ func (res *OpenSessionResponse) Format() string {
 }
 
 func (c *Client) OpenSession() (response *OpenSessionResponse, err error) {
+	return c.OpenSessionContext(context.Background())
+}
+
+// OpenSessionContext is like OpenSession but takes a context.
+func (c *Client) OpenSessionContext(ctx context.Context) (response *OpenSessionResponse, err error) {
 	cipherSuiteID := c.session.v20.cipherSuiteID
 
 	authAlg, integrityAlg, cryptAlg, err := getCipherSuiteAlgorithms(cipherSuiteID)
 	if err != nil {
-		return nil, fmt.Errorf("get cipher suite for id %v failed, err: %s", cipherSuiteID, err)
+		return nil, fmt.Errorf("get cipher suite for id %v failed, err: %w", cipherSuiteID, err)
 	}
 	c.session.v20.requestedAuthAlg = authAlg
 	c.session.v20.requestedIntegrityAlg = integrityAlg
@@ -167,15 +217,15 @@ func (c *Client) OpenSession() (response *OpenSessionResponse, err error) {
 
 	c.session.v20.state = SessionStateOpenSessionSent
 
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	if err != nil {
-		return nil, fmt.Errorf("client exchange failed, err: %s", err)
+		return nil, fmt.Errorf("client exchange failed, err: %w", err)
 	}
 
 	c.Debug("OPEN SESSION RESPONSE", response.Format())
 
 	if response.RmcpStatusCode != RmcpStatusCodeNoErrors {
-		err = fmt.Errorf("rakp status code error: (%#02x) %s", uint8(response.RmcpStatusCode), response.RmcpStatusCode)
+		err = fmt.Errorf("rakp status code error, err: %w", response.RmcpStatusCode.err())
 		return
 	}
 
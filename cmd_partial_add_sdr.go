@@ -0,0 +1,82 @@
+package ipmi
+
+import (
+	"context"
+	"fmt"
+)
+
+// 33.14 Partial Add SDR Command
+type PartialAddSDRRequest struct {
+	ReservationID uint16
+
+	// RecordID is 0000h for the first part of the record,
+	// and the Record ID returned by the first part for the other parts.
+	RecordID uint16
+
+	// Offset into the record.
+	Offset uint8
+
+	// LastData indicates the last record data is being transferred,
+	// otherwise the partial add is in progress.
+	LastData bool
+
+	RecordData []byte
+}
+
+type PartialAddSDRResponse struct {
+	RecordID uint16 // Record ID for added record, LS Byte first
+}
+
+func (req *PartialAddSDRRequest) Command() Command {
+	return CommandPartialAddSDR
+}
+
+func (req *PartialAddSDRRequest) Pack() []byte {
+	out := make([]byte, 6+len(req.RecordData))
+	packUint16L(req.ReservationID, out, 0)
+	packUint16L(req.RecordID, out, 2)
+	packUint8(req.Offset, out, 4)
+	if req.LastData {
+		packUint8(0x01, out, 5)
+	}
+	packBytes(req.RecordData, out, 6)
+	return out
+}
+
+func (res *PartialAddSDRResponse) Unpack(msg []byte) error {
+	if len(msg) < 2 {
+		return ErrUnpackedDataTooShortWith(len(msg), 2)
+	}
+	res.RecordID, _, _ = unpackUint16L(msg, 0)
+	return nil
+}
+
+func (res *PartialAddSDRResponse) CompletionCodes() map[uint8]string {
+	return map[uint8]string{
+		0x80: "record rejected due to length mismatch",
+	}
+}
+
+func (res *PartialAddSDRResponse) Format() string {
+	return fmt.Sprintf("Record ID : %d (%#02x)", res.RecordID, res.RecordID)
+}
+
+// PartialAddSDR adds a part of the SDR record to the SDR Repository.
+// The record must be transferred in order, from offset 0, the record header is included in the first part.
+func (c *Client) PartialAddSDR(reservationID uint16, recordID uint16, offset uint8, lastData bool, recordData []byte) (response *PartialAddSDRResponse, err error) {
+	return c.PartialAddSDRContext(context.Background(), reservationID, recordID, offset, lastData, recordData)
+}
+
+// PartialAddSDRContext is like PartialAddSDR but takes a context.
+func (c *Client) PartialAddSDRContext(ctx context.Context, reservationID uint16, recordID uint16, offset uint8, lastData bool, recordData []byte) (response *PartialAddSDRResponse, err error) {
+	request := &PartialAddSDRRequest{
+		ReservationID: reservationID,
+		RecordID:      recordID,
+		Offset:        offset,
+		LastData:      lastData,
+		RecordData:    recordData,
+	}
+	response = &PartialAddSDRResponse{}
+	err = c.ExchangeContext(ctx, request, response)
+	return
+}
@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 29.3 Platform Event Message Command
 type PlatformEventMessageRequest struct {
 	// The Generator ID field is a required element of an Event Request Message.
@@ -62,8 +64,13 @@ func (res *PlatformEventMessageResponse) Format() string {
 }
 
 func (c *Client) PlatformEventMessage(request *PlatformEventMessageRequest) (response *PlatformEventMessageResponse, err error) {
+	return c.PlatformEventMessageContext(context.Background(), request)
+}
+
+// PlatformEventMessageContext is like PlatformEventMessage but takes a context.
+func (c *Client) PlatformEventMessageContext(ctx context.Context, request *PlatformEventMessageRequest) (response *PlatformEventMessageResponse, err error) {
 	// Todo, consider GeneratorID
 	response = &PlatformEventMessageResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
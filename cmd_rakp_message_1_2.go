@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -100,6 +101,51 @@ func (r *RAKPMessage1) Role() uint8 {
 	return privilegeLevel
 }
 
+// Unpack is used by the managed system (like the simulator) to decode the received request.
+func (r *RAKPMessage1) Unpack(msg []byte) error {
+	if len(msg) < 28 {
+		return ErrUnpackedDataTooShortWith(len(msg), 28)
+	}
+
+	r.MessageTag, _, _ = unpackUint8(msg, 0)
+	// 3 bytes reserved
+	r.ManagedSystemSessionID, _, _ = unpackUint32L(msg, 4)
+	r.RemoteConsoleRandomNumber = array16(msg[8:24])
+
+	role, _, _ := unpackUint8(msg, 24)
+	r.NameOnlyLookup = isBit4Set(role)
+	r.RequestedMaximumPrivilegeLevel = PrivilegeLevel(role & 0x0f)
+	// 2 bytes reserved
+
+	r.UsernameLength, _, _ = unpackUint8(msg, 27)
+	if len(msg) < 28+int(r.UsernameLength) {
+		return ErrUnpackedDataTooShortWith(len(msg), 28+int(r.UsernameLength))
+	}
+	r.Username, _, _ = unpackBytes(msg, 28, int(r.UsernameLength))
+	return nil
+}
+
+// Pack is used by the managed system (like the simulator) to encode the response.
+func (res *RAKPMessage2) Pack() []byte {
+	if res.RmcpStatusCode != RmcpStatusCodeNoErrors {
+		msg := make([]byte, 8)
+		packUint8(res.MessageTag, msg, 0)
+		packUint8(uint8(res.RmcpStatusCode), msg, 1)
+		packUint32L(res.RemoteConsoleSessionID, msg, 4)
+		return msg
+	}
+
+	msg := make([]byte, 40+len(res.KeyExchangeAuthenticationCode))
+	packUint8(res.MessageTag, msg, 0)
+	packUint8(uint8(res.RmcpStatusCode), msg, 1)
+	// 2 bytes reserved
+	packUint32L(res.RemoteConsoleSessionID, msg, 4)
+	packBytes(res.ManagedSystemRandomNumber[:], msg, 8)
+	packBytes(res.ManagedSystemGUID[:], msg, 24)
+	packBytes(res.KeyExchangeAuthenticationCode, msg, 40)
+	return msg
+}
+
 func (res *RAKPMessage2) Unpack(msg []byte) error {
 	// If RAKPMessage1 failed to be validated, the returned RAKPMessage2 only holds 8 bytes.
 	if len(msg) < 8 {
@@ -113,7 +159,7 @@ func (res *RAKPMessage2) Unpack(msg []byte) error {
 
 	// Now we can check whether RmcpStatusCode indicates error
 	if res.RmcpStatusCode != RmcpStatusCodeNoErrors {
-		return fmt.Errorf("the return status of rakp2 has error: %v", res.RmcpStatusCode)
+		return fmt.Errorf("the return status of rakp2 has error: %w", res.RmcpStatusCode.err())
 	}
 
 	if len(msg) < 40 {
@@ -161,18 +207,23 @@ func (c *Client) ValidateRAKP2(rakp2 *RAKPMessage2) (bool, error) {
 	// rakp2 authcode is valid
 	authcode, err := c.generate_rakp2_authcode()
 	if err != nil {
-		return false, fmt.Errorf("generate rakp2 authcode failed, err: %s", err)
+		return false, fmt.Errorf("generate rakp2 authcode failed, err: %w", err)
 	}
 
 	c.DebugBytes("rakp2 returned auth code", rakp2.KeyExchangeAuthenticationCode, 16)
 
 	if !isByteSliceEqual(authcode, rakp2.KeyExchangeAuthenticationCode) {
-		return false, fmt.Errorf("rakp2 authcode not equal, console: %x, bmc: %x", authcode, rakp2.KeyExchangeAuthenticationCode)
+		return false, fmt.Errorf("%w: rakp2 authcode not equal, console: %x, bmc: %x", ErrAuthFailed, authcode, rakp2.KeyExchangeAuthenticationCode)
 	}
 	return true, nil
 }
 
 func (c *Client) RAKPMessage1() (response *RAKPMessage2, err error) {
+	return c.RAKPMessage1Context(context.Background())
+}
+
+// RAKPMessage1Context is like RAKPMessage1 but takes a context.
+func (c *Client) RAKPMessage1Context(ctx context.Context) (response *RAKPMessage2, err error) {
 
 	c.session.v20.consoleRand = array16(randomBytes(16))
 	c.DebugBytes("console generate console random number", c.session.v20.consoleRand[:], 16)
@@ -194,7 +245,7 @@ func (c *Client) RAKPMessage1() (response *RAKPMessage2, err error) {
 	}
 	c.session.v20.state = SessionStateRakp1Sent
 
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	if err != nil {
 		return nil, err
 	}
@@ -205,7 +256,7 @@ func (c *Client) RAKPMessage1() (response *RAKPMessage2, err error) {
 	c.session.v20.bmcRand = response.ManagedSystemRandomNumber // will be used in rakp3 to generate authCode
 
 	if _, err = c.ValidateRAKP2(response); err != nil {
-		err = fmt.Errorf("validate rakp2 message failed, err: %s", err)
+		err = fmt.Errorf("validate rakp2 message failed, err: %w", err)
 		return
 	}
 
@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -61,6 +62,32 @@ func (req *RAKPMessage3) Pack() []byte {
 	return msg
 }
 
+// Unpack is used by the managed system (like the simulator) to decode the received request.
+func (req *RAKPMessage3) Unpack(msg []byte) error {
+	if len(msg) < 8 {
+		return ErrUnpackedDataTooShortWith(len(msg), 8)
+	}
+
+	req.MessageTag, _, _ = unpackUint8(msg, 0)
+	b1, _, _ := unpackUint8(msg, 1)
+	req.RmcpStatusCode = RmcpStatusCode(b1)
+	// 2 bytes reserved
+	req.ManagedSystemSessionID, _, _ = unpackUint32L(msg, 4)
+	req.KeyExchangeAuthenticationCode, _, _ = unpackBytes(msg, 8, len(msg)-8)
+	return nil
+}
+
+// Pack is used by the managed system (like the simulator) to encode the response.
+func (res *RAKPMessage4) Pack() []byte {
+	msg := make([]byte, 8+len(res.IntegrityCheckValue))
+	packUint8(res.MessageTag, msg, 0)
+	packUint8(uint8(res.RmcpStatusCode), msg, 1)
+	// 2 bytes reserved
+	packUint32L(res.MgmtConsoleSessionID, msg, 4)
+	packBytes(res.IntegrityCheckValue, msg, 8)
+	return msg
+}
+
 func (res *RAKPMessage4) Unpack(msg []byte) error {
 	authCodeLen := 0
 	switch res.authAlg {
@@ -100,31 +127,36 @@ func (res *RAKPMessage4) Format() string {
 
 // authAlg is used to parse the returned RAKPMessage4 message
 func (c *Client) RAKPMessage3() (response *RAKPMessage4, err error) {
+	return c.RAKPMessage3Context(context.Background())
+}
+
+// RAKPMessage3Context is like RAKPMessage3 but takes a context.
+func (c *Client) RAKPMessage3Context(ctx context.Context) (response *RAKPMessage4, err error) {
 	// create session integrity key
 	sik, err := c.generate_sik()
 	if err != nil {
-		err = fmt.Errorf("generate sik failed, err: %s", err)
+		err = fmt.Errorf("generate sik failed, err: %w", err)
 		return
 	}
 	c.session.v20.sik = sik
 
 	k1, err := c.generate_k1()
 	if err != nil {
-		err = fmt.Errorf("generate k1 failed, err: %s", err)
+		err = fmt.Errorf("generate k1 failed, err: %w", err)
 		return
 	}
 	c.session.v20.k1 = k1
 
 	k2, err := c.generate_k2()
 	if err != nil {
-		err = fmt.Errorf("generate k2 failed, err: %s", err)
+		err = fmt.Errorf("generate k2 failed, err: %w", err)
 		return
 	}
 	c.session.v20.k2 = k2
 
 	authCode, err := c.generate_rakp3_authcode()
 	if err != nil {
-		return nil, fmt.Errorf("generate rakp3 auth code failed, err: %s", err)
+		return nil, fmt.Errorf("generate rakp3 auth code failed, err: %w", err)
 	}
 
 	request := &RAKPMessage3{
@@ -139,13 +171,13 @@ func (c *Client) RAKPMessage3() (response *RAKPMessage4, err error) {
 	}
 	c.session.v20.state = SessionStateRakp3Sent
 
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	if err != nil {
 		return nil, err
 	}
 
 	if _, err = c.ValidateRAKP4(response); err != nil {
-		return nil, fmt.Errorf("validate rakp4 failed, err: %s", err)
+		return nil, fmt.Errorf("validate rakp4 failed, err: %w", err)
 	}
 
 	c.session.v20.state = SessionStateActive
@@ -155,7 +187,7 @@ func (c *Client) RAKPMessage3() (response *RAKPMessage4, err error) {
 
 func (c *Client) ValidateRAKP4(response *RAKPMessage4) (bool, error) {
 	if response.RmcpStatusCode != RmcpStatusCodeNoErrors {
-		return false, fmt.Errorf("rakp4 status code not ok, %x", response.RmcpStatusCode)
+		return false, fmt.Errorf("rakp4 status code not ok, err: %w", response.RmcpStatusCode.err())
 	}
 	// verify
 	if c.session.v20.consoleSessionID != response.MgmtConsoleSessionID {
@@ -164,14 +196,14 @@ func (c *Client) ValidateRAKP4(response *RAKPMessage4) (bool, error) {
 
 	authCode, err := c.generate_rakp4_authcode()
 	if err != nil {
-		return false, fmt.Errorf("generate rakp4 auth code failed, err: %s", err)
+		return false, fmt.Errorf("generate rakp4 auth code failed, err: %w", err)
 	}
 
 	c.DebugBytes("rakp4 console computed authcode", authCode, 16)
 	c.DebugBytes("rakp4 bmc returned authcode", response.IntegrityCheckValue, 16)
 
 	if !isByteSliceEqual(response.IntegrityCheckValue, authCode) {
-		return false, fmt.Errorf("rakp4 returned integrity check not passed, console mac %0x, bmc mac: %0x", authCode, response.IntegrityCheckValue)
+		return false, fmt.Errorf("%w: rakp4 returned integrity check not passed, console mac %0x, bmc mac: %0x", ErrAuthFailed, authCode, response.IntegrityCheckValue)
 	}
 	return true, nil
 }
@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
@@ -51,6 +52,11 @@ func (res *CommandRawResponse) Format() string {
 }
 
 func (c *Client) RawCommand(netFn NetFn, cmd uint8, data []byte, name string) (response *CommandRawResponse, err error) {
+	return c.RawCommandContext(context.Background(), netFn, cmd, data, name)
+}
+
+// RawCommandContext is like RawCommand but takes a context.
+func (c *Client) RawCommandContext(ctx context.Context, netFn NetFn, cmd uint8, data []byte, name string) (response *CommandRawResponse, err error) {
 	request := &CommandRawRequest{
 		NetFn: netFn,
 		Cmd:   cmd,
@@ -58,6 +64,6 @@ func (c *Client) RawCommand(netFn NetFn, cmd uint8, data []byte, name string) (r
 		Name:  name,
 	}
 	response = &CommandRawResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
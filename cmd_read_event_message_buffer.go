@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 22.8 Read Event Message Buffer Command
 type ReadEventMessageBufferRequest struct {
 }
@@ -38,8 +40,13 @@ func (res *ReadEventMessageBufferResponse) Format() string {
 }
 
 func (c *Client) ReadEventMessageBuffer() (response *ReadEventMessageBufferResponse, err error) {
+	return c.ReadEventMessageBufferContext(context.Background())
+}
+
+// ReadEventMessageBufferContext is like ReadEventMessageBuffer but takes a context.
+func (c *Client) ReadEventMessageBufferContext(ctx context.Context) (response *ReadEventMessageBufferResponse, err error) {
 	request := &ReadEventMessageBufferRequest{}
 	response = &ReadEventMessageBufferResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,6 +1,8 @@
 package ipmi
 
 import (
+	"context"
+	"errors"
 	"fmt"
 )
 
@@ -54,18 +56,23 @@ Data           : %02x`,
 
 // The command returns the specified data from the FRU Inventory Info area.
 func (c *Client) ReadFRUData(fruDeviceID uint8, readOffset uint16, readCount uint8) (response *ReadFRUDataResponse, err error) {
+	return c.ReadFRUDataContext(context.Background(), fruDeviceID, readOffset, readCount)
+}
+
+// ReadFRUDataContext is like ReadFRUData but takes a context.
+func (c *Client) ReadFRUDataContext(ctx context.Context, fruDeviceID uint8, readOffset uint16, readCount uint8) (response *ReadFRUDataResponse, err error) {
 	request := &ReadFRUDataRequest{
 		FRUDeviceID: fruDeviceID,
 		ReadOffset:  readOffset,
 		ReadCount:   readCount,
 	}
 	response = &ReadFRUDataResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
 
 // readFRUDataByLength reads FRU Data in loop until reaches the specified data length
-func (c *Client) readFRUDataByLength(deviceID uint8, offset uint16, length uint16) ([]byte, error) {
+func (c *Client) readFRUDataByLength(ctx context.Context, deviceID uint8, offset uint16, length uint16) ([]byte, error) {
 	var data []byte
 	c.Debugf("Read FRU Data by Length, offset: (%d), length: (%d)\n", offset, length)
 
@@ -74,9 +81,9 @@ func (c *Client) readFRUDataByLength(deviceID uint8, offset uint16, length uint1
 			break
 		}
 
-		res, err := c.tryReadFRUData(deviceID, offset, length)
+		res, err := c.tryReadFRUData(ctx, deviceID, offset, length)
 		if err != nil {
-			return nil, fmt.Errorf("tryReadFRUData failed, err: %s", err)
+			return nil, fmt.Errorf("tryReadFRUData failed, err: %w", err)
 		}
 		c.Debug("", res.Format())
 		data = append(data, res.Data...)
@@ -94,7 +101,7 @@ func (c *Client) readFRUDataByLength(deviceID uint8, offset uint16, length uint1
 // tryReadFRUData will try to read FRU data with a read count which starts with
 // the minimal number of the specified length and the hard-coded 32, if the
 // ReadFRUData failed, it try another request with a decreased read count.
-func (c *Client) tryReadFRUData(deviceID uint8, readOffset uint16, length uint16) (response *ReadFRUDataResponse, err error) {
+func (c *Client) tryReadFRUData(ctx context.Context, deviceID uint8, readOffset uint16, length uint16) (response *ReadFRUDataResponse, err error) {
 	var readCount uint8 = 32
 	if length <= uint16(readCount) {
 		readCount = uint8(length)
@@ -106,14 +113,14 @@ func (c *Client) tryReadFRUData(deviceID uint8, readOffset uint16, length uint16
 		}
 
 		c.Debugf("Try Read FRU Data, offset: (%d), count: (%d)\n", readOffset, readCount)
-		res, err := c.ReadFRUData(deviceID, readOffset, readCount)
+		res, err := c.ReadFRUDataContext(ctx, deviceID, readOffset, readCount)
 		if err == nil {
 			return res, nil
 		}
 
-		resErr, ok := err.(*ResponseError)
-		if !ok {
-			return nil, fmt.Errorf("ReadFRUData failed, err: %s", err)
+		var resErr *ResponseError
+		if !errors.As(err, &resErr) {
+			return nil, fmt.Errorf("ReadFRUData failed, err: %w", err)
 		}
 
 		cc := resErr.CompletionCode()
@@ -121,7 +128,7 @@ func (c *Client) tryReadFRUData(deviceID uint8, readOffset uint16, length uint16
 			readCount -= 1
 			continue
 		} else {
-			return nil, fmt.Errorf("ReadFRUData failed, err: %s", err)
+			return nil, fmt.Errorf("ReadFRUData failed, err: %w", err)
 		}
 	}
 }
@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 35.4 Reserve Device SDR Repository Command
 type ReserveDeviceSDRRepoRequest struct {
 	// empty
@@ -36,8 +38,13 @@ func (res *ReserveDeviceSDRRepoResponse) Format() string {
 
 // This command is used to obtain a Reservation ID.
 func (c *Client) ReserveDeviceSDRRepo() (response *ReserveDeviceSDRRepoResponse, err error) {
+	return c.ReserveDeviceSDRRepoContext(context.Background())
+}
+
+// ReserveDeviceSDRRepoContext is like ReserveDeviceSDRRepo but takes a context.
+func (c *Client) ReserveDeviceSDRRepoContext(ctx context.Context) (response *ReserveDeviceSDRRepoResponse, err error) {
 	request := &ReserveDeviceSDRRepoRequest{}
 	response = &ReserveDeviceSDRRepoResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 31.4 Reserve SEL Command
 type ReserveSELRequest struct {
 	// empty
@@ -37,8 +39,13 @@ func (res *ReserveSELResponse) Format() string {
 }
 
 func (c *Client) ReserveSEL() (response *ReserveSELResponse, err error) {
+	return c.ReserveSELContext(context.Background())
+}
+
+// ReserveSELContext is like ReserveSEL but takes a context.
+func (c *Client) ReserveSELContext(ctx context.Context) (response *ReserveSELResponse, err error) {
 	request := &ReserveSELRequest{}
 	response = &ReserveSELResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
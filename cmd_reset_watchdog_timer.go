@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 27.5 Reset Watchdog Timer Command
 type ResetWatchdogTimerRequest struct {
 }
@@ -31,8 +33,13 @@ func (res *ResetWatchdogTimerResponse) Format() string {
 }
 
 func (c *Client) ResetWatchdogTimer() (response *ResetWatchdogTimerResponse, err error) {
+	return c.ResetWatchdogTimerContext(context.Background())
+}
+
+// ResetWatchdogTimerContext is like ResetWatchdogTimer but takes a context.
+func (c *Client) ResetWatchdogTimerContext(ctx context.Context) (response *ResetWatchdogTimerResponse, err error) {
 	request := &ResetWatchdogTimerRequest{}
 	response = &ResetWatchdogTimerResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,6 +1,9 @@
 package ipmi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // RmcpPingRequest
 // 13.2.3 RMCP/ASF Presence Ping Message
@@ -35,12 +38,37 @@ func (req *RmcpPingRequest) Command() Command {
 	return CommandNone
 }
 
+// Pack is used by the managed system (like the simulator) to encode the Presence Pong data.
+func (res *RmcpPingResponse) Pack() []byte {
+	msg := make([]byte, 16)
+	// RMCP/ASF fields are most-significant byte first
+	packUint32(res.OEMIANA, msg, 0)
+	packUint32(res.OEMDefined, msg, 4)
+
+	var b uint8 = res.ASFVersion & 0x0f
+	if res.IPMISupported {
+		b = setBit7(b)
+	}
+	packUint8(b, msg, 8)
+
+	var c uint8
+	if res.RMCPSecurityExtensionsSupported {
+		c = setBit7(c)
+	}
+	if res.DMTFDashSupported {
+		c = setBit5(c)
+	}
+	packUint8(c, msg, 9)
+	// 6 bytes reserved
+	return msg
+}
+
 func (res *RmcpPingResponse) Unpack(msg []byte) error {
 	if len(msg) < 16 {
 		return ErrUnpackedDataTooShortWith(len(msg), 16)
 	}
-	res.OEMIANA, _, _ = unpackUint32L(msg, 0)
-	res.OEMDefined, _, _ = unpackUint32L(msg, 4)
+	res.OEMIANA, _, _ = unpackUint32(msg, 0)
+	res.OEMDefined, _, _ = unpackUint32(msg, 4)
 
 	b, _, _ := unpackUint8(msg, 8)
 	res.IPMISupported = isBit7Set(b)
@@ -64,9 +92,14 @@ func (res *RmcpPingResponse) Format() string {
 }
 
 func (c *Client) RmcpPing() (response *RmcpPingResponse, err error) {
+	return c.RmcpPingContext(context.Background())
+}
+
+// RmcpPingContext is like RmcpPing but takes a context.
+func (c *Client) RmcpPingContext(ctx context.Context) (response *RmcpPingResponse, err error) {
 	request := &RmcpPingRequest{}
 	response = &RmcpPingResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 
 	return
 }
@@ -0,0 +1,75 @@
+package ipmi
+
+import (
+	"context"
+	"fmt"
+)
+
+// 33.21 Run Initialization Agent Command
+type RunInitializationAgentRequest struct {
+	// GetStatusFlag gets the status of the last run, otherwise run the Initialization Agent.
+	GetStatusFlag bool
+}
+
+type RunInitializationAgentResponse struct {
+	Completed bool
+}
+
+func (req *RunInitializationAgentRequest) Command() Command {
+	return CommandRunInitializationAgent
+}
+
+func (req *RunInitializationAgentRequest) Pack() []byte {
+	if req.GetStatusFlag {
+		return []byte{0x00}
+	}
+	return []byte{0x01}
+}
+
+func (res *RunInitializationAgentResponse) Unpack(msg []byte) error {
+	if len(msg) < 1 {
+		return ErrUnpackedDataTooShortWith(len(msg), 1)
+	}
+	b, _, _ := unpackUint8(msg, 0)
+	res.Completed = isBit0Set(b)
+	return nil
+}
+
+func (res *RunInitializationAgentResponse) CompletionCodes() map[uint8]string {
+	return map[uint8]string{}
+}
+
+func (res *RunInitializationAgentResponse) Format() string {
+	return fmt.Sprintf("Initialization completed : %v", res.Completed)
+}
+
+// RunInitializationAgent runs the Initialization Agent, which initializes the sensors
+// per the Sensor Initialization settings of the SDRs in the SDR Repository.
+func (c *Client) RunInitializationAgent() (response *RunInitializationAgentResponse, err error) {
+	return c.RunInitializationAgentContext(context.Background())
+}
+
+// RunInitializationAgentContext is like RunInitializationAgent but takes a context.
+func (c *Client) RunInitializationAgentContext(ctx context.Context) (response *RunInitializationAgentResponse, err error) {
+	request := &RunInitializationAgentRequest{
+		GetStatusFlag: false,
+	}
+	response = &RunInitializationAgentResponse{}
+	err = c.ExchangeContext(ctx, request, response)
+	return
+}
+
+// GetInitializationAgentStatus gets the status of the last run of the Initialization Agent.
+func (c *Client) GetInitializationAgentStatus() (response *RunInitializationAgentResponse, err error) {
+	return c.GetInitializationAgentStatusContext(context.Background())
+}
+
+// GetInitializationAgentStatusContext is like GetInitializationAgentStatus but takes a context.
+func (c *Client) GetInitializationAgentStatusContext(ctx context.Context) (response *RunInitializationAgentResponse, err error) {
+	request := &RunInitializationAgentRequest{
+		GetStatusFlag: true,
+	}
+	response = &RunInitializationAgentResponse{}
+	err = c.ExchangeContext(ctx, request, response)
+	return
+}
@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 22.7 Send Message Command
 type SendMessageRequest struct {
 	// [7:6] 00b = No tracking
@@ -67,6 +69,11 @@ func (res *SendMessageResponse) Format() string {
 }
 
 func (c *Client) SendMessage(channelNumber uint8, authenticated bool, encrypted bool, trackMask uint8, data []byte) (response *SendMessageResponse, err error) {
+	return c.SendMessageContext(context.Background(), channelNumber, authenticated, encrypted, trackMask, data)
+}
+
+// SendMessageContext is like SendMessage but takes a context.
+func (c *Client) SendMessageContext(ctx context.Context, channelNumber uint8, authenticated bool, encrypted bool, trackMask uint8, data []byte) (response *SendMessageResponse, err error) {
 	request := &SendMessageRequest{
 		ChannelNumber: channelNumber,
 		Authenticated: authenticated,
@@ -75,6 +82,6 @@ func (c *Client) SendMessage(channelNumber uint8, authenticated bool, encrypted
 		MessageData:   data,
 	}
 	response = &SendMessageResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 20.6 Set ACPI Power State Command
 type SetACPIPowerStateRequest struct {
 	SetSystemPowerState bool // false means don't change system power state
@@ -121,7 +123,12 @@ func (res *SetACPIPowerStateResponse) Format() string {
 
 // This command is provided to allow system software to tell a controller the present ACPI power state of the system.
 func (c *Client) SetACPIPowerState(request *SetACPIPowerStateRequest) (err error) {
+	return c.SetACPIPowerStateContext(context.Background(), request)
+}
+
+// SetACPIPowerStateContext is like SetACPIPowerState but takes a context.
+func (c *Client) SetACPIPowerStateContext(ctx context.Context, request *SetACPIPowerStateRequest) (err error) {
 	response := &SetACPIPowerStateResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
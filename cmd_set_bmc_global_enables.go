@@ -1,6 +1,9 @@
 package ipmi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // 22.1 Set BMC Global Enables Command
 type SetBMCGlobalEnablesRequest struct {
@@ -66,9 +69,14 @@ func (res *SetBMCGlobalEnablesResponse) Format() string {
 }
 
 func (c *Client) SetBMCGlobalEnables(enableSystemEventLogging bool, enableEventMessageBuffer bool, enableEventMessageBufferFullInterrupt bool, enableReceiveMessageQueueInterrupt bool) (response *SetBMCGlobalEnablesResponse, err error) {
-	getRes, err := c.GetBMCGlobalEnables()
+	return c.SetBMCGlobalEnablesContext(context.Background(), enableSystemEventLogging, enableEventMessageBuffer, enableEventMessageBufferFullInterrupt, enableReceiveMessageQueueInterrupt)
+}
+
+// SetBMCGlobalEnablesContext is like SetBMCGlobalEnables but takes a context.
+func (c *Client) SetBMCGlobalEnablesContext(ctx context.Context, enableSystemEventLogging bool, enableEventMessageBuffer bool, enableEventMessageBufferFullInterrupt bool, enableReceiveMessageQueueInterrupt bool) (response *SetBMCGlobalEnablesResponse, err error) {
+	getRes, err := c.GetBMCGlobalEnablesContext(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("GetBMCGlobalEnables failed, err: %s", err)
+		return nil, fmt.Errorf("GetBMCGlobalEnables failed, err: %w", err)
 	}
 
 	request := &SetBMCGlobalEnablesRequest{
@@ -82,6 +90,6 @@ func (c *Client) SetBMCGlobalEnables(enableSystemEventLogging bool, enableEventM
 		EnableReceiveMessageQueueInterrupt:    enableReceiveMessageQueueInterrupt,
 	}
 	response = &SetBMCGlobalEnablesResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 22.22 Set Channel Access Command
 type SetChannelAccessRequest struct {
 	ChannelNumber uint8
@@ -66,7 +68,12 @@ func (res *SetChannelAccessResponse) Format() string {
 }
 
 func (c *Client) SetChannelAccess(request *SetChannelAccessRequest) (response *SetChannelAccessResponse, err error) {
+	return c.SetChannelAccessContext(context.Background(), request)
+}
+
+// SetChannelAccessContext is like SetChannelAccess but takes a context.
+func (c *Client) SetChannelAccessContext(ctx context.Context, request *SetChannelAccessRequest) (response *SetChannelAccessResponse, err error) {
 	response = &SetChannelAccessResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
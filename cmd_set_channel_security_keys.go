@@ -0,0 +1,138 @@
+package ipmi
+
+import (
+	"context"
+	"fmt"
+)
+
+// BMCKeyLength is the length of the BMC key (Kg), 160 bits.
+const BMCKeyLength int = 20
+
+type ChannelSecurityKeysOperation uint8
+
+const (
+	ChannelSecurityKeysOperationRead ChannelSecurityKeysOperation = 0x00
+	ChannelSecurityKeysOperationSet  ChannelSecurityKeysOperation = 0x01
+	ChannelSecurityKeysOperationLock ChannelSecurityKeysOperation = 0x02
+)
+
+type ChannelSecurityKeyID uint8
+
+const (
+	// K_R, the key used to generate the random numbers of RAKP.
+	ChannelSecurityKeyIDKR ChannelSecurityKeyID = 0x00
+	// K_G, the BMC key used as the key to generate SIK, see 13.33.
+	ChannelSecurityKeyIDKG ChannelSecurityKeyID = 0x01
+)
+
+type ChannelSecurityKeyLockStatus uint8
+
+const (
+	ChannelSecurityKeyNotLockable       ChannelSecurityKeyLockStatus = 0x00
+	ChannelSecurityKeyLocked            ChannelSecurityKeyLockStatus = 0x01
+	ChannelSecurityKeyUnlocked          ChannelSecurityKeyLockStatus = 0x02
+	ChannelSecurityKeyLockableNotLocked ChannelSecurityKeyLockStatus = 0x03
+)
+
+func (s ChannelSecurityKeyLockStatus) String() string {
+	m := map[ChannelSecurityKeyLockStatus]string{
+		0x00: "key is not lockable",
+		0x01: "key is locked",
+		0x02: "key is unlocked",
+		0x03: "key is lockable, but not locked",
+	}
+	o, ok := m[s]
+	if ok {
+		return o
+	}
+	return ""
+}
+
+// 22.25 Set Channel Security Keys Command
+type SetChannelSecurityKeysRequest struct {
+	ChannelNumber uint8
+	Operation     ChannelSecurityKeysOperation
+	KeyID         ChannelSecurityKeyID
+
+	// KeyValue is only used for set key operation.
+	KeyValue []byte
+}
+
+type SetChannelSecurityKeysResponse struct {
+	LockStatus ChannelSecurityKeyLockStatus
+
+	// KeyValue is only returned for read key operation.
+	KeyValue []byte
+}
+
+func (req *SetChannelSecurityKeysRequest) Command() Command {
+	return CommandSetChannelSecurityKeys
+}
+
+func (req *SetChannelSecurityKeysRequest) Pack() []byte {
+	out := make([]byte, 3+len(req.KeyValue))
+	packUint8(req.ChannelNumber&0x0f, out, 0)
+	packUint8(uint8(req.Operation)&0x03, out, 1)
+	packUint8(uint8(req.KeyID), out, 2)
+	packBytes(req.KeyValue, out, 3)
+	return out
+}
+
+func (res *SetChannelSecurityKeysResponse) Unpack(msg []byte) error {
+	if len(msg) < 1 {
+		return ErrUnpackedDataTooShortWith(len(msg), 1)
+	}
+	res.LockStatus = ChannelSecurityKeyLockStatus(msg[0] & 0x03)
+	res.KeyValue, _, _ = unpackBytes(msg, 1, len(msg)-1)
+	return nil
+}
+
+func (res *SetChannelSecurityKeysResponse) CompletionCodes() map[uint8]string {
+	return map[uint8]string{
+		0x80: "Cannot perform set / confirm. Key is locked",
+		0x81: "insufficient key bytes",
+		0x82: "too many key bytes",
+		0x83: "key value does not meet criteria for specified type of key",
+		0x84: "KR is not used. BMC uses a random number generation approach that does not require a KR value",
+	}
+}
+
+func (res *SetChannelSecurityKeysResponse) Format() string {
+	return fmt.Sprintf(`Lock Status : %s
+Key Value   : %x`,
+		res.LockStatus,
+		res.KeyValue,
+	)
+}
+
+func (c *Client) SetChannelSecurityKeys(request *SetChannelSecurityKeysRequest) (response *SetChannelSecurityKeysResponse, err error) {
+	return c.SetChannelSecurityKeysContext(context.Background(), request)
+}
+
+// SetChannelSecurityKeysContext is like SetChannelSecurityKeys but takes a context.
+func (c *Client) SetChannelSecurityKeysContext(ctx context.Context, request *SetChannelSecurityKeysRequest) (response *SetChannelSecurityKeysResponse, err error) {
+	response = &SetChannelSecurityKeysResponse{}
+	err = c.ExchangeContext(ctx, request, response)
+	return
+}
+
+// SetBMCKey sets the BMC key (Kg) of the channel, the key is padded with zeros to 20 bytes.
+// A zero Kg (all bytes are 0) means Kg is not used, and the user password (Kuid) is used instead.
+func (c *Client) SetBMCKey(channelNumber uint8, key []byte) (response *SetChannelSecurityKeysResponse, err error) {
+	return c.SetBMCKeyContext(context.Background(), channelNumber, key)
+}
+
+// SetBMCKeyContext is like SetBMCKey but takes a context.
+func (c *Client) SetBMCKeyContext(ctx context.Context, channelNumber uint8, key []byte) (response *SetChannelSecurityKeysResponse, err error) {
+	if len(key) > BMCKeyLength {
+		return nil, fmt.Errorf("bmc key too long, exceed (%d) bytes", BMCKeyLength)
+	}
+
+	request := &SetChannelSecurityKeysRequest{
+		ChannelNumber: channelNumber,
+		Operation:     ChannelSecurityKeysOperationSet,
+		KeyID:         ChannelSecurityKeyIDKG,
+		KeyValue:      padBytes(string(key), BMCKeyLength, 0x00),
+	}
+	return c.SetChannelSecurityKeysContext(ctx, request)
+}
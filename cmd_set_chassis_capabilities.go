@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 28.7 Set Chassis Capabilities Command
 type SetChassisCapabilitiesRequest struct {
 	ProvideFrontPanelLockout bool
@@ -54,7 +56,12 @@ func (res *SetChassisCapabilitiesResponse) Format() string {
 }
 
 func (c *Client) SetChassisCapabilities(request *SetChassisCapabilitiesRequest) (response *SetChassisCapabilitiesResponse, err error) {
+	return c.SetChassisCapabilitiesContext(context.Background(), request)
+}
+
+// SetChassisCapabilitiesContext is like SetChassisCapabilities but takes a context.
+func (c *Client) SetChassisCapabilitiesContext(ctx context.Context, request *SetChassisCapabilitiesRequest) (response *SetChassisCapabilitiesResponse, err error) {
 	response = &SetChassisCapabilitiesResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 29.1 Set Event Receiver Command
 type SetEventReceiverRequest struct {
 	// Event Receiver Slave Address. 0FFh disables Event Message Generation, Otherwise:
@@ -34,11 +36,16 @@ func (res *SetEventReceiverResponse) Format() string {
 }
 
 func (c *Client) SetEventReceiver(slaveAddress uint8, lun uint8) (response *SetEventReceiverResponse, err error) {
+	return c.SetEventReceiverContext(context.Background(), slaveAddress, lun)
+}
+
+// SetEventReceiverContext is like SetEventReceiver but takes a context.
+func (c *Client) SetEventReceiverContext(ctx context.Context, slaveAddress uint8, lun uint8) (response *SetEventReceiverResponse, err error) {
 	request := &SetEventReceiverRequest{
 		SlaveAddress: slaveAddress,
 		LUN:          lun,
 	}
 	response = &SetEventReceiverResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 23.1 Set LAN Configuration Parameters Command
 type SetLanConfigParamsRequest struct {
 	ChannelNumber int8
@@ -38,8 +40,13 @@ func (res *SetLanConfigParamsResponse) Format() string {
 
 // Todo
 func (c *Client) SetLanConfigParams() (response *SetLanConfigParamsResponse, err error) {
+	return c.SetLanConfigParamsContext(context.Background())
+}
+
+// SetLanConfigParamsContext is like SetLanConfigParams but takes a context.
+func (c *Client) SetLanConfigParamsContext(ctx context.Context) (response *SetLanConfigParamsResponse, err error) {
 	request := &SetLanConfigParamsRequest{}
 	response = &SetLanConfigParamsResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
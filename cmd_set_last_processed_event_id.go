@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 30.5 Set Last Processed Event ID Command
 type SetLastProcessedEventIdRequest struct {
 	// 0b = set Record ID for last record processed by software.
@@ -46,8 +48,13 @@ func (res *SetLastProcessedEventIdResponse) Format() string {
 }
 
 func (c *Client) SetLastProcessedEventId() (response *SetLastProcessedEventIdResponse, err error) {
+	return c.SetLastProcessedEventIdContext(context.Background())
+}
+
+// SetLastProcessedEventIdContext is like SetLastProcessedEventId but takes a context.
+func (c *Client) SetLastProcessedEventIdContext(ctx context.Context) (response *SetLastProcessedEventIdResponse, err error) {
 	request := &SetLastProcessedEventIdRequest{}
 	response = &SetLastProcessedEventIdResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 30.3 Set PEF Configuration Parameters Command
 type SetPEFConfigParametersRequest struct {
 	ParamSelector uint8
@@ -48,8 +50,13 @@ func (res *SetPEFConfigParametersResponse) Format() string {
 }
 
 func (c *Client) SetPEFConfigParameters() (response *SetPEFConfigParametersResponse, err error) {
+	return c.SetPEFConfigParametersContext(context.Background())
+}
+
+// SetPEFConfigParametersContext is like SetPEFConfigParameters but takes a context.
+func (c *Client) SetPEFConfigParametersContext(ctx context.Context) (response *SetPEFConfigParametersResponse, err error) {
 	request := &SetPEFConfigParametersRequest{}
 	response = &SetPEFConfigParametersResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
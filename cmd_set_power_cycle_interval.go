@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 28.9 Set Power Cycle Interval
 type SetPowerCycleIntervalRequest struct {
 	IntervalInSec uint8
@@ -32,10 +34,15 @@ func (res *SetPowerCycleIntervalResponse) Format() string {
 }
 
 func (c *Client) SetPowerCycleInterval(intervalInSec uint8) (response *SetPowerCycleIntervalResponse, err error) {
+	return c.SetPowerCycleIntervalContext(context.Background(), intervalInSec)
+}
+
+// SetPowerCycleIntervalContext is like SetPowerCycleInterval but takes a context.
+func (c *Client) SetPowerCycleIntervalContext(ctx context.Context, intervalInSec uint8) (response *SetPowerCycleIntervalResponse, err error) {
 	request := &SetPowerCycleIntervalRequest{
 		IntervalInSec: intervalInSec,
 	}
 	response = &SetPowerCycleIntervalResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,6 +1,9 @@
 package ipmi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // 28.8 Set Power Restore Policy Command
 type SetPowerRestorePolicyRequest struct {
@@ -50,10 +53,15 @@ Policy previous   :"%s`,
 }
 
 func (c *Client) SetPowerRestorePolicy(policy PowerRestorePolicy) (response *SetPowerRestorePolicyResponse, err error) {
+	return c.SetPowerRestorePolicyContext(context.Background(), policy)
+}
+
+// SetPowerRestorePolicyContext is like SetPowerRestorePolicy but takes a context.
+func (c *Client) SetPowerRestorePolicyContext(ctx context.Context, policy PowerRestorePolicy) (response *SetPowerRestorePolicyResponse, err error) {
 	request := &SetPowerRestorePolicyRequest{
 		PowerRestorePolicy: policy,
 	}
 	response = &SetPowerRestorePolicyResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -37,10 +38,15 @@ func (res *SetSELTimeResponse) Format() string {
 }
 
 func (c *Client) SetSELTime(t time.Time) (response *SetSELTimeResponse, err error) {
+	return c.SetSELTimeContext(context.Background(), t)
+}
+
+// SetSELTimeContext is like SetSELTime but takes a context.
+func (c *Client) SetSELTimeContext(ctx context.Context, t time.Time) (response *SetSELTimeResponse, err error) {
 	request := &SetSELTimeRequest{
 		Time: t,
 	}
 	response = &SetSELTimeResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 35.6 Set Sensor Hysteresis Command
 type SetSensorHysteresisRequest struct {
 	SensorNumber       uint8
@@ -39,12 +41,17 @@ func (res *SetSensorHysteresisResponse) Format() string {
 // This command provides a mechanism for setting the hysteresis values associated
 // with the thresholds of a sensor that has threshold based event generation.
 func (c *Client) SetSensorHysteresis(sensorNumber uint8, positiveHysteresis uint8, negativeHysteresis uint8) (response *SetSensorHysteresisResponse, err error) {
+	return c.SetSensorHysteresisContext(context.Background(), sensorNumber, positiveHysteresis, negativeHysteresis)
+}
+
+// SetSensorHysteresisContext is like SetSensorHysteresis but takes a context.
+func (c *Client) SetSensorHysteresisContext(ctx context.Context, sensorNumber uint8, positiveHysteresis uint8, negativeHysteresis uint8) (response *SetSensorHysteresisResponse, err error) {
 	request := &SetSensorHysteresisRequest{
 		SensorNumber:       sensorNumber,
 		PositiveHysteresis: positiveHysteresis,
 		NegativeHysteresis: negativeHysteresis,
 	}
 	response = &SetSensorHysteresisResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
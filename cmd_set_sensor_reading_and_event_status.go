@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 35.17 Set Sensor Reading And Event Status Command
 type SetSensorReadingAndEventStatusRequest struct {
 	SensorNumber uint8
@@ -60,7 +62,12 @@ func (res *SetSensorReadingAndEventStatusResponse) Format() string {
 }
 
 func (c *Client) SetSensorReadingAndEventStatus(request *SetSensorReadingAndEventStatusRequest) (response *SetSensorReadingAndEventStatusResponse, err error) {
+	return c.SetSensorReadingAndEventStatusContext(context.Background(), request)
+}
+
+// SetSensorReadingAndEventStatusContext is like SetSensorReadingAndEventStatus but takes a context.
+func (c *Client) SetSensorReadingAndEventStatusContext(ctx context.Context, request *SetSensorReadingAndEventStatusRequest) (response *SetSensorReadingAndEventStatusResponse, err error) {
 	response = &SetSensorReadingAndEventStatusResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
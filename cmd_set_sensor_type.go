@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 35.15 Set Sensor Type Command
 type SetSensorTypeRequest struct {
 	SensorNumber     uint8
@@ -36,12 +38,17 @@ func (res *SetSensorTypeResponse) Format() string {
 }
 
 func (c *Client) SetSensorType(sensorNumber uint8, sensorType SensorType, eventReadingType EventReadingType) (response *SetSensorTypeResponse, err error) {
+	return c.SetSensorTypeContext(context.Background(), sensorNumber, sensorType, eventReadingType)
+}
+
+// SetSensorTypeContext is like SetSensorType but takes a context.
+func (c *Client) SetSensorTypeContext(ctx context.Context, sensorNumber uint8, sensorType SensorType, eventReadingType EventReadingType) (response *SetSensorTypeResponse, err error) {
 	request := &SetSensorTypeRequest{
 		SensorNumber:     sensorNumber,
 		SensorType:       sensorType,
 		EventReadingType: eventReadingType,
 	}
 	response = &SetSensorTypeResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
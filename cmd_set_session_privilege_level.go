@@ -1,6 +1,9 @@
 package ipmi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // 22.18 Set Session Privilege Level Command
 type SetSessionPrivilegeLevelRequest struct {
@@ -43,10 +46,15 @@ func (res *SetSessionPrivilegeLevelResponse) Format() string {
 }
 
 func (c *Client) SetSessionPrivilegeLevel(privilegeLevel PrivilegeLevel) (response *SetSessionPrivilegeLevelResponse, err error) {
+	return c.SetSessionPrivilegeLevelContext(context.Background(), privilegeLevel)
+}
+
+// SetSessionPrivilegeLevelContext is like SetSessionPrivilegeLevel but takes a context.
+func (c *Client) SetSessionPrivilegeLevelContext(ctx context.Context, privilegeLevel PrivilegeLevel) (response *SetSessionPrivilegeLevelResponse, err error) {
 	request := &SetSessionPrivilegeLevelRequest{
 		PrivilegeLevel: privilegeLevel,
 	}
 	response = &SetSessionPrivilegeLevelResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 26.2 Set SOL Configuration Parameters Command
 type SetSOLConfigParamsRequest struct {
 	ChannelNumber     uint8
@@ -40,12 +42,17 @@ func (res *SetSOLConfigurationParametersResponse) Format() string {
 }
 
 func (c *Client) SetSOLConfigurationParameters(channelNumber uint8, paramSelector uint8, paramData []byte) (response *SetSOLConfigurationParametersResponse, err error) {
+	return c.SetSOLConfigurationParametersContext(context.Background(), channelNumber, paramSelector, paramData)
+}
+
+// SetSOLConfigurationParametersContext is like SetSOLConfigurationParameters but takes a context.
+func (c *Client) SetSOLConfigurationParametersContext(ctx context.Context, channelNumber uint8, paramSelector uint8, paramData []byte) (response *SetSOLConfigurationParametersResponse, err error) {
 	request := &SetSOLConfigParamsRequest{
 		ChannelNumber:     channelNumber,
 		ParameterSelector: paramSelector,
 		ParameterData:     paramData,
 	}
 	response = &SetSOLConfigurationParametersResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,6 +1,9 @@
 package ipmi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // 28.12 Set System Boot Options Command
 type SetSystemBootOptionsRequest struct {
@@ -68,8 +71,13 @@ func (res *SetSystemBootOptionsResponse) Format() string {
 // The boot flags only apply for one system restart. It is the responsibility of the system BIOS
 // to read these settings from the BMC and then clear the boot flags
 func (c *Client) SetSystemBootOptions(request *SetSystemBootOptionsRequest) (response *SetSystemBootOptionsResponse, err error) {
+	return c.SetSystemBootOptionsContext(context.Background(), request)
+}
+
+// SetSystemBootOptionsContext is like SetSystemBootOptions but takes a context.
+func (c *Client) SetSystemBootOptionsContext(ctx context.Context, request *SetSystemBootOptionsRequest) (response *SetSystemBootOptionsResponse, err error) {
 	response = &SetSystemBootOptionsResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
 
@@ -77,6 +85,11 @@ func (c *Client) SetSystemBootOptions(request *SetSystemBootOptionsRequest) (res
 // persist of false means it applies to next boot only.
 // persist of true means this setting is persistent for all future boots.
 func (c *Client) SetBootDevice(bootDeviceSelector BootDeviceSelector, bootType BIOSBootType, persist bool) error {
+	return c.SetBootDeviceContext(context.Background(), bootDeviceSelector, bootType, persist)
+}
+
+// SetBootDeviceContext is like SetBootDevice but takes a context.
+func (c *Client) SetBootDeviceContext(ctx context.Context, bootDeviceSelector BootDeviceSelector, bootType BIOSBootType, persist bool) error {
 	req := &SetSystemBootOptionsRequest{
 		MarkParameterInvalid: false,
 		ParameterSelector:    BOPS_BootFlags,
@@ -89,8 +102,8 @@ func (c *Client) SetBootDevice(bootDeviceSelector BootDeviceSelector, bootType B
 			},
 		},
 	}
-	if _, err := c.SetSystemBootOptions(req); err != nil {
-		return fmt.Errorf("SetSystemBootOptions failed, err: %s", err)
+	if _, err := c.SetSystemBootOptionsContext(ctx, req); err != nil {
+		return fmt.Errorf("SetSystemBootOptions failed, err: %w", err)
 	}
 	return nil
 }
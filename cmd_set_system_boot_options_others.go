@@ -1,8 +1,16 @@
 package ipmi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 func (c *Client) SetBootParamSetInProgressState(progressState BOP_SetInProgressState) error {
+	return c.SetBootParamSetInProgressStateContext(context.Background(), progressState)
+}
+
+// SetBootParamSetInProgressStateContext is like SetBootParamSetInProgressState but takes a context.
+func (c *Client) SetBootParamSetInProgressStateContext(ctx context.Context, progressState BOP_SetInProgressState) error {
 	r := &SetSystemBootOptionsRequest{
 		MarkParameterInvalid: false,
 		ParameterSelector:    BOPS_SetInProgressState,
@@ -12,16 +20,21 @@ func (c *Client) SetBootParamSetInProgressState(progressState BOP_SetInProgressS
 		},
 	}
 
-	_, err := c.SetSystemBootOptions(r)
+	_, err := c.SetSystemBootOptionsContext(ctx, r)
 	if err != nil {
-		return fmt.Errorf("SetSystemBootOptions failed, err: %s", err)
+		return fmt.Errorf("SetSystemBootOptions failed, err: %w", err)
 	}
 
 	return nil
 }
 
 func (c *Client) SetBootParamBootFlags(bootFlags *BOP_BootFlags) error {
-	if err := c.SetBootParamSetInProgressState(SetInProgressState_SetInProgress); err != nil {
+	return c.SetBootParamBootFlagsContext(context.Background(), bootFlags)
+}
+
+// SetBootParamBootFlagsContext is like SetBootParamBootFlags but takes a context.
+func (c *Client) SetBootParamBootFlagsContext(ctx context.Context, bootFlags *BOP_BootFlags) error {
+	if err := c.SetBootParamSetInProgressStateContext(ctx, SetInProgressState_SetInProgress); err != nil {
 		goto OUT
 	} else {
 		r := &SetSystemBootOptionsRequest{
@@ -32,21 +45,26 @@ func (c *Client) SetBootParamBootFlags(bootFlags *BOP_BootFlags) error {
 			},
 		}
 
-		_, err := c.SetSystemBootOptions(r)
+		_, err := c.SetSystemBootOptionsContext(ctx, r)
 		if err != nil {
-			return fmt.Errorf("SetSystemBootOptions failed, err: %s", err)
+			return fmt.Errorf("SetSystemBootOptions failed, err: %w", err)
 		}
 	}
 
 OUT:
-	if err := c.SetBootParamSetInProgressState(SetInProgressState_SetComplete); err != nil {
-		return fmt.Errorf("SetBootParamSetInProgressState failed, err: %s", err)
+	if err := c.SetBootParamSetInProgressStateContext(ctx, SetInProgressState_SetComplete); err != nil {
+		return fmt.Errorf("SetBootParamSetInProgressState failed, err: %w", err)
 	}
 
 	return nil
 }
 
 func (c *Client) SetBootParamClearAck(by BootInfoAcknowledgeBy) error {
+	return c.SetBootParamClearAckContext(context.Background(), by)
+}
+
+// SetBootParamClearAckContext is like SetBootParamClearAck but takes a context.
+func (c *Client) SetBootParamClearAckContext(ctx context.Context, by BootInfoAcknowledgeBy) error {
 	ack := &BOP_BootInfoAcknowledge{}
 
 	switch by {
@@ -70,9 +88,9 @@ func (c *Client) SetBootParamClearAck(by BootInfoAcknowledgeBy) error {
 		},
 	}
 
-	_, err := c.SetSystemBootOptions(r)
+	_, err := c.SetSystemBootOptionsContext(ctx, r)
 	if err != nil {
-		return fmt.Errorf("SetSystemBootOptions failed, err: %s", err)
+		return fmt.Errorf("SetSystemBootOptions failed, err: %w", err)
 	}
 
 	return nil
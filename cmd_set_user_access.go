@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 22.26 Set User Access Command
 type SetUserAccessRequest struct {
 	EnableChanging bool
@@ -44,7 +46,7 @@ func (req *SetUserAccessRequest) Pack() []byte {
 	}
 	packUint8(b, out, 0)
 	packUint8(req.UserID&0x3f, out, 1)
-	packUint8(req.MaxPrivLevel&0x3f, out, 1)
+	packUint8(req.MaxPrivLevel&0x3f, out, 2)
 	packUint8(req.SessionLimit&0x0f, out, 3)
 
 	return out
@@ -69,7 +71,12 @@ func (res *SetUserAccessResponse) Format() string {
 }
 
 func (c *Client) SetUserAccess(request *SetUserAccessRequest) (response *SetUserAccessResponse, err error) {
+	return c.SetUserAccessContext(context.Background(), request)
+}
+
+// SetUserAccessContext is like SetUserAccess but takes a context.
+func (c *Client) SetUserAccessContext(ctx context.Context, request *SetUserAccessRequest) (response *SetUserAccessResponse, err error) {
 	response = &SetUserAccessResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
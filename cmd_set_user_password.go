@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 22.30 Set User Password Command
 type SetUserPasswordRequest struct {
 	// [5:0] - User ID. 000000b = reserved. (User ID 1 is permanently associated with User 1, the null user name).
@@ -66,6 +68,11 @@ func (res *SetUserPasswordResponse) Format() string {
 }
 
 func (c *Client) SetUserPassword(userID uint8, password string, stored20 bool) (response *SetUserPasswordResponse, err error) {
+	return c.SetUserPasswordContext(context.Background(), userID, password, stored20)
+}
+
+// SetUserPasswordContext is like SetUserPassword but takes a context.
+func (c *Client) SetUserPasswordContext(ctx context.Context, userID uint8, password string, stored20 bool) (response *SetUserPasswordResponse, err error) {
 	request := &SetUserPasswordRequest{
 		UserID:    userID,
 		Stored20:  stored20,
@@ -73,11 +80,16 @@ func (c *Client) SetUserPassword(userID uint8, password string, stored20 bool) (
 		Password:  password,
 	}
 	response = &SetUserPasswordResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
 
 func (c *Client) TestUserPassword(userID uint8, password string, stored20 bool) (response *SetUserPasswordResponse, err error) {
+	return c.TestUserPasswordContext(context.Background(), userID, password, stored20)
+}
+
+// TestUserPasswordContext is like TestUserPassword but takes a context.
+func (c *Client) TestUserPasswordContext(ctx context.Context, userID uint8, password string, stored20 bool) (response *SetUserPasswordResponse, err error) {
 	request := &SetUserPasswordRequest{
 		UserID:    userID,
 		Stored20:  stored20,
@@ -85,26 +97,36 @@ func (c *Client) TestUserPassword(userID uint8, password string, stored20 bool)
 		Password:  password,
 	}
 	response = &SetUserPasswordResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
 
 func (c *Client) DisableUser(userID uint8) (err error) {
+	return c.DisableUserContext(context.Background(), userID)
+}
+
+// DisableUserContext is like DisableUser but takes a context.
+func (c *Client) DisableUserContext(ctx context.Context, userID uint8) (err error) {
 	request := &SetUserPasswordRequest{
 		UserID:    userID,
 		Operation: PasswordOperationDisableUser,
 	}
 	response := &SetUserPasswordResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return err
 }
 
 func (c *Client) EnableUser(userID uint8) (err error) {
+	return c.EnableUserContext(context.Background(), userID)
+}
+
+// EnableUserContext is like EnableUser but takes a context.
+func (c *Client) EnableUserContext(ctx context.Context, userID uint8) (err error) {
 	request := &SetUserPasswordRequest{
 		UserID:    userID,
 		Operation: PasswordOperationEnableUser,
 	}
 	response := &SetUserPasswordResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return err
 }
@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 22.28 Set User Name Command
 type SetUsernameRequest struct {
 	// [5:0] - User ID. 000000b = reserved. (User ID 1 is permanently associated with User 1, the null user name).
@@ -43,11 +45,16 @@ func (res *SetUsernameResponse) Format() string {
 }
 
 func (c *Client) SetUsername(userID uint8, username string) (response *SetUsernameResponse, err error) {
+	return c.SetUsernameContext(context.Background(), userID, username)
+}
+
+// SetUsernameContext is like SetUsername but takes a context.
+func (c *Client) SetUsernameContext(ctx context.Context, userID uint8, username string) (response *SetUsernameResponse, err error) {
 	request := &SetUsernameRequest{
 		UserID:   userID,
 		Username: username,
 	}
 	response = &SetUsernameResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
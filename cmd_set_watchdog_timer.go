@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 27.6 Set Watchdog Timer Command
 type SetWatchdogTimerRequest struct {
 	DontLog       bool
@@ -58,8 +60,13 @@ func (res *SetWatchdogTimerResponse) Format() string {
 }
 
 func (c *Client) SetWatchdogTimer() (response *SetWatchdogTimerResponse, err error) {
+	return c.SetWatchdogTimerContext(context.Background())
+}
+
+// SetWatchdogTimerContext is like SetWatchdogTimer but takes a context.
+func (c *Client) SetWatchdogTimerContext(ctx context.Context) (response *SetWatchdogTimerResponse, err error) {
 	request := &SetWatchdogTimerRequest{}
 	response = &SetWatchdogTimerResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 26.1 SOL Activating Command
 type SOLActivatingRequest struct {
 	SessionState       uint8
@@ -37,8 +39,13 @@ func (res *SOLActivatingResponse) Format() string {
 }
 
 func (c *Client) SOLActivating() (response *SOLActivatingResponse, err error) {
+	return c.SOLActivatingContext(context.Background())
+}
+
+// SOLActivatingContext is like SOLActivating but takes a context.
+func (c *Client) SOLActivatingContext(ctx context.Context) (response *SOLActivatingResponse, err error) {
 	request := &SOLActivatingRequest{}
 	response = &SOLActivatingResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
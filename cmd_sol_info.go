@@ -1,8 +1,16 @@
 package ipmi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 func (c *Client) SOLInfo(channelNumber uint8) (*SOLConfigParam, error) {
+	return c.SOLInfoContext(context.Background(), channelNumber)
+}
+
+// SOLInfoContext is like SOLInfo but takes a context.
+func (c *Client) SOLInfoContext(ctx context.Context, channelNumber uint8) (*SOLConfigParam, error) {
 	solConfigParam := &SOLConfigParam{}
 
 	params := []SOLConfigParamSelector{
@@ -18,13 +26,13 @@ func (c *Client) SOLInfo(channelNumber uint8) (*SOLConfigParam, error) {
 	}
 
 	for _, param := range params {
-		res, err := c.GetSOLConfigParams(channelNumber, param)
+		res, err := c.GetSOLConfigParamsContext(ctx, channelNumber, param)
 		if err != nil {
-			return nil, fmt.Errorf("GetSOLConfigParams for %d failed, err: %s", uint8(param), err)
+			return nil, fmt.Errorf("GetSOLConfigParams for %d failed, err: %w", uint8(param), err)
 		}
 
 		if err = ParseSOLParamData(param, res.ParameterData, solConfigParam); err != nil {
-			return nil, fmt.Errorf("ParseSOLParamData failed, err: %s", err)
+			return nil, fmt.Errorf("ParseSOLParamData failed, err: %w", err)
 		}
 	}
 
@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 23.3 Suspend BMC ARPs Command
 type SuspendARPsRequest struct {
 	ChannelNumber        uint8
@@ -57,12 +59,17 @@ func (res *SuspendARPsResponse) Format() string {
 }
 
 func (c *Client) SuspendARPs(channelNumber uint8, suspendARP bool, suspendGratuitousARP bool) (response *SuspendARPsResponse, err error) {
+	return c.SuspendARPsContext(context.Background(), channelNumber, suspendARP, suspendGratuitousARP)
+}
+
+// SuspendARPsContext is like SuspendARPs but takes a context.
+func (c *Client) SuspendARPsContext(ctx context.Context, channelNumber uint8, suspendARP bool, suspendGratuitousARP bool) (response *SuspendARPsResponse, err error) {
 	request := &SuspendARPsRequest{
 		ChannelNumber:        channelNumber,
 		SuspendARP:           suspendARP,
 		SuspendGratuitousARP: suspendGratuitousARP,
 	}
 	response = &SuspendARPsResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
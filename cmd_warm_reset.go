@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 20.3 Warm Reset Command
 type WarmResetRequest struct {
 	// empty
@@ -29,8 +31,13 @@ func (res *WarmResetResponse) Format() string {
 }
 
 func (c *Client) WarmReset() (err error) {
+	return c.WarmResetContext(context.Background())
+}
+
+// WarmResetContext is like WarmReset but takes a context.
+func (c *Client) WarmResetContext(ctx context.Context) (err error) {
 	request := &WarmResetRequest{}
 	response := &WarmResetResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -20,7 +21,7 @@ func (req *WriteFRUDataRequest) Command() Command {
 }
 
 func (req *WriteFRUDataRequest) Pack() []byte {
-	out := make([]byte, 4)
+	out := make([]byte, 3+len(req.WriteData))
 	packUint8(req.FRUDeviceID, out, 0)
 	packUint16L(req.WriteOffset, out, 1)
 	packBytes(req.WriteData, out, 3)
@@ -49,12 +50,17 @@ func (res *WriteFRUDataResponse) Format() string {
 
 // The command writes the specified byte or word to the FRU Inventory Info area. This is a low level direct interface to a non-volatile storage area. This means that the interface does not interpret or check any semantics or formatting for the data being written.
 func (c *Client) WriteFRUData(fruDeviceID uint8, writeOffset uint16, writeData []byte) (response *WriteFRUDataResponse, err error) {
+	return c.WriteFRUDataContext(context.Background(), fruDeviceID, writeOffset, writeData)
+}
+
+// WriteFRUDataContext is like WriteFRUData but takes a context.
+func (c *Client) WriteFRUDataContext(ctx context.Context, fruDeviceID uint8, writeOffset uint16, writeData []byte) (response *WriteFRUDataResponse, err error) {
 	request := &WriteFRUDataRequest{
 		FRUDeviceID: fruDeviceID,
 		WriteOffset: writeOffset,
 		WriteData:   writeData,
 	}
 	response = &WriteFRUDataResponse{}
-	err = c.Exchange(request, response)
+	err = c.ExchangeContext(ctx, request, response)
 	return
 }
@@ -7,10 +7,24 @@ import (
 
 var (
 	ErrUnpackedDataTooShort = errors.New("unpacked data is too short")
+
+	// ErrSessionInvalid means the lan/lanplus session is lost, it's no longer accepted by the BMC,
+	// like the session is timed out or closed by the BMC.
+	ErrSessionInvalid = errors.New("session invalid")
+
+	// ErrTimeout means no response of the request is received in time,
+	// after all the retransmissions of lan/lanplus requests.
+	ErrTimeout = errors.New("timeout")
+
+	// ErrAuthFailed means the BMC rejects the username or password,
+	// or the authentication code of the BMC does not match, when establishing the session.
+	ErrAuthFailed = errors.New("authentication failed")
+
+	ErrManagerClosed = errors.New("manager closed")
 )
 
 func ErrUnpackedDataTooShortWith(actual int, expected int) error {
-	return fmt.Errorf("%s (%d/%d)", ErrUnpackedDataTooShort, actual, expected)
+	return fmt.Errorf("%w (%d/%d)", ErrUnpackedDataTooShort, actual, expected)
 }
 
 func ErrNotEnoughDataWith(msg string, actual int, expected int) error {
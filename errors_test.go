@@ -0,0 +1,69 @@
+package ipmi
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func Test_ResponseError_Is(t *testing.T) {
+	for cc, cErr := range completionCodeErrors {
+		var err error = &ResponseError{completionCode: cc, description: cc.String()}
+		err = fmt.Errorf("GetSensorReading failed, err: %w", err)
+
+		if !errors.Is(err, cErr) {
+			t.Errorf("expect %#02x matches %q", uint8(cc), cErr)
+		}
+		if cc != CompletionCodeNodeBusy && errors.Is(err, ErrNodeBusy) {
+			t.Errorf("expect %#02x not matches %q", uint8(cc), ErrNodeBusy)
+		}
+
+		var respErr *ResponseError
+		if !errors.As(err, &respErr) || respErr.CompletionCode() != cc {
+			t.Errorf("expect %#02x unwrapped as ResponseError", uint8(cc))
+		}
+	}
+
+	// command-specific completion codes have no generic errors
+	err := &ResponseError{completionCode: 0x80}
+	for _, cErr := range completionCodeErrors {
+		if errors.Is(err, cErr) {
+			t.Errorf("expect 0x80 not matches %q", cErr)
+		}
+	}
+}
+
+func Test_ErrorsWrapped(t *testing.T) {
+	transport := &rawTransport{
+		replies: map[Command][]byte{
+			{ID: CommandGetSDR.ID, NetFn: CommandGetSDR.NetFn}: {uint8(CompletionCodeNodeBusy)},
+		},
+	}
+	client, err := NewClientWithTransport(transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.GetSensors()
+	if !errors.Is(err, ErrNodeBusy) {
+		t.Errorf("expect GetSensors failed with ErrNodeBusy, got: %v", err)
+	}
+
+	_, err = client.GetSELEntries(0)
+	if !errors.Is(err, ErrInvalidCommand) {
+		t.Errorf("expect GetSELEntries failed with ErrInvalidCommand, got: %v", err)
+	}
+
+	if err := (&GetDeviceIDResponse{}).Unpack([]byte{0x20}); !errors.Is(err, ErrUnpackedDataTooShort) {
+		t.Errorf("expect Unpack failed with ErrUnpackedDataTooShort, got: %v", err)
+	}
+}
+
+func Test_RmcpStatusCode_err(t *testing.T) {
+	if err := RmcpStatusCodeUnauthorizedName.err(); !errors.Is(err, ErrAuthFailed) {
+		t.Errorf("expect unauthorized name is auth failure, got: %v", err)
+	}
+	if err := RmcpStatusCodeNoCipherSuiteMatch.err(); errors.Is(err, ErrAuthFailed) {
+		t.Errorf("expect no cipher suite match is not auth failure, got: %v", err)
+	}
+}
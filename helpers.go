@@ -4,10 +4,12 @@ import (
 	"encoding/base32"
 	"encoding/base64"
 	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"math"
 	"math/rand"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/kr/pretty"
@@ -55,6 +57,10 @@ func (c *Client) Debugf(format string, object ...interface{}) {
 	if !c.debug {
 		return
 	}
+	if c.logger != nil {
+		c.logger.Debug(strings.TrimSpace(pretty.Sprintf(format, object...)))
+		return
+	}
 	debugf(format, object...)
 }
 
@@ -62,6 +68,10 @@ func (c *Client) Debug(header string, object interface{}) {
 	if !c.debug {
 		return
 	}
+	if c.logger != nil {
+		c.logger.Debug(header, "object", pretty.Sprintf("%# v", object))
+		return
+	}
 	debug(header, object)
 }
 
@@ -70,31 +80,38 @@ func (c *Client) DebugBytes(header string, data []byte, width int) {
 	if !c.debug {
 		return
 	}
+	if c.logger != nil {
+		c.logger.Debug(header, "length", len(data), "bytes", hex.EncodeToString(data))
+		return
+	}
 	debugBytes(header, data, width)
 }
 
 func (c *Client) DebugfRed(format string, object ...interface{}) {
-	if !c.debug {
-		return
-	}
 	const colorRed = "\033[0;31m"
-	fmt.Printf(colorRed+format+"\033[0m", object...)
+	c.debugfColor(colorRed, format, object...)
 }
 
 func (c *Client) DebugfGreen(format string, object ...interface{}) {
-	if !c.debug {
-		return
-	}
 	const colorGreen = "\033[0;32m"
-	fmt.Printf(colorGreen+format+"\033[0m", object...)
+	c.debugfColor(colorGreen, format, object...)
 }
 
 func (c *Client) DebugfYellow(format string, object ...interface{}) {
+	const colorYellow = "\033[0;33m"
+	c.debugfColor(colorYellow, format, object...)
+}
+
+// debugfColor prints the colored message to stdout, the color is dropped if the logger is set.
+func (c *Client) debugfColor(color string, format string, object ...interface{}) {
 	if !c.debug {
 		return
 	}
-	const colorYellow = "\033[0;33m"
-	fmt.Printf(colorYellow+format+"\033[0m", object...)
+	if c.logger != nil {
+		c.logger.Debug(strings.TrimSpace(fmt.Sprintf(format, object...)))
+		return
+	}
+	fmt.Printf(color+format+"\033[0m", object...)
 }
 
 // 37 Timestamp Format
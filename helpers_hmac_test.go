@@ -123,3 +123,177 @@ func Test_RC4_Decrypt(t *testing.T) {
 		}
 	}
 }
+
+func Test_generate_hmac_sha256(t *testing.T) {
+	// RFC 4231 Test Case 2
+	got, err := generate_hmac("sha256", []byte("what do ya want for nothing?"), []byte("Jefe"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{
+		0x5b, 0xdc, 0xc1, 0x46, 0xbf, 0x60, 0x75, 0x4e, 0x6a, 0x04, 0x24, 0x26, 0x08, 0x95, 0x75, 0xc7,
+		0x5a, 0x00, 0x3f, 0x08, 0x9d, 0x27, 0x39, 0x83, 0x9d, 0xec, 0x58, 0xb9, 0x64, 0xec, 0x38, 0x43,
+	}
+	if !isByteSliceEqual(got, expected) {
+		t.Errorf("got does not match expected, got: %x, want: %x", got, expected)
+	}
+}
+
+// newSHA256TestClient returns a client in the state after rakp2 is received
+// for cipher suite 17 (RAKP-HMAC-SHA256, HMAC-SHA256-128, AES-CBC-128).
+func newSHA256TestClient() *Client {
+	c := &Client{
+		Username: "admin",
+		Password: "password",
+		session: &session{
+			v20: v20{
+				authAlg:          AuthAlgRAKP_HMAC_SHA256,
+				integrityAlg:     IntegrityAlg_HMAC_SHA256_128,
+				cryptAlg:         CryptAlg_AES_CBC_128,
+				consoleSessionID: 0x0a0b0c0d,
+				bmcSessionID:     0x11223344,
+				role:             0x14,
+			},
+		},
+	}
+	for i := 0; i < 16; i++ {
+		c.session.v20.consoleRand[i] = 0x10 + uint8(i)
+		c.session.v20.bmcRand[i] = 0xa0 + uint8(i)
+		c.session.v20.bmcGUID[i] = 0x40 + uint8(i)
+	}
+	return c
+}
+
+func Test_SHA256_KeyDerivation(t *testing.T) {
+	c := newSHA256TestClient()
+
+	// the expected values are computed independently by following
+	// 13.31 RMCP+ Authenticated Key-Exchange Protocol and 13.32 Generating Additional Keying Material.
+	expectedSIK := []byte{
+		0x4a, 0x79, 0x09, 0x11, 0x4f, 0xaf, 0x07, 0x9b, 0x47, 0x7f, 0xe3, 0x3c, 0xdb, 0xb3, 0xec, 0x57,
+		0x90, 0x73, 0xe1, 0xeb, 0x26, 0x22, 0x57, 0x3e, 0xc8, 0x5b, 0xee, 0xc3, 0xfd, 0xbd, 0x78, 0xed,
+	}
+	expectedK1 := []byte{
+		0xc4, 0x63, 0x1d, 0x41, 0x42, 0x84, 0x89, 0x17, 0xd8, 0x5e, 0xec, 0xab, 0x39, 0x83, 0x8f, 0x06,
+		0x9a, 0x54, 0x09, 0x79, 0x65, 0xad, 0xc0, 0x74, 0x4c, 0xe0, 0xcc, 0x95, 0xa9, 0x25, 0x84, 0xef,
+	}
+	expectedK2 := []byte{
+		0xed, 0xc3, 0xdf, 0x49, 0x96, 0xe7, 0xf2, 0x35, 0xfc, 0x65, 0xe2, 0x7e, 0x17, 0x43, 0xb0, 0xb6,
+		0xbe, 0xe4, 0x14, 0x6a, 0xc0, 0x4e, 0x25, 0xaa, 0xe2, 0xfb, 0xb1, 0x59, 0x7e, 0x06, 0x06, 0xb0,
+	}
+	expectedRAKP2 := []byte{
+		0x7f, 0x2e, 0xc9, 0xec, 0x1d, 0x45, 0x70, 0x08, 0xee, 0xb6, 0xa9, 0xf4, 0xbb, 0x17, 0xcf, 0xbc,
+		0x44, 0x33, 0xbb, 0xdc, 0x02, 0xb0, 0x07, 0x7c, 0x9d, 0x1e, 0x05, 0x94, 0x93, 0x35, 0x8e, 0xb7,
+	}
+	expectedRAKP3 := []byte{
+		0x84, 0x47, 0xfd, 0xc0, 0xe2, 0x9b, 0x25, 0x90, 0x68, 0x8b, 0xca, 0x5f, 0x73, 0x24, 0xb4, 0xa9,
+		0x5c, 0x84, 0x89, 0x14, 0xa0, 0xaa, 0xf7, 0xcd, 0x0e, 0x1f, 0x05, 0xa8, 0x01, 0x51, 0x1d, 0xa4,
+	}
+	expectedRAKP4 := []byte{
+		0x95, 0x20, 0xa2, 0x6f, 0xae, 0x1e, 0x78, 0x27, 0x80, 0x6b, 0xd6, 0x3c, 0xef, 0x77, 0xf2, 0xd2,
+	}
+	expectedIntegrity := []byte{
+		0x0a, 0x41, 0x08, 0x35, 0xb3, 0xea, 0x6e, 0x57, 0xcb, 0x00, 0xcb, 0xa3, 0xbb, 0xa4, 0x00, 0x5a,
+	}
+
+	rakp2, err := c.generate_rakp2_authcode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isByteSliceEqual(rakp2, expectedRAKP2) {
+		t.Errorf("rakp2 auth code not match, got: %x, want: %x", rakp2, expectedRAKP2)
+	}
+
+	sik, err := c.generate_sik()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isByteSliceEqual(sik, expectedSIK) {
+		t.Errorf("sik not match, got: %x, want: %x", sik, expectedSIK)
+	}
+	c.session.v20.sik = sik
+
+	k1, err := c.generate_k1()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isByteSliceEqual(k1, expectedK1) {
+		t.Errorf("k1 not match, got: %x, want: %x", k1, expectedK1)
+	}
+	c.session.v20.k1 = k1
+
+	k2, err := c.generate_k2()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isByteSliceEqual(k2, expectedK2) {
+		t.Errorf("k2 not match, got: %x, want: %x", k2, expectedK2)
+	}
+
+	rakp3, err := c.generate_rakp3_authcode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isByteSliceEqual(rakp3, expectedRAKP3) {
+		t.Errorf("rakp3 auth code not match, got: %x, want: %x", rakp3, expectedRAKP3)
+	}
+
+	// cipher suites 15, 16 and 17 all use RAKP-HMAC-SHA256 for the rakp4 integrity check value
+	for _, integrityAlg := range []IntegrityAlg{IntegrityAlg_None, IntegrityAlg_HMAC_SHA256_128} {
+		c.session.v20.integrityAlg = integrityAlg
+		rakp4, err := c.generate_rakp4_authcode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !isByteSliceEqual(rakp4, expectedRAKP4) {
+			t.Errorf("rakp4 auth code not match for integrity algorithm %x, got: %x, want: %x", integrityAlg, rakp4, expectedRAKP4)
+		}
+	}
+
+	// the data range covered by the AuthCode field of a session trailer
+	input := []byte{0x06, 0x06, 0xc0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x08, 0x00, 0x20, 0x18, 0x84, 0x00, 0x3b, 0x04}
+	integrity, err := c.genIntegrityAuthCode(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isByteSliceEqual(integrity, expectedIntegrity) {
+		t.Errorf("integrity auth code not match, got: %x, want: %x", integrity, expectedIntegrity)
+	}
+}
+
+func Test_SHA256_SIKWithBMCKey(t *testing.T) {
+	c := newSHA256TestClient()
+
+	key := make([]byte, 20)
+	for i := range key {
+		key[i] = uint8(i + 1)
+	}
+	c.WithBMCKey(key)
+
+	// the expected value is computed independently by following 13.31, with Kg as the hmac key.
+	expected := []byte{
+		0x1f, 0xa3, 0x03, 0xbe, 0x2b, 0x8a, 0x2d, 0x20, 0x61, 0x22, 0x95, 0x6a, 0x6a, 0x98, 0x8c, 0xde,
+		0x41, 0xdf, 0xbb, 0xf4, 0x82, 0x6c, 0x74, 0x95, 0xb7, 0xa5, 0x54, 0x93, 0x43, 0x20, 0x8d, 0xb7,
+	}
+
+	sik, err := c.generate_sik()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isByteSliceEqual(sik, expected) {
+		t.Errorf("sik not match, got: %x, want: %x", sik, expected)
+	}
+
+	// rakp2 and rakp3 auth codes are still generated by the user password (Kuid)
+	rakp3, err := c.generate_rakp3_authcode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedRAKP3 := []byte{
+		0x84, 0x47, 0xfd, 0xc0, 0xe2, 0x9b, 0x25, 0x90, 0x68, 0x8b, 0xca, 0x5f, 0x73, 0x24, 0xb4, 0xa9,
+		0x5c, 0x84, 0x89, 0x14, 0xa0, 0xaa, 0xf7, 0xcd, 0x0e, 0x1f, 0x05, 0xa8, 0x01, 0x51, 0x1d, 0xa4,
+	}
+	if !isByteSliceEqual(rakp3, expectedRAKP3) {
+		t.Errorf("rakp3 auth code should not use bmc key, got: %x, want: %x", rakp3, expectedRAKP3)
+	}
+}
@@ -1,7 +1,6 @@
 package ipmi
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -122,7 +121,7 @@ func (c *Client) buildRawPayload(reqCmd Request) (PayloadType, []byte, error) {
 		// Standard Payload Types
 		ipmiReq, err := c.BuildIPMIRequest(reqCmd)
 		if err != nil {
-			return 0, nil, fmt.Errorf("BuildIPMIRequest failed, err: %s", err)
+			return 0, nil, fmt.Errorf("BuildIPMIRequest failed, err: %w", err)
 		}
 
 		c.Debug(">>>> IPMI Request", ipmiReq)
@@ -132,33 +131,75 @@ func (c *Client) buildRawPayload(reqCmd Request) (PayloadType, []byte, error) {
 	return payloadType, rawPayload, nil
 }
 
-func (c *Client) exchangeLAN(request Request, response Response) error {
+func (c *Client) exchangeLAN(ctx context.Context, request Request, response Response) error {
+	if c.pipeline != nil {
+		return c.exchangeLANPipelined(ctx, request, response)
+	}
+
 	c.Debug(">> Command Request", request)
 
-	rmcp, err := c.BuildRmcpRequest(request)
+	// The raw payload is built only once, so all the retransmissions carry
+	// the same IPMI Requester Sequence number, which is used to drop the
+	// duplicated or late replies.
+	payloadType, rawPayload, err := c.buildRawPayload(request)
 	if err != nil {
-		return fmt.Errorf("build RMCP+ request msg failed, err: %s", err)
+		return fmt.Errorf("build RMCP+ request msg failed, err: %w", err)
+	}
+	c.DebugBytes("rawPayload", rawPayload, 16)
+	expect := newRmcpExpect(payloadType, rawPayload)
+
+	attemptTimeout := c.attemptTimeout
+	if attemptTimeout <= 0 {
+		attemptTimeout = c.timeout
+	}
+
+	attempts := c.retries + 1
+	for attempt := 1; ; attempt++ {
+		err = c.exchangeLANOnce(ctx, request, response, payloadType, rawPayload, expect, attemptTimeout)
+		if err == nil || !isTimeoutError(err) || ctx.Err() != nil {
+			return err
+		}
+		if attempt >= attempts {
+			return fmt.Errorf("%w: no response after %d attempts, err: %w", ErrTimeout, attempts, err)
+		}
+		c.DebugfYellow("no response in %s, retransmit (%d/%d)\n", attemptTimeout, attempt, c.retries)
+		c.observeRetransmission(request.Command())
+	}
+}
+
+// exchangeLANOnce sends the raw payload in a new RMCP message and waits for the matched reply.
+func (c *Client) exchangeLANOnce(ctx context.Context, request Request, response Response, payloadType PayloadType, rawPayload []byte, expect *rmcpExpect, timeout time.Duration) error {
+	rmcp, err := c.buildRmcp(request, payloadType, rawPayload)
+	if err != nil {
+		return fmt.Errorf("build RMCP+ request msg failed, err: %w", err)
 	}
 	c.Debug(">>>>>> RMCP Request", rmcp)
 	sent := rmcp.Pack()
 	c.DebugBytes("sent", sent, 16)
 
-	ctx := context.Background()
-	recv, err := c.udpClient.Exchange(ctx, bytes.NewReader(sent))
-	if err != nil {
-		return fmt.Errorf("client udp exchange msg failed, err: %s", err)
+	var parseErr error
+	match := func(recv []byte) bool {
+		c.DebugBytes("recv", recv, 16)
+		parseErr = c.parseRmcpResponse(recv, response, expect)
+		if parseErr == errUnmatchedResponse {
+			c.DebugfYellow("drop unmatched response\n")
+			return false
+		}
+		return true
 	}
-	c.DebugBytes("recv", recv, 16)
 
-	if err := c.ParseRmcpResponse(recv, response); err != nil {
+	if _, err := c.udpClient.exchange(ctx, sent, timeout, match); err != nil {
+		return fmt.Errorf("client udp exchange msg failed, err: %w", err)
+	}
+
+	if parseErr != nil {
 		// Warn, must directly return err. (DO NOT wrap err to another error)
-		// The error returned by ParseRmcpResponse might be of *ResponseError type.
-		return err
+		// The error returned by parseRmcpResponse might be of *ResponseError type.
+		return parseErr
 	}
 
 	c.Debug("<< Command Response", response)
 	return nil
-
 }
 
 // 13.14
@@ -168,6 +209,13 @@ func (c *Client) exchangeLAN(request Request, response Response) error {
 // 3. Get Session Challenge
 // 4. Activate Session
 func (c *Client) Connect15() error {
+	return c.Connect15Context(context.Background())
+}
+
+// Connect15Context is like Connect15 but takes a context.
+func (c *Client) Connect15Context(ctx context.Context) error {
+	ctx = withSessionSetup(ctx)
+
 	var (
 		err           error
 		channelNumber uint8 = ChannelNumberSelf
@@ -177,31 +225,34 @@ func (c *Client) Connect15() error {
 		c.maxPrivilegeLevel = PrivilegeLevelAdministrator
 	}
 
-	_, err = c.GetChannelAuthenticationCapabilities(channelNumber, c.maxPrivilegeLevel)
+	_, err = c.GetChannelAuthenticationCapabilitiesContext(ctx, channelNumber, c.maxPrivilegeLevel)
 	if err != nil {
-		return fmt.Errorf("GetChannelAuthenticationCapabilities failed, err: %s", err)
+		return fmt.Errorf("GetChannelAuthenticationCapabilities failed, err: %w", err)
 	}
 
-	_, err = c.GetSessionChallenge()
+	_, err = c.GetSessionChallengeContext(ctx)
 	if err != nil {
-		return fmt.Errorf("GetSessionChallenge failed, err: %s", err)
+		var respErr *ResponseError
+		if errors.As(err, &respErr) && (respErr.CompletionCode() == 0x81 || respErr.CompletionCode() == 0x82) {
+			// invalid user name, or null user name not enabled
+			return fmt.Errorf("%w: GetSessionChallenge failed, err: %w", ErrAuthFailed, err)
+		}
+		return fmt.Errorf("GetSessionChallenge failed, err: %w", err)
 	}
 
 	c.session.v15.preSession = true
 
-	_, err = c.ActivateSession()
+	_, err = c.ActivateSessionContext(ctx)
 	if err != nil {
-		return fmt.Errorf("ActivateSession failed, err: %s", err)
+		return fmt.Errorf("ActivateSession failed, err: %w", err)
 	}
 
-	_, err = c.SetSessionPrivilegeLevel(c.maxPrivilegeLevel)
+	_, err = c.SetSessionPrivilegeLevelContext(ctx, c.maxPrivilegeLevel)
 	if err != nil {
-		return fmt.Errorf("SetSessionPrivilegeLevel to (%s) failed, err: %s", c.maxPrivilegeLevel, err)
+		return fmt.Errorf("SetSessionPrivilegeLevel to (%s) failed, err: %w", c.maxPrivilegeLevel, err)
 	}
 
-	go func() {
-		c.keepSessionAlive(DefaultKeepAliveIntervalSec)
-	}()
+	c.startKeepSessionAlive()
 
 	return nil
 
@@ -209,6 +260,13 @@ func (c *Client) Connect15() error {
 
 // see 13.15 IPMI v2.0/RMCP+ Session Activation
 func (c *Client) Connect20() error {
+	return c.Connect20Context(context.Background())
+}
+
+// Connect20Context is like Connect20 but takes a context.
+func (c *Client) Connect20Context(ctx context.Context) error {
+	ctx = withSessionSetup(ctx)
+
 	var (
 		err           error
 		channelNumber uint8 = ChannelNumberSelf
@@ -218,12 +276,12 @@ func (c *Client) Connect20() error {
 		c.maxPrivilegeLevel = PrivilegeLevelAdministrator
 	}
 
-	_, err = c.GetChannelAuthenticationCapabilities(channelNumber, c.maxPrivilegeLevel)
+	_, err = c.GetChannelAuthenticationCapabilitiesContext(ctx, channelNumber, c.maxPrivilegeLevel)
 	if err != nil {
-		return fmt.Errorf("cmd: Get Channel Authentication Capabilities failed, err: %s", err)
+		return fmt.Errorf("cmd: Get Channel Authentication Capabilities failed, err: %w", err)
 	}
 
-	tryCiphers := c.findBestCipherSuites()
+	tryCiphers := c.findBestCipherSuites(ctx)
 
 	if c.session.v20.cipherSuiteID != CipherSuiteIDReserved {
 		// client explicitly specified a cipher suite to use
@@ -241,41 +299,40 @@ func (c *Client) Connect20() error {
 
 		c.session.v20.cipherSuiteID = cipherSuiteID
 
-		_, err = c.OpenSession()
+		_, err = c.OpenSessionContext(ctx)
 		if err != nil {
-			errs = append(errs, fmt.Errorf("cmd: RMCP+ Open Session failed with cipher suite id (%v), err: %s", cipherSuiteID, err))
+			errs = append(errs, fmt.Errorf("cmd: RMCP+ Open Session failed with cipher suite id (%v), err: %w", cipherSuiteID, err))
 			continue
 		}
 
-		_, err = c.RAKPMessage1()
+		_, err = c.RAKPMessage1Context(ctx)
 		if err != nil {
-			errs = append(errs, fmt.Errorf("cmd: rakp1 failed with cipher suite id (%v), err: %s", cipherSuiteID, err))
+			errs = append(errs, fmt.Errorf("cmd: rakp1 failed with cipher suite id (%v), err: %w", cipherSuiteID, err))
 			continue
 		}
 
-		_, err = c.RAKPMessage3()
+		_, err = c.RAKPMessage3Context(ctx)
 		if err != nil {
-			errs = append(errs, fmt.Errorf("cmd: rakp3 failed with cipher suite id (%v), err: %s", cipherSuiteID, err))
+			errs = append(errs, fmt.Errorf("cmd: rakp3 failed with cipher suite id (%v), err: %w", cipherSuiteID, err))
 			continue
 		}
 
 		c.DebugfGreen("\n\nconnect20 success with cipher suite id (%v)\n\n\n", cipherSuiteID)
+		c.observeCipherSuite(cipherSuiteID)
 		success = true
 		break
 	}
 
 	if !success {
-		return fmt.Errorf("connect20 failed after try all cipher suite ids (%v), errs: \n%v", tryCiphers, errors.Join(errs...))
+		return fmt.Errorf("connect20 failed after try all cipher suite ids (%v), errs: \n%w", tryCiphers, errors.Join(errs...))
 	}
 
-	_, err = c.SetSessionPrivilegeLevel(c.maxPrivilegeLevel)
+	_, err = c.SetSessionPrivilegeLevelContext(ctx, c.maxPrivilegeLevel)
 	if err != nil {
-		return fmt.Errorf("SetSessionPrivilegeLevel to (%s) failed, err: %s", c.maxPrivilegeLevel, err)
+		return fmt.Errorf("SetSessionPrivilegeLevel to (%s) failed, err: %w", c.maxPrivilegeLevel, err)
 	}
 
-	go func() {
-		c.keepSessionAlive(DefaultKeepAliveIntervalSec)
-	}()
+	c.startKeepSessionAlive()
 
 	return nil
 }
@@ -284,6 +341,11 @@ func (c *Client) Connect20() error {
 // GetChannelAuthenticationCapabilities command, then decide to use v1.5 or v2.0
 // for subsequent requests.
 func (c *Client) ConnectAuto() error {
+	return c.ConnectAutoContext(context.Background())
+}
+
+// ConnectAutoContext is like ConnectAuto but takes a context.
+func (c *Client) ConnectAutoContext(ctx context.Context) error {
 	var (
 		err error
 
@@ -294,22 +356,22 @@ func (c *Client) ConnectAuto() error {
 
 	// force use IPMI v1.5 first
 	c.v20 = false
-	cap, err := c.GetChannelAuthenticationCapabilities(channelNumber, privilegeLevel)
+	cap, err := c.GetChannelAuthenticationCapabilitiesContext(ctx, channelNumber, privilegeLevel)
 	if err != nil {
-		return fmt.Errorf("cmd: Get Channel Authentication Capabilities failed, err: %s", err)
+		return fmt.Errorf("cmd: Get Channel Authentication Capabilities failed, err: %w", err)
 	}
 	if cap.SupportIPMIv20 {
 		c.v20 = true
-		return c.Connect20()
+		return c.Connect20Context(ctx)
 	}
 	if cap.SupportIPMIv15 {
-		return c.Connect15()
+		return c.Connect15Context(ctx)
 	}
 	return fmt.Errorf("client does not support IPMI v1.5 and IPMI v.20")
 }
 
 // closeLAN closes session used in LAN communication.
-func (c *Client) closeLAN() error {
+func (c *Client) closeLAN(ctx context.Context) error {
 	// close the channel to notify the keepAliveSession goroutine to stop
 	close(c.closedCh)
 
@@ -323,28 +385,47 @@ func (c *Client) closeLAN() error {
 	request := &CloseSessionRequest{
 		SessionID: sessionID,
 	}
-	if _, err := c.CloseSession(request); err != nil {
-		return fmt.Errorf("CloseSession failed, err: %s", err)
+	if _, err := c.CloseSessionContext(ctx, request); err != nil {
+		return fmt.Errorf("CloseSession failed, err: %w", err)
 	}
 
 	if err := c.udpClient.Close(); err != nil {
-		return fmt.Errorf("close udp connection failed, err: %s", err)
+		return fmt.Errorf("close udp connection failed, err: %w", err)
 	}
 
 	return nil
 }
 
+// startKeepSessionAlive starts the keepSessionAlive goroutine if it is not started yet.
+func (c *Client) startKeepSessionAlive() {
+	c.keepAliveOnce.Do(func() {
+		go c.keepSessionAlive(DefaultKeepAliveIntervalSec)
+	})
+}
+
 // 6.12.15 Session Inactivity Timeouts
 func (c *Client) keepSessionAlive(intervalSec int) {
 	var period = time.Duration(intervalSec) * time.Second
 	ticker := time.NewTicker(period)
 	defer ticker.Stop()
 
+	// ctx is canceled when the client is closed, so that an in-flight
+	// keepalive request does not block Close.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-c.closedCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
 	c.Debugf("keepSessionAlive started")
 	for {
 		select {
 		case <-ticker.C:
-			if _, err := c.GetCurrentSessionInfo(); err != nil {
+			if _, err := c.GetCurrentSessionInfoContext(ctx); err != nil {
 				c.DebugfRed("keepSessionAlive failed, GetCurrentSessionInfo failed, err: %s", err)
 			}
 		case <-c.closedCh:
@@ -0,0 +1,104 @@
+package ipmi
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+// buildLAN15Response builds a session-less IPMI v1.5 LAN reply for the IPMI request
+// carried in the received RMCP message, with rqSeq of the reply replaced by the given value.
+func buildLAN15Response(recv []byte, rqSeq uint8, data []byte) []byte {
+	// RMCP header (4 bytes) + session header without auth code (10 bytes)
+	ipmiReq := recv[14:]
+
+	payload := make([]byte, 0, 8+len(data))
+	payload = append(payload, ipmiReq[3], (ipmiReq[1]&0xfc)+0x04, 0x00)
+	payload = append(payload, ipmiReq[0], rqSeq<<2|ipmiReq[4]&0x03, ipmiReq[5], 0x00)
+	payload = append(payload, data...)
+	payload = append(payload, 0x00)
+
+	checksum := func(b []byte) uint8 {
+		var c uint8
+		for _, v := range b {
+			c += v
+		}
+		return -c
+	}
+	payload[2] = checksum(payload[0:2])
+	payload[len(payload)-1] = checksum(payload[3 : len(payload)-1])
+
+	msg := []byte{0x06, 0x00, 0xff, 0x07, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, uint8(len(payload))}
+	return append(msg, payload...)
+}
+
+func Test_exchangeLAN_Retransmit(t *testing.T) {
+	server, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("listen udp failed, err: %s", err)
+	}
+	defer server.Close()
+
+	// The server drops the first transmission, then replies the second transmission
+	// with a late reply of another request before the real reply.
+	received := make(chan int, 8)
+	go func() {
+		buf := make([]byte, 1024)
+		for count := 1; ; count++ {
+			n, addr, err := server.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			received <- count
+			if count == 1 {
+				continue
+			}
+			rqSeq := buf[14+4] >> 2
+			_, _ = server.WriteToUDP(buildLAN15Response(buf[:n], rqSeq+1, []byte{0x57, 0x00}), addr)
+			_, _ = server.WriteToUDP(buildLAN15Response(buf[:n], rqSeq, []byte{0x55, 0x00}), addr)
+		}
+	}()
+
+	addr := server.LocalAddr().(*net.UDPAddr)
+	client, err := NewClient(addr.IP.String(), addr.Port, "user", "pass")
+	if err != nil {
+		t.Fatalf("new client failed, err: %s", err)
+	}
+	client.WithInterface(InterfaceLan).WithRetries(2).WithAttemptTimeout(200 * time.Millisecond)
+	client.v20 = false
+
+	res, err := client.GetSelfTestResults()
+	if err != nil {
+		t.Fatalf("GetSelfTestResults failed, err: %s", err)
+	}
+	if res.Byte1 != 0x55 {
+		t.Errorf("unmatched response not dropped, got Byte1: %#02x", res.Byte1)
+	}
+	if len(received) != 2 {
+		t.Errorf("expected 2 transmissions, got: %d", len(received))
+	}
+}
+
+func Test_exchangeLAN_RetriesExhausted(t *testing.T) {
+	server, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("listen udp failed, err: %s", err)
+	}
+	defer server.Close()
+
+	addr := server.LocalAddr().(*net.UDPAddr)
+	client, err := NewClient(addr.IP.String(), addr.Port, "user", "pass")
+	if err != nil {
+		t.Fatalf("new client failed, err: %s", err)
+	}
+	client.WithInterface(InterfaceLan).WithRetries(1).WithAttemptTimeout(100 * time.Millisecond)
+	client.v20 = false
+
+	start := time.Now()
+	if _, err := client.GetSelfTestResults(); !isTimeoutError(err) {
+		t.Fatalf("expected timeout error, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond || elapsed > 2*time.Second {
+		t.Errorf("unexpected elapsed time for 2 attempts: %s", elapsed)
+	}
+}
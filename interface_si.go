@@ -0,0 +1,114 @@
+package ipmi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bougou/go-ipmi/si"
+)
+
+type siipmi struct {
+	// open opens the system interface, it's nil if the conn is provided by NewSIClientWithConn.
+	open func() (si.Conn, error)
+
+	conn si.Conn
+}
+
+// ConnectSI try to initialize the client by opening the KCS, BT or SSIF system interface.
+func (c *Client) ConnectSI() error {
+	return c.ConnectSIContext(context.Background())
+}
+
+// ConnectSIContext is like ConnectSI but takes a context.
+func (c *Client) ConnectSIContext(ctx context.Context) error {
+	if c.siipmi.conn == nil {
+		conn, err := c.siipmi.open()
+		if err != nil {
+			return fmt.Errorf("open %s interface failed, err: %w", c.Interface, err)
+		}
+		c.siipmi.conn = conn
+	}
+
+	if bt, ok := c.siipmi.conn.(*si.BT); ok {
+		res, err := c.GetBTInterfaceCapabilitiesContext(ctx)
+		if err != nil {
+			return fmt.Errorf("GetBTInterfaceCapabilities failed, err: %w", err)
+		}
+		c.Debug("BT Interface Capabilities", res)
+		bt.SetCapabilities(res.InputBufferMessageSizeBytes, res.OutputBufferMessageSizeBytes,
+			time.Duration(res.BMCRequestToResponseTimeSec)*time.Second, int(res.RecommendedRetries))
+	}
+
+	return nil
+}
+
+// closeSI closes the system interface.
+func (c *Client) closeSI() error {
+	c.lock()
+	conn := c.siipmi.conn
+	// the interface is opened again by the next ConnectSI,
+	// except the conn provided by NewSIClientWithConn which can not be reopened.
+	if c.siipmi.open != nil {
+		c.siipmi.conn = nil
+	}
+	c.unlock()
+
+	if conn == nil {
+		return nil
+	}
+	if err := conn.Close(); err != nil {
+		return fmt.Errorf("close %s interface failed, err: %w", c.Interface, err)
+	}
+	return nil
+}
+
+func (c *Client) exchangeSI(ctx context.Context, request Request, response Response) error {
+	if c.target != nil {
+		return fmt.Errorf("bridging is not supported by the %s interface", c.Interface)
+	}
+	if c.siipmi.conn == nil {
+		return fmt.Errorf("the %s interface is not connected", c.Interface)
+	}
+
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	c.Debug(">> Command Request", request)
+	netFn := request.Command().NetFn
+	cmd := request.Command().ID
+	data := request.Pack()
+
+	msg := make([]byte, 2+len(data))
+	msg[0] = uint8(netFn) << 2 // LUN 0
+	msg[1] = cmd
+	copy(msg[2:], data)
+	c.DebugBytes("sent", msg, 16)
+
+	res, err := c.siipmi.conn.Exchange(ctx, msg)
+	if err != nil {
+		if isTimeoutError(err) && ctx.Err() == nil {
+			return fmt.Errorf("%w: %s exchange failed, err: %w", ErrTimeout, c.Interface, err)
+		}
+		return fmt.Errorf("%s exchange failed, err: %w", c.Interface, err)
+	}
+	c.DebugBytes("recv", res, 16)
+
+	// NetFn/LUN, Command, Completion Code, Data
+	if len(res) < 3 {
+		return fmt.Errorf("response too short, got %d bytes", len(res))
+	}
+	if NetFn(res[0]>>2) != netFn+1 || res[1] != cmd {
+		return fmt.Errorf("response netfn (%#02x) cmd (%#02x) not match the request netfn (%#02x) cmd (%#02x)", res[0]>>2, res[1], netFn, cmd)
+	}
+
+	if err := UnpackResponse(res[2:], response); err != nil {
+		return err
+	}
+
+	c.Debug("<< Command Response", response)
+	return nil
+}
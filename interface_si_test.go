@@ -0,0 +1,192 @@
+package ipmi
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/bougou/go-ipmi/si"
+)
+
+// fakeSIConn replies the requests by the handler.
+type fakeSIConn struct {
+	handler func(msg []byte) []byte
+	sent    [][]byte
+	closed  bool
+}
+
+func (conn *fakeSIConn) Exchange(ctx context.Context, msg []byte) ([]byte, error) {
+	conn.sent = append(conn.sent, msg)
+	return conn.handler(msg), nil
+}
+
+func (conn *fakeSIConn) Close() error {
+	conn.closed = true
+	return nil
+}
+
+func Test_exchangeSI(t *testing.T) {
+	conn := &fakeSIConn{
+		handler: func(msg []byte) []byte {
+			if msg[0] == uint8(NetFnAppRequest)<<2 && msg[1] == CommandGetDeviceID.ID {
+				return []byte{uint8(NetFnAppResponse) << 2, msg[1], 0x00, 0x20, 0x81, 0x01, 0x10, 0x02, 0xbf, 0x57, 0x01, 0x00, 0x34, 0x12}
+			}
+			// mismatched command in the response
+			return []byte{msg[0] + 0x04, msg[1] + 1, 0x00}
+		},
+	}
+
+	client, err := NewSIClientWithConn(InterfaceKCS, conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Connect(); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.GetDeviceID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.DeviceID != 0x20 || res.ManufacturerID != 0x157 || res.ProductID != 0x1234 {
+		t.Errorf("device id response not match, got: %+v", res)
+	}
+
+	if _, err := client.GetSELInfo(); err == nil {
+		t.Error("expect error for mismatched response")
+	}
+	if !bytes.Equal(conn.sent[1], []byte{uint8(NetFnStorageRequest) << 2, CommandGetSELInfo.ID}) {
+		t.Errorf("request not match, got: % x", conn.sent[1])
+	}
+
+	client.WithTarget(0, 0x2c, 0)
+	if _, err := client.GetDeviceID(); err == nil {
+		t.Error("expect error for bridged request")
+	}
+
+	if err := client.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !conn.closed {
+		t.Error("conn not closed")
+	}
+}
+
+// kcsPort is the BMC side of the KCS interface on the I/O ports, it stops working once closed.
+type kcsPort struct {
+	handler func(req []byte) []byte
+
+	state  si.KCSState
+	status uint8
+	out    uint8
+
+	req      []byte
+	res      []byte
+	writeEnd bool
+	closed   bool
+}
+
+func (p *kcsPort) InB(port uint16) (uint8, error) {
+	if p.closed {
+		return 0, os.ErrClosed
+	}
+	switch port {
+	case si.DefaultKCSBase:
+		p.status &^= si.KCSStatusOBF
+		return p.out, nil
+	case si.DefaultKCSBase + 1:
+		return p.status&0x3f | uint8(p.state)<<6, nil
+	}
+	return 0xff, nil
+}
+
+func (p *kcsPort) OutB(port uint16, v uint8) error {
+	if p.closed {
+		return os.ErrClosed
+	}
+	switch port {
+	case si.DefaultKCSBase + 1:
+		switch v {
+		case si.KCSWriteStart:
+			p.state, p.req, p.writeEnd = si.KCSStateWrite, nil, false
+		case si.KCSWriteEnd:
+			p.writeEnd = true
+		default:
+			p.state = si.KCSStateError
+		}
+	case si.DefaultKCSBase:
+		switch {
+		case p.state == si.KCSStateWrite:
+			p.req = append(p.req, v)
+			if p.writeEnd {
+				p.state = si.KCSStateRead
+				p.res = p.handler(p.req)
+				p.next()
+			}
+		case p.state == si.KCSStateRead && len(p.res) == 0:
+			p.state = si.KCSStateIdle
+			p.out = 0x00
+			p.status |= si.KCSStatusOBF
+		case p.state == si.KCSStateRead:
+			p.next()
+		}
+	}
+	return nil
+}
+
+func (p *kcsPort) next() {
+	p.out, p.res = p.res[0], p.res[1:]
+	p.status |= si.KCSStatusOBF
+}
+
+func (p *kcsPort) Close() error {
+	p.closed = true
+	return nil
+}
+
+func Test_closeSI_Reconnect(t *testing.T) {
+	var ports []*kcsPort
+	client, err := NewSIClientWithConn(InterfaceKCS, &fakeSIConn{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.siipmi.conn = nil
+	client.siipmi.open = func() (si.Conn, error) {
+		port := &kcsPort{
+			handler: func(req []byte) []byte {
+				return []byte{uint8(NetFnAppResponse) << 2, req[1], 0x00, 0x20, 0x81, 0x01, 0x10, 0x02, 0xbf, 0x57, 0x01, 0x00, 0x34, 0x12}
+			},
+		}
+		ports = append(ports, port)
+		return si.NewKCS(port, 0), nil
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := client.Connect(); err != nil {
+			t.Fatal(err)
+		}
+		res, err := client.GetDeviceID()
+		if err != nil {
+			t.Fatalf("exchange after connect %d failed, err: %s", i+1, err)
+		}
+		if res.DeviceID != 0x20 || res.ProductID != 0x1234 {
+			t.Errorf("device id response not match, got: %+v", res)
+		}
+		if err := client.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(ports) != 2 {
+		t.Fatalf("expect the interface opened again after close, opened %d times", len(ports))
+	}
+	for i, port := range ports {
+		if !port.closed {
+			t.Errorf("port %d not closed", i)
+		}
+	}
+	if _, err := client.GetDeviceID(); err == nil {
+		t.Error("expect error when exchanging on the closed interface")
+	}
+}
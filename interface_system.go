@@ -1,6 +1,7 @@
 package ipmi
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"os"
@@ -10,19 +11,46 @@ import (
 )
 
 type openipmi struct {
-	myAddr         uint8
-	msgID          int64
-	targetAddr     uint8
-	targetChannel  uint8
-	targetIPMBAddr uint8
-	transitAddr    uint8
-	transitLUN     uint8
-
-	file *os.File // /dev/ipmi0
+	myAddr uint8
+
+	dev open.Device // /dev/ipmi0
+	// mux receives all the messages from dev, and matches the responses to the requests by MsgID.
+	mux *open.Mux
+
+	// events is created by the first call of Events, and closed with mux.
+	events chan *EventMessage
 }
 
 // ConnectOpen try to initialize the client by open the device of linux ipmi driver.
 func (c *Client) ConnectOpen(devnum int32) error {
+	return c.ConnectOpenContext(context.Background(), devnum)
+}
+
+// ConnectOpenContext is like ConnectOpen but takes a context.
+func (c *Client) ConnectOpenContext(ctx context.Context, devnum int32) error {
+	if c.openipmi.dev == nil {
+		dev, err := c.openDevice(devnum)
+		if err != nil {
+			return err
+		}
+		// set opened ipmi dev file
+		c.lock()
+		c.openipmi.dev = dev
+		c.unlock()
+	}
+
+	var receiveEvents uint32 = 1
+	if err := c.openipmi.dev.Ioctl(open.IPMICTL_SET_GETS_EVENTS_CMD, unsafe.Pointer(&receiveEvents)); err != nil {
+		return fmt.Errorf("ioctl failed, cloud not enable event receiver, err: %w", err)
+	}
+
+	// start receiving the messages, so the events are buffered for Events
+	c.openMux()
+
+	return nil
+}
+
+func (c *Client) openDevice(devnum int32) (open.Device, error) {
 	c.Debugf("Using ipmi device %d\n", devnum)
 
 	// try the following devices
@@ -49,102 +77,163 @@ func (c *Client) ConnectOpen(devnum int32) error {
 	tryOpenFile(ipmiDev3)
 
 	if file == nil {
-		return fmt.Errorf("ipmi dev file not opened")
+		return nil, fmt.Errorf("ipmi dev file not opened")
 	}
 
 	c.Debugf("opened ipmi dev file: %v, descriptor is: %d\n", file, file.Fd())
-	// set opened ipmi dev file
-	c.openipmi.file = file
+	return open.NewDeviceFile(file), nil
+}
 
-	var receiveEvents uint32 = 1
-	if err := open.IOCTL(c.openipmi.file.Fd(), open.IPMICTL_SET_GETS_EVENTS_CMD, uintptr(unsafe.Pointer(&receiveEvents))); err != nil {
-		return fmt.Errorf("ioctl failed, cloud not enable event receiver, err: %s", err)
-	}
+// openMux returns the mux of the ipmi device, it's started if not yet.
+func (c *Client) openMux() *open.Mux {
+	c.lock()
+	defer c.unlock()
 
-	return nil
+	if c.openipmi.mux == nil {
+		c.openipmi.mux = open.NewMux(c.openipmi.dev, DefaultEventsBufferSize)
+	}
+	return c.openipmi.mux
 }
 
-// closeOpen closes the ipmi dev file.
+// closeOpen closes the ipmi dev file, the client can be connected again later.
 func (c *Client) closeOpen() error {
-	if err := c.openipmi.file.Close(); err != nil {
-		return fmt.Errorf("close open file failed, err: %s", err)
+	c.lock()
+	dev, mux := c.openipmi.dev, c.openipmi.mux
+	c.openipmi.dev = nil
+	c.openipmi.mux = nil
+	c.openipmi.events = nil
+	c.unlock()
+
+	if mux != nil {
+		_ = mux.Close()
+	}
+	if dev == nil {
+		return nil
+	}
+
+	if err := dev.Close(); err != nil {
+		return fmt.Errorf("close open file failed, err: %w", err)
 	}
 	return nil
 }
 
-func (c *Client) exchangeOpen(request Request, response Response) error {
-	if c.openipmi.targetAddr != 0 && c.openipmi.targetAddr != c.openipmi.myAddr {
-
-	} else {
+func (c *Client) exchangeOpen(ctx context.Context, request Request, response Response) error {
+	var addr open.Address
+	switch {
+	case c.target != nil && c.transit != nil:
+		c.Debugf("\nSending request [%s] (%#02x) to target (%#02x) on channel (%d) through transit (%#02x) on channel (%d)\n",
+			request.Command().Name, request.Command().ID, c.target.slaveAddr, c.target.channel, c.transit.slaveAddr, c.transit.channel)
+		addr = open.NewIPMBAddr(uint16(c.transit.channel), c.transit.slaveAddr, 0)
+	case c.target != nil:
+		c.Debugf("\nSending request [%s] (%#02x) to target (%#02x) on channel (%d)\n",
+			request.Command().Name, request.Command().ID, c.target.slaveAddr, c.target.channel)
+		addr = open.NewIPMBAddr(uint16(c.target.channel), c.target.slaveAddr, c.target.lun)
+	default:
 		// otherwise use system interface
 		c.Debugf("\nSending request [%s] (%#02x) to System Interface\n", request.Command().Name, request.Command().ID)
+		addr = open.NewSystemInterfaceAddr(0)
 	}
 
-	recv, err := c.openSendRequest(request)
+	recv, inner, err := c.openSendRequest(ctx, addr, request)
 	if err != nil {
-		return fmt.Errorf("openSendRequest failed, err: %s", err)
+		if isTimeoutError(err) && ctx.Err() == nil {
+			return fmt.Errorf("%w: openSendRequest failed, err: %w", ErrTimeout, err)
+		}
+		return fmt.Errorf("openSendRequest failed, err: %w", err)
 	}
 
 	c.DebugBytes("recv data", recv, 16)
 	c.Debugf("\n\n")
 
-	// recv[0] is cc
-	if len(recv) < 1 {
-		return fmt.Errorf("recv data at least contains one completion code byte")
-	}
-
-	ccode := recv[0]
-	if ccode != 0x00 {
-		return &ResponseError{
-			completionCode: CompletionCode(ccode),
-			description:    fmt.Sprintf("ipmiRes CompletionCode (%#02x) is not normal: %s", ccode, StrCC(response, ccode)),
+	if c.target != nil && c.transit != nil {
+		recv, err = c.unwrapOpenTransit(recv, inner)
+		if err != nil {
+			return err
 		}
 	}
 
-	var unpackData = []byte{}
-	if len(recv) > 1 {
-		unpackData = recv[1:]
-	}
-
-	if err := response.Unpack(unpackData); err != nil {
-		return &ResponseError{
-			completionCode: CompletionCode(recv[0]),
-			description:    fmt.Sprintf("unpack response failed, err: %s", err),
-		}
+	// recv[0] is cc
+	if err := UnpackResponse(recv, response); err != nil {
+		return err
 	}
 
 	c.Debug("<< Command Response", response)
 	return nil
 }
 
-func (c *Client) openSendRequest(request Request) ([]byte, error) {
+// openSendRequest sends the request to the address and returns the received data.
+// If the request is bridged through the transit controller, the encapsulated request is also returned.
+func (c *Client) openSendRequest(ctx context.Context, addr open.Address, request Request) ([]byte, *IPMIRequest, error) {
+	netFn := uint8(request.Command().NetFn)
+	cmd := uint8(request.Command().ID)
+	cmdData := request.Pack()
 
-	var dataPtr *byte
+	var inner *IPMIRequest
+	if c.target != nil && c.transit != nil {
+		// The driver sends the request to the transit controller, which bridges the
+		// encapsulated request to the target and embeds its reply in the reply of Send Message.
+		inner = &IPMIRequest{
+			ResponderAddr:     c.target.slaveAddr,
+			NetFn:             request.Command().NetFn,
+			ResponderLUN:      c.target.lun,
+			RequesterAddr:     c.openipmi.myAddr,
+			RequesterSequence: uint8(rand.Intn(int(IPMIRequesterSequenceMax))) + 1,
+			Command:           request.Command().ID,
+			CommandData:       cmdData,
+		}
+		inner.ComputeChecksum()
+		sendMessage := &SendMessageRequest{
+			TrackMask:     0x01,
+			ChannelNumber: c.target.channel,
+			MessageData:   inner.Pack(),
+		}
 
-	cmdData := request.Pack()
+		netFn = uint8(NetFnAppRequest)
+		cmd = uint8(CommandSendMessage.ID)
+		cmdData = sendMessage.Pack()
+	}
+
+	var dataPtr *byte
 	if len(cmdData) > 0 {
 		dataPtr = &cmdData[0]
 	}
 
-	msg := &open.IPMI_MSG{
-		NetFn:   uint8(request.Command().NetFn),
-		Cmd:     uint8(request.Command().ID),
+	msg := open.IPMI_MSG{
+		NetFn:   netFn,
+		Cmd:     cmd,
 		Data:    dataPtr,
 		DataLen: uint16(len(cmdData)),
 	}
 
-	addr := &open.IPMI_SYSTEM_INTERFACE_ADDR{
-		AddrType: open.IPMI_SYSTEM_INTERFACE_ADDR_TYPE,
-		Channel:  open.IPMI_BMC_CHANNEL,
+	c.Debug("IPMI_MSG", msg)
+	recv, err := c.openMux().SendRequest(ctx, addr, msg, c.timeout)
+	return recv, inner, err
+}
+
+// unwrapOpenTransit returns the completion code and data of the reply of the bridged request
+// embedded in the reply of Send Message from the transit controller.
+// The embedded reply must match the Requester Sequence number and the command of the inner request.
+func (c *Client) unwrapOpenTransit(recv []byte, inner *IPMIRequest) ([]byte, error) {
+	if len(recv) < 1 {
+		return nil, ErrUnpackedDataTooShortWith(len(recv), 1)
+	}
+	if ccode := recv[0]; ccode != 0x00 {
+		return nil, &ResponseError{
+			completionCode: CompletionCode(ccode),
+			description:    fmt.Sprintf("Send Message CompletionCode (%#02x) is not normal: %s", ccode, StrCC(&SendMessageResponse{}, ccode)),
+		}
+	}
+
+	embedded := &IPMIResponse{}
+	if err := embedded.Unpack(recv[1:]); err != nil {
+		return nil, fmt.Errorf("unpack embedded ipmiRes failed, err: %w", err)
 	}
+	c.Debug("<<<< Embedded IPMI Response", embedded)
 
-	req := &open.IPMI_REQ{
-		Addr:    addr,
-		AddrLen: int(unsafe.Sizeof(addr)),
-		MsgID:   rand.Int63(),
-		Msg:     *msg,
+	if embedded.RequesterSequence != inner.RequesterSequence || embedded.Command != inner.Command {
+		return nil, fmt.Errorf("the embedded response (rqSeq %d, cmd %#02x) does not match the bridged request (rqSeq %d, cmd %#02x)",
+			embedded.RequesterSequence, embedded.Command, inner.RequesterSequence, inner.Command)
 	}
 
-	c.Debug("IPMI_REQ", req)
-	return open.SendCommand(c.openipmi.file, req, c.timeout)
+	return append([]byte{embedded.CompletionCode}, embedded.Data...), nil
 }
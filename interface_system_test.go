@@ -0,0 +1,275 @@
+package ipmi
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/bougou/go-ipmi/open"
+)
+
+// fakeDevice replies the requests by the handler, and delivers
+// the queued messages (like events) before the reply.
+type fakeDevice struct {
+	handler func(addr *open.IPMI_ADDR, netFn uint8, cmd uint8, data []byte) []byte
+
+	once  sync.Once
+	queue chan *fakeMsg
+
+	mu    sync.Mutex
+	reqs  []*open.IPMI_ADDR
+	ioctl []uintptr
+}
+
+type fakeMsg struct {
+	recvType int
+	addr     *open.IPMI_ADDR
+	msgID    int64
+	netFn    uint8
+	cmd      uint8
+	data     []byte
+}
+
+func (d *fakeDevice) push(msg *fakeMsg) {
+	d.once.Do(func() {
+		d.queue = make(chan *fakeMsg, 16)
+	})
+	d.queue <- msg
+}
+
+func (d *fakeDevice) requests() []*open.IPMI_ADDR {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]*open.IPMI_ADDR{}, d.reqs...)
+}
+
+func (d *fakeDevice) Send(req *open.IPMI_REQ) error {
+	var data []byte
+	if req.Msg.DataLen > 0 {
+		data = append(data, unsafe.Slice(req.Msg.Data, req.Msg.DataLen)...)
+	}
+	addr := *req.IPMIAddr()
+	d.mu.Lock()
+	d.reqs = append(d.reqs, &addr)
+	d.mu.Unlock()
+
+	if req.Msg.NetFn&0x01 == 1 {
+		// response to a received command
+		d.push(&fakeMsg{
+			recvType: open.IPMI_RESPONSE_RESPONSE_TYPE,
+			addr:     &addr,
+			msgID:    req.MsgID,
+			data:     []byte{0x00},
+		})
+		return nil
+	}
+
+	d.push(&fakeMsg{
+		recvType: open.IPMI_RESPONSE_RECV_TYPE,
+		addr:     &addr,
+		msgID:    req.MsgID,
+		netFn:    req.Msg.NetFn + 1,
+		cmd:      req.Msg.Cmd,
+		data:     d.handler(&addr, req.Msg.NetFn, req.Msg.Cmd, data),
+	})
+	return nil
+}
+
+func (d *fakeDevice) Receive(ctx context.Context, recv *open.IPMI_RECV, deadline time.Time) error {
+	d.once.Do(func() {
+		d.queue = make(chan *fakeMsg, 16)
+	})
+
+	var msg *fakeMsg
+	select {
+	case msg = <-d.queue:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	recv.RecvType = msg.recvType
+	*(*open.IPMI_ADDR)(unsafe.Pointer(recv.Addr)) = *msg.addr
+	recv.MsgID = msg.msgID
+	recv.Msg.NetFn = msg.netFn
+	recv.Msg.Cmd = msg.cmd
+	recv.Msg.DataLen = uint16(copy(unsafe.Slice(recv.Msg.Data, open.IPMI_BUF_SIZE), msg.data))
+	return nil
+}
+
+func (d *fakeDevice) Ioctl(op uintptr, arg unsafe.Pointer) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.ioctl = append(d.ioctl, op)
+	return nil
+}
+
+func (d *fakeDevice) Close() error {
+	return nil
+}
+
+func Test_exchangeOpen_IPMB(t *testing.T) {
+	deviceID := []byte{0x00, 0x2c, 0x81, 0x01, 0x10, 0x02, 0xbf, 0x57, 0x01, 0x00, 0x34, 0x12}
+
+	dev := &fakeDevice{
+		handler: func(addr *open.IPMI_ADDR, netFn uint8, cmd uint8, data []byte) []byte {
+			if addr.AddrType == open.IPMI_IPMB_ADDR_TYPE && addr.IPMBAddr().SlaveAddr == 0x2c && cmd == CommandGetDeviceID.ID {
+				return deviceID
+			}
+			return []byte{uint8(CompletionCodeInvalidCommand)}
+		},
+	}
+	// an event received before the response is not taken as the response
+	dev.push(&fakeMsg{
+		recvType: open.IPMI_ASYNC_EVENT_RECV_TYPE,
+		addr:     open.NewSystemInterfaceAddr(0).IPMIAddr(),
+		data:     make([]byte, 16),
+	})
+
+	client, err := NewOpenClientWithDevice(dev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Connect(); err != nil {
+		t.Fatal(err)
+	}
+
+	client.WithTarget(0, 0x2c, 0)
+	res, err := client.GetDeviceID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.DeviceID != 0x2c || res.ManufacturerID != 0x157 || res.ProductID != 0x1234 {
+		t.Errorf("device id response not match, got: %+v", res)
+	}
+	reqs := dev.requests()
+	if ipmb := reqs[0].IPMBAddr(); reqs[0].AddrType != open.IPMI_IPMB_ADDR_TYPE || ipmb.SlaveAddr != 0x2c || ipmb.Channel != 0 {
+		t.Errorf("request addr not match, got: %+v", reqs[0])
+	}
+
+	// not bridged
+	client.WithTarget(0, 0, 0)
+	if _, err := client.GetDeviceID(); err == nil {
+		t.Error("expect error for request to the BMC")
+	}
+	if reqs := dev.requests(); reqs[1].AddrType != open.IPMI_SYSTEM_INTERFACE_ADDR_TYPE {
+		t.Errorf("request addr not match, got: %+v", reqs[1])
+	}
+}
+
+func Test_exchangeOpen_Transit(t *testing.T) {
+	// staleSeq makes the transit embed the reply of another request
+	var staleSeq bool
+	dev := &fakeDevice{
+		handler: func(addr *open.IPMI_ADDR, netFn uint8, cmd uint8, data []byte) []byte {
+			if addr.IPMBAddr().SlaveAddr != 0x82 || cmd != CommandSendMessage.ID || data[0] != 0x46 {
+				return []byte{uint8(CompletionCodeInvalidCommand)}
+			}
+			// reply of Send Message from the transit, embedding the reply of the target
+			inner := append(make([]byte, 14), data[1:]...)
+			rqSeq := data[1+4] >> 2
+			if staleSeq {
+				rqSeq = rqSeq%uint8(IPMIRequesterSequenceMax) + 1
+			}
+			innerRes := buildLAN15Response(inner, rqSeq, []byte{0x2c, 0x81, 0x01, 0x10, 0x02, 0xbf, 0x57, 0x01, 0x00, 0x34, 0x12})
+			return append([]byte{0x00}, innerRes[14:]...)
+		},
+	}
+
+	client, err := NewOpenClientWithDevice(dev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.WithTarget(6, 0x2c, 0).WithTransit(7, 0x82)
+
+	res, err := client.GetDeviceID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.DeviceID != 0x2c || res.ProductID != 0x1234 {
+		t.Errorf("device id response not match, got: %+v", res)
+	}
+	if ipmb := dev.requests()[0].IPMBAddr(); ipmb.Channel != 7 || ipmb.SlaveAddr != 0x82 {
+		t.Errorf("request addr not match, got: %+v", ipmb)
+	}
+
+	staleSeq = true
+	if _, err := client.GetDeviceID(); err == nil {
+		t.Error("expect the embedded response of another request rejected")
+	}
+}
+
+func Test_Events(t *testing.T) {
+	dev := &fakeDevice{
+		handler: func(addr *open.IPMI_ADDR, netFn uint8, cmd uint8, data []byte) []byte {
+			return []byte{0x00}
+		},
+	}
+
+	client, err := NewOpenClientWithDevice(dev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	events, err := client.Events()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.RegisterForCommand(NetFnAppRequest, CommandGetDeviceID.ID); err != nil {
+		t.Fatal(err)
+	}
+	if ops := dev.ioctl; ops[len(ops)-1] != open.IPMICTL_REGISTER_FOR_CMD {
+		t.Errorf("register for command ioctl not called, got: %v", ops)
+	}
+
+	// Platform Event: temperature sensor 0x30, upper critical going high asserted
+	dev.push(&fakeMsg{
+		recvType: open.IPMI_ASYNC_EVENT_RECV_TYPE,
+		addr:     open.NewSystemInterfaceAddr(0).IPMIAddr(),
+		data:     []byte{0x01, 0x00, 0x02, 0x00, 0x00, 0x00, 0x00, 0x20, 0x00, 0x04, 0x01, 0x30, 0x01, 0x09, 0xff, 0xff},
+	})
+	dev.push(&fakeMsg{
+		recvType: open.IPMI_CMD_RECV_TYPE,
+		addr:     open.NewIPMBAddr(0, 0x82, 0).IPMIAddr(),
+		msgID:    7,
+		netFn:    uint8(NetFnAppRequest),
+		cmd:      CommandGetDeviceID.ID,
+	})
+
+	timeout := time.After(5 * time.Second)
+	recv := func() *EventMessage {
+		select {
+		case ev := <-events:
+			return ev
+		case <-timeout:
+			t.Fatal("no event received")
+		}
+		return nil
+	}
+
+	ev := recv()
+	if ev.Kind != EventKindEvent || ev.SEL == nil || ev.SEL.Standard == nil {
+		t.Fatalf("event not parsed, got: %+v", ev)
+	}
+	if ev.SEL.Standard.SensorType != SensorTypeTemperature || ev.SEL.Standard.SensorNumber != 0x30 {
+		t.Errorf("event sensor not match, got: %+v", ev.SEL.Standard)
+	}
+
+	ev = recv()
+	if ev.Kind != EventKindCommand || ev.SourceAddr != 0x82 || ev.Command != CommandGetDeviceID.ID {
+		t.Fatalf("command not match, got: %+v", ev)
+	}
+	if err := client.RespondCommand(context.Background(), ev, CompletionCodeNormal, []byte{0x20}); err != nil {
+		t.Fatal(err)
+	}
+
+	// requests are still matched by MsgID while events are consumed
+	if _, err := client.GetDeviceID(); err == nil {
+		t.Error("expect error for short response")
+	}
+}
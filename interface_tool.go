@@ -2,8 +2,11 @@ package ipmi
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"fmt"
+	"math"
+	"os"
 	"os/exec"
 	"regexp"
 	"strconv"
@@ -11,11 +14,27 @@ import (
 )
 
 var (
-	toolError = regexp.MustCompile(`^Unable to send RAW command \(channel=0x(?P<channel>[0-9a-fA-F]+) netfn=0x(?P<netfn>[0-9a-fA-F]+) lun=0x(?P<lun>[0-9a-fA-F]+) cmd=0x(?P<cmd>[0-9a-fA-F]+) rsp=0x(?P<rsp>[0-9a-fA-F]+)\): (?P<message>.*)`)
+	// toolError matches the error line of ipmitool raw command, it might be preceded by
+	// the verbose logs (ipmitool -v) or followed by the session close messages.
+	toolError = regexp.MustCompile(`(?m)^Unable to send RAW command \(channel=0x(?P<channel>[0-9a-fA-F]+) netfn=0x(?P<netfn>[0-9a-fA-F]+) lun=0x(?P<lun>[0-9a-fA-F]+) cmd=0x(?P<cmd>[0-9a-fA-F]+)(?: rsp=0x(?P<rsp>[0-9a-fA-F]+))?\)(?:: (?P<message>.*))?`)
+
+	// toolRawLine matches the lines of the response data printed by ipmitool raw command.
+	toolRawLine = regexp.MustCompile(`^\s*[0-9a-fA-F]{2}(\s+[0-9a-fA-F]{2})*\s*$`)
 )
 
+type toolipmi struct {
+	// path is the file path of ipmitool binary or a wrapper script,
+	// empty for ipmitool in PATH.
+	path string
+}
+
 // ConnectTool try to initialize the client.
 func (c *Client) ConnectTool(devnum int32) error {
+	return c.ConnectToolContext(context.Background(), devnum)
+}
+
+// ConnectToolContext is like ConnectTool but takes a context.
+func (c *Client) ConnectToolContext(ctx context.Context, devnum int32) error {
 	return nil
 }
 
@@ -24,62 +43,172 @@ func (c *Client) closeTool() error {
 	return nil
 }
 
-func (c *Client) exchangeTool(request Request, response Response) error {
+// toolPath returns the file path of ipmitool.
+//
+// Host was used as the file path of ipmitool before the tool interface could talk to
+// a remote BMC, it's still honoured if Username is not set. It's an error if Host and
+// the path passed to NewToolClient are both set and differ.
+func (c *Client) toolPath() (string, error) {
+	path := ""
+	if c.toolipmi != nil {
+		path = c.toolipmi.path
+	}
+	if c.Host != "" && !c.toolRemote() {
+		if path != "" && path != c.Host {
+			return "", fmt.Errorf("ambiguous ipmitool path, got (%s) by NewToolClient and (%s) by Host, set Username to talk to the remote BMC", path, c.Host)
+		}
+		path = c.Host
+	}
+	if path == "" {
+		path = "ipmitool"
+	}
+	return path, nil
+}
+
+// toolRemote reports whether the ipmitool talks to a remote BMC.
+func (c *Client) toolRemote() bool {
+	return c.toolipmi != nil && c.Host != "" && c.Username != ""
+}
+
+// toolArgs returns the ipmitool options (before the ipmitool command) from the client settings.
+func (c *Client) toolArgs() []string {
+	var args []string
+
+	if c.debug {
+		args = append(args, "-v")
+	}
+
+	if c.toolRemote() {
+		args = append(args, "-I", "lanplus", "-H", c.Host, "-U", c.Username, "-E")
+		if c.Port != 0 && c.Port != 623 {
+			args = append(args, "-p", strconv.Itoa(c.Port))
+		}
+		if c.session != nil {
+			if c.session.v20.cipherSuiteID != CipherSuiteIDReserved {
+				args = append(args, "-C", strconv.Itoa(int(c.session.v20.cipherSuiteID)))
+			}
+			if len(c.session.v20.bmcKey) != 0 {
+				args = append(args, "-y", hex.EncodeToString(c.session.v20.bmcKey))
+			}
+		}
+		if c.maxPrivilegeLevel != PrivilegeLevelUnspecified {
+			args = append(args, "-L", c.maxPrivilegeLevel.String())
+		}
+		if c.retries > 0 {
+			args = append(args, "-R", strconv.Itoa(c.retries))
+		}
+		if c.attemptTimeout > 0 {
+			args = append(args, "-N", strconv.Itoa(int(math.Ceil(c.attemptTimeout.Seconds()))))
+		}
+	}
+
+	if c.target != nil {
+		args = append(args, "-b", strconv.Itoa(int(c.target.channel)), "-t", fmt.Sprintf("0x%02x", c.target.slaveAddr))
+		if c.target.lun != 0 {
+			args = append(args, "-l", strconv.Itoa(int(c.target.lun)))
+		}
+		if c.transit != nil {
+			args = append(args, "-B", strconv.Itoa(int(c.transit.channel)), "-T", fmt.Sprintf("0x%02x", c.transit.slaveAddr))
+		}
+	}
+
+	return args
+}
+
+func (c *Client) exchangeTool(ctx context.Context, request Request, response Response) error {
 	data := request.Pack()
 	msg := make([]byte, 2+len(data))
 	msg[0] = uint8(request.Command().NetFn)
 	msg[1] = uint8(request.Command().ID)
 	copy(msg[2:], data)
 
-	args := append([]string{"raw"}, rawEncode(msg)...)
+	args := c.toolArgs()
+	args = append(args, "raw")
+	args = append(args, rawEncode(msg)...)
 
-	path := c.Host
-	if path == "" {
-		path = "ipmitool"
+	path, err := c.toolPath()
+	if err != nil {
+		return err
 	}
 
-	cmd := exec.Command(path, args...)
+	// the ipmitool process is killed if ctx is done before it exits
+	cmd := exec.CommandContext(ctx, path, args...)
+	if c.toolRemote() {
+		// used by ipmitool -E option
+		cmd.Env = append(os.Environ(), "IPMI_PASSWORD="+c.Password)
+	}
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
 	c.Debugf(">>> Run cmd: \n>>> %s\n", cmd.String())
-	err := cmd.Run()
+	err = cmd.Run()
+	if stderr.Len() > 0 {
+		c.Debugf("<<< ipmitool stderr: \n%s\n", stderr.String())
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return fmt.Errorf("ipmitool run aborted, err: %w", ctxErr)
+	}
 	if err != nil {
-		if bytes.HasPrefix(stderr.Bytes(), []byte("Unable to send RAW command")) {
-			submatches := toolError.FindSubmatch(stderr.Bytes())
-			if len(submatches) == 7 && len(submatches[5]) == 2 {
-				code, err := strconv.ParseUint(string(submatches[5]), 16, 0)
-				if err != nil {
-					return fmt.Errorf("CompletionCode parse failed, err: %s", err)
-				}
-				return &ResponseError{
-					completionCode: CompletionCode(uint8(code)),
-					description:    fmt.Sprintf("Raw command failed, err: %s", string(submatches[6])),
-				}
-			}
+		if respErr := parseToolError(stderr.Bytes()); respErr != nil {
+			return respErr
 		}
-		return fmt.Errorf("ipmitool run failed, err: %s", err)
+		return fmt.Errorf("ipmitool run failed, err: %s, stderr: %s", err, lastLine(stderr.String()))
 	}
 
-	output := stdout.String()
-	resp, err := rawDecode(strings.TrimSpace(output))
+	resp, err := parseToolOutput(stdout.String())
 	if err != nil {
-		return fmt.Errorf("decode response failed, err: %s", err)
+		return fmt.Errorf("decode response failed, err: %w", err)
 	}
 	if err := response.Unpack(resp); err != nil {
-		return fmt.Errorf("unpack response failed, err: %s", err)
+		return fmt.Errorf("unpack response failed, err: %w", err)
 	}
 
 	return nil
 }
 
+// parseToolError returns the ResponseError if the ipmitool raw command got a
+// non-zero completion code, otherwise nil.
+func parseToolError(stderr []byte) *ResponseError {
+	submatches := toolError.FindSubmatch(stderr)
+	if len(submatches) != 7 || len(submatches[5]) != 2 {
+		return nil
+	}
+	code, err := strconv.ParseUint(string(submatches[5]), 16, 8)
+	if err != nil {
+		return nil
+	}
+	return &ResponseError{
+		completionCode: CompletionCode(uint8(code)),
+		description:    fmt.Sprintf("Raw command failed, err: %s", strings.TrimSpace(string(submatches[6]))),
+	}
+}
+
+// parseToolOutput decodes the response data printed by ipmitool raw command.
+// The lines not of hex bytes (like the verbose logs of ipmitool -v) are ignored.
+func parseToolOutput(output string) ([]byte, error) {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if toolRawLine.MatchString(line) {
+			lines = append(lines, strings.TrimSpace(line))
+		}
+	}
+	return rawDecode(strings.Join(lines, " "))
+}
+
+func lastLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		return s[i+1:]
+	}
+	return s
+}
+
 func rawDecode(data string) ([]byte, error) {
 	var buf bytes.Buffer
 
-	data = strings.ReplaceAll(data, "\n", "")
-	for _, s := range strings.Split(data, " ") {
+	for _, s := range strings.Fields(data) {
 		b, err := hex.DecodeString(s)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,206 @@
+package ipmi
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_parseToolOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		expect []byte
+	}{
+		{
+			name:   "plain",
+			output: " 00 01 02\n",
+			expect: []byte{0x00, 0x01, 0x02},
+		},
+		{
+			name:   "multiple lines",
+			output: " 51 00 00 01 02 03 04 05 06 07 08 09 0a 0b 0c 0d\n 0e 0f\n",
+			expect: []byte{0x51, 0x00, 0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f},
+		},
+		{
+			name:   "verbose",
+			output: "Running Get PICMG Properties my_addr 0x20, transit 0, target 0x20\nRAW REQ (channel=0x0 netfn=0x6 lun=0x0 cmd=0x1 data_len=0)\nRAW RSP (2 bytes)\n 20 81\n",
+			expect: []byte{0x20, 0x81},
+		},
+		{
+			name:   "empty",
+			output: "\n",
+			expect: []byte{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseToolOutput(tt.output)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, tt.expect) {
+				t.Errorf("not match, expect: %v, got: %v", tt.expect, got)
+			}
+		})
+	}
+}
+
+func Test_parseToolError(t *testing.T) {
+	stderr := "Get Auth Capabilities command failed\n" +
+		">> Sending IPMI command payload\n" +
+		"Unable to send RAW command (channel=0x0 netfn=0xa lun=0x0 cmd=0x43 rsp=0xcb): Requested sensor, data, or record not found\n" +
+		"Close Session command failed\n"
+
+	respErr := parseToolError([]byte(stderr))
+	if respErr == nil {
+		t.Fatal("expect ResponseError, got nil")
+	}
+	if respErr.CompletionCode() != CompletionCode(0xcb) {
+		t.Errorf("completion code not match, expect: 0xcb, got: %#02x", respErr.CompletionCode())
+	}
+
+	// no response at all is not a completion code error
+	if respErr := parseToolError([]byte("Unable to send RAW command (channel=0x0 netfn=0x6 lun=0x0 cmd=0x1)\n")); respErr != nil {
+		t.Errorf("expect nil, got: %v", respErr)
+	}
+}
+
+func Test_NewToolLanplusClient_Args(t *testing.T) {
+	client, err := NewToolLanplusClient("", "10.0.0.1", 1623, "admin", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.WithCipherSuiteID(CipherSuiteID17).
+		WithMaxPrivilegeLevel(PrivilegeLevelOperator).
+		WithRetries(2).
+		WithAttemptTimeout(1500*time.Millisecond).
+		WithTarget(7, 0x72, 0).
+		WithTransit(0, 0x82)
+
+	expect := []string{
+		"-I", "lanplus", "-H", "10.0.0.1", "-U", "admin", "-E", "-p", "1623",
+		"-C", "17", "-L", "OPERATOR", "-R", "2", "-N", "2",
+		"-b", "7", "-t", "0x72", "-B", "0", "-T", "0x82",
+	}
+	if got := client.toolArgs(); !reflect.DeepEqual(got, expect) {
+		t.Errorf("args not match\nexpect: %v\ngot:    %v", expect, got)
+	}
+	if path, err := client.toolPath(); err != nil || path != "ipmitool" {
+		t.Errorf("path not match, expect: ipmitool, got: %s, err: %v", path, err)
+	}
+
+	local, _ := NewToolClient("/usr/bin/ipmitool")
+	if got := local.toolArgs(); len(got) != 0 {
+		t.Errorf("local client expect no args, got: %v", got)
+	}
+}
+
+func Test_toolPath(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		host      string
+		user      string
+		expect    string
+		expectErr bool
+	}{
+		{name: "default", expect: "ipmitool"},
+		{name: "path", path: "/usr/bin/ipmitool", expect: "/usr/bin/ipmitool"},
+		{name: "legacy host", host: "/usr/local/bin/ipmitool", expect: "/usr/local/bin/ipmitool"},
+		{name: "same path and host", path: "/usr/bin/ipmitool", host: "/usr/bin/ipmitool", expect: "/usr/bin/ipmitool"},
+		{name: "ambiguous", path: "/usr/bin/ipmitool", host: "/usr/local/bin/ipmitool", expectErr: true},
+		{name: "remote", path: "/usr/bin/ipmitool", host: "10.0.0.1", user: "admin", expect: "/usr/bin/ipmitool"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewToolClient(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			client.Host = tt.host
+			client.Username = tt.user
+
+			path, err := client.toolPath()
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("expect error, got path: %s", path)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if path != tt.expect {
+				t.Errorf("path not match, expect: %s, got: %s", tt.expect, path)
+			}
+		})
+	}
+}
+
+func Test_exchangeTool(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script not supported")
+	}
+
+	// the fake ipmitool records its arguments and password,
+	// then fails the Get SEL Info command, and replies the others.
+	dir := t.TempDir()
+	argsFile := filepath.Join(dir, "args")
+	script := `#!/bin/sh
+echo "$@" > ` + argsFile + `
+echo "$IPMI_PASSWORD" >> ` + argsFile + `
+case "$*" in
+*"raw 0x0a 0x40"*)
+  echo ">> Sending IPMI command payload" >&2
+  echo "Unable to send RAW command (channel=0x0 netfn=0xa lun=0x0 cmd=0x40 rsp=0xc1): Invalid command" >&2
+  exit 1
+  ;;
+esac
+echo "RAW RSP (12 bytes)"
+echo " 20 81 01 10 02 bf 57 01 00 34 12"
+`
+	path := filepath.Join(dir, "ipmitool")
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := NewToolLanplusClient(path, "bmc.example.com", 0, "admin", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.GetDeviceID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.ManufacturerID != 0x157 || res.ProductID != 0x1234 {
+		t.Errorf("device id response not match, got: %+v", res)
+	}
+
+	recorded, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(recorded)), "\n")
+	if len(lines) != 2 || lines[0] != "-I lanplus -H bmc.example.com -U admin -E raw 0x06 0x01" || lines[1] != "secret" {
+		t.Errorf("ipmitool invocation not match, got: %q", lines)
+	}
+	if strings.Contains(lines[0], "secret") {
+		t.Error("password passed by arguments")
+	}
+
+	_, err = client.GetSELInfo()
+	var respErr *ResponseError
+	if !errors.As(err, &respErr) || respErr.CompletionCode() != CompletionCodeInvalidCommand {
+		t.Errorf("expect ResponseError with completion code %#02x, got: %v", CompletionCodeInvalidCommand, err)
+	}
+}
@@ -0,0 +1,462 @@
+package ipmi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultManagerMaxSessionsPerHost is the default number of sessions opened to one BMC at the same time.
+	// BMCs only support a few concurrent sessions (4 is a common value), so the default
+	// leaves the remaining session slots for other consoles.
+	DefaultManagerMaxSessionsPerHost int = 2
+
+	// DefaultManagerIdleTimeoutSec is the default seconds after which an unused session is closed.
+	// It's less than the common session inactivity timeout (60s) of BMCs.
+	DefaultManagerIdleTimeoutSec int = 30
+
+	// DefaultManagerFanOutConcurrency is the default number of hosts a fan-out works on at the same time.
+	DefaultManagerFanOutConcurrency int = 64
+)
+
+// DialFunc creates a connected Client for host.
+// It is called by Manager when no idle session of the host is available.
+// If it returns an error, the Client returned together (if any) is closed.
+type DialFunc func(ctx context.Context, host string) (*Client, error)
+
+// Manager holds a bounded pool of authenticated sessions keyed by host,
+// so that many BMCs can be operated without creating a Client for each operation.
+//
+//	m := ipmi.NewManager(func(ctx context.Context, host string) (*ipmi.Client, error) {
+//		client, err := ipmi.NewClient(host, 623, "root", "123456")
+//		if err != nil {
+//			return nil, err
+//		}
+//		return client, client.ConnectContext(ctx)
+//	}).WithMaxSessions(1000)
+//	defer m.Close()
+//
+//	err := m.Run(ctx, hosts, func(ctx context.Context, host string, client *ipmi.Client) error {
+//		_, err := client.ChassisControlContext(ctx, ipmi.ChassisControlPowerUp)
+//		return err
+//	})
+type Manager struct {
+	dial DialFunc
+
+	// maxSessions is the number of sessions opened to all hosts at the same time, zero means no limit.
+	maxSessions        int
+	maxSessionsPerHost int
+	idleTimeout        time.Duration
+	fanOutConcurrency  int
+
+	mu    sync.Mutex
+	hosts map[string]*managedHost
+	// sessions is the number of opened (or being opened) sessions.
+	sessions int
+	// changed is closed and replaced each time a session is released or closed,
+	// to wake up the goroutines waiting for a session.
+	changed chan struct{}
+	closed  bool
+
+	janitorOnce sync.Once
+	closedCh    chan struct{}
+}
+
+type managedHost struct {
+	// slots limits the number of concurrent operations (and so the sessions) of the host.
+	slots chan struct{}
+	// idle holds the sessions not in use, the most recently used one is the last.
+	idle []*managedSession
+	// refs is the number of Do calls of the host in progress.
+	refs int
+}
+
+type managedSession struct {
+	host     string
+	client   *Client
+	lastUsed time.Time
+}
+
+// NewManager creates a Manager which creates sessions by dial.
+func NewManager(dial DialFunc) *Manager {
+	return &Manager{
+		dial:               dial,
+		maxSessionsPerHost: DefaultManagerMaxSessionsPerHost,
+		idleTimeout:        time.Second * time.Duration(DefaultManagerIdleTimeoutSec),
+		fanOutConcurrency:  DefaultManagerFanOutConcurrency,
+
+		hosts:    make(map[string]*managedHost),
+		changed:  make(chan struct{}),
+		closedCh: make(chan struct{}),
+	}
+}
+
+// WithMaxSessions limits the number of sessions opened to all hosts, zero means no limit.
+// When the limit is reached, the least recently used idle session is closed
+// to make room for a new one.
+func (m *Manager) WithMaxSessions(n int) *Manager {
+	m.maxSessions = n
+	return m
+}
+
+// WithMaxSessionsPerHost limits the number of concurrent operations, and so the sessions, of one host.
+// It should be less than the number of session slots supported by the BMC.
+// It must be called before the Manager is used.
+func (m *Manager) WithMaxSessionsPerHost(n int) *Manager {
+	if n < 1 {
+		n = 1
+	}
+	m.maxSessionsPerHost = n
+	return m
+}
+
+// WithIdleTimeout sets the duration after which an unused session is closed, zero disables idle expiry.
+func (m *Manager) WithIdleTimeout(timeout time.Duration) *Manager {
+	m.idleTimeout = timeout
+	return m
+}
+
+// WithFanOutConcurrency sets the number of hosts a fan-out works on at the same time.
+func (m *Manager) WithFanOutConcurrency(n int) *Manager {
+	if n < 1 {
+		n = 1
+	}
+	m.fanOutConcurrency = n
+	return m
+}
+
+// Do runs fn with a session of host.
+//
+// An idle session of host is reused if any, or else a new session is created by the DialFunc.
+// After fn returns, the session is put back to the pool, unless fn returns an error other than
+// *ResponseError, which means the session might be broken, then the session is closed.
+func (m *Manager) Do(ctx context.Context, host string, fn func(ctx context.Context, client *Client) error) error {
+	m.janitorOnce.Do(m.startJanitor)
+
+	h, err := m.host(host)
+	if err != nil {
+		return err
+	}
+	defer m.unref(host, h)
+
+	select {
+	case h.slots <- struct{}{}:
+	case <-ctx.Done():
+		return fmt.Errorf("wait session of host (%s) failed, err: %w", host, ctx.Err())
+	}
+	defer func() { <-h.slots }()
+
+	s, err := m.acquire(ctx, host)
+	if err != nil {
+		return err
+	}
+
+	err = fn(ctx, s.client)
+
+	var respErr *ResponseError
+	m.release(s, err != nil && !errors.As(err, &respErr))
+
+	return err
+}
+
+// Close closes all the idle sessions. The sessions in use are closed after they are released.
+func (m *Manager) Close() error {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return nil
+	}
+	m.closed = true
+	close(m.closedCh)
+
+	var idle []*managedSession
+	for _, h := range m.hosts {
+		idle = append(idle, h.idle...)
+		h.idle = nil
+	}
+	m.sessions -= len(idle)
+	m.notifyLocked()
+	m.mu.Unlock()
+
+	var errs []error
+	for _, s := range idle {
+		if err := s.client.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("close session of host (%s) failed, err: %w", s.host, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m *Manager) host(host string) (*managedHost, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return nil, ErrManagerClosed
+	}
+
+	h, ok := m.hosts[host]
+	if !ok {
+		h = &managedHost{
+			slots: make(chan struct{}, m.maxSessionsPerHost),
+		}
+		m.hosts[host] = h
+	}
+	h.refs++
+	return h, nil
+}
+
+// unref releases the reference of the host taken by host.
+func (m *Manager) unref(host string, h *managedHost) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	h.refs--
+	m.pruneHostLocked(host, h)
+}
+
+// pruneHostLocked removes the host if it has neither idle sessions nor operations in progress,
+// so the hosts operated once do not stay in the Manager forever.
+func (m *Manager) pruneHostLocked(host string, h *managedHost) {
+	if h.refs == 0 && len(h.idle) == 0 {
+		delete(m.hosts, host)
+	}
+}
+
+// acquire takes an idle session of host, or creates a new one.
+func (m *Manager) acquire(ctx context.Context, host string) (*managedSession, error) {
+	for {
+		m.mu.Lock()
+		if m.closed {
+			m.mu.Unlock()
+			return nil, ErrManagerClosed
+		}
+
+		h := m.hosts[host]
+		if n := len(h.idle); n > 0 {
+			s := h.idle[n-1]
+			h.idle = h.idle[:n-1]
+			m.mu.Unlock()
+			return s, nil
+		}
+
+		if m.maxSessions <= 0 || m.sessions < m.maxSessions {
+			m.sessions++
+			m.mu.Unlock()
+
+			client, err := m.dial(ctx, host)
+			if err != nil {
+				// the client might be returned together with the error of connecting it
+				if client != nil {
+					_ = client.Close()
+				}
+				m.mu.Lock()
+				m.sessions--
+				m.notifyLocked()
+				m.mu.Unlock()
+				return nil, fmt.Errorf("dial host (%s) failed, err: %w", host, err)
+			}
+			return &managedSession{host: host, client: client}, nil
+		}
+
+		// make room by closing the least recently used idle session of other hosts
+		if victim := m.removeOldestIdleLocked(); victim != nil {
+			m.sessions--
+			m.mu.Unlock()
+			_ = victim.client.CloseContext(ctx)
+			continue
+		}
+
+		changed := m.changed
+		m.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("wait session of host (%s) failed, err: %w", host, ctx.Err())
+		}
+	}
+}
+
+// release puts the session back to the pool, or closes it if discard is true.
+func (m *Manager) release(s *managedSession, discard bool) {
+	m.mu.Lock()
+	if discard || m.closed {
+		m.sessions--
+		m.notifyLocked()
+		m.mu.Unlock()
+		_ = s.client.Close()
+		return
+	}
+
+	s.lastUsed = time.Now()
+	h := m.hosts[s.host]
+	h.idle = append(h.idle, s)
+	m.notifyLocked()
+	m.mu.Unlock()
+}
+
+func (m *Manager) removeOldestIdleLocked() *managedSession {
+	var oldest *managedHost
+	for _, h := range m.hosts {
+		if len(h.idle) == 0 {
+			continue
+		}
+		if oldest == nil || h.idle[0].lastUsed.Before(oldest.idle[0].lastUsed) {
+			oldest = h
+		}
+	}
+	if oldest == nil {
+		return nil
+	}
+
+	s := oldest.idle[0]
+	oldest.idle = oldest.idle[1:]
+	return s
+}
+
+func (m *Manager) notifyLocked() {
+	close(m.changed)
+	m.changed = make(chan struct{})
+}
+
+func (m *Manager) startJanitor() {
+	if m.idleTimeout <= 0 {
+		return
+	}
+
+	interval := m.idleTimeout / 2
+	if interval < 10*time.Millisecond {
+		interval = 10 * time.Millisecond
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-m.closedCh:
+				return
+			case <-ticker.C:
+				m.closeExpired()
+			}
+		}
+	}()
+}
+
+// closeExpired closes the sessions which are idle longer than idleTimeout,
+// and removes the hosts left without sessions.
+func (m *Manager) closeExpired() {
+	deadline := time.Now().Add(-m.idleTimeout)
+
+	m.mu.Lock()
+	var expired []*managedSession
+	for host, h := range m.hosts {
+		// idle sessions are ordered by lastUsed
+		i := 0
+		for i < len(h.idle) && h.idle[i].lastUsed.Before(deadline) {
+			i++
+		}
+		expired = append(expired, h.idle[:i]...)
+		h.idle = h.idle[i:]
+		m.pruneHostLocked(host, h)
+	}
+	if len(expired) > 0 {
+		m.sessions -= len(expired)
+		m.notifyLocked()
+	}
+	m.mu.Unlock()
+
+	for _, s := range expired {
+		_ = s.client.Close()
+	}
+}
+
+// HostResult is the result of a fan-out function on one host.
+type HostResult[T any] struct {
+	Host  string
+	Value T
+	Err   error
+}
+
+// FanOutError aggregates the errors of the hosts failed in a fan-out.
+type FanOutError struct {
+	// Errors maps the failed host to its error.
+	Errors map[string]error
+}
+
+func (e *FanOutError) Error() string {
+	hosts := make([]string, 0, len(e.Errors))
+	for host := range e.Errors {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	msgs := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", host, e.Errors[host]))
+	}
+	return fmt.Sprintf("%d host(s) failed, errs: %s", len(hosts), strings.Join(msgs, "; "))
+}
+
+func (e *FanOutError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// FanOut runs fn with a session of each of hosts concurrently, see Manager.Do.
+// The results are returned in the order of hosts.
+// The returned error is a *FanOutError if fn failed on any host.
+func FanOut[T any](ctx context.Context, m *Manager, hosts []string, fn func(ctx context.Context, host string, client *Client) (T, error)) ([]HostResult[T], error) {
+	results := make([]HostResult[T], len(hosts))
+
+	sem := make(chan struct{}, m.fanOutConcurrency)
+	var wg sync.WaitGroup
+	for i, host := range hosts {
+		results[i].Host = host
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(r *HostResult[T]) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			r.Err = m.Do(ctx, r.Host, func(ctx context.Context, client *Client) error {
+				v, err := fn(ctx, r.Host, client)
+				r.Value = v
+				return err
+			})
+		}(&results[i])
+	}
+	wg.Wait()
+
+	errs := make(map[string]error)
+	for _, r := range results {
+		if r.Err != nil {
+			errs[r.Host] = r.Err
+		}
+	}
+	if len(errs) > 0 {
+		return results, &FanOutError{Errors: errs}
+	}
+	return results, nil
+}
+
+// Run runs fn with a session of each of hosts concurrently, see FanOut.
+func (m *Manager) Run(ctx context.Context, hosts []string, fn func(ctx context.Context, host string, client *Client) error) error {
+	_, err := FanOut(ctx, m, hosts, func(ctx context.Context, host string, client *Client) (struct{}, error) {
+		return struct{}{}, fn(ctx, host, client)
+	})
+	return err
+}
@@ -0,0 +1,169 @@
+package ipmi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test_Manager_Do(t *testing.T) {
+	var dialed int32
+	m := NewManager(func(ctx context.Context, host string) (*Client, error) {
+		atomic.AddInt32(&dialed, 1)
+		return NewToolClient(host)
+	}).WithMaxSessionsPerHost(2)
+	defer m.Close()
+
+	var running, maxRunning int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := m.Do(context.Background(), "bmc1", func(ctx context.Context, client *Client) error {
+				n := atomic.AddInt32(&running, 1)
+				defer atomic.AddInt32(&running, -1)
+				for {
+					old := atomic.LoadInt32(&maxRunning)
+					if n <= old || atomic.CompareAndSwapInt32(&maxRunning, old, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				return nil
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxRunning > 2 {
+		t.Errorf("per host limit not respected, max concurrent operations: %d", maxRunning)
+	}
+	if dialed > 2 {
+		t.Errorf("sessions not reused, dialed %d times", dialed)
+	}
+
+	// a broken session is not put back to the pool
+	_ = m.Do(context.Background(), "bmc1", func(ctx context.Context, client *Client) error {
+		return errors.New("broken")
+	})
+	m.mu.Lock()
+	sessions := m.sessions
+	m.mu.Unlock()
+	if sessions != int(dialed)-1 {
+		t.Errorf("broken session not discarded, sessions: %d, dialed: %d", sessions, dialed)
+	}
+}
+
+func Test_Manager_MaxSessions(t *testing.T) {
+	m := NewManager(func(ctx context.Context, host string) (*Client, error) {
+		return NewToolClient(host)
+	}).WithMaxSessions(2).WithIdleTimeout(0)
+	defer m.Close()
+
+	noop := func(ctx context.Context, client *Client) error { return nil }
+	for _, host := range []string{"bmc1", "bmc2", "bmc3", "bmc1"} {
+		if err := m.Do(context.Background(), host, noop); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.sessions != 2 {
+		t.Errorf("sessions not match, expect 2, actual %d", m.sessions)
+	}
+	if h := m.hosts["bmc2"]; h != nil && len(h.idle) != 0 {
+		t.Errorf("least recently used session of bmc2 not evicted")
+	}
+}
+
+func Test_Manager_IdleTimeout(t *testing.T) {
+	m := NewManager(func(ctx context.Context, host string) (*Client, error) {
+		return NewToolClient(host)
+	}).WithIdleTimeout(20 * time.Millisecond)
+	defer m.Close()
+
+	if err := m.Do(context.Background(), "bmc1", func(ctx context.Context, client *Client) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.sessions != 0 {
+		t.Errorf("idle session not expired, sessions: %d", m.sessions)
+	}
+	if len(m.hosts) != 0 {
+		t.Errorf("host without sessions not removed, hosts: %d", len(m.hosts))
+	}
+}
+
+func Test_Manager_DialError(t *testing.T) {
+	var dialed *Client
+	m := NewManager(func(ctx context.Context, host string) (*Client, error) {
+		client, err := NewClient(host, 1, "user", "pass")
+		if err != nil {
+			return nil, err
+		}
+		client.WithTimeout(100 * time.Millisecond)
+		dialed = client
+		return client, errors.New("connect failed")
+	})
+	defer m.Close()
+
+	if err := m.Do(context.Background(), "127.0.0.1", func(ctx context.Context, client *Client) error { return nil }); err == nil {
+		t.Fatal("expect dial error")
+	}
+	select {
+	case <-dialed.closedCh:
+	default:
+		t.Error("client returned with the dial error not closed")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.sessions != 0 || len(m.hosts) != 0 {
+		t.Errorf("failed host not removed, sessions: %d, hosts: %d", m.sessions, len(m.hosts))
+	}
+}
+
+func Test_FanOut(t *testing.T) {
+	m := NewManager(func(ctx context.Context, host string) (*Client, error) {
+		if host == "bad" {
+			return nil, errors.New("unreachable")
+		}
+		return NewToolClient(host)
+	})
+	defer m.Close()
+
+	hosts := []string{"bmc1", "bad", "bmc2"}
+	results, err := FanOut(context.Background(), m, hosts, func(ctx context.Context, host string, client *Client) (string, error) {
+		return fmt.Sprintf("hello %s", host), nil
+	})
+
+	var fanOutErr *FanOutError
+	if !errors.As(err, &fanOutErr) {
+		t.Fatalf("expect *FanOutError, got %v", err)
+	}
+	if len(fanOutErr.Errors) != 1 || fanOutErr.Errors["bad"] == nil {
+		t.Errorf("errors not match, got %v", fanOutErr.Errors)
+	}
+
+	for i, host := range hosts {
+		if results[i].Host != host {
+			t.Errorf("result %d host not match, expect %s, actual %s", i, host, results[i].Host)
+		}
+		if host != "bad" && results[i].Value != "hello "+host {
+			t.Errorf("result %d value not match, actual %s", i, results[i].Value)
+		}
+	}
+}
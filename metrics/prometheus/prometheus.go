@@ -0,0 +1,139 @@
+// Package prometheus exposes the metrics of ipmi.Client as prometheus collectors.
+//
+// It's a separate module, so the go-ipmi module does not depend on the prometheus client.
+//
+//	metrics := prometheus.NewMetrics("ipmi")
+//	prom.MustRegister(metrics)
+//
+//	client.WithMetrics(metrics)
+package prometheus
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bougou/go-ipmi"
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics implements ipmi.Metrics and prometheus.Collector.
+// One Metrics can be shared by the clients of many hosts, the metrics are labeled by host.
+type Metrics struct {
+	exchangeDuration *prom.HistogramVec
+	exchangeErrors   *prom.CounterVec
+	timeouts         *prom.CounterVec
+	retransmissions  *prom.CounterVec
+	reconnects       *prom.CounterVec
+	cipherSuite      *prom.GaugeVec
+}
+
+var _ ipmi.Metrics = (*Metrics)(nil)
+var _ prom.Collector = (*Metrics)(nil)
+
+// NewMetrics creates the metrics whose names are prefixed by namespace.
+func NewMetrics(namespace string) *Metrics {
+	return &Metrics{
+		exchangeDuration: prom.NewHistogramVec(prom.HistogramOpts{
+			Namespace: namespace,
+			Name:      "exchange_duration_seconds",
+			Help:      "The latency of the exchanges by command.",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 20},
+		}, []string{"host", "command"}),
+
+		exchangeErrors: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace,
+			Name:      "exchange_completion_code_errors_total",
+			Help:      "The number of the responses whose completion code is not normal by command.",
+		}, []string{"host", "command", "completion_code"}),
+
+		timeouts: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace,
+			Name:      "exchange_timeouts_total",
+			Help:      "The number of the requests which got no response in time by command.",
+		}, []string{"host", "command"}),
+
+		retransmissions: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace,
+			Name:      "retransmissions_total",
+			Help:      "The number of the retransmitted lan/lanplus requests by command.",
+		}, []string{"host", "command"}),
+
+		reconnects: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace,
+			Name:      "session_reconnects_total",
+			Help:      "The number of the re-establishments of the lost sessions by result.",
+		}, []string{"host", "result"}),
+
+		cipherSuite: prom.NewGaugeVec(prom.GaugeOpts{
+			Namespace: namespace,
+			Name:      "session_cipher_suite",
+			Help:      "The cipher suite ID of the established lanplus session.",
+		}, []string{"host"}),
+	}
+}
+
+func (m *Metrics) collectors() []prom.Collector {
+	return []prom.Collector{
+		m.exchangeDuration,
+		m.exchangeErrors,
+		m.timeouts,
+		m.retransmissions,
+		m.reconnects,
+		m.cipherSuite,
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (m *Metrics) Describe(ch chan<- *prom.Desc) {
+	for _, c := range m.collectors() {
+		c.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (m *Metrics) Collect(ch chan<- prom.Metric) {
+	for _, c := range m.collectors() {
+		c.Collect(ch)
+	}
+}
+
+// ObserveExchange implements ipmi.Metrics.
+func (m *Metrics) ObserveExchange(host string, command ipmi.Command, completionCode ipmi.CompletionCode, latency time.Duration, err error) {
+	name := commandName(command)
+	m.exchangeDuration.WithLabelValues(host, name).Observe(latency.Seconds())
+	if completionCode != ipmi.CompletionCodeNormal {
+		m.exchangeErrors.WithLabelValues(host, name, fmt.Sprintf("%#02x", uint8(completionCode))).Inc()
+	}
+}
+
+// ObserveTimeout implements ipmi.Metrics.
+func (m *Metrics) ObserveTimeout(host string, command ipmi.Command) {
+	m.timeouts.WithLabelValues(host, commandName(command)).Inc()
+}
+
+// ObserveRetransmission implements ipmi.Metrics.
+func (m *Metrics) ObserveRetransmission(host string, command ipmi.Command) {
+	m.retransmissions.WithLabelValues(host, commandName(command)).Inc()
+}
+
+// ObserveReconnect implements ipmi.Metrics.
+func (m *Metrics) ObserveReconnect(host string, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	m.reconnects.WithLabelValues(host, result).Inc()
+}
+
+// ObserveCipherSuite implements ipmi.Metrics.
+func (m *Metrics) ObserveCipherSuite(host string, cipherSuiteID ipmi.CipherSuiteID) {
+	m.cipherSuite.WithLabelValues(host).Set(float64(cipherSuiteID))
+}
+
+// commandName returns the name of the command, or the netfn and command id for the unnamed commands.
+func commandName(command ipmi.Command) string {
+	if command.Name != "" {
+		return command.Name
+	}
+	return fmt.Sprintf("%#02x/%#02x", uint8(command.NetFn), command.ID)
+}
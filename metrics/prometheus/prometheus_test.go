@@ -0,0 +1,63 @@
+package prometheus
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bougou/go-ipmi"
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func Test_Metrics(t *testing.T) {
+	metrics := NewMetrics("ipmi")
+	registry := prom.NewPedanticRegistry()
+	if err := registry.Register(metrics); err != nil {
+		t.Fatal(err)
+	}
+
+	host := "10.0.0.1"
+	metrics.ObserveExchange(host, ipmi.CommandGetDeviceID, ipmi.CompletionCodeNormal, 10*time.Millisecond, nil)
+	metrics.ObserveExchange(host, ipmi.CommandGetSELInfo, ipmi.CompletionCodeInvalidCommand, 20*time.Millisecond, errors.New("invalid command"))
+	metrics.ObserveTimeout(host, ipmi.CommandGetSELInfo)
+	metrics.ObserveRetransmission(host, ipmi.CommandGetSELInfo)
+	metrics.ObserveRetransmission(host, ipmi.CommandGetSELInfo)
+	metrics.ObserveReconnect(host, nil)
+	metrics.ObserveReconnect(host, errors.New("connect failed"))
+	metrics.ObserveCipherSuite(host, ipmi.CipherSuiteID17)
+
+	expected := `
+# HELP ipmi_exchange_completion_code_errors_total The number of the responses whose completion code is not normal by command.
+# TYPE ipmi_exchange_completion_code_errors_total counter
+ipmi_exchange_completion_code_errors_total{command="Get SEL Info",completion_code="0xc1",host="10.0.0.1"} 1
+# HELP ipmi_exchange_timeouts_total The number of the requests which got no response in time by command.
+# TYPE ipmi_exchange_timeouts_total counter
+ipmi_exchange_timeouts_total{command="Get SEL Info",host="10.0.0.1"} 1
+# HELP ipmi_retransmissions_total The number of the retransmitted lan/lanplus requests by command.
+# TYPE ipmi_retransmissions_total counter
+ipmi_retransmissions_total{command="Get SEL Info",host="10.0.0.1"} 2
+# HELP ipmi_session_cipher_suite The cipher suite ID of the established lanplus session.
+# TYPE ipmi_session_cipher_suite gauge
+ipmi_session_cipher_suite{host="10.0.0.1"} 17
+# HELP ipmi_session_reconnects_total The number of the re-establishments of the lost sessions by result.
+# TYPE ipmi_session_reconnects_total counter
+ipmi_session_reconnects_total{host="10.0.0.1",result="failure"} 1
+ipmi_session_reconnects_total{host="10.0.0.1",result="success"} 1
+`
+	err := testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"ipmi_exchange_completion_code_errors_total",
+		"ipmi_exchange_timeouts_total",
+		"ipmi_retransmissions_total",
+		"ipmi_session_cipher_suite",
+		"ipmi_session_reconnects_total",
+	)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count := testutil.CollectAndCount(metrics, "ipmi_exchange_duration_seconds"); count != 2 {
+		t.Errorf("expect 2 exchange duration series, got: %d", count)
+	}
+}
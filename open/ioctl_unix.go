@@ -11,7 +11,7 @@ import (
 func IOCTL(fd, name, data uintptr) error {
 	_, _, ep := syscall.Syscall(syscall.SYS_IOCTL, fd, name, data)
 	if ep != 0 {
-		return fmt.Errorf("syscall err: (%#02x) %s", uint8(ep), syscall.Errno(ep))
+		return fmt.Errorf("syscall err: (%#02x) %w", uint8(ep), ep)
 	}
 	return nil
 }
@@ -81,6 +81,51 @@ type IPMI_ADDR struct {
 	Data     [IPMI_MAX_ADDR_SIZE]byte // Addr Data
 }
 
+// Address is implemented by the structs of the different IPMI ADDR TYPEs.
+type Address interface {
+	// IPMIAddr converts the address to the generic IPMI_ADDR.
+	IPMIAddr() *IPMI_ADDR
+
+	// AddrLen returns the size of the address struct, it is used as the AddrLen of IPMI_REQ.
+	AddrLen() int
+}
+
+// copyAddr copies size bytes of the address struct at src to dst.
+// All the address structs share the same layout of the AddrType and Channel fields with IPMI_ADDR.
+func copyAddr(dst unsafe.Pointer, src unsafe.Pointer, size uintptr) {
+	copy(unsafe.Slice((*byte)(dst), size), unsafe.Slice((*byte)(src), size))
+}
+
+func (addr *IPMI_ADDR) IPMIAddr() *IPMI_ADDR {
+	out := *addr
+	return &out
+}
+
+func (addr *IPMI_ADDR) AddrLen() int {
+	return int(unsafe.Sizeof(*addr))
+}
+
+// SystemInterfaceAddr returns the address as IPMI_SYSTEM_INTERFACE_ADDR.
+func (addr *IPMI_ADDR) SystemInterfaceAddr() *IPMI_SYSTEM_INTERFACE_ADDR {
+	out := &IPMI_SYSTEM_INTERFACE_ADDR{}
+	copyAddr(unsafe.Pointer(out), unsafe.Pointer(addr), unsafe.Sizeof(*out))
+	return out
+}
+
+// IPMBAddr returns the address as IPMI_IPMB_ADDR.
+func (addr *IPMI_ADDR) IPMBAddr() *IPMI_IPMB_ADDR {
+	out := &IPMI_IPMB_ADDR{}
+	copyAddr(unsafe.Pointer(out), unsafe.Pointer(addr), unsafe.Sizeof(*out))
+	return out
+}
+
+// LANAddr returns the address as IPMI_LAN_ADDR.
+func (addr *IPMI_ADDR) LANAddr() *IPMI_LAN_ADDR {
+	out := &IPMI_LAN_ADDR{}
+	copyAddr(unsafe.Pointer(out), unsafe.Pointer(addr), unsafe.Sizeof(*out))
+	return out
+}
+
 const IPMI_SYSTEM_INTERFACE_ADDR_TYPE = 0x0c
 
 // IPMI_SYSTEM_INTERFACE_ADDR holds addr data of addr type IPMI_SYSTEM_INTERFACE_ADDR_TYPE.
@@ -90,6 +135,25 @@ type IPMI_SYSTEM_INTERFACE_ADDR struct {
 	LUN      uint8
 }
 
+// NewSystemInterfaceAddr returns the address of the BMC.
+func NewSystemInterfaceAddr(lun uint8) *IPMI_SYSTEM_INTERFACE_ADDR {
+	return &IPMI_SYSTEM_INTERFACE_ADDR{
+		AddrType: IPMI_SYSTEM_INTERFACE_ADDR_TYPE,
+		Channel:  IPMI_BMC_CHANNEL,
+		LUN:      lun,
+	}
+}
+
+func (addr *IPMI_SYSTEM_INTERFACE_ADDR) IPMIAddr() *IPMI_ADDR {
+	out := &IPMI_ADDR{}
+	copyAddr(unsafe.Pointer(out), unsafe.Pointer(addr), unsafe.Sizeof(*addr))
+	return out
+}
+
+func (addr *IPMI_SYSTEM_INTERFACE_ADDR) AddrLen() int {
+	return int(unsafe.Sizeof(*addr))
+}
+
 const IPMI_IPMB_ADDR_TYPE = 0x01
 const IPMI_IPMB_BROADCAST_ADDR_TYPE = 0x41 // Used for broadcast get device id as described in section 17.9 of the IPMI 1.5 manual.
 
@@ -103,6 +167,27 @@ type IPMI_IPMB_ADDR struct {
 	LUN       uint8
 }
 
+// NewIPMBAddr returns the address of the controller of slaveAddr on the IPMB channel,
+// the driver sends the request to it by Send Message command and gets the response by Get Message command.
+func NewIPMBAddr(channel uint16, slaveAddr uint8, lun uint8) *IPMI_IPMB_ADDR {
+	return &IPMI_IPMB_ADDR{
+		AddrType:  IPMI_IPMB_ADDR_TYPE,
+		Channel:   channel,
+		SlaveAddr: slaveAddr,
+		LUN:       lun,
+	}
+}
+
+func (addr *IPMI_IPMB_ADDR) IPMIAddr() *IPMI_ADDR {
+	out := &IPMI_ADDR{}
+	copyAddr(unsafe.Pointer(out), unsafe.Pointer(addr), unsafe.Sizeof(*addr))
+	return out
+}
+
+func (addr *IPMI_IPMB_ADDR) AddrLen() int {
+	return int(unsafe.Sizeof(*addr))
+}
+
 const IPMI_IPMB_DIRECT_ADDR_TYPE = 0x81
 
 // IPMI_IPMB_DIRECT_ADDR holds addr data of addr type IPMI_IPMB_DIRECT_ADDR_TYPE.
@@ -134,6 +219,29 @@ type IPMI_LAN_ADDR struct {
 	LUN           uint8
 }
 
+// NewLANAddr returns the address of the remote software of the session on the LAN channel.
+func NewLANAddr(channel uint16, privilege uint8, sessionHandle uint8, remoteSWID uint8, localSWID uint8, lun uint8) *IPMI_LAN_ADDR {
+	return &IPMI_LAN_ADDR{
+		AddrType:      IPMI_LAN_ADDR_TYPE,
+		Channel:       channel,
+		Privilege:     privilege,
+		SessionHandle: sessionHandle,
+		RemoteSWID:    remoteSWID,
+		LocalSWID:     localSWID,
+		LUN:           lun,
+	}
+}
+
+func (addr *IPMI_LAN_ADDR) IPMIAddr() *IPMI_ADDR {
+	out := &IPMI_ADDR{}
+	copyAddr(unsafe.Pointer(out), unsafe.Pointer(addr), unsafe.Sizeof(*addr))
+	return out
+}
+
+func (addr *IPMI_LAN_ADDR) AddrLen() int {
+	return int(unsafe.Sizeof(*addr))
+}
+
 // IPMI_MSG holds a raw IPMI message without any addressing. This covers both
 // commands and responses. The completion code is always the first
 // byte of data in the response (as the spec shows the messages laid out).
@@ -157,6 +265,8 @@ func (msg *IPMI_MSG) MsgData() ([]byte, error) {
 
 // unsafe.Sizeof of IPMI_REQ is 8+8(4+4)+8+16 = 40.
 type IPMI_REQ struct {
+	// Addr points to the address of AddrLen bytes. The address of the other ADDR TYPEs
+	// can be set by NewReq, and got by IPMIAddr.
 	Addr    *IPMI_SYSTEM_INTERFACE_ADDR
 	AddrLen int
 
@@ -169,13 +279,49 @@ type IPMI_REQ struct {
 	Msg   IPMI_MSG
 }
 
+// NewReq returns the request of msg to the address.
+func NewReq(addr Address, msgID int64, msg IPMI_MSG) *IPMI_REQ {
+	return &IPMI_REQ{
+		Addr:    (*IPMI_SYSTEM_INTERFACE_ADDR)(unsafe.Pointer(addr.IPMIAddr())),
+		AddrLen: addr.AddrLen(),
+		MsgID:   msgID,
+		Msg:     msg,
+	}
+}
+
+// IPMIAddr returns the address of the request as IPMI_ADDR.
+func (req *IPMI_REQ) IPMIAddr() *IPMI_ADDR {
+	return readAddr(unsafe.Pointer(req.Addr), req.AddrLen)
+}
+
 // unsafe.Sizeof of IPMI_RECV is 8(4+4)+8+8(4+4)+8+16 = 48.
 type IPMI_RECV struct {
 	RecvType int
-	Addr     *IPMI_SYSTEM_INTERFACE_ADDR
-	AddrLen  int
-	MsgID    int64
-	Msg      IPMI_MSG
+	// Addr points to the buffer of AddrLen bytes to receive the address,
+	// NewRecv provides a buffer large enough for all the ADDR TYPEs, which is got by IPMIAddr.
+	Addr    *IPMI_SYSTEM_INTERFACE_ADDR
+	AddrLen int
+	MsgID   int64
+	Msg     IPMI_MSG
+}
+
+// IPMIAddr returns the received address as IPMI_ADDR.
+func (recv *IPMI_RECV) IPMIAddr() *IPMI_ADDR {
+	return readAddr(unsafe.Pointer(recv.Addr), recv.AddrLen)
+}
+
+// readAddr copies the address of addrLen bytes at src to an IPMI_ADDR.
+func readAddr(src unsafe.Pointer, addrLen int) *IPMI_ADDR {
+	out := &IPMI_ADDR{}
+	if src == nil {
+		return out
+	}
+	size := uintptr(addrLen)
+	if size > unsafe.Sizeof(*out) {
+		size = unsafe.Sizeof(*out)
+	}
+	copyAddr(unsafe.Pointer(out), src, size)
+	return out
 }
 
 type IPMI_REQ_SETTIME struct {
@@ -0,0 +1,47 @@
+package open
+
+import (
+	"testing"
+	"unsafe"
+)
+
+func Test_AddrLen(t *testing.T) {
+	// the sizes of the address structs of linux/ipmi.h
+	tests := []struct {
+		addr   Address
+		expect int
+	}{
+		{NewSystemInterfaceAddr(0), 8},
+		{NewIPMBAddr(0, 0x2c, 0), 8},
+		{NewLANAddr(1, 4, 1, 0x81, 0x20, 0), 12},
+	}
+	for _, tt := range tests {
+		if got := tt.addr.AddrLen(); got != tt.expect {
+			t.Errorf("%T addr len not match, expect: %d, got: %d", tt.addr, tt.expect, got)
+		}
+	}
+
+	if size := unsafe.Sizeof(IPMI_ADDR{}); size != 40 {
+		t.Errorf("IPMI_ADDR size not match, expect: 40, got: %d", size)
+	}
+}
+
+func Test_IPMIAddr(t *testing.T) {
+	addr := NewIPMBAddr(6, 0x2c, 1).IPMIAddr()
+	if addr.AddrType != IPMI_IPMB_ADDR_TYPE || addr.Channel != 6 || addr.Data[0] != 0x2c || addr.Data[1] != 1 {
+		t.Errorf("ipmb addr not match, got: %+v", addr)
+	}
+	if ipmb := addr.IPMBAddr(); *ipmb != *NewIPMBAddr(6, 0x2c, 1) {
+		t.Errorf("ipmb addr not converted back, got: %+v", ipmb)
+	}
+
+	lan := NewLANAddr(1, 4, 2, 0x81, 0x20, 0)
+	if got := lan.IPMIAddr().LANAddr(); *got != *lan {
+		t.Errorf("lan addr not converted back, got: %+v", got)
+	}
+
+	si := NewSystemInterfaceAddr(0).IPMIAddr()
+	if si.AddrType != IPMI_SYSTEM_INTERFACE_ADDR_TYPE || si.Channel != IPMI_BMC_CHANNEL {
+		t.Errorf("system interface addr not match, got: %+v", si)
+	}
+}
@@ -0,0 +1,170 @@
+package open
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Message is a message received from the device.
+type Message struct {
+	// RecvType is one of IPMI_RESPONSE_RECV_TYPE, IPMI_ASYNC_EVENT_RECV_TYPE,
+	// IPMI_CMD_RECV_TYPE, IPMI_RESPONSE_RESPONSE_TYPE and IPMI_OEM_RECV_TYPE.
+	RecvType int
+	// Addr is the address the message is received from.
+	Addr  IPMI_ADDR
+	MsgID int64
+	NetFn uint8
+	Cmd   uint8
+	// Data starts with the completion code for responses.
+	Data []byte
+}
+
+// Mux multiplexes the requests on one device. A single goroutine receives
+// all the messages from the device, the responses are delivered to the requests
+// by MsgID, and the other messages (like events and received commands)
+// are delivered to the Messages channel.
+type Mux struct {
+	dev Device
+
+	mu      sync.Mutex
+	msgID   int64
+	pending map[int64]chan *Message
+
+	messages chan *Message
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	once   sync.Once
+}
+
+// NewMux starts receiving the messages from the device.
+// The Messages channel buffers size messages, the messages are dropped if the channel is full.
+func NewMux(dev Device, size int) *Mux {
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &Mux{
+		dev:      dev,
+		pending:  make(map[int64]chan *Message),
+		messages: make(chan *Message, size),
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+	go m.receive(ctx)
+	return m
+}
+
+// Messages returns the channel of the received messages which are not responses of the requests.
+// The channel is closed after the Mux is closed.
+func (m *Mux) Messages() <-chan *Message {
+	return m.messages
+}
+
+// Close stops receiving messages, the device is not closed.
+func (m *Mux) Close() error {
+	m.once.Do(func() {
+		m.cancel()
+		<-m.done
+	})
+	return nil
+}
+
+// Send sends the request to the device without waiting for its response,
+// it's used to respond to the received commands.
+func (m *Mux) Send(req *IPMI_REQ) error {
+	return m.dev.Send(req)
+}
+
+// SendRequest sends the message to the address, and waits for its response.
+// The returned data starts with the completion code.
+func (m *Mux) SendRequest(ctx context.Context, addr Address, msg IPMI_MSG, timeout time.Duration) ([]byte, error) {
+	if timeout == 0 {
+		timeout = IPMI_FILE_READ_TIMEOUT
+	}
+
+	ch := make(chan *Message, 1)
+	m.mu.Lock()
+	m.msgID++
+	msgID := m.msgID
+	m.pending[msgID] = ch
+	m.mu.Unlock()
+
+	defer func() {
+		m.mu.Lock()
+		delete(m.pending, msgID)
+		m.mu.Unlock()
+	}()
+
+	if err := m.dev.Send(NewReq(addr, msgID, msg)); err != nil {
+		return nil, fmt.Errorf("SetReq failed, err: %w", err)
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case res := <-ch:
+		return res.Data, nil
+	case <-timer.C:
+		return nil, fmt.Errorf("wait for response of msg id (%d) timeout after %s, err: %w", msgID, timeout, os.ErrDeadlineExceeded)
+	case <-ctx.Done():
+		return nil, fmt.Errorf("wait for response aborted, err: %w", ctx.Err())
+	case <-m.done:
+		return nil, fmt.Errorf("mux closed")
+	}
+}
+
+func (m *Mux) receive(ctx context.Context) {
+	defer close(m.done)
+	defer close(m.messages)
+
+	for {
+		recv, recvBuf := NewRecv()
+		if err := m.dev.Receive(ctx, recv, time.Time{}); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			// avoid busy looping on a broken device
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(100 * time.Millisecond):
+			}
+			continue
+		}
+
+		if recv.Msg.DataLen >= IPMI_BUF_SIZE {
+			continue
+		}
+		data := make([]byte, recv.Msg.DataLen)
+		copy(data, recvBuf)
+
+		msg := &Message{
+			RecvType: recv.RecvType,
+			Addr:     *recv.IPMIAddr(),
+			MsgID:    recv.MsgID,
+			NetFn:    recv.Msg.NetFn,
+			Cmd:      recv.Msg.Cmd,
+			Data:     data,
+		}
+
+		if msg.RecvType == IPMI_RESPONSE_RECV_TYPE {
+			m.mu.Lock()
+			ch, ok := m.pending[msg.MsgID]
+			m.mu.Unlock()
+			if ok {
+				select {
+				case ch <- msg:
+				default:
+				}
+			}
+			continue
+		}
+
+		select {
+		case m.messages <- msg:
+		default:
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package open
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"runtime"
@@ -9,6 +11,99 @@ import (
 	"unsafe"
 )
 
+// Device is the ipmi device the requests are sent to and the messages are received from.
+// It's implemented by DeviceFile for the device file of linux ipmi driver,
+// and can be faked to test the code using the open interface without the driver.
+type Device interface {
+	// Send issues the IPMICTL_SEND_COMMAND ioctl with req.
+	Send(req *IPMI_REQ) error
+
+	// Receive waits until a message is available, then issues the IPMICTL_RECEIVE_MSG_TRUNC ioctl
+	// to receive the message into recv. The buffers of recv.Addr and recv.Msg.Data must be provided by caller.
+	// The wait is aborted as soon as ctx is done or the deadline is exceeded.
+	Receive(ctx context.Context, recv *IPMI_RECV, deadline time.Time) error
+
+	// Ioctl issues the ioctl op with the arg pointer.
+	Ioctl(op uintptr, arg unsafe.Pointer) error
+
+	Close() error
+}
+
+// DeviceFile is the Device of an opened device file of linux ipmi driver, like /dev/ipmi0.
+type DeviceFile struct {
+	file *os.File
+}
+
+func NewDeviceFile(file *os.File) *DeviceFile {
+	return &DeviceFile{file: file}
+}
+
+func (d *DeviceFile) Send(req *IPMI_REQ) error {
+	for {
+		err := SetReq(d.file.Fd(), IPMICTL_SEND_COMMAND, req)
+		if errors.Is(err, syscall.EINTR) {
+			continue
+		}
+		return err
+	}
+}
+
+func (d *DeviceFile) Receive(ctx context.Context, recv *IPMI_RECV, deadline time.Time) error {
+	conn, err := d.file.SyscallConn()
+	if err != nil {
+		return fmt.Errorf("failed to get syscall conn from file: %w", err)
+	}
+	if err := d.file.SetReadDeadline(deadline); err != nil {
+		return fmt.Errorf("failed to set read deadline on file: %w", err)
+	}
+
+	// Moving the read deadline to now wakes up the poller wait below.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = d.file.SetReadDeadline(time.Now())
+		case <-done:
+		}
+	}()
+
+	var rerr error
+	readMsgFunc := func(fd uintptr) bool {
+		for {
+			err := GetRecv(fd, IPMICTL_RECEIVE_MSG_TRUNC, recv)
+			switch {
+			case errors.Is(err, syscall.EINTR):
+				continue
+			case errors.Is(err, syscall.EAGAIN):
+				// no message yet, wait until the file is readable
+				return false
+			case err != nil:
+				rerr = fmt.Errorf("GetRecv failed, err: %w", err)
+			}
+			return true
+		}
+	}
+
+	if err := conn.Read(readMsgFunc); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return fmt.Errorf("read from syscall conn aborted, err: %w", ctxErr)
+		}
+		return fmt.Errorf("failed to read from syscall conn: %w", err)
+	}
+	return rerr
+}
+
+func (d *DeviceFile) Ioctl(op uintptr, arg unsafe.Pointer) error {
+	err := IOCTL(d.file.Fd(), op, uintptr(arg))
+	runtime.KeepAlive(arg)
+	return err
+}
+
+func (d *DeviceFile) Close() error {
+	return d.file.Close()
+}
+
 func SetReq(fd uintptr, op uintptr, req *IPMI_REQ) error {
 	err := IOCTL(fd, op, uintptr(unsafe.Pointer(req)))
 	runtime.KeepAlive(req)
@@ -22,66 +117,62 @@ func GetRecv(fd uintptr, op uintptr, recv *IPMI_RECV) error {
 }
 
 func SendCommand(file *os.File, req *IPMI_REQ, timeout time.Duration) ([]byte, error) {
+	return SendCommandContext(context.Background(), file, req, timeout)
+}
+
+// SendCommandContext is like SendCommand but the wait for the response
+// is aborted as soon as ctx is done.
+func SendCommandContext(ctx context.Context, file *os.File, req *IPMI_REQ, timeout time.Duration) ([]byte, error) {
+	return SendRequest(ctx, NewDeviceFile(file), req, timeout)
+}
+
+// SendRequest sends the request to the device and waits for its response.
+// The received messages which are not the response of the request (like the events) are dropped.
+// The returned data starts with the completion code.
+func SendRequest(ctx context.Context, dev Device, req *IPMI_REQ, timeout time.Duration) ([]byte, error) {
 	if timeout == 0 {
 		timeout = IPMI_FILE_READ_TIMEOUT
 	}
 
-	fd := file.Fd()
-
-	for {
-		switch err := SetReq(fd, IPMICTL_SEND_COMMAND, req); {
-		case err == syscall.EINTR:
-			continue
-		case err != nil:
-			return nil, fmt.Errorf("SetReq failed, err: %s", err)
-		}
-		break
+	if err := dev.Send(req); err != nil {
+		return nil, fmt.Errorf("SetReq failed, err: %w", err)
 	}
 
-	recvBuf := make([]byte, IPMI_BUF_SIZE)
-	recv := &IPMI_RECV{
-		Addr:    req.Addr,
-		AddrLen: req.AddrLen,
-		Msg: IPMI_MSG{
-			Data:    &recvBuf[0],
-			DataLen: IPMI_BUF_SIZE,
-		},
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
 	}
 
-	var result []byte
-	var rerr error
-
-	readMsgFunc := func(fd uintptr) bool {
-		if err := GetRecv(fd, IPMICTL_RECEIVE_MSG_TRUNC, recv); err != nil {
-			rerr = fmt.Errorf("GetRecv failed, err: %s", err)
-			return false
+	for {
+		recv, recvBuf := NewRecv()
+		if err := dev.Receive(ctx, recv, deadline); err != nil {
+			return nil, err
 		}
 
-		if recv.MsgID != req.MsgID {
-			rerr = fmt.Errorf("received msg id not match")
-			return false
+		if recv.RecvType != IPMI_RESPONSE_RECV_TYPE || recv.MsgID != req.MsgID {
+			// not the response of this request
+			continue
 		}
 
 		if recv.Msg.DataLen >= IPMI_BUF_SIZE {
-			rerr = fmt.Errorf("received data length longer than buf size: %d > %d", recv.Msg.DataLen, IPMI_BUF_SIZE)
-		} else {
-			// recvBuf[0] is completion code.
-			result = recvBuf[:recv.Msg.DataLen:recv.Msg.DataLen]
-			rerr = nil
+			return nil, fmt.Errorf("received data length longer than buf size: %d > %d", recv.Msg.DataLen, IPMI_BUF_SIZE)
 		}
-		return true
-	}
 
-	conn, err := file.SyscallConn()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get syscall conn from file: %s", err)
-	}
-	if err := file.SetReadDeadline(time.Now().Add(timeout)); err != nil {
-		return nil, fmt.Errorf("failed to set read deadline on file: %s", err)
-	}
-	if err := conn.Read(readMsgFunc); err != nil {
-		return nil, fmt.Errorf("failed to read from syscall conn: %s", err)
+		// recvBuf[0] is completion code.
+		return recvBuf[:recv.Msg.DataLen:recv.Msg.DataLen], nil
 	}
+}
 
-	return result, rerr
+// NewRecv returns an IPMI_RECV with the address and data buffers to receive a message.
+func NewRecv() (*IPMI_RECV, []byte) {
+	recvBuf := make([]byte, IPMI_BUF_SIZE)
+	recv := &IPMI_RECV{
+		Addr:    (*IPMI_SYSTEM_INTERFACE_ADDR)(unsafe.Pointer(&IPMI_ADDR{})),
+		AddrLen: int(unsafe.Sizeof(IPMI_ADDR{})),
+		Msg: IPMI_MSG{
+			Data:    &recvBuf[0],
+			DataLen: IPMI_BUF_SIZE,
+		},
+	}
+	return recv, recvBuf
 }
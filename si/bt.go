@@ -0,0 +1,209 @@
+package si
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// 11.6 BT Interface Registers, the bits of the BT_CTRL register.
+// Writing 1 to H2B_ATN sets it, writing 1 to B2H_ATN, SMS_ATN and OEM0 clears them,
+// writing 1 to H_BUSY toggles it, and writing 1 to CLR_WR_PTR or CLR_RD_PTR clears the pointer.
+const (
+	BTCtrlClrWrPtr uint8 = 1 << 0
+	BTCtrlClrRdPtr uint8 = 1 << 1
+	BTCtrlH2BATN   uint8 = 1 << 2
+	BTCtrlB2HATN   uint8 = 1 << 3
+	BTCtrlSMSATN   uint8 = 1 << 4
+	BTCtrlOEM0     uint8 = 1 << 5
+	BTCtrlHBusy    uint8 = 1 << 6
+	BTCtrlBBusy    uint8 = 1 << 7
+)
+
+// BTDefaultBufferSize is the buffer size assumed before the capabilities are known,
+// the minimal buffer size required by the spec.
+const BTDefaultBufferSize uint8 = 64
+
+// BT is the host side of the Block Transfer interface, see 11 Block Transfer (BT) Interface.
+// The BT_CTRL register is polled, interrupts are not used.
+type BT struct {
+	io   PortIO
+	base uint16
+
+	mu  sync.Mutex
+	seq uint8
+
+	inputSize  uint8
+	outputSize uint8
+
+	poller  poller
+	retries int
+}
+
+// NewBT creates the BT interface whose BT_CTRL register is at base, the HOST2BMC/BMC2HOST buffer
+// register at base+1 and BT_INTMASK register at base+2.
+func NewBT(io PortIO, base uint16) *BT {
+	if base == 0 {
+		base = DefaultBTBase
+	}
+	return &BT{
+		io:         io,
+		base:       base,
+		inputSize:  BTDefaultBufferSize,
+		outputSize: BTDefaultBufferSize,
+		poller: poller{
+			timeout:  DefaultWaitTimeout,
+			interval: 100 * time.Microsecond,
+		},
+		retries: DefaultRetries,
+	}
+}
+
+// OpenBT creates the BT interface at base by /dev/port.
+func OpenBT(base uint16) (*BT, error) {
+	port, err := OpenDevPort()
+	if err != nil {
+		return nil, err
+	}
+	return NewBT(port, base), nil
+}
+
+// SetCapabilities applies the result of the Get BT Interface Capabilities command.
+// The zero values are ignored.
+func (bt *BT) SetCapabilities(inputSize uint8, outputSize uint8, timeout time.Duration, retries int) {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+
+	if inputSize != 0 {
+		bt.inputSize = inputSize
+	}
+	if outputSize != 0 {
+		bt.outputSize = outputSize
+	}
+	if timeout != 0 {
+		bt.poller.timeout = timeout
+	}
+	if retries != 0 {
+		bt.retries = retries
+	}
+}
+
+func (bt *BT) Close() error {
+	if closer, ok := bt.io.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func (bt *BT) Exchange(ctx context.Context, msg []byte) ([]byte, error) {
+	if len(msg) < 2 {
+		return nil, fmt.Errorf("message at least contains NetFn/LUN and Command bytes")
+	}
+
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+
+	// Length, NetFn/LUN, Seq, Cmd, Data
+	if len(msg)+2 > int(bt.inputSize) {
+		return nil, fmt.Errorf("message (%d bytes) exceeds the BT input buffer (%d bytes)", len(msg)+2, bt.inputSize)
+	}
+
+	var err error
+	for attempt := 0; attempt <= bt.retries; attempt++ {
+		bt.seq++
+		var res []byte
+		res, err = bt.exchange(ctx, msg, bt.seq)
+		if err == nil || ctx.Err() != nil {
+			return res, err
+		}
+		// reset the interface before retrying
+		_ = bt.io.OutB(bt.base, BTCtrlClrWrPtr|BTCtrlClrRdPtr)
+	}
+	return nil, err
+}
+
+// exchange writes the request and reads the response, see 11.6.1 BT Host to BMC Message Transfer.
+func (bt *BT) exchange(ctx context.Context, msg []byte, seq uint8) ([]byte, error) {
+	err := bt.poller.poll(ctx, "BT B_BUSY and H2B_ATN clear", func() (bool, error) {
+		ctrl, err := bt.io.InB(bt.base)
+		return ctrl&(BTCtrlBBusy|BTCtrlH2BATN) == 0, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bt.io.OutB(bt.base, BTCtrlClrWrPtr); err != nil {
+		return nil, err
+	}
+	req := make([]byte, 0, len(msg)+2)
+	req = append(req, uint8(len(msg)+1), msg[0], seq)
+	req = append(req, msg[1:]...)
+	for _, b := range req {
+		if err := bt.io.OutB(bt.base+1, b); err != nil {
+			return nil, err
+		}
+	}
+	if err := bt.io.OutB(bt.base, BTCtrlH2BATN); err != nil {
+		return nil, err
+	}
+
+	for {
+		err := bt.poller.poll(ctx, "BT B2H_ATN set", func() (bool, error) {
+			ctrl, err := bt.io.InB(bt.base)
+			return ctrl&BTCtrlB2HATN != 0, err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := bt.read()
+		if err != nil {
+			return nil, err
+		}
+		// Length, NetFn/LUN, Seq, Cmd, Completion Code, Data
+		if len(res) < 4 {
+			return nil, fmt.Errorf("BT response too short, got %d bytes", len(res))
+		}
+		if res[1] != seq {
+			// the late response of a previous attempt
+			continue
+		}
+		return append([]byte{res[0]}, res[2:]...), nil
+	}
+}
+
+// read reads the message from BMC2HOST buffer, the Length byte is not returned.
+func (bt *BT) read() ([]byte, error) {
+	// set H_BUSY
+	if err := bt.io.OutB(bt.base, BTCtrlHBusy); err != nil {
+		return nil, err
+	}
+	// clear H_BUSY when done
+	defer func() {
+		_ = bt.io.OutB(bt.base, BTCtrlHBusy)
+	}()
+
+	if err := bt.io.OutB(bt.base, BTCtrlB2HATN); err != nil {
+		return nil, err
+	}
+	if err := bt.io.OutB(bt.base, BTCtrlClrRdPtr); err != nil {
+		return nil, err
+	}
+
+	length, err := bt.io.InB(bt.base + 1)
+	if err != nil {
+		return nil, err
+	}
+	if length > bt.outputSize {
+		return nil, fmt.Errorf("BT response length (%d) exceeds the output buffer (%d bytes)", length, bt.outputSize)
+	}
+	res := make([]byte, length)
+	for i := range res {
+		if res[i], err = bt.io.InB(bt.base + 1); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
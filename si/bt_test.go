@@ -0,0 +1,158 @@
+package si
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+// btSim is the BMC side of the BT interface, it processes the request as soon as H2B_ATN is set.
+type btSim struct {
+	base    uint16
+	handler func(req []byte) []byte
+
+	ctrl uint8
+	in   []byte
+	out  []byte
+	rd   int
+
+	// queued are the responses delivered after the host finishes reading the current one.
+	queued [][]byte
+
+	// stale makes the BMC send the response of the previous Seq before the right one.
+	stale bool
+}
+
+func (s *btSim) InB(port uint16) (uint8, error) {
+	switch port {
+	case s.base:
+		return s.ctrl, nil
+	case s.base + 1:
+		if s.rd >= len(s.out) {
+			return 0, nil
+		}
+		b := s.out[s.rd]
+		s.rd++
+		return b, nil
+	}
+	return 0, nil
+}
+
+func (s *btSim) OutB(port uint16, v uint8) error {
+	switch port {
+	case s.base + 1:
+		s.in = append(s.in, v)
+		return nil
+	case s.base:
+	default:
+		return nil
+	}
+
+	if v&BTCtrlClrWrPtr != 0 {
+		s.in = nil
+	}
+	if v&BTCtrlClrRdPtr != 0 {
+		s.rd = 0
+	}
+	if v&BTCtrlB2HATN != 0 {
+		s.ctrl &^= BTCtrlB2HATN
+	}
+	if v&BTCtrlHBusy != 0 {
+		s.ctrl ^= BTCtrlHBusy
+		if s.ctrl&BTCtrlHBusy == 0 && len(s.queued) > 0 {
+			s.respond(s.queued[0])
+			s.queued = s.queued[1:]
+		}
+	}
+	if v&BTCtrlH2BATN != 0 {
+		s.process()
+	}
+	return nil
+}
+
+// process handles the request in the HOST2BMC buffer.
+func (s *btSim) process() {
+	// Length, NetFn/LUN, Seq, Cmd, Data
+	req := s.in
+	s.in = nil
+	msg := append([]byte{req[1]}, req[3:]...)
+	// NetFn/LUN, Cmd, Completion Code, Data
+	res := s.handler(msg)
+	seq := req[2]
+
+	if s.stale {
+		s.stale = false
+		s.respond(s.frame(res, seq-1))
+		s.queued = append(s.queued, s.frame(res, seq))
+		return
+	}
+	s.respond(s.frame(res, seq))
+}
+
+func (s *btSim) frame(res []byte, seq uint8) []byte {
+	out := []byte{uint8(len(res) + 1), res[0], seq}
+	return append(out, res[1:]...)
+}
+
+func (s *btSim) respond(out []byte) {
+	s.out = out
+	s.rd = 0
+	s.ctrl |= BTCtrlB2HATN
+}
+
+func Test_BT(t *testing.T) {
+	deviceID := []byte{0x07 << 2, 0x01, 0x00, 0x20, 0x81, 0x01, 0x10, 0x02, 0xbf, 0x57, 0x01, 0x00, 0x34, 0x12}
+	sim := &btSim{
+		base: DefaultBTBase,
+		handler: func(req []byte) []byte {
+			// Get Device ID
+			if req[0] == 0x06<<2 && req[1] == 0x01 {
+				return deviceID
+			}
+			return []byte{req[0] + 0x04, req[1], 0xc1}
+		},
+	}
+	bt := NewBT(sim, 0)
+
+	res, err := bt.Exchange(context.Background(), []byte{0x06 << 2, 0x01})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(res, deviceID) {
+		t.Errorf("response not match, expected: % x, got: % x", deviceID, res)
+	}
+	if sim.ctrl != 0 {
+		t.Errorf("expect BT_CTRL cleared after the transfer, got: %#02x", sim.ctrl)
+	}
+
+	// the late response of a previous Seq is skipped
+	sim.stale = true
+	res, err = bt.Exchange(context.Background(), []byte{0x0a << 2, 0x43, 0x00, 0x00, 0x00, 0x00, 0x00, 0xff})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(res, []byte{0x0b << 2, 0x43, 0xc1}) {
+		t.Errorf("response not match, got: % x", res)
+	}
+	if len(sim.queued) != 0 {
+		t.Errorf("expect the right response read, %d responses left", len(sim.queued))
+	}
+}
+
+func Test_BT_BufferSize(t *testing.T) {
+	sim := &btSim{
+		base: DefaultBTBase,
+		handler: func(req []byte) []byte {
+			return append([]byte{req[0] + 0x04, req[1], 0x00}, make([]byte, 40)...)
+		},
+	}
+	bt := NewBT(sim, 0)
+	bt.SetCapabilities(16, 32, 0, 0)
+
+	if _, err := bt.Exchange(context.Background(), make([]byte, 15)); err == nil {
+		t.Error("expect error when the message exceeds the input buffer")
+	}
+	if _, err := bt.Exchange(context.Background(), []byte{0x06 << 2, 0x01}); err == nil {
+		t.Error("expect error when the response exceeds the output buffer")
+	}
+}
@@ -0,0 +1,95 @@
+//go:build linux
+// +build linux
+
+package si
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// see: https://github.com/torvalds/linux/blob/master/include/uapi/linux/i2c-dev.h
+const (
+	i2cSlave uintptr = 0x0703
+	i2cSMBus uintptr = 0x0720
+
+	i2cSMBusRead      uint8  = 1
+	i2cSMBusWrite     uint8  = 0
+	i2cSMBusBlockData uint32 = 5
+)
+
+// i2cSMBusIoctlData is struct i2c_smbus_ioctl_data.
+type i2cSMBusIoctlData struct {
+	readWrite uint8
+	command   uint8
+	size      uint32
+	// union i2c_smbus_data, block[0] is the length
+	data *[SSIFMaxBlockSize + 2]byte
+}
+
+// I2C is the SMBus by the i2c-dev device file, like /dev/i2c-0.
+type I2C struct {
+	file *os.File
+}
+
+// OpenI2C opens /dev/i2c-<bus> and sets the 7-bit slave addr.
+func OpenI2C(bus int, addr uint8) (*I2C, error) {
+	path := fmt.Sprintf("/dev/i2c-%d", bus)
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("open %s failed, err: %w", path, err)
+	}
+	if err := ioctl(file.Fd(), i2cSlave, uintptr(addr)); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("set i2c slave address %#02x failed, err: %w", addr, err)
+	}
+	return &I2C{file: file}, nil
+}
+
+func (d *I2C) WriteBlock(cmd uint8, data []byte) error {
+	if len(data) > SSIFMaxBlockSize {
+		return fmt.Errorf("block (%d bytes) exceeds %d bytes", len(data), SSIFMaxBlockSize)
+	}
+	var block [SSIFMaxBlockSize + 2]byte
+	block[0] = uint8(len(data))
+	copy(block[1:], data)
+	args := i2cSMBusIoctlData{
+		readWrite: i2cSMBusWrite,
+		command:   cmd,
+		size:      i2cSMBusBlockData,
+		data:      &block,
+	}
+	return ioctl(d.file.Fd(), i2cSMBus, uintptr(unsafe.Pointer(&args)))
+}
+
+func (d *I2C) ReadBlock(cmd uint8) ([]byte, error) {
+	var block [SSIFMaxBlockSize + 2]byte
+	args := i2cSMBusIoctlData{
+		readWrite: i2cSMBusRead,
+		command:   cmd,
+		size:      i2cSMBusBlockData,
+		data:      &block,
+	}
+	if err := ioctl(d.file.Fd(), i2cSMBus, uintptr(unsafe.Pointer(&args))); err != nil {
+		return nil, err
+	}
+	n := int(block[0])
+	if n > SSIFMaxBlockSize {
+		n = SSIFMaxBlockSize
+	}
+	return append([]byte{}, block[1:1+n]...), nil
+}
+
+func (d *I2C) Close() error {
+	return d.file.Close()
+}
+
+func ioctl(fd, op, arg uintptr) error {
+	_, _, ep := syscall.Syscall(syscall.SYS_IOCTL, fd, op, arg)
+	if ep != 0 {
+		return fmt.Errorf("syscall err: (%#02x) %w", uint8(ep), ep)
+	}
+	return nil
+}
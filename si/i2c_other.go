@@ -0,0 +1,25 @@
+//go:build !linux
+// +build !linux
+
+package si
+
+import "fmt"
+
+// I2C is the SMBus by the i2c-dev device file, it's only supported on linux.
+type I2C struct{}
+
+func OpenI2C(bus int, addr uint8) (*I2C, error) {
+	return nil, fmt.Errorf("i2c-dev is only supported on linux")
+}
+
+func (d *I2C) WriteBlock(cmd uint8, data []byte) error {
+	return fmt.Errorf("i2c-dev is only supported on linux")
+}
+
+func (d *I2C) ReadBlock(cmd uint8) ([]byte, error) {
+	return nil, fmt.Errorf("i2c-dev is only supported on linux")
+}
+
+func (d *I2C) Close() error {
+	return nil
+}
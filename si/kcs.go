@@ -0,0 +1,301 @@
+package si
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+)
+
+// 9.7 KCS Interface Registers
+const (
+	KCSStatusOBF    uint8 = 1 << 0 // Output Buffer Full
+	KCSStatusIBF    uint8 = 1 << 1 // Input Buffer Full
+	KCSStatusSMSATN uint8 = 1 << 2
+	KCSStatusCD     uint8 = 1 << 3 // Command/Data, the last write was to the command register
+)
+
+// KCSState is the State bits [7:6] of the KCS status register.
+type KCSState uint8
+
+const (
+	KCSStateIdle  KCSState = 0
+	KCSStateRead  KCSState = 1
+	KCSStateWrite KCSState = 2
+	KCSStateError KCSState = 3
+)
+
+func (s KCSState) String() string {
+	switch s {
+	case KCSStateIdle:
+		return "IDLE_STATE"
+	case KCSStateRead:
+		return "READ_STATE"
+	case KCSStateWrite:
+		return "WRITE_STATE"
+	case KCSStateError:
+		return "ERROR_STATE"
+	}
+	return "unknown"
+}
+
+// 9.10 KCS Interface Control Codes
+const (
+	KCSGetStatusAbort uint8 = 0x60
+	KCSWriteStart     uint8 = 0x61
+	KCSWriteEnd       uint8 = 0x62
+	KCSRead           uint8 = 0x68
+)
+
+// KCS is the host side of the Keyboard Controller Style interface, see 9 Keyboard Controller Style (KCS) Interface.
+// The status register is polled, interrupts are not used.
+type KCS struct {
+	io   PortIO
+	base uint16
+
+	poller  poller
+	retries int
+}
+
+// NewKCS creates the KCS interface whose data register is at base, and status/command register at base+1.
+func NewKCS(io PortIO, base uint16) *KCS {
+	if base == 0 {
+		base = DefaultKCSBase
+	}
+	return &KCS{
+		io:   io,
+		base: base,
+		poller: poller{
+			timeout:  DefaultWaitTimeout,
+			interval: 100 * time.Microsecond,
+		},
+		retries: DefaultRetries,
+	}
+}
+
+// OpenKCS creates the KCS interface at base by /dev/port.
+func OpenKCS(base uint16) (*KCS, error) {
+	port, err := OpenDevPort()
+	if err != nil {
+		return nil, err
+	}
+	return NewKCS(port, base), nil
+}
+
+// WithWaitTimeout sets how long to wait for the BMC to process each byte.
+func (k *KCS) WithWaitTimeout(timeout time.Duration) *KCS {
+	k.poller.timeout = timeout
+	return k
+}
+
+// WithRetries sets how many times the message is resent after an error of the interface.
+func (k *KCS) WithRetries(retries int) *KCS {
+	k.retries = retries
+	return k
+}
+
+func (k *KCS) Close() error {
+	if closer, ok := k.io.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func (k *KCS) Exchange(ctx context.Context, msg []byte) ([]byte, error) {
+	if len(msg) < 2 {
+		return nil, fmt.Errorf("message at least contains NetFn/LUN and Command bytes")
+	}
+
+	var err error
+	for attempt := 0; attempt <= k.retries; attempt++ {
+		var res []byte
+		res, err = k.exchange(ctx, msg)
+		if err == nil {
+			return res, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		if abortErr := k.abort(ctx); abortErr != nil {
+			return nil, fmt.Errorf("%s, and abort failed, err: %s", err, abortErr)
+		}
+	}
+	return nil, err
+}
+
+// exchange runs the write transfer and the read transfer,
+// see Figure 9-6, KCS Interface SMS to BMC Write Transfer Flow Chart,
+// and Figure 9-7, KCS Interface BMC to SMS Read Transfer Flow Chart.
+func (k *KCS) exchange(ctx context.Context, msg []byte) ([]byte, error) {
+	if err := k.waitIBF(ctx); err != nil {
+		return nil, err
+	}
+	if err := k.clearOBF(); err != nil {
+		return nil, err
+	}
+
+	if err := k.writeCommand(ctx, KCSWriteStart); err != nil {
+		return nil, err
+	}
+	if err := k.expectState(KCSStateWrite); err != nil {
+		return nil, err
+	}
+
+	for i, b := range msg {
+		if i == len(msg)-1 {
+			if err := k.writeCommand(ctx, KCSWriteEnd); err != nil {
+				return nil, err
+			}
+			if err := k.expectState(KCSStateWrite); err != nil {
+				return nil, err
+			}
+		}
+		if err := k.writeData(ctx, b); err != nil {
+			return nil, err
+		}
+		if i < len(msg)-1 {
+			if err := k.expectState(KCSStateWrite); err != nil {
+				return nil, err
+			}
+			if err := k.clearOBF(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var res []byte
+	for {
+		state, err := k.state()
+		if err != nil {
+			return nil, err
+		}
+
+		switch state {
+		case KCSStateRead:
+			if err := k.waitOBF(ctx); err != nil {
+				return nil, err
+			}
+			b, err := k.io.InB(k.base)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, b)
+			if err := k.writeData(ctx, KCSRead); err != nil {
+				return nil, err
+			}
+
+		case KCSStateIdle:
+			if err := k.waitOBF(ctx); err != nil {
+				return nil, err
+			}
+			// dummy data byte
+			if _, err := k.io.InB(k.base); err != nil {
+				return nil, err
+			}
+			return res, nil
+
+		default:
+			return nil, fmt.Errorf("unexpected KCS state %s while reading", state)
+		}
+	}
+}
+
+// abort brings the interface back to IDLE_STATE, the status code of the BMC is read and discarded,
+// see Figure 9-8, KCS Error Exit and 9.12 Error Recovery.
+func (k *KCS) abort(ctx context.Context) error {
+	if err := k.waitIBF(ctx); err != nil {
+		return err
+	}
+	if err := k.writeCommand(ctx, KCSGetStatusAbort); err != nil {
+		return err
+	}
+	if err := k.writeData(ctx, 0x00); err != nil {
+		return err
+	}
+	if err := k.expectState(KCSStateRead); err != nil {
+		return err
+	}
+	if err := k.waitOBF(ctx); err != nil {
+		return err
+	}
+	// the status code, see Table 9-5, KCS Interface Status Codes
+	if _, err := k.io.InB(k.base); err != nil {
+		return err
+	}
+	if err := k.writeData(ctx, KCSRead); err != nil {
+		return err
+	}
+	if err := k.expectState(KCSStateIdle); err != nil {
+		return err
+	}
+	if err := k.waitOBF(ctx); err != nil {
+		return err
+	}
+	_, err := k.io.InB(k.base)
+	return err
+}
+
+func (k *KCS) status() (uint8, error) {
+	return k.io.InB(k.base + 1)
+}
+
+func (k *KCS) state() (KCSState, error) {
+	status, err := k.status()
+	if err != nil {
+		return 0, err
+	}
+	return KCSState(status >> 6), nil
+}
+
+func (k *KCS) expectState(expected KCSState) error {
+	state, err := k.state()
+	if err != nil {
+		return err
+	}
+	if state != expected {
+		return fmt.Errorf("unexpected KCS state %s, expected %s", state, expected)
+	}
+	return nil
+}
+
+func (k *KCS) waitIBF(ctx context.Context) error {
+	return k.poller.poll(ctx, "KCS IBF clear", func() (bool, error) {
+		status, err := k.status()
+		return status&KCSStatusIBF == 0, err
+	})
+}
+
+func (k *KCS) waitOBF(ctx context.Context) error {
+	return k.poller.poll(ctx, "KCS OBF set", func() (bool, error) {
+		status, err := k.status()
+		return status&KCSStatusOBF != 0, err
+	})
+}
+
+func (k *KCS) clearOBF() error {
+	status, err := k.status()
+	if err != nil {
+		return err
+	}
+	if status&KCSStatusOBF != 0 {
+		_, err = k.io.InB(k.base)
+	}
+	return err
+}
+
+func (k *KCS) writeCommand(ctx context.Context, cmd uint8) error {
+	if err := k.io.OutB(k.base+1, cmd); err != nil {
+		return err
+	}
+	if err := k.waitIBF(ctx); err != nil {
+		return err
+	}
+	return k.clearOBF()
+}
+
+func (k *KCS) writeData(ctx context.Context, b uint8) error {
+	if err := k.io.OutB(k.base, b); err != nil {
+		return err
+	}
+	return k.waitIBF(ctx)
+}
@@ -0,0 +1,190 @@
+package si
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+// kcsSim is the BMC side of the KCS interface, it processes each write of the host immediately.
+type kcsSim struct {
+	base    uint16
+	handler func(req []byte) []byte
+
+	state  KCSState
+	status uint8
+	out    uint8
+
+	req      []byte
+	res      []byte
+	writeEnd bool
+	aborting bool
+
+	// failWriteEnd makes the BMC go to ERROR_STATE on the next WRITE_END.
+	failWriteEnd bool
+	aborts       int
+}
+
+func (s *kcsSim) setOut(b uint8) {
+	s.out = b
+	s.status |= KCSStatusOBF
+}
+
+func (s *kcsSim) InB(port uint16) (uint8, error) {
+	switch port {
+	case s.base:
+		s.status &^= KCSStatusOBF
+		return s.out, nil
+	case s.base + 1:
+		return s.status&0x3f | uint8(s.state)<<6, nil
+	}
+	return 0xff, nil
+}
+
+func (s *kcsSim) OutB(port uint16, v uint8) error {
+	switch port {
+	case s.base + 1:
+		s.status |= KCSStatusCD
+		s.command(v)
+	case s.base:
+		s.status &^= KCSStatusCD
+		s.data(v)
+	}
+	// the BMC has read the input buffer
+	s.status &^= KCSStatusIBF
+	return nil
+}
+
+func (s *kcsSim) command(cmd uint8) {
+	switch cmd {
+	case KCSWriteStart:
+		s.state = KCSStateWrite
+		s.req = nil
+		s.writeEnd = false
+		s.aborting = false
+		// a stale output byte the host must clear
+		s.setOut(0xee)
+	case KCSWriteEnd:
+		if s.failWriteEnd {
+			s.failWriteEnd = false
+			s.state = KCSStateError
+			return
+		}
+		if s.state != KCSStateWrite {
+			s.state = KCSStateError
+			return
+		}
+		s.writeEnd = true
+	case KCSGetStatusAbort:
+		s.aborts++
+		s.state = KCSStateWrite
+		s.aborting = true
+		s.setOut(0x00)
+	default:
+		s.state = KCSStateError
+	}
+}
+
+func (s *kcsSim) data(b uint8) {
+	switch s.state {
+	case KCSStateWrite:
+		if s.aborting {
+			// the status code, 01h Aborted by command
+			s.state = KCSStateRead
+			s.res = []byte{0x01}
+			s.setOut(s.next())
+			return
+		}
+		s.req = append(s.req, b)
+		if s.writeEnd {
+			s.state = KCSStateRead
+			s.res = s.handler(s.req)
+			s.setOut(s.next())
+		}
+	case KCSStateRead:
+		if b != KCSRead {
+			s.state = KCSStateError
+			return
+		}
+		if len(s.res) == 0 {
+			s.state = KCSStateIdle
+			s.aborting = false
+			// dummy byte
+			s.setOut(0x00)
+			return
+		}
+		s.setOut(s.next())
+	default:
+		s.state = KCSStateError
+	}
+}
+
+func (s *kcsSim) next() uint8 {
+	b := s.res[0]
+	s.res = s.res[1:]
+	return b
+}
+
+func Test_KCS(t *testing.T) {
+	sim := &kcsSim{
+		base: DefaultKCSBase,
+		handler: func(req []byte) []byte {
+			// Get Device ID
+			if req[0] == 0x06<<2 && req[1] == 0x01 {
+				return []byte{0x07 << 2, 0x01, 0x00, 0x20, 0x81, 0x01, 0x10, 0x02, 0xbf, 0x57, 0x01, 0x00, 0x34, 0x12}
+			}
+			return []byte{req[0] + 0x04, req[1], 0xc1}
+		},
+	}
+	kcs := NewKCS(sim, 0)
+
+	res, err := kcs.Exchange(context.Background(), []byte{0x06 << 2, 0x01})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{0x07 << 2, 0x01, 0x00, 0x20, 0x81, 0x01, 0x10, 0x02, 0xbf, 0x57, 0x01, 0x00, 0x34, 0x12}
+	if !bytes.Equal(res, expected) {
+		t.Errorf("response not match, expected: % x, got: % x", expected, res)
+	}
+	if sim.state != KCSStateIdle {
+		t.Errorf("expect KCS idle after the transfer, got: %s", sim.state)
+	}
+
+	// the request with data, the BMC fails the first attempt
+	sim.failWriteEnd = true
+	res, err = kcs.Exchange(context.Background(), []byte{0x0a << 2, 0x43, 0x00, 0x00, 0x00, 0x00, 0x00, 0xff})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(res, []byte{0x0b << 2, 0x43, 0xc1}) {
+		t.Errorf("response not match, got: % x", res)
+	}
+	if sim.aborts != 1 {
+		t.Errorf("expect 1 abort, got: %d", sim.aborts)
+	}
+	if len(sim.req) != 8 {
+		t.Errorf("request not fully written, got: % x", sim.req)
+	}
+}
+
+func Test_KCS_Timeout(t *testing.T) {
+	sim := &kcsSim{base: DefaultKCSBase}
+	// the BMC never reads the input buffer
+	sim.status = KCSStatusIBF
+	kcs := NewKCS(stuckIBF{sim}, 0).WithRetries(0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := kcs.Exchange(ctx, []byte{0x06 << 2, 0x01}); err == nil {
+		t.Error("expect error when IBF is never cleared")
+	}
+}
+
+// stuckIBF keeps the IBF bit set.
+type stuckIBF struct {
+	*kcsSim
+}
+
+func (s stuckIBF) OutB(port uint16, v uint8) error {
+	return nil
+}
@@ -0,0 +1,105 @@
+package si
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Conn exchanges the IPMI messages with the BMC over a system interface
+// (KCS, BT or SSIF) without the linux ipmi driver.
+//
+// The request message is the NetFn/LUN, Command and Data bytes, the response message
+// is the NetFn/LUN, Command, Completion Code and Data bytes.
+// The framing bytes of the interface (like the BT Seq) are not included.
+type Conn interface {
+	Exchange(ctx context.Context, msg []byte) ([]byte, error)
+	Close() error
+}
+
+const (
+	// DefaultKCSBase is the default I/O port base address of the KCS interface (data register),
+	// the status/command register is at base+1.
+	DefaultKCSBase uint16 = 0xca2
+
+	// DefaultBTBase is the default I/O port base address of the BT interface (control register),
+	// the buffer register is at base+1, and the interrupt mask register at base+2.
+	DefaultBTBase uint16 = 0xe4
+
+	// DefaultSSIFAddr is the default 7-bit SMBus slave address of the SSIF interface.
+	DefaultSSIFAddr uint8 = 0x10
+
+	// DefaultWaitTimeout is how long to wait for the BMC to change the state of the interface.
+	DefaultWaitTimeout time.Duration = 5 * time.Second
+
+	// DefaultRetries is how many times the message is resent after the interface is aborted.
+	DefaultRetries int = 2
+)
+
+// PortIO reads and writes the I/O ports of the system interface registers.
+type PortIO interface {
+	InB(port uint16) (uint8, error)
+	OutB(port uint16, v uint8) error
+}
+
+// DevPort is the PortIO by the /dev/port file, the offset of the file is the port address.
+type DevPort struct {
+	file *os.File
+}
+
+// OpenDevPort opens /dev/port, it generally requires root (CAP_SYS_RAWIO).
+func OpenDevPort() (*DevPort, error) {
+	file, err := os.OpenFile("/dev/port", os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("open /dev/port failed, err: %w", err)
+	}
+	return &DevPort{file: file}, nil
+}
+
+func (p *DevPort) InB(port uint16) (uint8, error) {
+	var b [1]byte
+	if _, err := p.file.ReadAt(b[:], int64(port)); err != nil {
+		return 0, fmt.Errorf("read port %#04x failed, err: %w", port, err)
+	}
+	return b[0], nil
+}
+
+func (p *DevPort) OutB(port uint16, v uint8) error {
+	if _, err := p.file.WriteAt([]byte{v}, int64(port)); err != nil {
+		return fmt.Errorf("write port %#04x failed, err: %w", port, err)
+	}
+	return nil
+}
+
+func (p *DevPort) Close() error {
+	return p.file.Close()
+}
+
+// poller polls a condition until it is met, ctx is done or the timeout is exceeded.
+type poller struct {
+	timeout  time.Duration
+	interval time.Duration
+}
+
+func (p poller) poll(ctx context.Context, what string, cond func() (bool, error)) error {
+	deadline := time.Now().Add(p.timeout)
+	for {
+		ok, err := cond()
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("wait for %s aborted, err: %w", what, err)
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("wait for %s timeout after %s, err: %w", what, p.timeout, os.ErrDeadlineExceeded)
+		}
+		if p.interval > 0 {
+			time.Sleep(p.interval)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package si
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// 12.15 SMBus System Interface (SSIF) commands
+const (
+	SSIFWriteSingle      uint8 = 0x02
+	SSIFWriteMultiStart  uint8 = 0x06
+	SSIFWriteMultiMiddle uint8 = 0x07
+	SSIFWriteMultiEnd    uint8 = 0x08
+	SSIFReadStart        uint8 = 0x03
+	SSIFReadMiddle       uint8 = 0x09
+	SSIFReadRetry        uint8 = 0x0a
+)
+
+// SSIFMaxBlockSize is the max data size of a SMBus block transfer.
+const SSIFMaxBlockSize = 32
+
+// SMBus does the SMBus block transfers to the SSIF slave address.
+type SMBus interface {
+	// WriteBlock does the SMBus Block Write with the command code.
+	WriteBlock(cmd uint8, data []byte) error
+
+	// ReadBlock does the SMBus Block Read with the command code.
+	// It fails if the BMC NAKs, like when the response is not ready.
+	ReadBlock(cmd uint8) ([]byte, error)
+}
+
+// SSIF is the host side of the SMBus System Interface, see 12 SMBus System Interface (SSIF).
+// The response is polled, the SMBus alert is not used.
+type SSIF struct {
+	bus SMBus
+
+	mu sync.Mutex
+
+	poller  poller
+	retries int
+}
+
+// NewSSIF creates the SSIF interface on the SMBus.
+func NewSSIF(bus SMBus) *SSIF {
+	return &SSIF{
+		bus: bus,
+		poller: poller{
+			timeout: DefaultWaitTimeout,
+			// the BMC is given some time to process the request before it's polled again
+			interval: 10 * time.Millisecond,
+		},
+		retries: DefaultRetries,
+	}
+}
+
+// OpenSSIF creates the SSIF interface at the 7-bit slave addr on the i2c bus by /dev/i2c-<bus>.
+func OpenSSIF(bus int, addr uint8) (*SSIF, error) {
+	if addr == 0 {
+		addr = DefaultSSIFAddr
+	}
+	dev, err := OpenI2C(bus, addr)
+	if err != nil {
+		return nil, err
+	}
+	return NewSSIF(dev), nil
+}
+
+func (s *SSIF) Close() error {
+	if closer, ok := s.bus.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func (s *SSIF) Exchange(ctx context.Context, msg []byte) ([]byte, error) {
+	if len(msg) < 2 {
+		return nil, fmt.Errorf("message at least contains NetFn/LUN and Command bytes")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var err error
+	for attempt := 0; attempt <= s.retries; attempt++ {
+		if err = s.write(msg); err != nil {
+			continue
+		}
+		var res []byte
+		res, err = s.read(ctx)
+		if err == nil || ctx.Err() != nil {
+			return res, err
+		}
+	}
+	return nil, err
+}
+
+// write sends the request by single-part or multi-part write.
+func (s *SSIF) write(msg []byte) error {
+	if len(msg) <= SSIFMaxBlockSize {
+		return s.bus.WriteBlock(SSIFWriteSingle, msg)
+	}
+
+	if err := s.bus.WriteBlock(SSIFWriteMultiStart, msg[:SSIFMaxBlockSize]); err != nil {
+		return err
+	}
+	msg = msg[SSIFMaxBlockSize:]
+	for len(msg) > SSIFMaxBlockSize {
+		if err := s.bus.WriteBlock(SSIFWriteMultiMiddle, msg[:SSIFMaxBlockSize]); err != nil {
+			return err
+		}
+		msg = msg[SSIFMaxBlockSize:]
+	}
+	return s.bus.WriteBlock(SSIFWriteMultiEnd, msg)
+}
+
+// read polls the response, the BMC NAKs the read until the response is ready.
+func (s *SSIF) read(ctx context.Context) ([]byte, error) {
+	var block []byte
+	err := s.poller.poll(ctx, "SSIF response", func() (bool, error) {
+		b, err := s.bus.ReadBlock(SSIFReadStart)
+		if err != nil {
+			return false, nil
+		}
+		block = b
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// single-part read
+	if len(block) < 2 || block[0] != 0x00 || block[1] != 0x01 {
+		return block, nil
+	}
+
+	// multi-part read, the first block starts with 00h 01h,
+	// the middle blocks start with the block number, and the last block with FFh
+	res := append([]byte{}, block[2:]...)
+	for blockNumber := 0; ; blockNumber++ {
+		block, err := s.bus.ReadBlock(SSIFReadMiddle)
+		if err != nil {
+			block, err = s.bus.ReadBlock(SSIFReadRetry)
+			if err != nil {
+				return nil, fmt.Errorf("read SSIF block %d failed, err: %w", blockNumber, err)
+			}
+		}
+		if len(block) < 1 {
+			return nil, fmt.Errorf("empty SSIF block %d", blockNumber)
+		}
+		res = append(res, block[1:]...)
+		if block[0] == 0xff {
+			return res, nil
+		}
+		if int(block[0]) != blockNumber {
+			return nil, fmt.Errorf("unexpected SSIF block number %d, expected %d", block[0], blockNumber)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package si
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+)
+
+// ssifSim is the BMC side of the SSIF interface, it NAKs the first read of each response.
+type ssifSim struct {
+	handler func(req []byte) []byte
+
+	req    []byte
+	res    []byte
+	naks   int
+	blocks int
+	writes []uint8
+}
+
+func (s *ssifSim) WriteBlock(cmd uint8, data []byte) error {
+	s.writes = append(s.writes, cmd)
+	switch cmd {
+	case SSIFWriteSingle, SSIFWriteMultiStart:
+		s.req = append([]byte{}, data...)
+	case SSIFWriteMultiMiddle, SSIFWriteMultiEnd:
+		s.req = append(s.req, data...)
+	}
+	if cmd == SSIFWriteSingle || cmd == SSIFWriteMultiEnd {
+		s.res = s.handler(s.req)
+		s.naks = 1
+		s.blocks = 0
+	}
+	return nil
+}
+
+func (s *ssifSim) ReadBlock(cmd uint8) ([]byte, error) {
+	switch cmd {
+	case SSIFReadStart:
+		if s.naks > 0 {
+			s.naks--
+			return nil, fmt.Errorf("nak")
+		}
+		if len(s.res) <= SSIFMaxBlockSize {
+			return s.res, nil
+		}
+		block := append([]byte{0x00, 0x01}, s.res[:SSIFMaxBlockSize-2]...)
+		s.res = s.res[SSIFMaxBlockSize-2:]
+		return block, nil
+
+	case SSIFReadMiddle:
+		if len(s.res) <= SSIFMaxBlockSize-1 {
+			return append([]byte{0xff}, s.res...), nil
+		}
+		block := append([]byte{uint8(s.blocks)}, s.res[:SSIFMaxBlockSize-1]...)
+		s.res = s.res[SSIFMaxBlockSize-1:]
+		s.blocks++
+		return block, nil
+	}
+	return nil, fmt.Errorf("nak")
+}
+
+func Test_SSIF(t *testing.T) {
+	payload := make([]byte, 100)
+	for i := range payload {
+		payload[i] = uint8(i)
+	}
+
+	sim := &ssifSim{
+		handler: func(req []byte) []byte {
+			// echo the request data in the response
+			return append([]byte{req[0] + 0x04, req[1], 0x00}, req[2:]...)
+		},
+	}
+	ssif := NewSSIF(sim)
+	ssif.poller.interval = 0
+
+	req := append([]byte{0x2e << 2, 0x01}, payload...)
+	res, err := ssif.Exchange(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := append([]byte{0x2f << 2, 0x01, 0x00}, payload...)
+	if !bytes.Equal(res, expected) {
+		t.Errorf("response not match, expected: % x, got: % x", expected, res)
+	}
+	if !bytes.Equal(sim.writes, []byte{SSIFWriteMultiStart, SSIFWriteMultiMiddle, SSIFWriteMultiMiddle, SSIFWriteMultiEnd}) {
+		t.Errorf("multi-part write not match, got: % x", sim.writes)
+	}
+
+	sim.writes = nil
+	res, err = ssif.Exchange(context.Background(), []byte{0x06 << 2, 0x01})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(res, []byte{0x07 << 2, 0x01, 0x00}) || !bytes.Equal(sim.writes, []byte{SSIFWriteSingle}) {
+		t.Errorf("single-part exchange not match, got: % x, writes: % x", res, sim.writes)
+	}
+}
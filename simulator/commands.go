@@ -0,0 +1,904 @@
+package simulator
+
+import (
+	"bytes"
+	"encoding/binary"
+	"time"
+
+	"github.com/bougou/go-ipmi"
+)
+
+// builtinHandlers are the commands supported by the simulated BMC besides the session management commands.
+// The privilege levels follow Appendix G - Command Assignments.
+var builtinHandlers = []struct {
+	command   ipmi.Command
+	privilege ipmi.PrivilegeLevel
+	fn        HandlerFunc
+}{
+	{ipmi.CommandGetDeviceID, ipmi.PrivilegeLevelUser, getDeviceID},
+	{ipmi.CommandGetSelfTestResults, ipmi.PrivilegeLevelUser, getSelfTestResults},
+	{ipmi.CommandGetDeviceGUID, ipmi.PrivilegeLevelUser, getGUID},
+	{ipmi.CommandGetSystemGUID, ipmi.PrivilegeLevelUser, getGUID},
+	{ipmi.CommandGetChannelInfo, ipmi.PrivilegeLevelUser, getChannelInfo},
+
+	{ipmi.CommandGetChassisStatus, ipmi.PrivilegeLevelUser, getChassisStatus},
+	{ipmi.CommandChassisControl, ipmi.PrivilegeLevelOperator, chassisControl},
+	{ipmi.CommandSetPowerRestorePolicy, ipmi.PrivilegeLevelOperator, setPowerRestorePolicy},
+
+	{ipmi.CommandGetSELInfo, ipmi.PrivilegeLevelUser, getSELInfo},
+	{ipmi.CommandReserveSEL, ipmi.PrivilegeLevelUser, reserveSEL},
+	{ipmi.CommandGetSELEntry, ipmi.PrivilegeLevelUser, getSELEntry},
+	{ipmi.CommandAddSELEntry, ipmi.PrivilegeLevelOperator, addSELEntry},
+	{ipmi.CommandDeleteSELEntry, ipmi.PrivilegeLevelOperator, deleteSELEntry},
+	{ipmi.CommandClearSEL, ipmi.PrivilegeLevelOperator, clearSEL},
+	{ipmi.CommandGetSELTime, ipmi.PrivilegeLevelUser, getSELTime},
+	{ipmi.CommandSetSELTime, ipmi.PrivilegeLevelOperator, setSELTime},
+
+	{ipmi.CommandGetSDRRepoInfo, ipmi.PrivilegeLevelUser, getSDRRepoInfo},
+	{ipmi.CommandReserveSDRRepo, ipmi.PrivilegeLevelUser, reserveSDRRepo},
+	{ipmi.CommandGetSDR, ipmi.PrivilegeLevelUser, getSDR},
+	{ipmi.CommandAddSDR, ipmi.PrivilegeLevelOperator, addSDR},
+	{ipmi.CommandPartialAddSDR, ipmi.PrivilegeLevelOperator, partialAddSDR},
+	{ipmi.CommandDeleteSDR, ipmi.PrivilegeLevelOperator, deleteSDR},
+	{ipmi.CommandClearSDRRepo, ipmi.PrivilegeLevelOperator, clearSDRRepo},
+
+	{ipmi.CommandGetSensorReading, ipmi.PrivilegeLevelUser, getSensorReading},
+	{ipmi.CommandGetSensorReadingFactors, ipmi.PrivilegeLevelUser, getSensorReadingFactors},
+	{ipmi.CommandGetSensorEventStatus, ipmi.PrivilegeLevelUser, getSensorEventStatus},
+	{ipmi.CommandGetSensorThresholds, ipmi.PrivilegeLevelUser, getSensorThresholds},
+	{ipmi.CommandSetSensorThresholds, ipmi.PrivilegeLevelOperator, setSensorThresholds},
+	{ipmi.CommandGetSensorHysteresis, ipmi.PrivilegeLevelUser, getSensorHysteresis},
+	{ipmi.CommandSetSensorHysteresis, ipmi.PrivilegeLevelOperator, setSensorHysteresis},
+
+	{ipmi.CommandGetFRUInventoryAreaInfo, ipmi.PrivilegeLevelUser, getFRUInventoryAreaInfo},
+	{ipmi.CommandReadFRUData, ipmi.PrivilegeLevelUser, readFRUData},
+	{ipmi.CommandWriteFRUData, ipmi.PrivilegeLevelOperator, writeFRUData},
+
+	{ipmi.CommandGetUserAccess, ipmi.PrivilegeLevelOperator, getUserAccess},
+	{ipmi.CommandSetUserAccess, ipmi.PrivilegeLevelAdministrator, setUserAccess},
+	{ipmi.CommandGetUsername, ipmi.PrivilegeLevelOperator, getUsername},
+	{ipmi.CommandSetUsername, ipmi.PrivilegeLevelAdministrator, setUsername},
+	{ipmi.CommandSetUserPassword, ipmi.PrivilegeLevelAdministrator, setUserPassword},
+
+	{ipmi.CommandGetLanConfigParams, ipmi.PrivilegeLevelOperator, getLanConfigParams},
+	{ipmi.CommandSetLanConfigParams, ipmi.PrivilegeLevelAdministrator, setLanConfigParams},
+}
+
+// getDeviceID implements 20.1 Get Device ID Command.
+func getDeviceID(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	out := make([]byte, 15)
+	out[0] = m.DeviceID
+	out[1] = m.DeviceRevision & 0x0f
+	out[2] = m.FirmwareMajor & 0x7f
+	out[3] = m.FirmwareMinor
+	out[4] = 0x02 // IPMI v2.0
+	out[5] = 0x8f // chassis, FRU inventory, SEL, SDR repository and sensor devices
+	out[6] = uint8(m.ManufacturerID)
+	out[7] = uint8(m.ManufacturerID >> 8)
+	out[8] = uint8(m.ManufacturerID >> 16)
+	binary.LittleEndian.PutUint16(out[9:], m.ProductID)
+	copy(out[11:], m.AuxiliaryFirmware[:])
+	return out, ipmi.CompletionCodeNormal
+}
+
+// getSelfTestResults implements 20.4 Get Self Test Results Command.
+func getSelfTestResults(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	return []byte{0x55, 0x00}, ipmi.CompletionCodeNormal // no error
+}
+
+// getGUID implements 20.8 Get Device GUID Command and 22.14 Get System GUID Command.
+func getGUID(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	return append([]byte{}, m.GUID[:]...), ipmi.CompletionCodeNormal
+}
+
+// getChannelInfo implements 22.24 Get Channel Info Command, only the LAN channel is present.
+func getChannelInfo(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	if len(req.Data) < 1 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+	if !isLanChannel(req.Data[0]) {
+		return nil, ipmi.CompletionCodeRequestDataFieldInvalid
+	}
+
+	return []byte{
+		LanChannelNumber,
+		0x04,             // 802.3 LAN
+		0x01,             // IPMB-1.0
+		0x80,             // multi-session
+		0xf2, 0x1b, 0x00, // IPMI Enterprise Number
+		0x00, 0x00,
+	}, ipmi.CompletionCodeNormal
+}
+
+// getChassisStatus implements 28.2 Get Chassis Status Command.
+func getChassisStatus(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	out := make([]byte, 3)
+	out[0] = uint8(m.PowerRestorePolicy&0x03) << 5
+	if m.PowerOn {
+		out[0] |= 0x01
+	}
+	return out, ipmi.CompletionCodeNormal
+}
+
+// chassisControl implements 28.3 Chassis Control Command.
+func chassisControl(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	if len(req.Data) < 1 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+
+	switch ipmi.ChassisControl(req.Data[0] & 0x0f) {
+	case ipmi.ChassisControlPowerDown, ipmi.ChassisControlSoftShutdown:
+		m.PowerOn = false
+	case ipmi.ChassisControlPowerUp:
+		m.PowerOn = true
+	case ipmi.ChassisControlPowerCycle, ipmi.ChassisControlHardReset:
+		// the system stays powered off for power cycle and hard reset
+		if !m.PowerOn {
+			return nil, ipmi.CompletionCodeCannotExecuteCommandNotSupported
+		}
+	case ipmi.ChassisControlDiagnosticInterrupt:
+	default:
+		return nil, ipmi.CompletionCodeRequestDataFieldInvalid
+	}
+	return nil, ipmi.CompletionCodeNormal
+}
+
+// setPowerRestorePolicy implements 28.8 Set Power Restore Policy Command.
+func setPowerRestorePolicy(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	if len(req.Data) < 1 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+
+	switch policy := req.Data[0] & 0x07; policy {
+	case 0x03:
+		// no change, only gets the supported policies
+	case uint8(ipmi.PowerRestorePolicyAlwaysOff), uint8(ipmi.PowerRestorePolicyPrevious), uint8(ipmi.PowerRestorePolicyAlwaysOn):
+		m.PowerRestorePolicy = ipmi.PowerRestorePolicy(policy)
+	default:
+		return nil, ipmi.CompletionCodeRequestDataFieldInvalid
+	}
+	return []byte{0x07}, ipmi.CompletionCodeNormal // all the policies are supported
+}
+
+// getSELInfo implements 31.2 Get SEL Info Command.
+func getSELInfo(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	out := make([]byte, 14)
+	out[0] = 0x51 // IPMI v2.0
+	binary.LittleEndian.PutUint16(out[1:], uint16(len(m.SEL)))
+	binary.LittleEndian.PutUint16(out[3:], freeBytes(len(m.SEL)*16))
+	binary.LittleEndian.PutUint32(out[5:], timestamp(m.selAddTime))
+	binary.LittleEndian.PutUint32(out[9:], timestamp(m.selEraseTime))
+	out[13] = 0x08 | 0x02 // delete and reserve are supported
+	return out, ipmi.CompletionCodeNormal
+}
+
+// reserveSEL implements 31.4 Reserve SEL Command.
+func reserveSEL(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	m.selReservationID = nextReservationID(m.selReservationID)
+	out := make([]byte, 2)
+	binary.LittleEndian.PutUint16(out, m.selReservationID)
+	return out, ipmi.CompletionCodeNormal
+}
+
+// getSELEntry implements 31.5 Get SEL Entry Command.
+func getSELEntry(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	if len(req.Data) < 6 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+	reservationID := binary.LittleEndian.Uint16(req.Data[0:])
+	recordID := binary.LittleEndian.Uint16(req.Data[2:])
+	offset, count := req.Data[4], req.Data[5]
+
+	// the reservation is only required for partial reading
+	if offset != 0 && reservationID != m.selReservationID {
+		return nil, ipmi.CompletionCodeReservationCanceled
+	}
+
+	i := m.selIndex(recordID)
+	if i < 0 {
+		return nil, ipmi.CompletionCodeRequestedDataNotPresent
+	}
+	nextRecordID := uint16(0xffff)
+	if i+1 < len(m.SEL) {
+		nextRecordID = m.SEL[i+1].RecordID
+	}
+
+	data, cc := readBytes(m.SEL[i].Pack(), offset, count)
+	if cc != ipmi.CompletionCodeNormal {
+		return nil, cc
+	}
+	out := make([]byte, 2, 2+len(data))
+	binary.LittleEndian.PutUint16(out, nextRecordID)
+	return append(out, data...), ipmi.CompletionCodeNormal
+}
+
+// addSELEntry implements 31.6 Add SEL Entry Command.
+func addSELEntry(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	if len(req.Data) != 16 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+	sel, err := ipmi.ParseSEL(req.Data)
+	if err != nil {
+		return nil, ipmi.CompletionCodeRequestDataFieldInvalid
+	}
+
+	out := make([]byte, 2)
+	binary.LittleEndian.PutUint16(out, m.AddSEL(sel))
+	return out, ipmi.CompletionCodeNormal
+}
+
+// deleteSELEntry implements 31.8 Delete SEL Entry Command.
+func deleteSELEntry(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	if len(req.Data) < 4 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+	if binary.LittleEndian.Uint16(req.Data[0:]) != m.selReservationID {
+		return nil, ipmi.CompletionCodeReservationCanceled
+	}
+
+	i := m.selIndex(binary.LittleEndian.Uint16(req.Data[2:]))
+	if i < 0 {
+		return nil, ipmi.CompletionCodeRequestedDataNotPresent
+	}
+	recordID := m.SEL[i].RecordID
+	m.SEL = append(m.SEL[:i], m.SEL[i+1:]...)
+	m.selEraseTime = time.Now()
+
+	out := make([]byte, 2)
+	binary.LittleEndian.PutUint16(out, recordID)
+	return out, ipmi.CompletionCodeNormal
+}
+
+// clearSEL implements 31.9 Clear SEL Command, the SEL is erased immediately.
+func clearSEL(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	if len(req.Data) < 6 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+	if binary.LittleEndian.Uint16(req.Data[0:]) != m.selReservationID {
+		return nil, ipmi.CompletionCodeReservationCanceled
+	}
+	if !bytes.Equal(req.Data[2:5], []byte("CLR")) {
+		return nil, ipmi.CompletionCodeRequestDataFieldInvalid
+	}
+
+	switch req.Data[5] {
+	case 0xaa: // initiate erase
+		m.SEL = nil
+		m.selEraseTime = time.Now()
+	case 0x00: // get erasure status
+	default:
+		return nil, ipmi.CompletionCodeRequestDataFieldInvalid
+	}
+	return []byte{0x01}, ipmi.CompletionCodeNormal // erasure completed
+}
+
+// getSELTime implements 31.10 Get SEL Time Command.
+func getSELTime(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	out := make([]byte, 4)
+	binary.LittleEndian.PutUint32(out, uint32(m.Now().Unix()))
+	return out, ipmi.CompletionCodeNormal
+}
+
+// setSELTime implements 31.11 Set SEL Time Command.
+func setSELTime(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	if len(req.Data) < 4 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+	t := time.Unix(int64(binary.LittleEndian.Uint32(req.Data)), 0)
+	m.SELTimeOffset = time.Until(t)
+	return nil, ipmi.CompletionCodeNormal
+}
+
+// getSDRRepoInfo implements 33.9 Get SDR Repository Info Command.
+func getSDRRepoInfo(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	records := m.sdrRepository()
+	var size int
+	for _, record := range records {
+		size += len(record)
+	}
+
+	out := make([]byte, 14)
+	out[0] = 0x51 // IPMI v2.0
+	binary.LittleEndian.PutUint16(out[1:], uint16(len(records)))
+	binary.LittleEndian.PutUint16(out[3:], freeBytes(size))
+	binary.LittleEndian.PutUint32(out[5:], timestamp(m.sdrAddTime))
+	binary.LittleEndian.PutUint32(out[9:], timestamp(m.sdrEraseTime))
+	out[13] = 0x20 | 0x08 | 0x04 | 0x02 // non-modal update, delete, partial add and reserve are supported
+	return out, ipmi.CompletionCodeNormal
+}
+
+// reserveSDRRepo implements 33.11 Reserve SDR Repository Command.
+func reserveSDRRepo(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	m.sdrReservationID = nextReservationID(m.sdrReservationID)
+	out := make([]byte, 2)
+	binary.LittleEndian.PutUint16(out, m.sdrReservationID)
+	return out, ipmi.CompletionCodeNormal
+}
+
+// getSDR implements 33.12 Get SDR Command.
+func getSDR(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	if len(req.Data) < 6 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+	reservationID := binary.LittleEndian.Uint16(req.Data[0:])
+	recordID := binary.LittleEndian.Uint16(req.Data[2:])
+	offset, count := req.Data[4], req.Data[5]
+
+	// the reservation is only required for partial reading
+	if offset != 0 && reservationID != m.sdrReservationID {
+		return nil, ipmi.CompletionCodeReservationCanceled
+	}
+
+	records := m.sdrRepository()
+	i := m.sdrIndex(recordID)
+	if i < 0 {
+		return nil, ipmi.CompletionCodeRequestedDataNotPresent
+	}
+	nextRecordID := uint16(0xffff)
+	if i+1 < len(records) {
+		nextRecordID = binary.LittleEndian.Uint16(records[i+1])
+	}
+
+	if m.SDRReadLimit != 0 && count > m.SDRReadLimit {
+		return nil, ipmi.CompletionCodeCannotReturnRequestedDataBytes
+	}
+	data, cc := readBytes(records[i], offset, count)
+	if cc != ipmi.CompletionCodeNormal {
+		return nil, cc
+	}
+	out := make([]byte, 2, 2+len(data))
+	binary.LittleEndian.PutUint16(out, nextRecordID)
+	return append(out, data...), ipmi.CompletionCodeNormal
+}
+
+// addSDR implements 33.13 Add SDR Command.
+func addSDR(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	if len(req.Data) < 5 || len(req.Data) != 5+int(req.Data[4]) {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+	record := make([]byte, len(req.Data))
+	copy(record, req.Data)
+
+	out := make([]byte, 2)
+	binary.LittleEndian.PutUint16(out, m.addSDR(record))
+	return out, ipmi.CompletionCodeNormal
+}
+
+// partialAddSDR implements 33.14 Partial Add SDR Command.
+func partialAddSDR(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	if len(req.Data) < 6 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+	if binary.LittleEndian.Uint16(req.Data[0:]) != m.sdrReservationID {
+		return nil, ipmi.CompletionCodeReservationCanceled
+	}
+	recordID := binary.LittleEndian.Uint16(req.Data[2:])
+	offset, last := req.Data[4], req.Data[5]&0x0f == 0x01
+
+	if recordID == 0x0000 && offset == 0 {
+		m.writableSDRs()
+		m.sdrPartial = nil
+		m.sdrPartialRecordID = m.sdrNextRecordID
+	} else if recordID != m.sdrPartialRecordID || int(offset) != len(m.sdrPartial) {
+		return nil, ipmi.CompletionCodeParameterOutOfRange
+	}
+	m.sdrPartial = append(m.sdrPartial, req.Data[6:]...)
+
+	out := make([]byte, 2)
+	binary.LittleEndian.PutUint16(out, m.sdrPartialRecordID)
+	if !last {
+		return out, ipmi.CompletionCodeNormal
+	}
+
+	record := m.sdrPartial
+	m.sdrPartial, m.sdrPartialRecordID = nil, 0
+	if len(record) < 5 || len(record) != 5+int(record[4]) {
+		return nil, 0x80 // record rejected due to length mismatch
+	}
+	m.addSDR(record)
+	return out, ipmi.CompletionCodeNormal
+}
+
+// deleteSDR implements 33.15 Delete SDR Command.
+func deleteSDR(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	if len(req.Data) < 4 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+	if binary.LittleEndian.Uint16(req.Data[0:]) != m.sdrReservationID {
+		return nil, ipmi.CompletionCodeReservationCanceled
+	}
+
+	i := m.sdrIndex(binary.LittleEndian.Uint16(req.Data[2:]))
+	if i < 0 {
+		return nil, ipmi.CompletionCodeRequestedDataNotPresent
+	}
+	records := m.writableSDRs()
+	out := records[i][:2:2]
+	m.sdrs = append(records[:i], records[i+1:]...)
+	m.sdrEraseTime = time.Now()
+	return out, ipmi.CompletionCodeNormal
+}
+
+// clearSDRRepo implements 33.16 Clear SDR Repository Command, the SDR repository is erased immediately.
+func clearSDRRepo(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	if len(req.Data) < 6 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+	if binary.LittleEndian.Uint16(req.Data[0:]) != m.sdrReservationID {
+		return nil, ipmi.CompletionCodeReservationCanceled
+	}
+	if !bytes.Equal(req.Data[2:5], []byte("CLR")) {
+		return nil, ipmi.CompletionCodeRequestDataFieldInvalid
+	}
+
+	switch req.Data[5] {
+	case 0xaa: // initiate erase
+		m.writableSDRs()
+		m.sdrs, m.sdrNextRecordID = nil, 1
+		m.sdrEraseTime = time.Now()
+	case 0x00: // get erasure status
+	default:
+		return nil, ipmi.CompletionCodeRequestDataFieldInvalid
+	}
+	return []byte{0x01}, ipmi.CompletionCodeNormal // erasure completed
+}
+
+// getSensorReading implements 35.14 Get Sensor Reading Command.
+func getSensorReading(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	sensor, cc := requestSensor(m, req)
+	if sensor == nil {
+		return nil, cc
+	}
+
+	if sensor.EventReadingType.IsThreshold() {
+		return []byte{sensor.Raw, 0xc0, 0xc0 | sensor.thresholdStatus()}, ipmi.CompletionCodeNormal
+	}
+	return []byte{0x00, 0xc0, uint8(sensor.States), 0x80 | uint8(sensor.States>>8)&0x7f}, ipmi.CompletionCodeNormal
+}
+
+// getSensorReadingFactors implements 35.5 Get Sensor Reading Factors Command.
+func getSensorReadingFactors(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	if len(req.Data) < 2 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+	sensor, cc := requestSensor(m, req)
+	if sensor == nil {
+		return nil, cc
+	}
+	if !sensor.EventReadingType.IsThreshold() {
+		return nil, ipmi.CompletionCodeInvalidCommand
+	}
+
+	// the factors are same for all the readings
+	mf := uint16(sensor.M) & 0x03ff
+	bf := uint16(sensor.B) & 0x03ff
+	return []byte{
+		0xff,
+		uint8(mf), uint8(mf>>8) << 6,
+		uint8(bf), uint8(bf>>8) << 6,
+		0x00,
+		uint8(sensor.RExp)<<4 | uint8(sensor.BExp)&0x0f,
+	}, ipmi.CompletionCodeNormal
+}
+
+// getSensorEventStatus implements 35.13 Get Sensor Event Status Command,
+// the sensors never generate events.
+func getSensorEventStatus(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	sensor, cc := requestSensor(m, req)
+	if sensor == nil {
+		return nil, cc
+	}
+	return []byte{0xc0, 0x00, 0x00}, ipmi.CompletionCodeNormal
+}
+
+// getSensorThresholds implements 35.9 Get Sensor Thresholds Command.
+func getSensorThresholds(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	sensor, cc := requestSensor(m, req)
+	if sensor == nil {
+		return nil, cc
+	}
+	if !sensor.EventReadingType.IsThreshold() {
+		return nil, ipmi.CompletionCodeInvalidCommand
+	}
+
+	out := []byte{sensor.thresholdMask()}
+	for _, typ := range thresholdTypes {
+		out = append(out, sensor.Thresholds[typ])
+	}
+	return out, ipmi.CompletionCodeNormal
+}
+
+// setSensorThresholds implements 35.8 Set Sensor Thresholds Command.
+func setSensorThresholds(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	if len(req.Data) < 8 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+	sensor, cc := requestSensor(m, req)
+	if sensor == nil {
+		return nil, cc
+	}
+	if !sensor.EventReadingType.IsThreshold() {
+		return nil, ipmi.CompletionCodeInvalidCommand
+	}
+
+	mask := req.Data[1]
+	if mask&^sensor.thresholdMask() != 0 {
+		// only the present thresholds are settable
+		return nil, ipmi.CompletionCodeRequestDataFieldInvalid
+	}
+	for i, typ := range thresholdTypes {
+		if mask&(1<<i) != 0 {
+			sensor.Thresholds[typ] = req.Data[2+i]
+		}
+	}
+	return nil, ipmi.CompletionCodeNormal
+}
+
+// getSensorHysteresis implements 35.7 Get Sensor Hysteresis Command.
+func getSensorHysteresis(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	sensor, cc := requestSensor(m, req)
+	if sensor == nil {
+		return nil, cc
+	}
+	if !sensor.EventReadingType.IsThreshold() {
+		return nil, ipmi.CompletionCodeInvalidCommand
+	}
+	return []byte{sensor.PositiveHysteresis, sensor.NegativeHysteresis}, ipmi.CompletionCodeNormal
+}
+
+// setSensorHysteresis implements 35.6 Set Sensor Hysteresis Command.
+func setSensorHysteresis(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	if len(req.Data) < 4 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+	sensor, cc := requestSensor(m, req)
+	if sensor == nil {
+		return nil, cc
+	}
+	if !sensor.EventReadingType.IsThreshold() {
+		return nil, ipmi.CompletionCodeInvalidCommand
+	}
+	sensor.PositiveHysteresis = req.Data[2]
+	sensor.NegativeHysteresis = req.Data[3]
+	return nil, ipmi.CompletionCodeNormal
+}
+
+// getFRUInventoryAreaInfo implements 34.1 Get FRU Inventory Area Info Command.
+func getFRUInventoryAreaInfo(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	fru, cc := requestFRU(m, req)
+	if fru == nil {
+		return nil, cc
+	}
+
+	out := make([]byte, 3)
+	binary.LittleEndian.PutUint16(out, uint16(len(fru)))
+	out[2] = 0x00 // accessed by bytes
+	return out, ipmi.CompletionCodeNormal
+}
+
+// readFRUData implements 34.2 Read FRU Data Command.
+func readFRUData(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	if len(req.Data) < 4 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+	fru, cc := requestFRU(m, req)
+	if fru == nil {
+		return nil, cc
+	}
+
+	offset := int(binary.LittleEndian.Uint16(req.Data[1:]))
+	if offset >= len(fru) {
+		return nil, ipmi.CompletionCodeParameterOutOfRange
+	}
+	end := offset + int(req.Data[3])
+	if end > len(fru) {
+		end = len(fru)
+	}
+
+	out := []byte{uint8(end - offset)}
+	return append(out, fru[offset:end]...), ipmi.CompletionCodeNormal
+}
+
+// writeFRUData implements 34.3 Write FRU Data Command.
+func writeFRUData(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	if len(req.Data) < 3 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+	fru, cc := requestFRU(m, req)
+	if fru == nil {
+		return nil, cc
+	}
+
+	offset := int(binary.LittleEndian.Uint16(req.Data[1:]))
+	data := req.Data[3:]
+	if offset+len(data) > len(fru) {
+		return nil, ipmi.CompletionCodeParameterOutOfRange
+	}
+	copy(fru[offset:], data)
+	return []byte{uint8(len(data))}, ipmi.CompletionCodeNormal
+}
+
+// getUserAccess implements 22.27 Get User Access Command.
+func getUserAccess(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	if len(req.Data) < 2 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+	if !isLanChannel(req.Data[0]) {
+		return nil, ipmi.CompletionCodeRequestDataFieldInvalid
+	}
+	userID := req.Data[1] & 0x3f
+	if userID == 0 || userID > MaxUsers {
+		return nil, ipmi.CompletionCodeParameterOutOfRange
+	}
+
+	var enabled uint8
+	for _, user := range m.Users {
+		if user != nil && user.Enabled {
+			enabled++
+		}
+	}
+
+	out := []byte{MaxUsers, enabled, 0x01, 0x0f} // user 1 has the fixed (null) name
+	if user := m.User(userID); user != nil {
+		if user.Enabled {
+			out[1] |= 0x40
+		} else {
+			out[1] |= 0x80
+		}
+		out[3] = uint8(user.MaxPrivilegeLevel) & 0x0f
+		if user.CallbackOnly {
+			out[3] |= 0x40
+		}
+		if user.LinkAuth {
+			out[3] |= 0x20
+		}
+		if user.IPMIMessaging {
+			out[3] |= 0x10
+		}
+	}
+	return out, ipmi.CompletionCodeNormal
+}
+
+// setUserAccess implements 22.26 Set User Access Command.
+func setUserAccess(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	if len(req.Data) < 3 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+	if !isLanChannel(req.Data[0]) {
+		return nil, ipmi.CompletionCodeRequestDataFieldInvalid
+	}
+	user, cc := requestUser(m, req.Data[1])
+	if user == nil {
+		return nil, cc
+	}
+
+	if req.Data[0]&0x80 != 0 {
+		user.CallbackOnly = req.Data[0]&0x40 != 0
+		user.LinkAuth = req.Data[0]&0x20 != 0
+		user.IPMIMessaging = req.Data[0]&0x10 != 0
+	}
+	switch privilege := ipmi.PrivilegeLevel(req.Data[2] & 0x0f); {
+	case privilege == 0x0f: // no access
+		user.MaxPrivilegeLevel = ipmi.PrivilegeLevelUnspecified
+	case privilege > ipmi.PrivilegeLevelOEM:
+		return nil, ipmi.CompletionCodeRequestDataFieldInvalid
+	default:
+		user.MaxPrivilegeLevel = privilege
+	}
+	return nil, ipmi.CompletionCodeNormal
+}
+
+// getUsername implements 22.29 Get User Name Command.
+func getUsername(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	if len(req.Data) < 1 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+	userID := req.Data[0] & 0x3f
+	if userID == 0 || userID > MaxUsers {
+		return nil, ipmi.CompletionCodeParameterOutOfRange
+	}
+
+	out := make([]byte, ipmi.IPMI_MAX_USER_NAME_LENGTH)
+	if user := m.User(userID); user != nil {
+		copy(out, user.Name)
+	}
+	return out, ipmi.CompletionCodeNormal
+}
+
+// setUsername implements 22.28 Set User Name Command, the name of user 1 is fixed.
+func setUsername(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	if len(req.Data) < 17 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+	if req.Data[0]&0x3f == 1 {
+		return nil, ipmi.CompletionCodeRequestDataFieldInvalid
+	}
+	user, cc := requestUser(m, req.Data[0])
+	if user == nil {
+		return nil, cc
+	}
+	user.Name = string(bytes.TrimRight(req.Data[1:17], "\x00"))
+	return nil, ipmi.CompletionCodeNormal
+}
+
+// setUserPassword implements 22.30 Set User Password Command.
+func setUserPassword(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	if len(req.Data) < 2 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+	user, cc := requestUser(m, req.Data[0])
+	if user == nil {
+		return nil, cc
+	}
+
+	operation := ipmi.PasswordOperation(req.Data[1] & 0x03)
+	var password string
+	if operation == ipmi.PasswordOperationSetPassword || operation == ipmi.PasswordOperationTestPassword {
+		size := 16
+		if req.Data[0]&0x80 != 0 {
+			size = 20
+		}
+		if len(req.Data) != 2+size {
+			return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+		}
+		password = string(bytes.TrimRight(req.Data[2:], "\x00"))
+	}
+
+	switch operation {
+	case ipmi.PasswordOperationDisableUser:
+		user.Enabled = false
+	case ipmi.PasswordOperationEnableUser:
+		user.Enabled = true
+	case ipmi.PasswordOperationSetPassword:
+		user.Password = password
+	case ipmi.PasswordOperationTestPassword:
+		if password != user.Password {
+			return nil, 0x80 // password test failed, password size correct, but password data does not match
+		}
+	}
+	return nil, ipmi.CompletionCodeNormal
+}
+
+// getLanConfigParams implements 23.2 Get LAN Configuration Parameters Command.
+func getLanConfigParams(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	if len(req.Data) < 4 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+	if !isLanChannel(req.Data[0]) {
+		return nil, ipmi.CompletionCodeRequestDataFieldInvalid
+	}
+
+	out := []byte{0x11} // parameter revision
+	if req.Data[0]&0x80 != 0 {
+		// only gets the parameter revision
+		return out, ipmi.CompletionCodeNormal
+	}
+	data, ok := m.LanConfig[ipmi.LanParamSelector(req.Data[1])]
+	if !ok {
+		return nil, 0x80 // parameter not supported
+	}
+	return append(out, data...), ipmi.CompletionCodeNormal
+}
+
+// setLanConfigParams implements 23.1 Set LAN Configuration Parameters Command,
+// only the parameters present in the model can be set.
+func setLanConfigParams(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	if len(req.Data) < 3 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+	if !isLanChannel(req.Data[0]) {
+		return nil, ipmi.CompletionCodeRequestDataFieldInvalid
+	}
+
+	paramSelector := ipmi.LanParamSelector(req.Data[1])
+	if _, ok := m.LanConfig[paramSelector]; !ok {
+		return nil, 0x80 // parameter not supported
+	}
+	if paramSelector == ipmi.LanParam_AuthTypeSupported {
+		return nil, 0x82 // attempt to write read-only parameter
+	}
+	m.LanConfig[paramSelector] = append([]byte{}, req.Data[2:]...)
+	return nil, ipmi.CompletionCodeNormal
+}
+
+// isLanChannel reports whether the channel number of the request data byte is the LAN channel.
+func isLanChannel(b uint8) bool {
+	channel := b & 0x0f
+	return channel == LanChannelNumber || channel == ipmi.ChannelNumberSelf
+}
+
+// requestSensor returns the sensor of the sensor number (the first byte) of the request.
+func requestSensor(m *Model, req *Request) (*Sensor, ipmi.CompletionCode) {
+	if len(req.Data) < 1 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+	sensor := m.Sensor(req.Data[0])
+	if sensor == nil {
+		return nil, ipmi.CompletionCodeRequestedDataNotPresent
+	}
+	return sensor, ipmi.CompletionCodeNormal
+}
+
+// requestFRU returns the FRU inventory area of the FRU device ID (the first byte) of the request.
+func requestFRU(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+	if len(req.Data) < 1 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+	fru, ok := m.FRU[req.Data[0]]
+	if !ok || len(fru) == 0 {
+		return nil, ipmi.CompletionCodeRequestedDataNotPresent
+	}
+	return fru, ipmi.CompletionCodeNormal
+}
+
+// requestUser returns the user of the user ID byte, the user is created if not exists.
+func requestUser(m *Model, b uint8) (*User, ipmi.CompletionCode) {
+	userID := b & 0x3f
+	if userID == 0 || userID > MaxUsers {
+		return nil, ipmi.CompletionCodeParameterOutOfRange
+	}
+	for len(m.Users) < int(userID) {
+		m.Users = append(m.Users, nil)
+	}
+	if m.Users[userID-1] == nil {
+		m.Users[userID-1] = &User{}
+	}
+	return m.Users[userID-1], ipmi.CompletionCodeNormal
+}
+
+// readBytes returns count bytes of data from offset, 0xff count means reading to the end.
+func readBytes(data []byte, offset uint8, count uint8) ([]byte, ipmi.CompletionCode) {
+	if int(offset) > len(data) {
+		return nil, ipmi.CompletionCodeParameterOutOfRange
+	}
+	end := len(data)
+	if count != 0xff && int(offset)+int(count) < end {
+		end = int(offset) + int(count)
+	}
+	return data[offset:end], ipmi.CompletionCodeNormal
+}
+
+// selIndex returns the index of the SEL record, 0000h means the first record and FFFFh means the last record.
+// It returns -1 if not found.
+func (m *Model) selIndex(recordID uint16) int {
+	if len(m.SEL) == 0 {
+		return -1
+	}
+	switch recordID {
+	case 0x0000:
+		return 0
+	case 0xffff:
+		return len(m.SEL) - 1
+	}
+	for i, sel := range m.SEL {
+		if sel.RecordID == recordID {
+			return i
+		}
+	}
+	return -1
+}
+
+// nextReservationID returns the new reservation ID, which is never 0.
+func nextReservationID(id uint16) uint16 {
+	id++
+	if id == 0 {
+		id++
+	}
+	return id
+}
+
+// timestamp returns the IPMI timestamp of t, FFFFFFFFh means unspecified for the zero time.
+func timestamp(t time.Time) uint32 {
+	if t.IsZero() {
+		return 0xffffffff
+	}
+	return uint32(t.Unix())
+}
+
+// freeBytes returns the free space of a 64KB storage whose used size is used.
+func freeBytes(used int) uint16 {
+	if used >= 0xfffe {
+		return 0
+	}
+	return uint16(0xfffe - used)
+}
@@ -0,0 +1,277 @@
+package simulator
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+
+	"github.com/bougou/go-ipmi"
+)
+
+// getChannelAuthCapabilities implements 22.13 Get Channel Authentication Capabilities Command.
+func (s *Server) getChannelAuthCapabilities(req *Request) ([]byte, ipmi.CompletionCode) {
+	if len(req.Data) < 2 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+	extended := req.Data[0]&0x80 != 0
+	channel := req.Data[0] & 0x0f
+	if channel != ipmi.ChannelNumberSelf && channel != LanChannelNumber {
+		return nil, ipmi.CompletionCodeRequestDataFieldInvalid
+	}
+
+	out := make([]byte, 8)
+	out[0] = LanChannelNumber
+	for _, authType := range authTypes {
+		out[1] |= 1 << authType
+	}
+	if extended {
+		out[1] |= 0x80
+		out[3] = 0x03 // IPMI v2.0 and IPMI v1.5
+	}
+
+	s.Model.Lock()
+	defer s.Model.Unlock()
+	if anonymous := s.Model.User(1); anonymous != nil && anonymous.Enabled {
+		out[2] |= 0x02 // null usernames enabled
+	}
+	out[2] |= 0x04 // non-null usernames enabled
+	if len(s.Model.BMCKey) != 0 {
+		out[2] |= 0x20 // Kg is set to non-zero value
+	}
+	return out, ipmi.CompletionCodeNormal
+}
+
+// getChannelCipherSuites implements 22.15 Get Channel Cipher Suites Command.
+// The records are listed by cipher suite.
+func (s *Server) getChannelCipherSuites(req *Request) ([]byte, ipmi.CompletionCode) {
+	if len(req.Data) < 3 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+	if ipmi.PayloadType(req.Data[1]) != ipmi.PayloadTypeIPMI {
+		return nil, ipmi.CompletionCodeRequestDataFieldInvalid
+	}
+
+	var records []byte
+	for _, cs := range cipherSuites {
+		records = append(records,
+			ipmi.StandardCipherSuite,
+			uint8(cs.id),
+			ipmi.CipherAlgTagBitAuthMask|uint8(cs.authAlg),
+			ipmi.CipherAlgTagBitIntegrityMask|uint8(cs.integrityAlg),
+			ipmi.CipherAlgTagBitEncryptionMask|uint8(cs.cryptAlg),
+		)
+	}
+
+	// each response holds 16 bytes of the records at most
+	start := int(req.Data[2]&0x3f) * 16
+	out := []byte{LanChannelNumber}
+	if start < len(records) {
+		end := start + 16
+		if end > len(records) {
+			end = len(records)
+		}
+		out = append(out, records[start:end]...)
+	}
+	return out, ipmi.CompletionCodeNormal
+}
+
+// getSessionChallenge implements 22.16 Get Session Challenge Command,
+// a temporary session is created for Activate Session.
+func (s *Server) getSessionChallenge(addr net.Addr, sess *session, req *Request) ([]byte, ipmi.CompletionCode) {
+	if sess != nil {
+		return nil, ipmi.CompletionCodeInvalidCommand
+	}
+	if len(req.Data) < 17 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+	authType := ipmi.AuthType(req.Data[0] & 0x0f)
+	if !isAuthTypeSupported(authType) {
+		return nil, ipmi.CompletionCodeRequestDataFieldInvalid
+	}
+	username := string(bytes.TrimRight(req.Data[1:17], "\x00"))
+
+	s.Model.Lock()
+	userID, user := s.Model.UserByName(username)
+	s.Model.Unlock()
+	if user == nil {
+		if username == "" {
+			return nil, 0x82 // null user name (User 1) not enabled
+		}
+		return nil, 0x81 // invalid user name
+	}
+
+	temp := s.newSession(addr)
+	if temp == nil {
+		return nil, ipmi.CompletionCodeNodeBusy
+	}
+	temp.authType = authType
+	temp.userID = userID
+	temp.password = user.Password
+	temp.maxPrivilege = user.MaxPrivilegeLevel
+	copy(temp.challenge[:], randomBytes(16))
+
+	out := make([]byte, 20)
+	binary.LittleEndian.PutUint32(out, temp.id)
+	copy(out[4:], temp.challenge[:])
+	return out, ipmi.CompletionCodeNormal
+}
+
+// activateSession implements 22.17 Activate Session Command.
+func (s *Server) activateSession(sess *session, req *Request) ([]byte, ipmi.CompletionCode) {
+	if sess == nil || sess.v20 {
+		return nil, ipmi.CompletionCodeInvalidCommand
+	}
+	if sess.active {
+		// the retried Activate Session request
+		return nil, ipmi.CompletionCodeCannotExecuteDuplicatedRequest
+	}
+	if len(req.Data) < 22 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+
+	authType := ipmi.AuthType(req.Data[0] & 0x0f)
+	maxPrivilege := ipmi.PrivilegeLevel(req.Data[1] & 0x0f)
+	if authType != sess.authType || !bytes.Equal(req.Data[2:18], sess.challenge[:]) {
+		return nil, ipmi.CompletionCodeRequestDataFieldInvalid
+	}
+	if maxPrivilege == ipmi.PrivilegeLevelUnspecified || maxPrivilege > sess.maxPrivilege {
+		return nil, 0x86 // requested maximum privilege level exceeds user and/or channel privilege limit
+	}
+
+	sess.active = true
+	sess.maxPrivilege = maxPrivilege
+	sess.privilege = minPrivilege(ipmi.PrivilegeLevelUser, maxPrivilege)
+	sess.outSeq = binary.LittleEndian.Uint32(req.Data[18:22])
+	for sess.inSeq == 0 {
+		sess.inSeq = binary.LittleEndian.Uint32(randomBytes(4))
+	}
+
+	out := make([]byte, 10)
+	out[0] = uint8(sess.authType)
+	binary.LittleEndian.PutUint32(out[1:], sess.id)
+	binary.LittleEndian.PutUint32(out[5:], sess.inSeq)
+	out[9] = uint8(sess.maxPrivilege)
+	return out, ipmi.CompletionCodeNormal
+}
+
+// setSessionPrivilegeLevel implements 22.18 Set Session Privilege Level Command.
+func (s *Server) setSessionPrivilegeLevel(sess *session, req *Request) ([]byte, ipmi.CompletionCode) {
+	if sess == nil || !sess.active {
+		return nil, ipmi.CompletionCodeCannotExecuteCommandSecurityRestrict
+	}
+	if len(req.Data) < 1 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+
+	privilege := ipmi.PrivilegeLevel(req.Data[0] & 0x0f)
+	switch {
+	case privilege == ipmi.PrivilegeLevelUnspecified:
+		// only returns the present privilege level
+	case privilege == ipmi.PrivilegeLevelCallback:
+		return nil, 0x80 // requested level not available for this user
+	case privilege > sess.maxPrivilege:
+		return nil, 0x81 // requested level exceeds channel and/or user privilege limit
+	default:
+		sess.privilege = privilege
+	}
+	return []byte{uint8(sess.privilege)}, ipmi.CompletionCodeNormal
+}
+
+// closeSession implements 22.19 Close Session Command.
+func (s *Server) closeSession(sess *session, req *Request) ([]byte, ipmi.CompletionCode) {
+	if sess == nil || !sess.active {
+		return nil, ipmi.CompletionCodeCannotExecuteCommandSecurityRestrict
+	}
+	if len(req.Data) < 4 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+
+	var target *session
+	if id := binary.LittleEndian.Uint32(req.Data); id != 0 {
+		target = s.sessions[id]
+	} else if len(req.Data) >= 5 {
+		target = s.sessionByHandle(req.Data[4])
+	}
+	if target == nil {
+		if binary.LittleEndian.Uint32(req.Data) == 0 {
+			return nil, 0x88 // invalid session handle
+		}
+		return nil, 0x87 // invalid session ID
+	}
+	if target != sess && sess.privilege < ipmi.PrivilegeLevelAdministrator {
+		return nil, ipmi.CompletionCodeCannotExecuteCommandSecurityRestrict
+	}
+
+	delete(s.sessions, target.id)
+	return nil, ipmi.CompletionCodeNormal
+}
+
+// getSessionInfo implements 22.20 Get Session Info Command.
+func (s *Server) getSessionInfo(sess *session, req *Request) ([]byte, ipmi.CompletionCode) {
+	if sess == nil || !sess.active {
+		return nil, ipmi.CompletionCodeCannotExecuteCommandSecurityRestrict
+	}
+	if len(req.Data) < 1 {
+		return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+	}
+
+	var active []*session
+	for _, other := range s.sessions {
+		if other.active {
+			active = append(active, other)
+		}
+	}
+
+	var target *session
+	switch index := req.Data[0]; index {
+	case 0x00:
+		target = sess
+	case 0xfe:
+		if len(req.Data) < 2 {
+			return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+		}
+		target = s.sessionByHandle(req.Data[1])
+	case 0xff:
+		if len(req.Data) < 5 {
+			return nil, ipmi.CompletionCodeRequestDataLengthInvalid
+		}
+		target = s.sessions[binary.LittleEndian.Uint32(req.Data[1:])]
+	default:
+		// the N-th active session, the order is by the session handle
+		for _, other := range active {
+			rank := 1
+			for _, o := range active {
+				if o.handle < other.handle {
+					rank++
+				}
+			}
+			if rank == int(index) {
+				target = other
+			}
+		}
+	}
+
+	out := []byte{0x00, MaxSessions, uint8(len(active))}
+	if target == nil || !target.active {
+		// no active session for the index
+		return out, ipmi.CompletionCodeNormal
+	}
+	out[0] = target.handle
+
+	var protocol uint8 // IPMI v1.5
+	if target.v20 {
+		protocol = 1 // IPMI v2.0/RMCP+
+	}
+	out = append(out, target.userID, uint8(target.privilege), protocol<<4|LanChannelNumber)
+
+	// the remote console IP address, MAC address and port
+	lan := make([]byte, 12)
+	if addr, ok := target.addr.(*net.UDPAddr); ok {
+		if ip := addr.IP.To4(); ip != nil {
+			copy(lan, ip)
+		}
+		binary.LittleEndian.PutUint16(lan[10:], uint16(addr.Port))
+	}
+	out = append(out, lan...)
+	return out, ipmi.CompletionCodeNormal
+}
@@ -0,0 +1,86 @@
+package simulator
+
+import (
+	"github.com/bougou/go-ipmi"
+)
+
+// FRUInfo holds the fields of the Board Info Area and the Product Info Area.
+type FRUInfo struct {
+	BoardManufacturer string
+	BoardProductName  string
+	BoardSerialNumber string
+	BoardPartNumber   string
+
+	ProductManufacturer string
+	ProductName         string
+	ProductPartNumber   string
+	ProductVersion      string
+	ProductSerialNumber string
+	ProductAssetTag     string
+}
+
+// NewFRUData generates the FRU inventory area which contains the Common Header,
+// the Board Info Area and the Product Info Area, see Platform Management FRU Information Storage Definition.
+func NewFRUData(info FRUInfo) []byte {
+	board := fruArea(
+		[]byte{0x01, 0x00, 0x00, 0x00, 0x00, 0x00}, // version, length, language, mfg date/time
+		info.BoardManufacturer,
+		info.BoardProductName,
+		info.BoardSerialNumber,
+		info.BoardPartNumber,
+		"", // FRU File ID
+	)
+	product := fruArea(
+		[]byte{0x01, 0x00, 0x00}, // version, length, language
+		info.ProductManufacturer,
+		info.ProductName,
+		info.ProductPartNumber,
+		info.ProductVersion,
+		info.ProductSerialNumber,
+		info.ProductAssetTag,
+		"", // FRU File ID
+	)
+
+	header := &ipmi.FRUCommonHeader{
+		FormatVersion:   ipmi.FRUFormatVersion,
+		BoardOffset8B:   1,
+		ProductOffset8B: uint8(1 + len(board)/8),
+	}
+	out := header.Pack()
+	out[7] = zeroChecksum(out[:7])
+
+	out = append(out, board...)
+	out = append(out, product...)
+	return out
+}
+
+// fruArea generates an info area whose fields are 8-bit ASCII strings.
+// The area is padded to multiple of 8 bytes, and the length byte (the second byte) and checksum are filled.
+func fruArea(head []byte, fields ...string) []byte {
+	area := append([]byte{}, head...)
+	for _, field := range fields {
+		if len(field) > 63 {
+			field = field[:63]
+		}
+		area = append(area, 0xc0|uint8(len(field)))
+		area = append(area, field...)
+	}
+	area = append(area, ipmi.FRUAreaFieldsEndMark)
+
+	// the last byte is the checksum
+	for (len(area)+1)%8 != 0 {
+		area = append(area, 0x00)
+	}
+	area[1] = uint8((len(area) + 1) / 8)
+	area = append(area, zeroChecksum(area))
+	return area
+}
+
+// zeroChecksum returns the byte which makes the sum of data and itself zero.
+func zeroChecksum(data []byte) uint8 {
+	var sum uint8
+	for _, b := range data {
+		sum += b
+	}
+	return -sum
+}
@@ -0,0 +1,475 @@
+package simulator
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bougou/go-ipmi"
+)
+
+// MaxUsers is the maximum number of the users of the LAN channel.
+const MaxUsers uint8 = 10
+
+// Model is the in-memory state of the simulated BMC.
+//
+// The fields can be changed directly before the server is started.
+// Once the server is serving, the model must be locked while it is read or changed,
+// the server holds the lock while handling each request.
+type Model struct {
+	sync.Mutex
+
+	// Get Device ID
+	DeviceID          uint8
+	DeviceRevision    uint8
+	FirmwareMajor     uint8
+	FirmwareMinor     uint8 // BCD encoded
+	ManufacturerID    uint32
+	ProductID         uint16
+	AuxiliaryFirmware [4]byte
+
+	// GUID is returned by Get Device GUID and Get System GUID, it's also used as the BMC GUID in RAKP.
+	GUID [16]byte
+
+	// BMCKey is the Kg used to generate the SIK of RMCP+ sessions,
+	// the user password is used (one-key logins) if it is empty.
+	BMCKey []byte
+
+	PowerOn            bool
+	PowerRestorePolicy ipmi.PowerRestorePolicy
+
+	// Users holds the users of the LAN channel, the user ID is the index plus 1.
+	// User 1 is the anonymous user (null username).
+	Users []*User
+
+	// Sensors are reported by Full Sensor Records in the SDR repository.
+	Sensors []*Sensor
+
+	// SDRs holds the raw records (with the 5 bytes header) appended to the SDR repository
+	// after the records of Sensors, like the FRU Device Locator records.
+	// The Record ID field of the records is overwritten.
+	SDRs [][]byte
+
+	// SDRReadLimit is the max number of bytes returned by a Get SDR request, 0 means no limit.
+	// The requests for more bytes are rejected with CAh (cannot return number of requested data bytes),
+	// like the BMCs which only support partial reads.
+	SDRReadLimit uint8
+
+	SEL []*ipmi.SEL
+	// SELTimeOffset is the offset of the SEL time from the system time, it is changed by Set SEL Time.
+	SELTimeOffset time.Duration
+
+	// FRU holds the FRU inventory area of each FRU device ID.
+	FRU map[uint8][]byte
+
+	// LanConfig holds the LAN configuration parameters of the LAN channel.
+	LanConfig map[ipmi.LanParamSelector][]byte
+
+	sdrReservationID uint16
+	sdrAddTime       time.Time
+	sdrEraseTime     time.Time
+
+	// sdrs holds the records of the SDR repository once it is written by the SDR write commands,
+	// before that the records are generated from Sensors and SDRs.
+	sdrs            [][]byte
+	sdrWritten      bool
+	sdrNextRecordID uint16
+	// sdrPartial is the record being added by Partial Add SDR.
+	sdrPartial         []byte
+	sdrPartialRecordID uint16
+
+	selReservationID uint16
+	selNextRecordID  uint16
+	selAddTime       time.Time
+	selEraseTime     time.Time
+}
+
+// User is a user of the simulated BMC.
+type User struct {
+	Name     string
+	Password string
+
+	Enabled bool
+
+	// MaxPrivilegeLevel is the channel privilege limit of the user.
+	MaxPrivilegeLevel ipmi.PrivilegeLevel
+
+	CallbackOnly  bool
+	LinkAuth      bool
+	IPMIMessaging bool
+}
+
+// Sensor is a sensor of the simulated BMC.
+type Sensor struct {
+	Number           uint8
+	Name             string
+	EntityID         ipmi.EntityID
+	EntityInstance   uint8
+	SensorType       ipmi.SensorType
+	EventReadingType ipmi.EventReadingType
+
+	// The factors of the reading conversion formula, see 36.3 Sensor Reading Conversion Formula.
+	// Only used for threshold based sensors.
+	// The factors of non-linear sensors are only returned by Get Sensor Reading Factors, not in the SDR.
+	Unit          ipmi.SensorUnitType
+	M             int16
+	B             int16
+	BExp          int8
+	RExp          int8
+	Linearization ipmi.LinearizationFunc
+
+	// Raw is the raw reading.
+	Raw uint8
+
+	// Thresholds holds the raw values of the readable and settable thresholds.
+	Thresholds         map[ipmi.SensorThresholdType]uint8
+	PositiveHysteresis uint8
+	NegativeHysteresis uint8
+
+	// States is the bitmap of the asserted states of discrete sensors.
+	States uint16
+}
+
+// NewModel creates a model of a powered on BMC with some sensors, an empty SEL,
+// the builtin FRU, a LAN configuration and the "admin" user (ID 2) whose password is "admin".
+func NewModel() *Model {
+	now := time.Now()
+	return &Model{
+		DeviceID:       0x20,
+		DeviceRevision: 0x01,
+		FirmwareMajor:  1,
+		FirmwareMinor:  0x23,
+		ManufacturerID: 0x000000, // unspecified
+		ProductID:      0x1234,
+		GUID: [16]byte{
+			0x78, 0x56, 0x34, 0x12, 0x34, 0x12, 0x78, 0x56,
+			0x90, 0xab, 0xcd, 0xef, 0x01, 0x23, 0x45, 0x67,
+		},
+
+		PowerOn:            true,
+		PowerRestorePolicy: ipmi.PowerRestorePolicyPrevious,
+
+		Users: []*User{
+			{MaxPrivilegeLevel: ipmi.PrivilegeLevelUser},
+			{
+				Name:              "admin",
+				Password:          "admin",
+				Enabled:           true,
+				MaxPrivilegeLevel: ipmi.PrivilegeLevelAdministrator,
+				LinkAuth:          true,
+				IPMIMessaging:     true,
+			},
+		},
+
+		Sensors: []*Sensor{
+			{
+				Number:           0x01,
+				Name:             "CPU Temp",
+				EntityID:         0x03, // processor
+				EntityInstance:   1,
+				SensorType:       ipmi.SensorTypeTemperature,
+				EventReadingType: ipmi.EventReadingTypeThreshold,
+				Unit:             ipmi.SensorUnitType_DegreesC,
+				M:                1,
+				Raw:              45,
+				Thresholds: map[ipmi.SensorThresholdType]uint8{
+					ipmi.SensorThresholdType_UNC: 85,
+					ipmi.SensorThresholdType_UCR: 95,
+					ipmi.SensorThresholdType_UNR: 100,
+				},
+				PositiveHysteresis: 2,
+				NegativeHysteresis: 2,
+			},
+			{
+				Number:           0x02,
+				Name:             "FAN1",
+				EntityID:         0x1d, // fan device
+				EntityInstance:   1,
+				SensorType:       ipmi.SensorTypeFan,
+				EventReadingType: ipmi.EventReadingTypeThreshold,
+				Unit:             ipmi.SensorUnitType_RPM,
+				M:                100,
+				Raw:              60,
+				Thresholds: map[ipmi.SensorThresholdType]uint8{
+					ipmi.SensorThresholdType_LNC: 10,
+					ipmi.SensorThresholdType_LCR: 5,
+					ipmi.SensorThresholdType_LNR: 2,
+				},
+			},
+			{
+				Number:           0x03,
+				Name:             "12V",
+				EntityID:         0x07, // system board
+				EntityInstance:   1,
+				SensorType:       ipmi.SensorTypeVoltage,
+				EventReadingType: ipmi.EventReadingTypeThreshold,
+				Unit:             ipmi.SensorUnitType_Volts,
+				M:                6,
+				RExp:             -2,
+				Raw:              200,
+				Thresholds: map[ipmi.SensorThresholdType]uint8{
+					ipmi.SensorThresholdType_LCR: 180,
+					ipmi.SensorThresholdType_UCR: 220,
+				},
+			},
+			{
+				Number:           0x04,
+				Name:             "PSU1 Status",
+				EntityID:         0x0a, // power supply
+				EntityInstance:   1,
+				SensorType:       ipmi.SensorTypePowerSupply,
+				EventReadingType: ipmi.EventReadingTypeSensorSpecific,
+				States:           0x0001, // presence detected
+			},
+		},
+
+		FRU: map[uint8][]byte{
+			0: NewFRUData(FRUInfo{
+				BoardManufacturer:   "go-ipmi",
+				BoardProductName:    "Simulated Board",
+				BoardSerialNumber:   "BSN0001",
+				BoardPartNumber:     "BPN0001",
+				ProductManufacturer: "go-ipmi",
+				ProductName:         "Simulated Server",
+				ProductPartNumber:   "PPN0001",
+				ProductVersion:      "1.0",
+				ProductSerialNumber: "PSN0001",
+			}),
+		},
+
+		LanConfig: map[ipmi.LanParamSelector][]byte{
+			ipmi.LanParam_SetInProgress:     {0x00},
+			ipmi.LanParam_AuthTypeSupported: {0x15},
+			ipmi.LanParam_AuthTypeEnables:   {0x14, 0x14, 0x14, 0x14, 0x00},
+			ipmi.LanParam_IP:                {192, 168, 0, 100},
+			ipmi.LanParam_IPSource:          {0x01}, // static
+			ipmi.LanParam_MAC:               {0x02, 0x00, 0x00, 0x00, 0x00, 0x01},
+			ipmi.LanParam_SubnetMask:        {255, 255, 255, 0},
+			ipmi.LanParam_DefaultGatewayIP:  {192, 168, 0, 1},
+			ipmi.LanParam_DefaultGatewayMAC: {0x02, 0x00, 0x00, 0x00, 0x00, 0xfe},
+			ipmi.LanParam_VLANID:            {0x00, 0x00},
+		},
+
+		sdrAddTime:      now,
+		selNextRecordID: 1,
+	}
+}
+
+// User returns the user of userID, or nil if not exists.
+func (m *Model) User(userID uint8) *User {
+	if userID == 0 || int(userID) > len(m.Users) {
+		return nil
+	}
+	return m.Users[userID-1]
+}
+
+// UserByName returns the enabled user of name and its user ID.
+func (m *Model) UserByName(name string) (uint8, *User) {
+	for i, user := range m.Users {
+		if user != nil && user.Enabled && user.Name == name {
+			return uint8(i + 1), user
+		}
+	}
+	return 0, nil
+}
+
+// Sensor returns the sensor of number, or nil if not exists.
+func (m *Model) Sensor(number uint8) *Sensor {
+	for _, sensor := range m.Sensors {
+		if sensor.Number == number {
+			return sensor
+		}
+	}
+	return nil
+}
+
+// AddSEL appends the SEL record and assigns its record ID.
+func (m *Model) AddSEL(sel *ipmi.SEL) uint16 {
+	if m.selNextRecordID == 0 || m.selNextRecordID == 0xffff {
+		m.selNextRecordID = 1
+	}
+	sel.RecordID = m.selNextRecordID
+	m.selNextRecordID++
+	m.SEL = append(m.SEL, sel)
+	m.selAddTime = time.Now()
+	return sel.RecordID
+}
+
+// Now returns the current time of the BMC.
+func (m *Model) Now() time.Time {
+	return time.Now().Add(m.SELTimeOffset)
+}
+
+// sdrRepository returns all the records of the SDR repository.
+// The record IDs of the generated records start from 1.
+func (m *Model) sdrRepository() [][]byte {
+	if m.sdrWritten {
+		return m.sdrs
+	}
+
+	var records [][]byte
+	for _, sensor := range m.Sensors {
+		records = append(records, sensor.fullSDR())
+	}
+	for _, raw := range m.SDRs {
+		record := make([]byte, len(raw))
+		copy(record, raw)
+		records = append(records, record)
+	}
+	for i, record := range records {
+		if len(record) >= 2 {
+			recordID := uint16(i + 1)
+			record[0] = uint8(recordID)
+			record[1] = uint8(recordID >> 8)
+		}
+	}
+	return records
+}
+
+// writableSDRs returns the records of the SDR repository for writing,
+// the generated records are kept from then on.
+func (m *Model) writableSDRs() [][]byte {
+	if !m.sdrWritten {
+		m.sdrs = m.sdrRepository()
+		m.sdrNextRecordID = uint16(len(m.sdrs) + 1)
+		m.sdrWritten = true
+	}
+	return m.sdrs
+}
+
+// addSDR appends the record to the SDR repository and returns the assigned record ID.
+func (m *Model) addSDR(record []byte) uint16 {
+	m.sdrs = append(m.writableSDRs(), record)
+
+	recordID := m.sdrNextRecordID
+	m.sdrNextRecordID++
+	record[0] = uint8(recordID)
+	record[1] = uint8(recordID >> 8)
+	m.sdrAddTime = time.Now()
+	return recordID
+}
+
+// sdrIndex returns the index of the SDR record, 0000h means the first record and FFFFh means the last record.
+// It returns -1 if not found.
+func (m *Model) sdrIndex(recordID uint16) int {
+	records := m.sdrRepository()
+	if len(records) == 0 {
+		return -1
+	}
+	switch recordID {
+	case 0x0000:
+		return 0
+	case 0xffff:
+		return len(records) - 1
+	}
+	for i, record := range records {
+		if len(record) >= 2 && uint16(record[0])|uint16(record[1])<<8 == recordID {
+			return i
+		}
+	}
+	return -1
+}
+
+// thresholdMask returns the bitmap of the thresholds of the sensor,
+// [5] UNR, [4] UCR, [3] UNC, [2] LNR, [1] LCR, [0] LNC.
+func (s *Sensor) thresholdMask() uint8 {
+	var mask uint8
+	for i, typ := range thresholdTypes {
+		if _, ok := s.Thresholds[typ]; ok {
+			mask |= 1 << i
+		}
+	}
+	return mask
+}
+
+// thresholdStatus returns the present threshold comparison status of the reading.
+func (s *Sensor) thresholdStatus() uint8 {
+	var status uint8
+	for i, typ := range thresholdTypes {
+		v, ok := s.Thresholds[typ]
+		if !ok {
+			continue
+		}
+		upper := i >= 3
+		if (upper && s.Raw >= v) || (!upper && s.Raw <= v) {
+			status |= 1 << i
+		}
+	}
+	return status
+}
+
+// thresholdTypes are ordered by their bit positions in the threshold masks.
+var thresholdTypes = []ipmi.SensorThresholdType{
+	ipmi.SensorThresholdType_LNC,
+	ipmi.SensorThresholdType_LCR,
+	ipmi.SensorThresholdType_LNR,
+	ipmi.SensorThresholdType_UNC,
+	ipmi.SensorThresholdType_UCR,
+	ipmi.SensorThresholdType_UNR,
+}
+
+// fullSDR generates the Full Sensor Record of the sensor, see 43.1 SDR Type 01h, Full Sensor Record.
+func (s *Sensor) fullSDR() []byte {
+	name := []byte(s.Name)
+	if len(name) > 16 {
+		name = name[:16]
+	}
+	record := make([]byte, 48+len(name))
+
+	record[2] = 0x51 // SDR Version
+	record[3] = uint8(ipmi.SDRRecordTypeFullSensor)
+	record[4] = uint8(len(record) - 5)
+
+	record[5] = ipmi.BMC_SA
+	record[6] = 0x00 // channel 0, LUN 0
+	record[7] = s.Number
+	record[8] = uint8(s.EntityID)
+	record[9] = s.EntityInstance & 0x7f
+	record[10] = 0x7f // scanning and events enabled
+	record[12] = uint8(s.SensorType)
+	record[13] = uint8(s.EventReadingType)
+
+	if s.EventReadingType.IsThreshold() {
+		// auto re-arm, hysteresis and thresholds are readable and settable
+		record[11] = 0x40 | 0x20 | 0x08
+
+		mask := s.thresholdMask()
+		record[18] = mask // readable thresholds
+		record[19] = mask // settable thresholds
+
+		record[20] = 0x00 // unsigned analog reading
+		record[21] = uint8(s.Unit)
+		record[23] = uint8(s.Linearization)
+
+		if !s.Linearization.IsNonLinear() {
+			m := uint16(s.M) & 0x03ff
+			record[24] = uint8(m)
+			record[25] = uint8(m>>8) << 6
+			b := uint16(s.B) & 0x03ff
+			record[26] = uint8(b)
+			record[27] = uint8(b>>8) << 6
+			record[29] = uint8(s.RExp)<<4 | uint8(s.BExp)&0x0f
+		}
+
+		record[34] = 0xff // sensor maximum reading
+		record[35] = 0x00 // sensor minimum reading
+
+		record[36] = s.Thresholds[ipmi.SensorThresholdType_UNR]
+		record[37] = s.Thresholds[ipmi.SensorThresholdType_UCR]
+		record[38] = s.Thresholds[ipmi.SensorThresholdType_UNC]
+		record[39] = s.Thresholds[ipmi.SensorThresholdType_LNR]
+		record[40] = s.Thresholds[ipmi.SensorThresholdType_LCR]
+		record[41] = s.Thresholds[ipmi.SensorThresholdType_LNC]
+		record[42] = s.PositiveHysteresis
+		record[43] = s.NegativeHysteresis
+	} else {
+		record[11] = 0x40 // auto re-arm
+		// the discrete reading mask, all states can be returned
+		record[18] = 0xff
+		record[19] = 0x7f
+		record[20] = 0xc0 // no analog reading
+	}
+
+	record[47] = 0xc0 | uint8(len(name)) // 8-bit ASCII + Latin 1
+	copy(record[48:], name)
+	return record
+}
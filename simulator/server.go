@@ -0,0 +1,325 @@
+// Package simulator implements a BMC which speaks IPMI over LAN,
+// including the RMCP/ASF presence ping, IPMI v1.5 sessions and IPMI v2.0 RMCP+ sessions.
+//
+// The BMC is backed by an in-memory Model of the device, sensors, SDR repository, SEL, FRU,
+// chassis, users and LAN configuration, so that the Client can be tested end to end
+// without real hardware.
+//
+//	server, err := simulator.Start(simulator.NewModel())
+//	if err != nil {
+//		return err
+//	}
+//	defer server.Close()
+//
+//	host, port := server.HostPort()
+//	client, err := ipmi.NewClient(host, port, "admin", "admin")
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bougou/go-ipmi"
+)
+
+const (
+	// LanChannelNumber is the channel number of the simulated LAN channel.
+	LanChannelNumber uint8 = 0x01
+
+	// MaxSessions is the maximum number of the concurrent sessions.
+	MaxSessions = 16
+
+	// SessionTimeout is the inactivity timeout of the sessions, see 6.12.15 Session Inactivity Timeouts.
+	SessionTimeout = 60 * time.Second
+
+	// the ASF message type of the presence pong, see 13.2.4 RMCP/ASF Presence Pong Message
+	asfMessageTypePong uint8 = 0x40
+
+	asfIANA uint32 = 4542
+)
+
+// Request is the IPMI request passed to the handlers.
+type Request struct {
+	NetFn   ipmi.NetFn
+	Command uint8
+	Data    []byte
+
+	// Privilege is the current privilege level of the session,
+	// it's PrivilegeLevelUnspecified for the requests sent outside of a session.
+	Privilege ipmi.PrivilegeLevel
+
+	// UserID is the user of the session, it's 0 for the requests sent outside of a session.
+	UserID uint8
+}
+
+// HandlerFunc handles the request of a command, it returns the response data and the completion code.
+// The model is locked while the handler is called.
+type HandlerFunc func(m *Model, req *Request) ([]byte, ipmi.CompletionCode)
+
+type handler struct {
+	// the minimum privilege level of the command,
+	// PrivilegeLevelUnspecified means the command is allowed outside of a session.
+	privilege ipmi.PrivilegeLevel
+	fn        HandlerFunc
+}
+
+type handlerKey struct {
+	netFn ipmi.NetFn
+	id    uint8
+}
+
+// Server is the simulated BMC which serves IPMI over LAN on an UDP connection.
+type Server struct {
+	Model *Model
+
+	handlers map[handlerKey]handler
+
+	mu       sync.Mutex
+	conn     net.PacketConn
+	sessions map[uint32]*session
+	handle   uint8
+}
+
+// NewServer creates a server of the model with the handlers of the builtin commands.
+func NewServer(model *Model) *Server {
+	s := &Server{
+		Model:    model,
+		handlers: make(map[handlerKey]handler),
+		sessions: make(map[uint32]*session),
+	}
+	for _, h := range builtinHandlers {
+		s.Handle(h.command, h.privilege, h.fn)
+	}
+	return s
+}
+
+// Start creates a server of the model, and serves it on a random port of the loopback address.
+func Start(model *Model) (*Server, error) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("listen failed, err: %w", err)
+	}
+
+	s := NewServer(model)
+	s.mu.Lock()
+	s.conn = conn
+	s.mu.Unlock()
+
+	go s.Serve(conn)
+	return s, nil
+}
+
+// Handle registers the handler of the command, it replaces the existing handler of the command.
+// The privilege is the minimum privilege level required to execute the command,
+// PrivilegeLevelUnspecified means the command is allowed outside of a session.
+//
+// The session management commands (like Activate Session and Close Session) are
+// always handled by the server itself. Handle must be called before the server is started.
+func (s *Server) Handle(command ipmi.Command, privilege ipmi.PrivilegeLevel, fn HandlerFunc) {
+	s.handlers[handlerKey{command.NetFn, command.ID}] = handler{
+		privilege: privilege,
+		fn:        fn,
+	}
+}
+
+// ListenAndServe listens on the UDP address and serves the BMC.
+func (s *Server) ListenAndServe(addr string) error {
+	conn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return fmt.Errorf("listen failed, err: %w", err)
+	}
+	return s.Serve(conn)
+}
+
+// Serve serves the BMC on the connection until the server is closed.
+// The messages are processed one by one in the order they are received.
+// It returns nil after Close is called.
+func (s *Server) Serve(conn net.PacketConn) error {
+	s.mu.Lock()
+	s.conn = conn
+	s.mu.Unlock()
+
+	buf := make([]byte, 2048)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return fmt.Errorf("read failed, err: %w", err)
+		}
+
+		msg := make([]byte, n)
+		copy(msg, buf[:n])
+
+		s.mu.Lock()
+		res := s.serveMessage(addr, msg)
+		s.mu.Unlock()
+		if len(res) == 0 {
+			continue
+		}
+		if _, err := conn.WriteTo(res, addr); err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return fmt.Errorf("write failed, err: %w", err)
+		}
+	}
+}
+
+// Addr returns the address the server is serving on, or nil if it is not serving.
+func (s *Server) Addr() net.Addr {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.LocalAddr()
+}
+
+// HostPort returns the host and port the server is serving on, which can be passed to ipmi.NewClient.
+func (s *Server) HostPort() (string, int) {
+	addr := s.Addr()
+	if addr == nil {
+		return "", 0
+	}
+	host, port, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return "", 0
+	}
+	p, _ := strconv.Atoi(port)
+	return host, p
+}
+
+// Close stops serving, all the sessions are discarded.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions = make(map[uint32]*session)
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.Close()
+}
+
+// serveMessage processes the received RMCP message, it returns the message to reply,
+// or nil if the message is dropped. The server must be locked.
+func (s *Server) serveMessage(addr net.Addr, msg []byte) []byte {
+	rmcp := &ipmi.Rmcp{}
+	if err := rmcp.Unpack(msg); err != nil {
+		return nil
+	}
+
+	switch {
+	case rmcp.ASF != nil:
+		return s.serveASF(rmcp.ASF)
+	case rmcp.Session15 != nil:
+		return s.serveSession15(addr, rmcp.Session15)
+	case rmcp.Session20 != nil:
+		return s.serveSession20(addr, msg, rmcp.Session20)
+	}
+	return nil
+}
+
+// serveASF replies the presence pong for the presence ping, see 13.2 RMCP/ASF Presence Ping/Pong.
+func (s *Server) serveASF(asf *ipmi.ASF) []byte {
+	if asf.MessageType != uint8(ipmi.MessageTypePing) {
+		return nil
+	}
+
+	pong := &ipmi.RmcpPingResponse{
+		OEMIANA:       asfIANA,
+		IPMISupported: true,
+		ASFVersion:    0x01, // ASF 1.0
+	}
+	data := pong.Pack()
+
+	rmcp := &ipmi.Rmcp{
+		RmcpHeader: ipmi.NewRmcpHeaderASF(),
+		ASF: &ipmi.ASF{
+			IANA:        asfIANA,
+			MessageType: asfMessageTypePong,
+			MessageTag:  asf.MessageTag,
+			DataLength:  uint8(len(data)),
+			Data:        data,
+		},
+	}
+	return rmcp.Pack()
+}
+
+// serveIPMI executes the IPMI request message and returns the IPMI response message.
+// The sess is nil for the requests sent outside of a session.
+func (s *Server) serveIPMI(addr net.Addr, sess *session, msg []byte) []byte {
+	ipmiReq := &ipmi.IPMIRequest{}
+	if err := ipmiReq.Unpack(msg); err != nil {
+		return nil
+	}
+
+	req := &Request{
+		NetFn:   ipmiReq.NetFn,
+		Command: ipmiReq.Command,
+		Data:    ipmiReq.CommandData,
+	}
+	if sess != nil && sess.active {
+		req.Privilege = sess.privilege
+		req.UserID = sess.userID
+	}
+
+	data, cc := s.execute(addr, sess, req)
+
+	ipmiRes := &ipmi.IPMIResponse{
+		RequesterAddr:     ipmiReq.RequesterAddr,
+		NetFn:             ipmiReq.NetFn | 0x01,
+		RequestLUN:        ipmiReq.RequesterLUN,
+		ResponderAddr:     ipmiReq.ResponderAddr,
+		RequesterSequence: ipmiReq.RequesterSequence,
+		ResponderLUN:      ipmiReq.ResponderLUN,
+		Command:           ipmiReq.Command,
+		CompletionCode:    uint8(cc),
+	}
+	if cc == ipmi.CompletionCodeNormal {
+		ipmiRes.Data = data
+	}
+	ipmiRes.ComputeChecksum()
+	return ipmiRes.Pack()
+}
+
+// execute dispatches the request to the session management commands or the handlers.
+func (s *Server) execute(addr net.Addr, sess *session, req *Request) ([]byte, ipmi.CompletionCode) {
+	if req.NetFn == ipmi.NetFnAppRequest {
+		switch req.Command {
+		case ipmi.CommandGetChannelAuthCapabilities.ID:
+			return s.getChannelAuthCapabilities(req)
+		case ipmi.CommandGetChannelCipherSuites.ID:
+			return s.getChannelCipherSuites(req)
+		case ipmi.CommandGetSessionChallenge.ID:
+			return s.getSessionChallenge(addr, sess, req)
+		case ipmi.CommandActivateSession.ID:
+			return s.activateSession(sess, req)
+		case ipmi.CommandSetSessionPrivilegeLevel.ID:
+			return s.setSessionPrivilegeLevel(sess, req)
+		case ipmi.CommandCloseSession.ID:
+			return s.closeSession(sess, req)
+		case ipmi.CommandGetSessionInfo.ID:
+			return s.getSessionInfo(sess, req)
+		}
+	}
+
+	h, ok := s.handlers[handlerKey{req.NetFn, req.Command}]
+	if !ok {
+		return nil, ipmi.CompletionCodeInvalidCommand
+	}
+	if h.privilege != ipmi.PrivilegeLevelUnspecified && req.Privilege < h.privilege {
+		return nil, ipmi.CompletionCodeCannotExecuteCommandSecurityRestrict
+	}
+
+	s.Model.Lock()
+	defer s.Model.Unlock()
+	return h.fn(s.Model, req)
+}
@@ -0,0 +1,677 @@
+package simulator
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"net"
+	"time"
+
+	"github.com/bougou/go-ipmi"
+)
+
+// session is an IPMI v1.5 session or an IPMI v2.0 RMCP+ session.
+type session struct {
+	// the Session ID assigned by the BMC
+	id     uint32
+	handle uint8
+	v20    bool
+	active bool
+	addr   net.Addr
+
+	userID       uint8
+	password     string
+	maxPrivilege ipmi.PrivilegeLevel
+	privilege    ipmi.PrivilegeLevel
+
+	// the last received sequence number and the last sent sequence number
+	inSeq  uint32
+	outSeq uint32
+
+	lastActivity time.Time
+
+	// IPMI v1.5
+	authType  ipmi.AuthType
+	challenge [16]byte
+
+	// IPMI v2.0
+	consoleID    uint32
+	authAlg      ipmi.AuthAlg
+	integrityAlg ipmi.IntegrityAlg
+	cryptAlg     ipmi.CryptAlg
+	consoleRand  [16]byte
+	bmcRand      [16]byte
+	role         uint8
+	username     []byte
+	sik          []byte
+	k1           []byte
+	k2           []byte
+}
+
+// cipherSuite is a cipher suite supported by the simulator, see 22.15.2 Cipher Suite IDs.
+type cipherSuite struct {
+	id           ipmi.CipherSuiteID
+	authAlg      ipmi.AuthAlg
+	integrityAlg ipmi.IntegrityAlg
+	cryptAlg     ipmi.CryptAlg
+}
+
+var cipherSuites = []cipherSuite{
+	{ipmi.CipherSuiteID1, ipmi.AuthAlgRAKP_HMAC_SHA1, ipmi.IntegrityAlg_None, ipmi.CryptAlg_None},
+	{ipmi.CipherSuiteID2, ipmi.AuthAlgRAKP_HMAC_SHA1, ipmi.IntegrityAlg_HMAC_SHA1_96, ipmi.CryptAlg_None},
+	{ipmi.CipherSuiteID3, ipmi.AuthAlgRAKP_HMAC_SHA1, ipmi.IntegrityAlg_HMAC_SHA1_96, ipmi.CryptAlg_AES_CBC_128},
+	{ipmi.CipherSuiteID6, ipmi.AuthAlgRAKP_HMAC_MD5, ipmi.IntegrityAlg_None, ipmi.CryptAlg_None},
+	{ipmi.CipherSuiteID7, ipmi.AuthAlgRAKP_HMAC_MD5, ipmi.IntegrityAlg_HMAC_MD5_128, ipmi.CryptAlg_None},
+	{ipmi.CipherSuiteID8, ipmi.AuthAlgRAKP_HMAC_MD5, ipmi.IntegrityAlg_HMAC_MD5_128, ipmi.CryptAlg_AES_CBC_128},
+	{ipmi.CipherSuiteID15, ipmi.AuthAlgRAKP_HMAC_SHA256, ipmi.IntegrityAlg_None, ipmi.CryptAlg_None},
+	{ipmi.CipherSuiteID16, ipmi.AuthAlgRAKP_HMAC_SHA256, ipmi.IntegrityAlg_HMAC_SHA256_128, ipmi.CryptAlg_None},
+	{ipmi.CipherSuiteID17, ipmi.AuthAlgRAKP_HMAC_SHA256, ipmi.IntegrityAlg_HMAC_SHA256_128, ipmi.CryptAlg_AES_CBC_128},
+}
+
+// the authentication types supported for IPMI v1.5 sessions.
+// MD2 is not supported.
+var authTypes = []ipmi.AuthType{ipmi.AuthTypeNone, ipmi.AuthTypeMD5, ipmi.AuthTypePassword}
+
+func isAuthTypeSupported(authType ipmi.AuthType) bool {
+	for _, t := range authTypes {
+		if t == authType {
+			return true
+		}
+	}
+	return false
+}
+
+// newSession creates a session with an unused session ID and handle.
+// The sessions not active in SessionTimeout are discarded, it returns nil
+// if the number of sessions exceeds MaxSessions.
+func (s *Server) newSession(addr net.Addr) *session {
+	now := time.Now()
+	for id, sess := range s.sessions {
+		if now.Sub(sess.lastActivity) > SessionTimeout {
+			delete(s.sessions, id)
+		}
+	}
+	if len(s.sessions) >= MaxSessions {
+		return nil
+	}
+
+	sess := &session{
+		addr:         addr,
+		lastActivity: now,
+	}
+	for sess.id == 0 || s.sessions[sess.id] != nil {
+		sess.id = binary.LittleEndian.Uint32(randomBytes(4))
+	}
+	for {
+		s.handle++
+		if s.handle != 0 && s.sessionByHandle(s.handle) == nil {
+			break
+		}
+	}
+	sess.handle = s.handle
+
+	s.sessions[sess.id] = sess
+	return sess
+}
+
+func (s *Server) sessionByHandle(handle uint8) *session {
+	for _, sess := range s.sessions {
+		if sess.handle == handle {
+			return sess
+		}
+	}
+	return nil
+}
+
+// session returns the session of id, the expired session is discarded.
+func (s *Server) session(id uint32) *session {
+	sess, ok := s.sessions[id]
+	if !ok {
+		return nil
+	}
+	if time.Since(sess.lastActivity) > SessionTimeout {
+		delete(s.sessions, id)
+		return nil
+	}
+	return sess
+}
+
+// acceptSequence reports whether the received sequence number is acceptable,
+// the messages out of order are accepted if they are within 8 of the highest received one,
+// see 6.12.13 Session Sequence Number Handling.
+func (sess *session) acceptSequence(seq uint32) bool {
+	if seq == 0 {
+		return false
+	}
+	if seq > sess.inSeq {
+		sess.inSeq = seq
+		return true
+	}
+	return sess.inSeq-seq < 8
+}
+
+// serveSession15 processes the IPMI v1.5 message.
+func (s *Server) serveSession15(addr net.Addr, s15 *ipmi.Session15) []byte {
+	hdr := s15.SessionHeader15
+
+	if hdr.SessionID == 0 {
+		res := s.serveIPMI(addr, nil, s15.Payload)
+		if res == nil {
+			return nil
+		}
+		return packSession15(&ipmi.SessionHeader15{AuthType: ipmi.AuthTypeNone}, res)
+	}
+
+	sess := s.session(hdr.SessionID)
+	if sess == nil || sess.v20 || hdr.AuthType != sess.authType {
+		return nil
+	}
+	if !bytes.Equal(sess.authCode15(hdr.SessionID, hdr.Sequence, s15.Payload), hdr.AuthCode) {
+		return nil
+	}
+	if sess.active && !sess.acceptSequence(hdr.Sequence) {
+		return nil
+	}
+	sess.lastActivity = time.Now()
+
+	res := s.serveIPMI(addr, sess, s15.Payload)
+	if res == nil {
+		return nil
+	}
+
+	resHdr := &ipmi.SessionHeader15{
+		AuthType:  sess.authType,
+		SessionID: sess.id,
+	}
+	if sess.active {
+		sess.outSeq++
+		resHdr.Sequence = sess.outSeq
+	}
+	resHdr.AuthCode = sess.authCode15(resHdr.SessionID, resHdr.Sequence, res)
+	return packSession15(resHdr, res)
+}
+
+// authCode15 generates the multi-session AuthCode, see 22.17.1 AuthCode Algorithms.
+func (sess *session) authCode15(sessionID uint32, seq uint32, payload []byte) []byte {
+	if sess.authType == ipmi.AuthTypeNone {
+		return nil
+	}
+	input := &ipmi.AuthCodeMultiSessionInput{
+		Password:   sess.password,
+		SessionID:  sessionID,
+		SessionSeq: seq,
+		IPMIData:   payload,
+	}
+	return input.AuthCode(sess.authType)
+}
+
+func packSession15(hdr *ipmi.SessionHeader15, payload []byte) []byte {
+	hdr.PayloadLength = uint8(len(payload))
+	rmcp := &ipmi.Rmcp{
+		RmcpHeader: ipmi.NewRmcpHeader(),
+		Session15: &ipmi.Session15{
+			SessionHeader15: hdr,
+			Payload:         payload,
+		},
+	}
+	return rmcp.Pack()
+}
+
+// serveSession20 processes the IPMI v2.0 RMCP+ message.
+func (s *Server) serveSession20(addr net.Addr, msg []byte, s20 *ipmi.Session20) []byte {
+	hdr := s20.SessionHeader20
+
+	switch hdr.PayloadType {
+	case ipmi.PayloadTypeRmcpOpenSessionRequest:
+		res := s.openSession(addr, s20.SessionPayload)
+		if res == nil {
+			return nil
+		}
+		return packSessionSetup(ipmi.PayloadTypeRmcpOpenSessionResponse, res)
+
+	case ipmi.PayloadTypeRAKPMessage1:
+		res := s.rakp1(s20.SessionPayload)
+		if res == nil {
+			return nil
+		}
+		return packSessionSetup(ipmi.PayloadTypeRAKPMessage2, res)
+
+	case ipmi.PayloadTypeRAKPMessage3:
+		res := s.rakp3(s20.SessionPayload)
+		if res == nil {
+			return nil
+		}
+		return packSessionSetup(ipmi.PayloadTypeRAKPMessage4, res)
+
+	case ipmi.PayloadTypeIPMI:
+		if hdr.SessionID == 0 {
+			res := s.serveIPMI(addr, nil, s20.SessionPayload)
+			if res == nil {
+				return nil
+			}
+			return packSessionSetup(ipmi.PayloadTypeIPMI, res)
+		}
+
+		sess := s.session(hdr.SessionID)
+		if sess == nil || !sess.v20 || !sess.active {
+			return nil
+		}
+		payload, ok := sess.open20(msg, s20)
+		if !ok {
+			return nil
+		}
+		sess.lastActivity = time.Now()
+
+		res := s.serveIPMI(addr, sess, payload)
+		if res == nil {
+			return nil
+		}
+		return sess.seal20(res)
+	}
+
+	return nil
+}
+
+// open20 checks the integrity and sequence number of the received message,
+// and decrypts the payload.
+func (sess *session) open20(msg []byte, s20 *ipmi.Session20) ([]byte, bool) {
+	hdr := s20.SessionHeader20
+
+	if sess.integrityAlg != ipmi.IntegrityAlg_None {
+		authCodeLen := sess.integrityAlg.AuthCodeLength()
+		if !hdr.PayloadAuthenticated || s20.SessionTrailer == nil || len(msg) < 4+authCodeLen {
+			return nil, false
+		}
+		expected := integrityAuthCode(sess.integrityAlg, sess.k1, msg[4:len(msg)-authCodeLen])
+		if !hmac.Equal(expected, msg[len(msg)-authCodeLen:]) {
+			return nil, false
+		}
+	}
+
+	if !sess.acceptSequence(hdr.Sequence) {
+		return nil, false
+	}
+
+	payload := s20.SessionPayload
+	if sess.cryptAlg == ipmi.CryptAlg_AES_CBC_128 {
+		if !hdr.PayloadEncrypted {
+			return nil, false
+		}
+		d, err := decryptAES(payload, sess.k2[:16])
+		if err != nil {
+			return nil, false
+		}
+		payload = d
+	}
+	return payload, true
+}
+
+// seal20 wraps the IPMI response message into the RMCP+ message of the session.
+func (sess *session) seal20(payload []byte) []byte {
+	sess.outSeq++
+	hdr := &ipmi.SessionHeader20{
+		AuthType:             ipmi.AuthTypeRMCPPlus,
+		PayloadType:          ipmi.PayloadTypeIPMI,
+		PayloadEncrypted:     sess.cryptAlg != ipmi.CryptAlg_None,
+		PayloadAuthenticated: sess.integrityAlg != ipmi.IntegrityAlg_None,
+		SessionID:            sess.consoleID,
+		Sequence:             sess.outSeq,
+	}
+
+	if hdr.PayloadEncrypted {
+		payload = encryptAES(payload, sess.k2[:16])
+	}
+	hdr.PayloadLength = uint16(len(payload))
+
+	s20 := &ipmi.Session20{
+		SessionHeader20: hdr,
+		SessionPayload:  payload,
+	}
+
+	if hdr.PayloadAuthenticated {
+		// the integrity data covers the session header, the payload and the trailer up to Next Header,
+		// which is padded to multiple of 4 bytes, see 13.28.4 Integrity Algorithms
+		hdrBytes := hdr.Pack()
+		padSize := (4 - (len(hdrBytes)+len(payload)+2)%4) % 4
+		trailer := &ipmi.SessionTrailer{
+			IntegrityPAD: bytes.Repeat([]byte{0xff}, padSize),
+			PadLength:    uint8(padSize),
+			NextHeader:   0x07,
+		}
+
+		input := append(hdrBytes, payload...)
+		input = append(input, trailer.IntegrityPAD...)
+		input = append(input, trailer.PadLength, trailer.NextHeader)
+		trailer.AuthCode = integrityAuthCode(sess.integrityAlg, sess.k1, input)
+		s20.SessionTrailer = trailer
+	}
+
+	rmcp := &ipmi.Rmcp{
+		RmcpHeader: ipmi.NewRmcpHeader(),
+		Session20:  s20,
+	}
+	return rmcp.Pack()
+}
+
+// packSessionSetup wraps the payload into the RMCP+ message sent outside of a session.
+func packSessionSetup(payloadType ipmi.PayloadType, payload []byte) []byte {
+	rmcp := &ipmi.Rmcp{
+		RmcpHeader: ipmi.NewRmcpHeader(),
+		Session20: &ipmi.Session20{
+			SessionHeader20: &ipmi.SessionHeader20{
+				AuthType:      ipmi.AuthTypeRMCPPlus,
+				PayloadType:   payloadType,
+				PayloadLength: uint16(len(payload)),
+			},
+			SessionPayload: payload,
+		},
+	}
+	return rmcp.Pack()
+}
+
+// openSession processes the RMCP+ Open Session Request, see 13.17.
+func (s *Server) openSession(addr net.Addr, data []byte) []byte {
+	req := &ipmi.OpenSessionRequest{}
+	if err := req.Unpack(data); err != nil {
+		return nil
+	}
+
+	res := &ipmi.OpenSessionResponse{
+		MessageTag:             req.MessageTag,
+		RemoteConsoleSessionID: req.RemoteConsoleSessionID,
+	}
+
+	var suite *cipherSuite
+	for i := range cipherSuites {
+		cs := &cipherSuites[i]
+		if uint8(cs.authAlg) == req.AuthAlg && uint8(cs.integrityAlg) == req.IntegrityAlg && uint8(cs.cryptAlg) == req.CryptAlg {
+			suite = cs
+			break
+		}
+	}
+	if suite == nil {
+		res.RmcpStatusCode = ipmi.RmcpStatusCodeNoCipherSuiteMatch
+		return res.Pack()
+	}
+
+	maxPrivilege := req.RequestedMaximumPrivilegeLevel
+	if maxPrivilege == ipmi.PrivilegeLevelUnspecified {
+		// the highest level matching the proposed algorithms
+		maxPrivilege = ipmi.PrivilegeLevelAdministrator
+	}
+	if maxPrivilege > ipmi.PrivilegeLevelOEM {
+		res.RmcpStatusCode = ipmi.RmcpStatusCodeInvalidRole
+		return res.Pack()
+	}
+
+	sess := s.newSession(addr)
+	if sess == nil {
+		res.RmcpStatusCode = ipmi.RmcpStatusCodeNoResToCreateSess
+		return res.Pack()
+	}
+	sess.v20 = true
+	sess.consoleID = req.RemoteConsoleSessionID
+	sess.maxPrivilege = maxPrivilege
+	sess.authAlg = suite.authAlg
+	sess.integrityAlg = suite.integrityAlg
+	sess.cryptAlg = suite.cryptAlg
+
+	res.RmcpStatusCode = ipmi.RmcpStatusCodeNoErrors
+	res.MaximumPrivilegeLevel = uint8(maxPrivilege)
+	res.ManagedSystemSessionID = sess.id
+	res.AuthenticationPayload = ipmi.AuthenticationPayload{PayloadType: 0x00, PayloadLength: 8, AuthAlg: uint8(sess.authAlg)}
+	res.IntegrityPayload = ipmi.IntegrityPayload{PayloadType: 0x01, PayloadLength: 8, IntegrityAlg: uint8(sess.integrityAlg)}
+	res.ConfidentialityPayload = ipmi.ConfidentialityPayload{PayloadType: 0x02, PayloadLength: 8, CryptAlg: uint8(sess.cryptAlg)}
+	return res.Pack()
+}
+
+// rakp1 processes the RAKP Message 1 and returns the RAKP Message 2, see 13.20 and 13.21.
+func (s *Server) rakp1(data []byte) []byte {
+	req := &ipmi.RAKPMessage1{}
+	if err := req.Unpack(data); err != nil {
+		return nil
+	}
+
+	res := &ipmi.RAKPMessage2{
+		MessageTag: req.MessageTag,
+	}
+
+	sess := s.session(req.ManagedSystemSessionID)
+	if sess == nil || !sess.v20 || sess.active {
+		res.RmcpStatusCode = ipmi.RmcpStatusCodeInvalidSessionID
+		return res.Pack()
+	}
+	res.RemoteConsoleSessionID = sess.consoleID
+
+	if req.UsernameLength > ipmi.IPMI_MAX_USER_NAME_LENGTH {
+		res.RmcpStatusCode = ipmi.RmcpStatusCodeInvalidNameLength
+		return res.Pack()
+	}
+
+	s.Model.Lock()
+	userID, user := s.Model.UserByName(string(req.Username))
+	guid := s.Model.GUID
+	s.Model.Unlock()
+
+	if user == nil {
+		res.RmcpStatusCode = ipmi.RmcpStatusCodeUnauthorizedName
+		return res.Pack()
+	}
+
+	privilege := req.RequestedMaximumPrivilegeLevel
+	if privilege == ipmi.PrivilegeLevelUnspecified || privilege > ipmi.PrivilegeLevelOEM {
+		res.RmcpStatusCode = ipmi.RmcpStatusCodeInvalidRole
+		return res.Pack()
+	}
+	if privilege > user.MaxPrivilegeLevel || privilege > sess.maxPrivilege {
+		res.RmcpStatusCode = ipmi.RmcpStatusCodeUnauthorizedRoleOfPriLevel
+		return res.Pack()
+	}
+
+	sess.userID = userID
+	sess.password = user.Password
+	sess.maxPrivilege = privilege
+	sess.consoleRand = req.RemoteConsoleRandomNumber
+	copy(sess.bmcRand[:], randomBytes(16))
+	sess.role = req.Role()
+	sess.username = req.Username
+	sess.lastActivity = time.Now()
+
+	// see 13.31 RMCP+ Authenticated Key-Exchange Protocol (RAKP)
+	var input []byte
+	input = binary.LittleEndian.AppendUint32(input, sess.consoleID)
+	input = binary.LittleEndian.AppendUint32(input, sess.id)
+	input = append(input, sess.consoleRand[:]...)
+	input = append(input, sess.bmcRand[:]...)
+	input = append(input, guid[:]...)
+	input = append(input, sess.role, uint8(len(sess.username)))
+	input = append(input, sess.username...)
+
+	res.RmcpStatusCode = ipmi.RmcpStatusCodeNoErrors
+	res.ManagedSystemRandomNumber = sess.bmcRand
+	res.ManagedSystemGUID = guid
+	res.KeyExchangeAuthenticationCode = authAuthCode(sess.authAlg, userKey(sess.password), input)
+	return res.Pack()
+}
+
+// rakp3 processes the RAKP Message 3 and returns the RAKP Message 4, see 13.22 and 13.23.
+func (s *Server) rakp3(data []byte) []byte {
+	req := &ipmi.RAKPMessage3{}
+	if err := req.Unpack(data); err != nil {
+		return nil
+	}
+
+	res := &ipmi.RAKPMessage4{
+		MessageTag: req.MessageTag,
+	}
+
+	sess := s.session(req.ManagedSystemSessionID)
+	if sess == nil || !sess.v20 || sess.active || sess.userID == 0 {
+		res.RmcpStatusCode = ipmi.RmcpStatusCodeInvalidSessionID
+		return res.Pack()
+	}
+	res.MgmtConsoleSessionID = sess.consoleID
+
+	if req.RmcpStatusCode != ipmi.RmcpStatusCodeNoErrors {
+		// the remote console aborts the session
+		delete(s.sessions, sess.id)
+		return nil
+	}
+
+	var input []byte
+	input = append(input, sess.bmcRand[:]...)
+	input = binary.LittleEndian.AppendUint32(input, sess.consoleID)
+	input = append(input, sess.role, uint8(len(sess.username)))
+	input = append(input, sess.username...)
+
+	expected := authAuthCode(sess.authAlg, userKey(sess.password), input)
+	if !hmac.Equal(expected, req.KeyExchangeAuthenticationCode) {
+		delete(s.sessions, sess.id)
+		res.RmcpStatusCode = ipmi.RmcpStatusCodeInvalidIntegrityCheckValue
+		return res.Pack()
+	}
+
+	s.Model.Lock()
+	kg := s.Model.BMCKey
+	guid := s.Model.GUID
+	s.Model.Unlock()
+	if len(kg) == 0 {
+		kg = userKey(sess.password)
+	}
+
+	// Session Integrity Key, see 13.31
+	input = input[:0]
+	input = append(input, sess.consoleRand[:]...)
+	input = append(input, sess.bmcRand[:]...)
+	input = append(input, sess.role, uint8(len(sess.username)))
+	input = append(input, sess.username...)
+	sess.sik = authAuthCode(sess.authAlg, kg, input)
+
+	// see 13.32 Generating Additional Keying Material
+	sess.k1 = authAuthCode(sess.authAlg, sess.sik, bytes.Repeat([]byte{0x01}, 20))
+	sess.k2 = authAuthCode(sess.authAlg, sess.sik, bytes.Repeat([]byte{0x02}, 20))
+
+	input = input[:0]
+	input = append(input, sess.consoleRand[:]...)
+	input = binary.LittleEndian.AppendUint32(input, sess.id)
+	input = append(input, guid[:]...)
+	icv := authAuthCode(sess.authAlg, sess.sik, input)
+	switch sess.authAlg {
+	case ipmi.AuthAlgRAKP_HMAC_SHA1:
+		icv = icv[:12]
+	case ipmi.AuthAlgRAKP_HMAC_MD5, ipmi.AuthAlgRAKP_HMAC_SHA256:
+		icv = icv[:16]
+	}
+
+	sess.active = true
+	sess.privilege = minPrivilege(ipmi.PrivilegeLevelUser, sess.maxPrivilege)
+	sess.lastActivity = time.Now()
+
+	res.RmcpStatusCode = ipmi.RmcpStatusCodeNoErrors
+	res.IntegrityCheckValue = icv
+	return res.Pack()
+}
+
+func minPrivilege(a, b ipmi.PrivilegeLevel) ipmi.PrivilegeLevel {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// userKey returns the Kuid, the password of the user padded to 20 bytes.
+func userKey(password string) []byte {
+	key := make([]byte, 20)
+	copy(key, password)
+	return key
+}
+
+func authHash(authAlg ipmi.AuthAlg) func() hash.Hash {
+	switch authAlg {
+	case ipmi.AuthAlgRAKP_HMAC_SHA1:
+		return sha1.New
+	case ipmi.AuthAlgRAKP_HMAC_MD5:
+		return md5.New
+	case ipmi.AuthAlgRAKP_HMAC_SHA256:
+		return sha256.New
+	}
+	return nil
+}
+
+// authAuthCode generates the HMAC by the authentication algorithm, it returns nil for RAKP-none.
+func authAuthCode(authAlg ipmi.AuthAlg, key []byte, data []byte) []byte {
+	h := authHash(authAlg)
+	if h == nil {
+		return nil
+	}
+	mac := hmac.New(h, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// integrityAuthCode generates the AuthCode of the session trailer, see 13.28.4 Integrity Algorithms.
+func integrityAuthCode(integrityAlg ipmi.IntegrityAlg, k1 []byte, data []byte) []byte {
+	var h func() hash.Hash
+	switch integrityAlg {
+	case ipmi.IntegrityAlg_HMAC_SHA1_96:
+		h = sha1.New
+	case ipmi.IntegrityAlg_HMAC_MD5_128:
+		h = md5.New
+	case ipmi.IntegrityAlg_HMAC_SHA256_128:
+		h = sha256.New
+	default:
+		return nil
+	}
+	mac := hmac.New(h, k1)
+	mac.Write(data)
+	return mac.Sum(nil)[:integrityAlg.AuthCodeLength()]
+}
+
+// encryptAES encrypts the payload by AES-CBC-128, the output is prefixed by the random IV,
+// see 13.29 AES-CBC-128 Encrypted Payload Format.
+func encryptAES(payload []byte, key []byte) []byte {
+	padLength := (aes.BlockSize - (len(payload)+1)%aes.BlockSize) % aes.BlockSize
+	data := append([]byte{}, payload...)
+	for i := 1; i <= padLength; i++ {
+		data = append(data, uint8(i))
+	}
+	data = append(data, uint8(padLength))
+
+	block, _ := aes.NewCipher(key)
+	iv := randomBytes(aes.BlockSize)
+	out := make([]byte, aes.BlockSize+len(data))
+	copy(out, iv)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(out[aes.BlockSize:], data)
+	return out
+}
+
+func decryptAES(data []byte, key []byte) ([]byte, error) {
+	if len(data) < 2*aes.BlockSize || len(data)%aes.BlockSize != 0 {
+		return nil, ipmi.ErrUnpackedDataTooShortWith(len(data), 2*aes.BlockSize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, len(data)-aes.BlockSize)
+	cipher.NewCBCDecrypter(block, data[:aes.BlockSize]).CryptBlocks(out, data[aes.BlockSize:])
+
+	padLength := int(out[len(out)-1])
+	if padLength >= len(out) {
+		return nil, ipmi.ErrUnpackedDataTooShortWith(len(out), padLength+1)
+	}
+	return out[:len(out)-padLength-1], nil
+}
+
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return b
+}
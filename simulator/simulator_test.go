@@ -0,0 +1,582 @@
+package simulator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/bougou/go-ipmi"
+)
+
+// newTestClient starts a server of the model and returns a client connected to it.
+func newTestClient(t *testing.T, model *Model, options ...func(*ipmi.Client)) (*Server, *ipmi.Client) {
+	t.Helper()
+
+	server, err := Start(model)
+	if err != nil {
+		t.Fatalf("start server failed, err: %s", err)
+	}
+	t.Cleanup(func() { server.Close() })
+
+	host, port := server.HostPort()
+	client, err := ipmi.NewClient(host, port, "admin", "admin")
+	if err != nil {
+		t.Fatalf("new client failed, err: %s", err)
+	}
+	client.WithTimeout(2 * time.Second)
+	for _, option := range options {
+		option(client)
+	}
+
+	if err := client.Connect(); err != nil {
+		t.Fatalf("connect failed, err: %s", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return server, client
+}
+
+func Test_Connect(t *testing.T) {
+	tests := []struct {
+		name   string
+		option func(*ipmi.Client)
+	}{
+		{
+			name:   "lanplus",
+			option: func(c *ipmi.Client) {},
+		},
+		{
+			name:   "lanplus cipher suite 3",
+			option: func(c *ipmi.Client) { c.WithCipherSuiteID(ipmi.CipherSuiteID3) },
+		},
+		{
+			name:   "lanplus cipher suite 17",
+			option: func(c *ipmi.Client) { c.WithCipherSuiteID(ipmi.CipherSuiteID17) },
+		},
+		{
+			name:   "lanplus cipher suite 1",
+			option: func(c *ipmi.Client) { c.WithCipherSuiteID(ipmi.CipherSuiteID1) },
+		},
+		{
+			name:   "lanplus cipher suite 8",
+			option: func(c *ipmi.Client) { c.WithCipherSuiteID(ipmi.CipherSuiteID8) },
+		},
+		{
+			name:   "lanplus bmc key",
+			option: func(c *ipmi.Client) { c.WithBMCKey([]byte("secret")) },
+		},
+		{
+			name:   "lan",
+			option: func(c *ipmi.Client) { c.WithInterface(ipmi.InterfaceLan) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := NewModel()
+			if tt.name == "lanplus bmc key" {
+				model.BMCKey = []byte("secret")
+			}
+			_, client := newTestClient(t, model, tt.option)
+
+			res, err := client.GetDeviceID()
+			if err != nil {
+				t.Fatalf("GetDeviceID failed, err: %s", err)
+			}
+			if res.DeviceID != model.DeviceID || res.ProductID != model.ProductID {
+				t.Errorf("unexpected device id: %+v", res)
+			}
+			if res.FirmwareVersionStr() != "1.23" {
+				t.Errorf("unexpected firmware version: %s", res.FirmwareVersionStr())
+			}
+
+			// the keepalive command
+			if _, err := client.GetCurrentSessionInfo(); err != nil {
+				t.Errorf("GetCurrentSessionInfo failed, err: %s", err)
+			}
+		})
+	}
+}
+
+func Test_Connect_WrongPassword(t *testing.T) {
+	for _, intf := range []ipmi.Interface{ipmi.InterfaceLanplus, ipmi.InterfaceLan} {
+		t.Run(string(intf), func(t *testing.T) {
+			server, err := Start(NewModel())
+			if err != nil {
+				t.Fatalf("start server failed, err: %s", err)
+			}
+			defer server.Close()
+
+			host, port := server.HostPort()
+			client, err := ipmi.NewClient(host, port, "admin", "wrong")
+			if err != nil {
+				t.Fatalf("new client failed, err: %s", err)
+			}
+			client.WithInterface(intf).WithTimeout(500 * time.Millisecond).WithRetries(0)
+
+			err = client.Connect()
+			if err == nil {
+				client.Close()
+				t.Fatalf("connect should fail with wrong password")
+			}
+			// the v1.5 BMC silently discards the message with wrong auth code
+			expected := ipmi.ErrTimeout
+			if intf == ipmi.InterfaceLanplus {
+				expected = ipmi.ErrAuthFailed
+			}
+			if !errors.Is(err, expected) {
+				t.Errorf("expect %q, got: %s", expected, err)
+			}
+		})
+	}
+}
+
+func Test_RmcpPing(t *testing.T) {
+	server, err := Start(NewModel())
+	if err != nil {
+		t.Fatalf("start server failed, err: %s", err)
+	}
+	defer server.Close()
+
+	host, port := server.HostPort()
+	client, err := ipmi.NewClient(host, port, "admin", "admin")
+	if err != nil {
+		t.Fatalf("new client failed, err: %s", err)
+	}
+	client.WithTimeout(2 * time.Second)
+
+	res, err := client.RmcpPing()
+	if err != nil {
+		t.Fatalf("RmcpPing failed, err: %s", err)
+	}
+	if !res.IPMISupported || res.OEMIANA != 4542 {
+		t.Errorf("unexpected pong: %+v", res)
+	}
+}
+
+func Test_Chassis(t *testing.T) {
+	server, client := newTestClient(t, NewModel())
+
+	if _, err := client.ChassisControl(ipmi.ChassisControlPowerDown); err != nil {
+		t.Fatalf("ChassisControl failed, err: %s", err)
+	}
+	status, err := client.GetChassisStatus()
+	if err != nil {
+		t.Fatalf("GetChassisStatus failed, err: %s", err)
+	}
+	if status.PowerIsOn {
+		t.Errorf("power should be off")
+	}
+
+	if _, err := client.SetPowerRestorePolicy(ipmi.PowerRestorePolicyAlwaysOn); err != nil {
+		t.Fatalf("SetPowerRestorePolicy failed, err: %s", err)
+	}
+
+	server.Model.Lock()
+	defer server.Model.Unlock()
+	if server.Model.PowerOn || server.Model.PowerRestorePolicy != ipmi.PowerRestorePolicyAlwaysOn {
+		t.Errorf("unexpected model: power %v, policy %v", server.Model.PowerOn, server.Model.PowerRestorePolicy)
+	}
+}
+
+func Test_SEL(t *testing.T) {
+	_, client := newTestClient(t, NewModel())
+
+	for i := 0; i < 3; i++ {
+		sel := &ipmi.SEL{
+			RecordType: 0x02, // system event record
+			Standard: &ipmi.SELStandard{
+				Timestamp:        time.Unix(1700000000, 0),
+				GeneratorID:      0x20,
+				EvMRev:           0x04,
+				SensorType:       ipmi.SensorTypeTemperature,
+				SensorNumber:     0x01,
+				EventDir:         ipmi.EventDirAssertion,
+				EventReadingType: ipmi.EventReadingTypeThreshold,
+				EventData: ipmi.EventData{
+					EventData1: 0x50 + uint8(i),
+					EventData2: 0xff,
+					EventData3: 0xff,
+				},
+			},
+		}
+		if _, err := client.AddSELEntry(sel); err != nil {
+			t.Fatalf("AddSELEntry failed, err: %s", err)
+		}
+	}
+
+	entries, err := client.GetSELEntries(0)
+	if err != nil {
+		t.Fatalf("GetSELEntries failed, err: %s", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 SEL entries, got %d", len(entries))
+	}
+	if entries[2].RecordID != 3 || entries[2].Standard.EventData.EventData1 != 0x52 {
+		t.Errorf("unexpected SEL entry: %+v", entries[2].Standard)
+	}
+
+	reserve, err := client.ReserveSEL()
+	if err != nil {
+		t.Fatalf("ReserveSEL failed, err: %s", err)
+	}
+	if _, err := client.DeleteSELEntry(2, reserve.ReservationID); err != nil {
+		t.Fatalf("DeleteSELEntry failed, err: %s", err)
+	}
+	info, err := client.GetSELInfo()
+	if err != nil {
+		t.Fatalf("GetSELInfo failed, err: %s", err)
+	}
+	if info.Entries != 2 {
+		t.Errorf("expected 2 SEL entries, got %d", info.Entries)
+	}
+
+	if _, err := client.ClearSEL(reserve.ReservationID); err != nil {
+		t.Fatalf("ClearSEL failed, err: %s", err)
+	}
+	if _, err := client.DeleteSELEntry(1, reserve.ReservationID+1); err == nil {
+		t.Errorf("DeleteSELEntry should fail with the canceled reservation")
+	}
+}
+
+func Test_Sensors(t *testing.T) {
+	_, client := newTestClient(t, NewModel())
+
+	sensors, err := client.GetSensors()
+	if err != nil {
+		t.Fatalf("GetSensors failed, err: %s", err)
+	}
+	if len(sensors) != 4 {
+		t.Fatalf("expected 4 sensors, got %d", len(sensors))
+	}
+
+	temp, err := client.GetSensorByName("CPU Temp")
+	if err != nil {
+		t.Fatalf("GetSensorByName failed, err: %s", err)
+	}
+	if temp.Value != 45 || temp.Threshold.UCR_Raw != 95 {
+		t.Errorf("unexpected sensor: value %v, ucr %v", temp.Value, temp.Threshold.UCR_Raw)
+	}
+
+	voltage, err := client.GetSensorByID(0x03)
+	if err != nil {
+		t.Fatalf("GetSensorByID failed, err: %s", err)
+	}
+	if voltage.Value != 12 {
+		t.Errorf("unexpected voltage: %v", voltage.Value)
+	}
+
+	if _, err := client.SetSensorHysteresis(0x01, 3, 4); err != nil {
+		t.Fatalf("SetSensorHysteresis failed, err: %s", err)
+	}
+	hysteresis, err := client.GetSensorHysteresis(0x01)
+	if err != nil {
+		t.Fatalf("GetSensorHysteresis failed, err: %s", err)
+	}
+	if hysteresis.PositiveRaw != 3 || hysteresis.NegativeRaw != 4 {
+		t.Errorf("unexpected hysteresis: %+v", hysteresis)
+	}
+}
+
+func Test_NonLinearSensor(t *testing.T) {
+	model := NewModel()
+	model.Sensors = append(model.Sensors, &Sensor{
+		Number:           0x05,
+		Name:             "Airflow",
+		EntityID:         0x07, // system board
+		EntityInstance:   1,
+		SensorType:       ipmi.SensorTypeOtherUnitsbased,
+		EventReadingType: ipmi.EventReadingTypeThreshold,
+		Unit:             ipmi.SensorUnitType_CFM,
+		M:                15,
+		B:                5,
+		BExp:             1,
+		RExp:             -1,
+		Linearization:    ipmi.LinearizationFunc_NonLinear,
+		Raw:              40,
+		Thresholds: map[ipmi.SensorThresholdType]uint8{
+			ipmi.SensorThresholdType_LCR: 10,
+		},
+	})
+	_, client := newTestClient(t, model)
+
+	sensor, err := client.GetSensorByID(0x05)
+	if err != nil {
+		t.Fatalf("GetSensorByID failed, err: %s", err)
+	}
+	// the SDR has no factors and they are kept, the reading is (15 * 40 + 5 * 10) * 0.1
+	if sensor.Value != 65 || sensor.Threshold.M != 0 {
+		t.Errorf("unexpected value: %v (factors %s)", sensor.Value, sensor.Threshold.ReadingFactors)
+	}
+	if sensor.Threshold.LCR != 20 {
+		t.Errorf("unexpected lcr: %v", sensor.Threshold.LCR)
+	}
+}
+
+func Test_SDRPartialRead(t *testing.T) {
+	_, client := newTestClient(t, NewModel())
+	expected, err := client.GetSDRs()
+	if err != nil {
+		t.Fatalf("GetSDRs failed, err: %s", err)
+	}
+
+	model := NewModel()
+	model.SDRReadLimit = 16
+	_, client = newTestClient(t, model)
+	sdrs, err := client.GetSDRs()
+	if err != nil {
+		t.Fatalf("GetSDRs by partial reads failed, err: %s", err)
+	}
+	if len(sdrs) != len(expected) {
+		t.Fatalf("expected %d sdrs, got %d", len(expected), len(sdrs))
+	}
+	for i := range sdrs {
+		if sdrs[i].SensorName() != expected[i].SensorName() || sdrs[i].NextRecordID != expected[i].NextRecordID {
+			t.Errorf("sdr %d not match, got %s (next %#04x), expected %s (next %#04x)",
+				i, sdrs[i].SensorName(), sdrs[i].NextRecordID, expected[i].SensorName(), expected[i].NextRecordID)
+		}
+	}
+}
+
+func Test_LoadSDRRepository(t *testing.T) {
+	_, client := newTestClient(t, NewModel())
+	sdrs, err := client.GetSDRs()
+	if err != nil {
+		t.Fatalf("GetSDRs failed, err: %s", err)
+	}
+	if len(sdrs) < 2 {
+		t.Fatalf("expected at least 2 sdrs, got %d", len(sdrs))
+	}
+
+	// load the records in reverse order without the last one
+	var records []*ipmi.SDR
+	for i := len(sdrs) - 2; i >= 0; i-- {
+		records = append(records, sdrs[i])
+	}
+	if err := client.LoadSDRRepository(records); err != nil {
+		t.Fatalf("LoadSDRRepository failed, err: %s", err)
+	}
+
+	loaded, err := client.GetSDRs()
+	if err != nil {
+		t.Fatalf("GetSDRs after loading failed, err: %s", err)
+	}
+	if len(loaded) != len(records) {
+		t.Fatalf("expected %d sdrs, got %d", len(records), len(loaded))
+	}
+	for i := range loaded {
+		want, _ := records[i].Pack()
+		got, _ := loaded[i].Pack()
+		if !bytes.Equal(got[2:], want[2:]) {
+			t.Errorf("sdr %d not match, got %x, expected %x", i, got, want)
+		}
+		if loaded[i].RecordHeader.RecordID != uint16(i+1) {
+			t.Errorf("sdr %d expected record id %d, got %d", i, i+1, loaded[i].RecordHeader.RecordID)
+		}
+	}
+
+	reservationID, err := client.ReserveSDRRepo()
+	if err != nil {
+		t.Fatalf("ReserveSDRRepo failed, err: %s", err)
+	}
+	if _, err := client.DeleteSDR(1, reservationID.ReservationID); err != nil {
+		t.Fatalf("DeleteSDR failed, err: %s", err)
+	}
+	if _, err := client.GetSDR(1); err == nil {
+		t.Errorf("expected error for the deleted sdr")
+	}
+}
+
+func Test_Dump(t *testing.T) {
+	_, client := newTestClient(t, NewModel())
+
+	var buf bytes.Buffer
+	if err := client.DumpSDRRepository(&buf); err != nil {
+		t.Fatalf("DumpSDRRepository failed, err: %s", err)
+	}
+	dumped, err := ipmi.ParseSDRDump(&buf)
+	if err != nil {
+		t.Fatalf("ParseSDRDump failed, err: %s", err)
+	}
+	sdrs, err := client.GetSDRs()
+	if err != nil {
+		t.Fatalf("GetSDRs failed, err: %s", err)
+	}
+	if len(dumped) != len(sdrs) {
+		t.Fatalf("expected %d sdrs, got %d", len(sdrs), len(dumped))
+	}
+	for i := range sdrs {
+		if dumped[i].SensorName() != sdrs[i].SensorName() || dumped[i].NextRecordID != sdrs[i].NextRecordID {
+			t.Errorf("sdr %d not match, got %s (next %#04x), expected %s (next %#04x)",
+				i, dumped[i].SensorName(), dumped[i].NextRecordID, sdrs[i].SensorName(), sdrs[i].NextRecordID)
+		}
+	}
+
+	sel := &ipmi.SEL{
+		RecordType: 0x02, // system event record
+		Standard: &ipmi.SELStandard{
+			EvMRev:           0x04,
+			SensorType:       ipmi.SensorTypeTemperature,
+			SensorNumber:     0x01,
+			EventReadingType: ipmi.EventReadingTypeThreshold,
+			EventData:        ipmi.EventData{EventData1: 0x59, EventData2: 0x5b, EventData3: 0x5a},
+		},
+	}
+	if _, err := client.AddSELEntry(sel); err != nil {
+		t.Fatalf("AddSELEntry failed, err: %s", err)
+	}
+	buf.Reset()
+	if err := client.SaveSEL(&buf); err != nil {
+		t.Fatalf("SaveSEL failed, err: %s", err)
+	}
+	sels, err := ipmi.ParseSELSave(&buf)
+	if err != nil {
+		t.Fatalf("ParseSELSave failed, err: %s", err)
+	}
+	if len(sels) != 1 || sels[0].Standard.EventData != sel.Standard.EventData {
+		t.Errorf("unexpected saved sels: %+v", sels)
+	}
+
+	buf.Reset()
+	if err := client.DumpFRU(0, &buf); err != nil {
+		t.Fatalf("DumpFRU failed, err: %s", err)
+	}
+	data, err := client.GetFRUData(0)
+	if err != nil {
+		t.Fatalf("GetFRUData failed, err: %s", err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Errorf("dumped fru data not match")
+	}
+}
+
+func Test_FRU(t *testing.T) {
+	_, client := newTestClient(t, NewModel())
+
+	fru, err := client.GetFRU(0, "Builtin FRU")
+	if err != nil {
+		t.Fatalf("GetFRU failed, err: %s", err)
+	}
+	if fru.BoardInfoArea == nil || string(fru.BoardInfoArea.Manufacturer) != "go-ipmi" {
+		t.Fatalf("unexpected board info area: %+v", fru.BoardInfoArea)
+	}
+	if fru.ProductInfoArea == nil || string(fru.ProductInfoArea.SerialNumber) != "PSN0001" {
+		t.Fatalf("unexpected product info area: %+v", fru.ProductInfoArea)
+	}
+
+	if _, err := client.WriteFRUData(0, 8+6+1, []byte("GO")); err != nil {
+		t.Fatalf("WriteFRUData failed, err: %s", err)
+	}
+	res, err := client.ReadFRUData(0, 8+6+1, 2)
+	if err != nil {
+		t.Fatalf("ReadFRUData failed, err: %s", err)
+	}
+	if string(res.Data) != "GO" {
+		t.Errorf("unexpected FRU data: %q", res.Data)
+	}
+}
+
+func Test_Users(t *testing.T) {
+	server, client := newTestClient(t, NewModel())
+
+	if _, err := client.SetUsername(3, "operator"); err != nil {
+		t.Fatalf("SetUsername failed, err: %s", err)
+	}
+	if _, err := client.SetUserPassword(3, "secret", false); err != nil {
+		t.Fatalf("SetUserPassword failed, err: %s", err)
+	}
+	if err := client.EnableUser(3); err != nil {
+		t.Fatalf("EnableUser failed, err: %s", err)
+	}
+	if _, err := client.SetUserAccess(&ipmi.SetUserAccessRequest{
+		EnableChanging:      true,
+		EnableIPMIMessaging: true,
+		ChannelNumber:       LanChannelNumber,
+		UserID:              3,
+		MaxPrivLevel:        uint8(ipmi.PrivilegeLevelOperator),
+	}); err != nil {
+		t.Fatalf("SetUserAccess failed, err: %s", err)
+	}
+
+	users, err := client.ListUser(LanChannelNumber)
+	if err != nil {
+		t.Fatalf("ListUser failed, err: %s", err)
+	}
+	var found bool
+	for _, user := range users {
+		if user.ID == 3 && user.Name == "operator" && user.MaxPrivLevel == ipmi.PrivilegeLevelOperator {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("user operator not found in %+v", users)
+	}
+
+	// the new user can login with the operator privilege
+	host, port := server.HostPort()
+	operator, err := ipmi.NewClient(host, port, "operator", "secret")
+	if err != nil {
+		t.Fatalf("new client failed, err: %s", err)
+	}
+	operator.WithTimeout(2 * time.Second).WithMaxPrivilegeLevel(ipmi.PrivilegeLevelOperator)
+	if err := operator.Connect(); err != nil {
+		t.Fatalf("connect failed, err: %s", err)
+	}
+	defer operator.Close()
+
+	if _, err := operator.SetUsername(4, "nobody"); err == nil {
+		t.Errorf("SetUsername should fail with the operator privilege")
+	}
+}
+
+func Test_LanConfig(t *testing.T) {
+	_, client := newTestClient(t, NewModel())
+
+	lanConfig, err := client.GetLanConfig(LanChannelNumber)
+	if err != nil {
+		t.Fatalf("GetLanConfig failed, err: %s", err)
+	}
+	if lanConfig.IP.String() != "192.168.0.100" {
+		t.Errorf("unexpected ip: %s", lanConfig.IP)
+	}
+	if lanConfig.MAC.String() != "02:00:00:00:00:01" {
+		t.Errorf("unexpected mac: %s", lanConfig.MAC)
+	}
+}
+
+func Test_Handle(t *testing.T) {
+	model := NewModel()
+	server := NewServer(model)
+	server.Handle(ipmi.CommandGetSelfTestResults, ipmi.PrivilegeLevelUser, func(m *Model, req *Request) ([]byte, ipmi.CompletionCode) {
+		return []byte{0x57, 0x04}, ipmi.CompletionCodeNormal
+	})
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen failed, err: %s", err)
+	}
+	go server.Serve(conn)
+	defer server.Close()
+
+	addr := conn.LocalAddr().(*net.UDPAddr)
+	host, port := addr.IP.String(), addr.Port
+	client, err := ipmi.NewClient(host, port, "admin", "admin")
+	if err != nil {
+		t.Fatalf("new client failed, err: %s", err)
+	}
+	client.WithTimeout(2 * time.Second)
+	if err := client.ConnectContext(context.Background()); err != nil {
+		t.Fatalf("connect failed, err: %s", err)
+	}
+	defer client.Close()
+
+	res, err := client.GetSelfTestResults()
+	if err != nil {
+		t.Fatalf("GetSelfTestResults failed, err: %s", err)
+	}
+	if res.Byte1 != 0x57 || res.Byte2 != 0x04 {
+		t.Errorf("unexpected self test results: %+v", res)
+	}
+}
@@ -0,0 +1,177 @@
+package ipmi
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Transport sends the IPMI requests to the BMC and receives the responses.
+//
+// The lan, lanplus, open, tool, kcs, bt and ssif interfaces are all implemented as Transport.
+// A third-party Transport (like a SSH-tunnelled or serial one) can be used by NewClientWithTransport,
+// then all the command methods of Client go through it.
+type Transport interface {
+	// Connect prepares the transport for exchanging, like opening the device or activating a session.
+	Connect(ctx context.Context) error
+
+	// Exchange sends the request and fills the response.
+	// If the response carries a not normal completion code, the returned error should be a *ResponseError,
+	// see UnpackResponse.
+	Exchange(ctx context.Context, request Request, response Response) error
+
+	// Close releases the resources of the transport, like closing the session.
+	Close(ctx context.Context) error
+}
+
+// NewClientWithTransport creates a client which exchanges by the specified transport.
+func NewClientWithTransport(transport Transport) (*Client, error) {
+	if transport == nil {
+		return nil, fmt.Errorf("nil transport")
+	}
+
+	return &Client{
+		transport: transport,
+
+		maxPrivilegeLevel: PrivilegeLevelUnspecified,
+		timeout:           time.Second * time.Duration(DefaultExchangeTimeoutSec),
+		bufferSize:        DefaultBufferSize,
+	}, nil
+}
+
+// WithTransport sets the transport used by the client, it's usually a wrapper (like RecordTransport)
+// of the transport returned by Transport. Setting nil restores the transport of the Interface of the client.
+func (c *Client) WithTransport(transport Transport) *Client {
+	c.transport = transport
+	return c
+}
+
+// Transport returns the transport used by the client.
+// For the clients not created by NewClientWithTransport, it's the transport of the Interface of the client,
+// which can be wrapped by another Transport.
+func (c *Client) Transport() (Transport, error) {
+	if c.transport != nil {
+		return c.transport, nil
+	}
+
+	switch c.Interface {
+	case "", InterfaceOpen:
+		return &openTransport{c: c, devnum: 0}, nil
+
+	case InterfaceTool:
+		return &toolTransport{c: c}, nil
+
+	case InterfaceKCS, InterfaceBT, InterfaceSSIF:
+		return &siTransport{c: c}, nil
+
+	case InterfaceLanplus:
+		return &lanTransport{c: c, v20: true}, nil
+
+	case InterfaceLan:
+		return &lanTransport{c: c, v20: false}, nil
+
+	default:
+		return nil, fmt.Errorf("not supported interface, supported: lan,lanplus,open,tool,kcs,bt,ssif")
+	}
+}
+
+// UnpackResponse fills response with msg, the first byte of msg is the completion code and
+// the rest is the response data. It returns a *ResponseError if the completion code is not normal.
+// It is intended to be used by the implementations of Transport.
+func UnpackResponse(msg []byte, response Response) error {
+	if len(msg) < 1 {
+		return fmt.Errorf("response data at least contains one completion code byte")
+	}
+
+	ccode := msg[0]
+	if ccode != 0x00 {
+		return &ResponseError{
+			completionCode: CompletionCode(ccode),
+			description:    fmt.Sprintf("ipmiRes CompletionCode (%#02x) is not normal: %s", ccode, StrCC(response, ccode)),
+		}
+	}
+
+	if err := response.Unpack(msg[1:]); err != nil {
+		return &ResponseError{
+			completionCode: CompletionCode(ccode),
+			description:    fmt.Sprintf("unpack response failed, err: %s", err),
+		}
+	}
+
+	return nil
+}
+
+// lanTransport exchanges over RMCP (lan, IPMI v1.5) or RMCP+ (lanplus, IPMI v2.0).
+type lanTransport struct {
+	c   *Client
+	v20 bool
+}
+
+func (t *lanTransport) Connect(ctx context.Context) error {
+	t.c.v20 = t.v20
+	if t.v20 {
+		return t.c.Connect20Context(ctx)
+	}
+	return t.c.Connect15Context(ctx)
+}
+
+func (t *lanTransport) Exchange(ctx context.Context, request Request, response Response) error {
+	return t.c.exchangeLANReconnect(ctx, request, response)
+}
+
+func (t *lanTransport) Close(ctx context.Context) error {
+	return t.c.closeLAN(ctx)
+}
+
+// openTransport exchanges by the linux ipmi driver (OpenIPMI).
+type openTransport struct {
+	c      *Client
+	devnum int32
+}
+
+func (t *openTransport) Connect(ctx context.Context) error {
+	return t.c.ConnectOpenContext(ctx, t.devnum)
+}
+
+func (t *openTransport) Exchange(ctx context.Context, request Request, response Response) error {
+	return t.c.exchangeOpen(ctx, request, response)
+}
+
+func (t *openTransport) Close(ctx context.Context) error {
+	return t.c.closeOpen()
+}
+
+// toolTransport exchanges by executing ipmitool.
+type toolTransport struct {
+	c *Client
+}
+
+func (t *toolTransport) Connect(ctx context.Context) error {
+	var devnum int32 = 0
+	return t.c.ConnectToolContext(ctx, devnum)
+}
+
+func (t *toolTransport) Exchange(ctx context.Context, request Request, response Response) error {
+	return t.c.exchangeTool(ctx, request, response)
+}
+
+func (t *toolTransport) Close(ctx context.Context) error {
+	return t.c.closeTool()
+}
+
+// siTransport exchanges by the KCS, BT or SSIF system interface without the linux ipmi driver.
+type siTransport struct {
+	c *Client
+}
+
+func (t *siTransport) Connect(ctx context.Context) error {
+	return t.c.ConnectSIContext(ctx)
+}
+
+func (t *siTransport) Exchange(ctx context.Context, request Request, response Response) error {
+	return t.c.exchangeSI(ctx, request, response)
+}
+
+func (t *siTransport) Close(ctx context.Context) error {
+	return t.c.closeSI()
+}
@@ -0,0 +1,278 @@
+package ipmi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// RecordedExchange is an exchange recorded by RecordTransport.
+// The fixture file holds one RecordedExchange per line in JSON.
+type RecordedExchange struct {
+	// Name is the name of the command, it's informative and not used for matching.
+	Name    string `json:"name,omitempty"`
+	NetFn   NetFn  `json:"netfn"`
+	Command uint8  `json:"cmd"`
+
+	// Request is the hex encoded request data.
+	Request string `json:"request"`
+
+	// Response is the hex encoded response message, the first byte is the completion code.
+	Response string `json:"response,omitempty"`
+
+	// Error is set if the exchange failed without a response, like timed out.
+	Error string `json:"error,omitempty"`
+
+	// ErrorKind classifies Error, the replayed error wraps the sentinel error of the kind.
+	ErrorKind RecordedErrorKind `json:"error_kind,omitempty"`
+}
+
+// RecordedErrorKind is the kind of the error of a recorded exchange.
+type RecordedErrorKind string
+
+const (
+	RecordedErrorTimeout        RecordedErrorKind = "timeout"         // ErrTimeout
+	RecordedErrorSessionInvalid RecordedErrorKind = "session_invalid" // ErrSessionInvalid
+	RecordedErrorAuthFailed     RecordedErrorKind = "auth_failed"     // ErrAuthFailed
+)
+
+// recordedErrorKind returns the kind of err, it's empty if err is none of the kinds.
+func recordedErrorKind(err error) RecordedErrorKind {
+	switch {
+	case errors.Is(err, ErrSessionInvalid):
+		return RecordedErrorSessionInvalid
+	case errors.Is(err, ErrAuthFailed):
+		return RecordedErrorAuthFailed
+	case isTimeoutError(err):
+		return RecordedErrorTimeout
+	}
+	return ""
+}
+
+// replayedError returns the recorded error, it wraps the sentinel error of the error kind.
+func (e *RecordedExchange) replayedError() error {
+	switch e.ErrorKind {
+	case RecordedErrorTimeout:
+		return fmt.Errorf("%w: %s", ErrTimeout, e.Error)
+	case RecordedErrorSessionInvalid:
+		return fmt.Errorf("%w: %s", ErrSessionInvalid, e.Error)
+	case RecordedErrorAuthFailed:
+		return fmt.Errorf("%w: %s", ErrAuthFailed, e.Error)
+	}
+	return errors.New(e.Error)
+}
+
+// RecordTransport wraps a Transport and records the request and response bytes of each exchange
+// into the writer, the records can be served back by ReplayTransport.
+//
+//	f, err := os.Create("fixture.jsonl")
+//	transport, err := client.Transport()
+//	client.WithTransport(ipmi.NewRecordTransport(transport, f))
+type RecordTransport struct {
+	transport Transport
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewRecordTransport creates a RecordTransport which records the exchanges of transport into w.
+func NewRecordTransport(transport Transport, w io.Writer) *RecordTransport {
+	return &RecordTransport{
+		transport: transport,
+		w:         w,
+	}
+}
+
+func (t *RecordTransport) Connect(ctx context.Context) error {
+	return t.transport.Connect(ctx)
+}
+
+func (t *RecordTransport) Exchange(ctx context.Context, request Request, response Response) error {
+	recorder := &responseRecorder{Response: response}
+	exchangeErr := t.transport.Exchange(ctx, request, recorder)
+
+	cmd := request.Command()
+	record := &RecordedExchange{
+		Name:    cmd.Name,
+		NetFn:   cmd.NetFn,
+		Command: cmd.ID,
+		Request: hex.EncodeToString(request.Pack()),
+	}
+
+	var respErr *ResponseError
+	switch {
+	case recorder.unpacked:
+		record.Response = hex.EncodeToString(append([]byte{uint8(CompletionCodeNormal)}, recorder.data...))
+	case errors.As(exchangeErr, &respErr):
+		record.Response = hex.EncodeToString([]byte{uint8(respErr.CompletionCode())})
+	case exchangeErr != nil:
+		record.Error = exchangeErr.Error()
+		record.ErrorKind = recordedErrorKind(exchangeErr)
+	}
+
+	if err := t.record(record); err != nil && exchangeErr == nil {
+		return err
+	}
+	return exchangeErr
+}
+
+func (t *RecordTransport) Close(ctx context.Context) error {
+	return t.transport.Close(ctx)
+}
+
+func (t *RecordTransport) record(record *RecordedExchange) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshal recorded exchange failed, err: %w", err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, err := t.w.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write recorded exchange failed, err: %w", err)
+	}
+	return nil
+}
+
+// responseRecorder keeps the response data passed to Unpack.
+type responseRecorder struct {
+	Response
+
+	unpacked bool
+	data     []byte
+}
+
+func (r *responseRecorder) Unpack(msg []byte) error {
+	r.unpacked = true
+	r.data = append([]byte{}, msg...)
+	return r.Response.Unpack(msg)
+}
+
+// ReplayTransport serves the exchanges recorded by RecordTransport.
+//
+// A request is answered by the first not yet replayed exchange whose netfn, command and request data
+// are the same, so the repeated requests (like polling a sensor reading) are answered in the recorded order.
+type ReplayTransport struct {
+	mu        sync.Mutex
+	exchanges []*RecordedExchange
+	replayed  []bool
+}
+
+// NewReplayTransport creates a ReplayTransport of the exchanges.
+func NewReplayTransport(exchanges []*RecordedExchange) *ReplayTransport {
+	return &ReplayTransport{
+		exchanges: exchanges,
+		replayed:  make([]bool, len(exchanges)),
+	}
+}
+
+// LoadReplayTransport creates a ReplayTransport of the fixture file written by RecordTransport.
+func LoadReplayTransport(file string) (*ReplayTransport, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("open fixture file failed, err: %w", err)
+	}
+	defer f.Close()
+
+	exchanges, err := ParseRecordedExchanges(f)
+	if err != nil {
+		return nil, fmt.Errorf("parse fixture file (%s) failed, err: %w", file, err)
+	}
+	return NewReplayTransport(exchanges), nil
+}
+
+// ParseRecordedExchanges reads the exchanges written by RecordTransport.
+// The empty lines and the lines starting with '#' are skipped, so the fixture files can be commented.
+func ParseRecordedExchanges(r io.Reader) ([]*RecordedExchange, error) {
+	exchanges := make([]*RecordedExchange, 0)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+
+		exchange := &RecordedExchange{}
+		if err := json.Unmarshal(line, exchange); err != nil {
+			return nil, fmt.Errorf("line %d: unmarshal failed, err: %w", lineNo, err)
+		}
+		if _, err := hex.DecodeString(exchange.Request); err != nil {
+			return nil, fmt.Errorf("line %d: invalid request, err: %w", lineNo, err)
+		}
+		if _, err := hex.DecodeString(exchange.Response); err != nil {
+			return nil, fmt.Errorf("line %d: invalid response, err: %w", lineNo, err)
+		}
+		if exchange.Response == "" && exchange.Error == "" {
+			return nil, fmt.Errorf("line %d: neither response nor error", lineNo)
+		}
+		switch exchange.ErrorKind {
+		case "", RecordedErrorTimeout, RecordedErrorSessionInvalid, RecordedErrorAuthFailed:
+		default:
+			return nil, fmt.Errorf("line %d: unknown error kind (%s)", lineNo, exchange.ErrorKind)
+		}
+		exchanges = append(exchanges, exchange)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read failed, err: %w", err)
+	}
+
+	return exchanges, nil
+}
+
+func (t *ReplayTransport) Connect(ctx context.Context) error {
+	return nil
+}
+
+func (t *ReplayTransport) Exchange(ctx context.Context, request Request, response Response) error {
+	cmd := request.Command()
+	data := hex.EncodeToString(request.Pack())
+
+	t.mu.Lock()
+	var exchange *RecordedExchange
+	for i, e := range t.exchanges {
+		if t.replayed[i] || e.NetFn != cmd.NetFn || e.Command != cmd.ID || e.Request != data {
+			continue
+		}
+		t.replayed[i] = true
+		exchange = e
+		break
+	}
+	t.mu.Unlock()
+
+	if exchange == nil {
+		return fmt.Errorf("no recorded exchange for request (%s) netfn (%#02x) cmd (%#02x) data (%s)", cmd.Name, uint8(cmd.NetFn), cmd.ID, data)
+	}
+	if exchange.Error != "" {
+		return exchange.replayedError()
+	}
+
+	msg, _ := hex.DecodeString(exchange.Response)
+	return UnpackResponse(msg, response)
+}
+
+func (t *ReplayTransport) Close(ctx context.Context) error {
+	return nil
+}
+
+// Remaining returns the exchanges which are not replayed yet.
+func (t *ReplayTransport) Remaining() []*RecordedExchange {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	remaining := make([]*RecordedExchange, 0)
+	for i, e := range t.exchanges {
+		if !t.replayed[i] {
+			remaining = append(remaining, e)
+		}
+	}
+	return remaining
+}
@@ -0,0 +1,161 @@
+package ipmi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func Test_RecordTransport_Replay(t *testing.T) {
+	transport := &rawTransport{
+		replies: map[Command][]byte{
+			{ID: CommandGetDeviceID.ID, NetFn: CommandGetDeviceID.NetFn}: {
+				0x00, 0x20, 0x81, 0x01, 0x10, 0x02, 0xbf, 0x57, 0x01, 0x00, 0x34, 0x12,
+			},
+		},
+	}
+
+	client, err := NewClientWithTransport(transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fixture bytes.Buffer
+	client.WithTransport(NewRecordTransport(transport, &fixture))
+
+	if err := client.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetDeviceID(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetSELInfo(); err == nil {
+		t.Fatal("expect GetSELInfo failed")
+	}
+
+	exchanges, err := ParseRecordedExchanges(&fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exchanges) != 2 {
+		t.Fatalf("expect 2 recorded exchanges, got: %d", len(exchanges))
+	}
+
+	replay := NewReplayTransport(exchanges)
+	client, err = NewClientWithTransport(replay)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.GetDeviceID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.DeviceID != 0x20 || res.ManufacturerID != 0x157 || res.ProductID != 0x1234 {
+		t.Errorf("device id response not match, got: %+v", res)
+	}
+
+	_, err = client.GetSELInfo()
+	var respErr *ResponseError
+	if !errors.As(err, &respErr) || respErr.CompletionCode() != CompletionCodeInvalidCommand {
+		t.Errorf("expect ResponseError with completion code %#02x, got: %v", CompletionCodeInvalidCommand, err)
+	}
+
+	if len(replay.Remaining()) != 0 {
+		t.Errorf("expect all exchanges replayed, remaining: %d", len(replay.Remaining()))
+	}
+
+	// the exchange is replayed only once
+	if _, err := client.GetDeviceID(); err == nil {
+		t.Error("expect no recorded exchange for the repeated request")
+	}
+}
+
+func Test_ReplayTransport_Fixture(t *testing.T) {
+	replay, err := LoadReplayTransport("testdata/replay_sel_entries.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := NewClientWithTransport(replay)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sels, err := client.GetSELEntries(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sels) != 2 {
+		t.Fatalf("expect 2 SEL entries, got: %d", len(sels))
+	}
+	if sels[1].RecordID != 2 || sels[1].Standard == nil || sels[1].Standard.EventDir != EventDirDeassertion {
+		t.Errorf("SEL entry not match, got: %+v", sels[1])
+	}
+}
+
+// errorTransport fails the exchanges of the commands with the errors.
+type errorTransport struct {
+	rawTransport
+	errs map[Command]error
+}
+
+func (t *errorTransport) Exchange(ctx context.Context, request Request, response Response) error {
+	cmd := request.Command()
+	if err, ok := t.errs[Command{ID: cmd.ID, NetFn: cmd.NetFn}]; ok {
+		return err
+	}
+	return t.rawTransport.Exchange(ctx, request, response)
+}
+
+func Test_ReplayTransport_ErrorKind(t *testing.T) {
+	transport := &errorTransport{
+		errs: map[Command]error{
+			{ID: CommandGetDeviceID.ID, NetFn: CommandGetDeviceID.NetFn}:               fmt.Errorf("%w: no response after 3 attempts", ErrTimeout),
+			{ID: CommandGetSELInfo.ID, NetFn: CommandGetSELInfo.NetFn}:                 fmt.Errorf("%w: the response is not authenticated", ErrSessionInvalid),
+			{ID: CommandGetSelfTestResults.ID, NetFn: CommandGetSelfTestResults.NetFn}: errors.New("write to conn failed"),
+		},
+	}
+
+	client, err := NewClientWithTransport(transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fixture bytes.Buffer
+	client.WithTransport(NewRecordTransport(transport, &fixture))
+	_, _ = client.GetDeviceID()
+	_, _ = client.GetSELInfo()
+	_, _ = client.GetSelfTestResults()
+
+	exchanges, err := ParseRecordedExchanges(&fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantKinds := []RecordedErrorKind{RecordedErrorTimeout, RecordedErrorSessionInvalid, ""}
+	if len(exchanges) != len(wantKinds) {
+		t.Fatalf("expect %d recorded exchanges, got: %d", len(wantKinds), len(exchanges))
+	}
+	for i, want := range wantKinds {
+		if exchanges[i].ErrorKind != want {
+			t.Errorf("exchange #%d: expect error kind %q, got: %q", i, want, exchanges[i].ErrorKind)
+		}
+	}
+
+	client, err = NewClientWithTransport(NewReplayTransport(exchanges))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetDeviceID(); !errors.Is(err, ErrTimeout) {
+		t.Errorf("expect ErrTimeout, got: %v", err)
+	}
+	if _, err := client.GetSELInfo(); !errors.Is(err, ErrSessionInvalid) {
+		t.Errorf("expect ErrSessionInvalid, got: %v", err)
+	}
+	if _, err := client.GetSelfTestResults(); err == nil || isTimeoutError(err) || errors.Is(err, ErrSessionInvalid) {
+		t.Errorf("expect plain error, got: %v", err)
+	}
+
+	if _, err := ParseRecordedExchanges(bytes.NewBufferString(`{"netfn":6,"cmd":1,"request":"","error":"x","error_kind":"busy"}`)); err == nil {
+		t.Error("expect unknown error kind rejected")
+	}
+}
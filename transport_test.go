@@ -0,0 +1,75 @@
+package ipmi
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// rawTransport replies the requests from a table keyed by netfn and command id.
+type rawTransport struct {
+	connected bool
+	closed    bool
+	replies   map[Command][]byte
+}
+
+func (t *rawTransport) Connect(ctx context.Context) error {
+	t.connected = true
+	return nil
+}
+
+func (t *rawTransport) Exchange(ctx context.Context, request Request, response Response) error {
+	cmd := request.Command()
+	msg, ok := t.replies[Command{ID: cmd.ID, NetFn: cmd.NetFn}]
+	if !ok {
+		msg = []byte{uint8(CompletionCodeInvalidCommand)}
+	}
+	return UnpackResponse(msg, response)
+}
+
+func (t *rawTransport) Close(ctx context.Context) error {
+	t.closed = true
+	return nil
+}
+
+func Test_NewClientWithTransport(t *testing.T) {
+	transport := &rawTransport{
+		replies: map[Command][]byte{
+			{ID: CommandGetDeviceID.ID, NetFn: CommandGetDeviceID.NetFn}: {
+				0x00, 0x20, 0x81, 0x01, 0x10, 0x02, 0xbf, 0x57, 0x01, 0x00, 0x34, 0x12,
+			},
+		},
+	}
+
+	client, err := NewClientWithTransport(transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	if !transport.connected {
+		t.Error("transport not connected")
+	}
+
+	res, err := client.GetDeviceID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.DeviceID != 0x20 || res.ManufacturerID != 0x157 || res.ProductID != 0x1234 {
+		t.Errorf("device id response not match, got: %+v", res)
+	}
+
+	_, err = client.GetSELInfo()
+	var respErr *ResponseError
+	if !errors.As(err, &respErr) || respErr.CompletionCode() != CompletionCodeInvalidCommand {
+		t.Errorf("expect ResponseError with completion code %#02x, got: %v", CompletionCodeInvalidCommand, err)
+	}
+
+	if err := client.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !transport.closed {
+		t.Error("transport not closed")
+	}
+}
@@ -154,7 +154,7 @@ func ParseBootOptionParameterData(paramSelector BootOptionParameterSelector, par
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("unpack paramData for paramSelector (%d) failed, err: %s", paramSelector, err)
+		return nil, fmt.Errorf("unpack paramData for paramSelector (%d) failed, err: %w", paramSelector, err)
 	}
 	return bop, nil
 }
@@ -1,5 +1,7 @@
 package ipmi
 
+import "context"
+
 // 22.15.2 Cipher Suite IDs
 type CipherSuiteID uint8
 
@@ -109,8 +111,8 @@ type CipherSuiteRecord struct {
 	CryptAlgs     []uint8 // Tag bits: [7:6]=10b
 }
 
-func (c *Client) findBestCipherSuites() []CipherSuiteID {
-	cipherSuiteRecords, err := c.GetAllChannelCipherSuites(ChannelNumberSelf)
+func (c *Client) findBestCipherSuites(ctx context.Context) []CipherSuiteID {
+	cipherSuiteRecords, err := c.GetAllChannelCipherSuitesContext(ctx, ChannelNumberSelf)
 	if err != nil {
 		return preferredCiphers
 	}
@@ -158,8 +160,10 @@ var preferredCiphers = []CipherSuiteID{
 	// CipherSuiteID3 -> 01h, 01h, 01h
 	CipherSuiteID3,
 
-	CipherSuiteID15,
+	// Newer BMCs may disable all the SHA1 cipher suites,
+	// HMAC-SHA256-128 integrity is preferred over no integrity.
 	CipherSuiteID16,
+	CipherSuiteID15,
 	CipherSuiteID18,
 	CipherSuiteID19,
 
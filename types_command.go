@@ -40,6 +40,12 @@ func (e *ResponseError) CompletionCode() CompletionCode {
 	return e.completionCode
 }
 
+// Is reports whether target is the error of the generic completion code of e, like ErrNodeBusy.
+func (e *ResponseError) Is(target error) bool {
+	cErr := e.completionCode.Err()
+	return cErr != nil && cErr == target
+}
+
 // Appendix G - Command Assignments
 // Command Number Assignments (Appendix G, table G-1)
 var (
@@ -111,9 +117,12 @@ var (
 	CommandMasterWriteRead                = Command{ID: 0x52, NetFn: NetFnAppRequest, Name: "Master Write-Read"}            // 53 unassigned
 	CommandGetChannelCipherSuites         = Command{ID: 0x54, NetFn: NetFnAppRequest, Name: "Get Channel Cipher Suites"}
 	CommandSuspendOrResumeEncryption      = Command{ID: 0x55, NetFn: NetFnAppRequest, Name: "Suspend/Resume Payload Encryption"}
-	CommandSetChannelCipherSuites         = Command{ID: 0x56, NetFn: NetFnAppRequest, Name: "Set Channel Security Keys"}
+	CommandSetChannelSecurityKeys         = Command{ID: 0x56, NetFn: NetFnAppRequest, Name: "Set Channel Security Keys"}
 	CommandGetSystemInterfaceCapabilities = Command{ID: 0x57, NetFn: NetFnAppRequest, Name: "Get System Interface Capabilities"}
 
+	// Deprecated: use CommandSetChannelSecurityKeys.
+	CommandSetChannelCipherSuites = CommandSetChannelSecurityKeys
+
 	// Chassis Device Commands
 	CommandGetChassisCapabilities = Command{ID: 0x00, NetFn: NetFnChassisRequest, Name: "Get Chassis Capabilities"}
 	CommandGetChassisStatus       = Command{ID: 0x01, NetFn: NetFnChassisRequest, Name: "Get Chassis Status"}
@@ -1,5 +1,7 @@
 package ipmi
 
+import "errors"
+
 type CompletionCode uint8
 
 // 5.2 Table 5 for generic completion codes
@@ -73,3 +75,69 @@ func (cc CompletionCode) String() string {
 	}
 	return ""
 }
+
+// The errors of the generic completion codes.
+// A *ResponseError matches the error of its completion code by errors.Is, even if it is wrapped.
+//
+//	if errors.Is(err, ipmi.ErrNodeBusy) {
+//		// retry later
+//	}
+var (
+	ErrNodeBusy                       = errors.New("node busy")
+	ErrInvalidCommand                 = errors.New("invalid command")
+	ErrInvalidCommandForLUN           = errors.New("invalid command on LUN")
+	ErrProcessTimeout                 = errors.New("timeout while processing command")
+	ErrOutOfSpace                     = errors.New("out of space")
+	ErrReservationCanceled            = errors.New("reservation cancelled or invalid")
+	ErrRequestDataTruncated           = errors.New("request data truncated")
+	ErrRequestDataLengthInvalid       = errors.New("request data length invalid")
+	ErrRequestDataLengthLimitExceeded = errors.New("request data field length limit exceeded")
+	ErrParameterOutOfRange            = errors.New("parameter out of range")
+	ErrCannotReturnRequestedDataBytes = errors.New("cannot return number of requested data bytes")
+	ErrRequestedDataNotPresent        = errors.New("requested sensor, data, or record not present")
+	ErrRequestDataFieldInvalid        = errors.New("invalid data field in request")
+	ErrIllegalCommand                 = errors.New("command illegal for specified sensor or record type")
+	ErrCannotProvideResponse          = errors.New("command response could not be provided")
+	ErrCannotExecuteDuplicatedRequest = errors.New("cannot execute duplicated request")
+	ErrSDRRepositoryInUpdate          = errors.New("SDR repository in update mode")
+	ErrFirmwareInUpdate               = errors.New("device firmware in update mode")
+	ErrBMCInitializing                = errors.New("BMC initialization in progress")
+	ErrDestinationUnavailable         = errors.New("destination unavailable")
+	ErrInsufficientPrivilege          = errors.New("insufficient privilege level")
+	ErrNotSupportedInPresentState     = errors.New("command not supported in present state")
+	ErrCommandDisabled                = errors.New("command disabled")
+	ErrUnspecified                    = errors.New("unspecified error")
+)
+
+var completionCodeErrors = map[CompletionCode]error{
+	CompletionCodeNodeBusy:                             ErrNodeBusy,
+	CompletionCodeInvalidCommand:                       ErrInvalidCommand,
+	CompletionCodeInvalidCommandForLUN:                 ErrInvalidCommandForLUN,
+	CompletionCodeProcessTimeout:                       ErrProcessTimeout,
+	CompletionCodeOutOfSpace:                           ErrOutOfSpace,
+	CompletionCodeReservationCanceled:                  ErrReservationCanceled,
+	CompletionCodeRequestDataTruncated:                 ErrRequestDataTruncated,
+	CompletionCodeRequestDataLengthInvalid:             ErrRequestDataLengthInvalid,
+	CompletionCodeRequestDataLengthLimitExceeded:       ErrRequestDataLengthLimitExceeded,
+	CompletionCodeParameterOutOfRange:                  ErrParameterOutOfRange,
+	CompletionCodeCannotReturnRequestedDataBytes:       ErrCannotReturnRequestedDataBytes,
+	CompletionCodeRequestedDataNotPresent:              ErrRequestedDataNotPresent,
+	CompletionCodeRequestDataFieldInvalid:              ErrRequestDataFieldInvalid,
+	CompletionCodeIllegalCommand:                       ErrIllegalCommand,
+	CompletionCodeCannotProvideResponse:                ErrCannotProvideResponse,
+	CompletionCodeCannotExecuteDuplicatedRequest:       ErrCannotExecuteDuplicatedRequest,
+	CompletionCodeCannotProvideResponseSDRRInUpdate:    ErrSDRRepositoryInUpdate,
+	CompletionCodeCannotProvideResponseFirmwareUpdate:  ErrFirmwareInUpdate,
+	CompletionCodeCannotProvideResponseBMCInitialize:   ErrBMCInitializing,
+	CompletionCodeDestinationUnavailable:               ErrDestinationUnavailable,
+	CompletionCodeCannotExecuteCommandSecurityRestrict: ErrInsufficientPrivilege,
+	CompletionCodeCannotExecuteCommandNotSupported:     ErrNotSupportedInPresentState,
+	CompletionCodeCannotExecuteCommandSubFnDisabled:    ErrCommandDisabled,
+	CompletionCodeUnspecifiedError:                     ErrUnspecified,
+}
+
+// Err returns the error of the generic completion code, or nil for the normal,
+// device-specific and command-specific completion codes.
+func (cc CompletionCode) Err() error {
+	return completionCodeErrors[cc]
+}
@@ -113,6 +113,67 @@ func (req *IPMIRequest) ComputeChecksum() {
 	req.Checksum2 = checksumFn(tempData, cs2Start, cs2End)
 }
 
+// Unpack is used by the responder (like the simulator) to decode the received request.
+func (req *IPMIRequest) Unpack(msg []byte) error {
+	if len(msg) < 7 {
+		return ErrUnpackedDataTooShortWith(len(msg), 7)
+	}
+
+	req.ResponderAddr, _, _ = unpackUint8(msg, 0)
+
+	b, _, _ := unpackUint8(msg, 1)
+	req.NetFn = NetFn(b >> 2)
+	req.ResponderLUN = b & 0x03
+
+	req.Checksum1, _, _ = unpackUint8(msg, 2)
+	req.RequesterAddr, _, _ = unpackUint8(msg, 3)
+
+	b4, _, _ := unpackUint8(msg, 4)
+	req.RequesterSequence = b4 >> 2
+	req.RequesterLUN = b4 & 0x03
+
+	req.Command, _, _ = unpackUint8(msg, 5)
+
+	dataLen := len(msg) - 6 - 1
+	req.CommandData, _, _ = unpackBytes(msg, 6, dataLen)
+	req.Checksum2, _, _ = unpackUint8(msg, len(msg)-1)
+
+	return nil
+}
+
+// Pack is used by the responder (like the simulator) to encode the response.
+func (res *IPMIResponse) Pack() []byte {
+	msgLen := 7 + len(res.Data) + 1
+	msg := make([]byte, msgLen)
+
+	packUint8(res.RequesterAddr, msg, 0)
+	packUint8(uint8(res.NetFn)<<2|res.RequestLUN&0x03, msg, 1)
+	packUint8(res.Checksum1, msg, 2)
+	packUint8(res.ResponderAddr, msg, 3)
+	packUint8(res.RequesterSequence<<2|res.ResponderLUN&0x03, msg, 4)
+	packUint8(res.Command, msg, 5)
+	packUint8(res.CompletionCode, msg, 6)
+	packBytes(res.Data, msg, 7)
+	packUint8(res.Checksum2, msg, msgLen-1)
+	return msg
+}
+
+// ComputeChecksum fills the Checksum1 and Checksum2 fields of the response.
+func (res *IPMIResponse) ComputeChecksum() {
+	tempData := res.Pack()
+	res.Checksum1 = checksum(tempData[0:2])
+	res.Checksum2 = checksum(tempData[3 : len(tempData)-1])
+}
+
+// checksum computes the 8-bit checksum, see IPMIRequest.ComputeChecksum.
+func checksum(msg []byte) uint8 {
+	var c uint8
+	for _, b := range msg {
+		c += b
+	}
+	return -c
+}
+
 func (res *IPMIResponse) Unpack(msg []byte) error {
 	if len(msg) < 8 {
 		return ErrUnpackedDataTooShortWith(len(msg), 8)
@@ -147,6 +208,14 @@ func (c *Client) BuildIPMIRequest(reqCmd Request) (*IPMIRequest, error) {
 	c.lock()
 	defer c.unlock()
 
+	// skip the sequence numbers still used by the in-flight requests
+	for i := uint8(0); c.pipeline != nil && c.pipeline.isPending(uint16(c.session.ipmiSeq)) && i < IPMIRequesterSequenceMax; i++ {
+		c.session.ipmiSeq += 1
+		if c.session.ipmiSeq > IPMIRequesterSequenceMax {
+			c.session.ipmiSeq = 1
+		}
+	}
+
 	ipmiReq := &IPMIRequest{
 		ResponderAddr: BMC_SA,
 
@@ -169,6 +238,10 @@ func (c *Client) BuildIPMIRequest(reqCmd Request) (*IPMIRequest, error) {
 
 	ipmiReq.ComputeChecksum()
 
+	if c.target != nil && bridgeable(reqCmd) {
+		return c.bridgeIPMIRequest(ipmiReq), nil
+	}
+
 	return ipmiReq, nil
 }
 
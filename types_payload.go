@@ -117,6 +117,19 @@ func (integrityAlg IntegrityAlg) String() string {
 	return ""
 }
 
+// AuthCodeLength returns the length of the AuthCode (Integrity Data) field
+// in the session trailer generated by the integrity algorithm.
+func (integrityAlg IntegrityAlg) AuthCodeLength() int {
+	switch integrityAlg {
+	case IntegrityAlg_HMAC_SHA1_96:
+		return 12
+	case IntegrityAlg_HMAC_MD5_128, IntegrityAlg_MD5_128, IntegrityAlg_HMAC_SHA256_128:
+		return 16
+	default:
+		return 0
+	}
+}
+
 // 13.28.5
 // Confidentiality (Encryption) Algorithms
 // AES is more secure than RC4
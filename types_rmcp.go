@@ -1,6 +1,9 @@
 package ipmi
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 const (
 	RmcpVersion uint8 = 0x06
@@ -46,7 +49,7 @@ func (r *Rmcp) Unpack(msg []byte) error {
 	rmcpHeader := &RmcpHeader{}
 	err := rmcpHeader.Unpack(msg[:4])
 	if err != nil {
-		return fmt.Errorf("unpack RmcpHeader failed, err: %s", err)
+		return fmt.Errorf("unpack RmcpHeader failed, err: %w", err)
 	}
 	r.RmcpHeader = rmcpHeader
 
@@ -58,7 +61,7 @@ func (r *Rmcp) Unpack(msg []byte) error {
 		asf := &ASF{}
 		err := asf.Unpack(msg[4:])
 		if err != nil {
-			return fmt.Errorf("unpack ASF failed, err: %s", err)
+			return fmt.Errorf("unpack ASF failed, err: %w", err)
 		}
 		r.ASF = asf
 		return nil
@@ -70,7 +73,7 @@ func (r *Rmcp) Unpack(msg []byte) error {
 		s20 := &Session20{}
 		err = s20.Unpack(msg[4:])
 		if err != nil {
-			return fmt.Errorf("unpack IPMI 2.0 Session failed, err: %s", err)
+			return fmt.Errorf("unpack IPMI 2.0 Session failed, err: %w", err)
 		}
 		r.Session20 = s20
 	} else {
@@ -78,7 +81,7 @@ func (r *Rmcp) Unpack(msg []byte) error {
 		s15 := &Session15{}
 		err = s15.Unpack(msg[4:])
 		if err != nil {
-			return fmt.Errorf("unpack IPMI 1.5 Session failed, err: %s", err)
+			return fmt.Errorf("unpack IPMI 1.5 Session failed, err: %w", err)
 		}
 		r.Session15 = s15
 	}
@@ -270,7 +273,7 @@ func (asf *ASF) Unpack(msg []byte) error {
 		return ErrUnpackedDataTooShortWith(len(msg), 8)
 	}
 
-	asf.IANA, _, _ = unpackUint32L(msg, 0)
+	asf.IANA, _, _ = unpackUint32(msg, 0) // MSB, not LSB
 	asf.MessageType, _, _ = unpackUint8(msg, 4)
 	asf.MessageTag, _, _ = unpackUint8(msg, 5)
 	// 1 byte reserved
@@ -286,10 +289,17 @@ func (asf *ASF) Unpack(msg []byte) error {
 func (c *Client) BuildRmcpRequest(reqCmd Request) (*Rmcp, error) {
 	payloadType, rawPayload, err := c.buildRawPayload(reqCmd)
 	if err != nil {
-		return nil, fmt.Errorf("buildRawPayload failed, err: %s", err)
+		return nil, fmt.Errorf("buildRawPayload failed, err: %w", err)
 	}
 	c.DebugBytes("rawPayload", rawPayload, 16)
 
+	return c.buildRmcp(reqCmd, payloadType, rawPayload)
+}
+
+// buildRmcp wraps the already built raw payload into a RMCP message.
+// Each call generates a new session header, so the session sequence number
+// is advanced for every (re)transmission of the same raw payload.
+func (c *Client) buildRmcp(reqCmd Request, payloadType PayloadType, rawPayload []byte) (*Rmcp, error) {
 	// ASF
 	if _, ok := reqCmd.(*RmcpPingRequest); ok {
 		rmcp := &Rmcp{
@@ -309,7 +319,7 @@ func (c *Client) BuildRmcpRequest(reqCmd Request) (*Rmcp, error) {
 	if c.v20 {
 		session20, err := c.genSession20(payloadType, rawPayload)
 		if err != nil {
-			return nil, fmt.Errorf("genSession20 failed, err: %s", err)
+			return nil, fmt.Errorf("genSession20 failed, err: %w", err)
 		}
 
 		rmcp := &Rmcp{
@@ -322,7 +332,7 @@ func (c *Client) BuildRmcpRequest(reqCmd Request) (*Rmcp, error) {
 	// IPMI 1.5
 	session15, err := c.genSession15(rawPayload)
 	if err != nil {
-		return nil, fmt.Errorf("genSession15 failed, err: %s", err)
+		return nil, fmt.Errorf("genSession15 failed, err: %w", err)
 	}
 
 	rmcp := &Rmcp{
@@ -335,9 +345,61 @@ func (c *Client) BuildRmcpRequest(reqCmd Request) (*Rmcp, error) {
 // ParseRmcpResponse parses msg bytes.
 // The response param should be passed as a pointer of the struct which implements the Response interface.
 func (c *Client) ParseRmcpResponse(msg []byte, response Response) error {
+	return c.parseRmcpResponse(msg, response, nil)
+}
+
+// errUnmatchedResponse is returned by parseRmcpResponse if the received message
+// is not the reply of the expected request, like a late reply of a timed out
+// request or a duplicated reply of a retransmitted request.
+var errUnmatchedResponse = errors.New("response does not match the request")
+
+// rmcpExpect holds the fields of the sent request which are used to match the received response.
+type rmcpExpect struct {
+	payloadType PayloadType
+
+	// for session setup payloads
+	messageTag uint8
+
+	// for IPMI payloads
+	rqSeq   uint8
+	command uint8
+
+	// for bridged IPMI payloads, the command of the request encapsulated in Send Message,
+	// the replies of the Send Message requests are followed by the reply of the bridged request.
+	bridged        bool
+	bridgedCommand uint8
+}
+
+// newRmcpExpect returns the expectation for the reply of the raw payload
+// built by buildRawPayload.
+func newRmcpExpect(payloadType PayloadType, rawPayload []byte) *rmcpExpect {
+	expect := &rmcpExpect{
+		payloadType: payloadType,
+	}
+
+	switch payloadType {
+	case PayloadTypeIPMI:
+		if len(rawPayload) >= 6 {
+			expect.rqSeq = rawPayload[4] >> 2
+			expect.command = rawPayload[5]
+			expect.bridgedCommand, expect.bridged = bridgedCommand(rawPayload)
+		}
+	default:
+		if len(rawPayload) >= 1 {
+			expect.messageTag = rawPayload[0]
+		}
+	}
+
+	return expect
+}
+
+// parseRmcpResponse is like ParseRmcpResponse, but if expect is not nil, it returns
+// errUnmatchedResponse without touching response for messages which are not
+// the reply of the expected request.
+func (c *Client) parseRmcpResponse(msg []byte, response Response, expect *rmcpExpect) error {
 	rmcp := &Rmcp{}
 	if err := rmcp.Unpack(msg); err != nil {
-		return fmt.Errorf("unpack rmcp failed, err: %s", err)
+		return fmt.Errorf("unpack rmcp failed, err: %w", err)
 	}
 	c.Debug("<<<<<< RMCP Response", rmcp)
 
@@ -346,7 +408,7 @@ func (c *Client) ParseRmcpResponse(msg []byte, response Response) error {
 			return fmt.Errorf("asf Data Length not equal")
 		}
 		if err := response.Unpack(rmcp.ASF.Data); err != nil {
-			return fmt.Errorf("unpack asf response failed, err: %s", err)
+			return fmt.Errorf("unpack asf response failed, err: %w", err)
 		}
 		return nil
 	}
@@ -356,24 +418,16 @@ func (c *Client) ParseRmcpResponse(msg []byte, response Response) error {
 
 		ipmiRes := IPMIResponse{}
 		if err := ipmiRes.Unpack(ipmiPayload); err != nil {
-			return fmt.Errorf("unpack ipmiRes failed, err: %s", err)
+			return fmt.Errorf("unpack ipmiRes failed, err: %w", err)
 		}
 		c.Debug("<<<< IPMI Response", ipmiRes)
 
-		ccode := ipmiRes.CompletionCode
-		if ccode != 0x00 {
-			return &ResponseError{
-				completionCode: CompletionCode(ccode),
-				description:    fmt.Sprintf("ipmiRes CompletionCode (%#02x) is not normal: %s", ccode, StrCC(response, ccode)),
-			}
+		if !expect.matchIPMI(&ipmiRes) {
+			return errUnmatchedResponse
 		}
 
-		// now ccode is 0x00, we can continue to deserialize response
-		if err := response.Unpack(ipmiRes.Data); err != nil {
-			return &ResponseError{
-				completionCode: 0x00,
-				description:    fmt.Sprintf("unpack response failed, err: %s", err),
-			}
+		if err := c.unpackIPMIResponse(&ipmiRes, response, expect); err != nil {
+			return err
 		}
 	}
 
@@ -387,19 +441,29 @@ func (c *Client) ParseRmcpResponse(msg []byte, response Response) error {
 			PayloadTypeRAKPMessage4:
 			// Session Setup Payload Types
 
+			if !expect.matchSessionSetup(sessionHdr.PayloadType, rmcp.Session20.SessionPayload) {
+				return errUnmatchedResponse
+			}
+
 			if err := response.Unpack(rmcp.Session20.SessionPayload); err != nil {
-				return fmt.Errorf("unpack session setup response failed, err: %s", err)
+				return fmt.Errorf("unpack session setup response failed, err: %w", err)
 			}
 			return nil
 
 		case PayloadTypeIPMI:
 			// Standard Payload Types
+			if c.session.v20.state == SessionStateActive {
+				if err := c.checkSession20(msg, rmcp.Session20); err != nil {
+					return err
+				}
+			}
+
 			ipmiPayload := rmcp.Session20.SessionPayload
 			if sessionHdr.PayloadEncrypted {
 				c.DebugBytes("decrypting", ipmiPayload, 16)
 				d, err := c.decryptPayload(rmcp.Session20.SessionPayload)
 				if err != nil {
-					return fmt.Errorf("decrypt session payload failed, err: %s", err)
+					return fmt.Errorf("decrypt session payload failed, err: %w", err)
 				}
 				ipmiPayload = d
 				c.DebugBytes("decrypted", ipmiPayload, 16)
@@ -407,31 +471,140 @@ func (c *Client) ParseRmcpResponse(msg []byte, response Response) error {
 
 			ipmiRes := IPMIResponse{}
 			if err := ipmiRes.Unpack(ipmiPayload); err != nil {
-				return fmt.Errorf("unpack ipmiRes failed, err: %s", err)
+				return fmt.Errorf("unpack ipmiRes failed, err: %w", err)
 			}
 			c.Debug("<<<< IPMI Response", ipmiRes)
 
-			ccode := ipmiRes.CompletionCode
-			if ccode != 0x00 {
-				return &ResponseError{
-					completionCode: CompletionCode(ccode),
-					description:    fmt.Sprintf("ipmiRes CompletionCode (%#02x) is not normal: %s", ccode, StrCC(response, ccode)),
-				}
+			if !expect.matchIPMI(&ipmiRes) {
+				return errUnmatchedResponse
 			}
 
-			// now ccode is 0x00, we can continue to deserialize response
-			if err := response.Unpack(ipmiRes.Data); err != nil {
-				return &ResponseError{
-					completionCode: 0x00,
-					description:    fmt.Sprintf("unpack response failed, err: %s", err),
-				}
+			if err := c.unpackIPMIResponse(&ipmiRes, response, expect); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkSession20 validates the session fields of the IPMI payload received in an active RMCP+ session.
+//
+// It returns errUnmatchedResponse for messages of other sessions.
+// If the lost sessions are re-established (see WithReconnectPolicy), it also returns an error
+// wrapping ErrSessionInvalid if the BMC replies outside of the session
+// or the integrity check value of the message is not right.
+// Those checks are skipped otherwise, so the lenient BMCs keep working.
+func (c *Client) checkSession20(msg []byte, s20 *Session20) error {
+	sessionHdr := s20.SessionHeader20
+
+	if sessionHdr.SessionID != 0 && sessionHdr.SessionID != c.session.v20.consoleSessionID {
+		return errUnmatchedResponse
+	}
+
+	if c.reconnectPolicy == nil {
+		return nil
+	}
+
+	if sessionHdr.SessionID == 0 {
+		return fmt.Errorf("%w: the response is sent outside of the session", ErrSessionInvalid)
+	}
+
+	if c.session.v20.integrityAlg == IntegrityAlg_None {
+		return nil
+	}
+
+	if !sessionHdr.PayloadAuthenticated || s20.SessionTrailer == nil {
+		return fmt.Errorf("%w: the response is not authenticated", ErrSessionInvalid)
+	}
+
+	// the integrity data covers from the AuthType/Format field (after the 4 bytes RMCP header)
+	// to the Next Header field of the session trailer, see 13.28.4
+	authCodeLen := c.session.v20.integrityAlg.AuthCodeLength()
+	if len(msg) < 4+authCodeLen {
+		return fmt.Errorf("%w: the response is too short", ErrSessionInvalid)
+	}
+	expected, err := c.genIntegrityAuthCode(msg[4 : len(msg)-authCodeLen])
+	if err != nil {
+		return fmt.Errorf("generate integrity authcode failed, err: %w", err)
+	}
+	if !isByteSliceEqual(expected, msg[len(msg)-authCodeLen:]) {
+		return fmt.Errorf("%w: integrity check value of the response not matched", ErrSessionInvalid)
+	}
+
+	return nil
+}
+
+func (expect *rmcpExpect) matchIPMI(ipmiRes *IPMIResponse) bool {
+	if expect == nil {
+		return true
+	}
+	return expect.payloadType == PayloadTypeIPMI &&
+		ipmiRes.RequesterSequence == expect.rqSeq &&
+		(ipmiRes.Command == expect.command || (expect.bridged && ipmiRes.Command == expect.bridgedCommand))
+}
+
+// unpackIPMIResponse checks the completion code of the matched IPMI response
+// and deserializes its data to response.
+//
+// For bridged requests, it returns errUnmatchedResponse for the reply of the Send Message
+// request unless the reply of the bridged request is embedded in it,
+// so that the caller continues to wait for the reply of the bridged request.
+func (c *Client) unpackIPMIResponse(ipmiRes *IPMIResponse, response Response, expect *rmcpExpect) error {
+	ccode := ipmiRes.CompletionCode
+
+	if expect != nil && expect.bridged && ipmiRes.Command == CommandSendMessage.ID && ipmiRes.Command != expect.bridgedCommand {
+		if ccode != 0x00 {
+			return &ResponseError{
+				completionCode: CompletionCode(ccode),
+				description:    fmt.Sprintf("Send Message CompletionCode (%#02x) is not normal: %s", ccode, StrCC(&SendMessageResponse{}, ccode)),
 			}
 		}
+
+		if len(ipmiRes.Data) < 8 {
+			c.Debugf("bridged request is sent, waiting for its reply\n")
+			return errUnmatchedResponse
+		}
+
+		// some BMCs embed the reply of the bridged request in the reply of Send Message
+		embedded := &IPMIResponse{}
+		if err := embedded.Unpack(ipmiRes.Data); err != nil {
+			return fmt.Errorf("unpack embedded ipmiRes failed, err: %w", err)
+		}
+		c.Debug("<<<< Embedded IPMI Response", embedded)
+		return c.unpackIPMIResponse(embedded, response, expect)
+	}
+
+	if ccode != 0x00 {
+		return &ResponseError{
+			completionCode: CompletionCode(ccode),
+			description:    fmt.Sprintf("ipmiRes CompletionCode (%#02x) is not normal: %s", ccode, StrCC(response, ccode)),
+		}
+	}
+
+	// now ccode is 0x00, we can continue to deserialize response
+	if err := response.Unpack(ipmiRes.Data); err != nil {
+		return &ResponseError{
+			completionCode: 0x00,
+			description:    fmt.Sprintf("unpack response failed, err: %s", err),
+		}
 	}
 
 	return nil
 }
 
+func (expect *rmcpExpect) matchSessionSetup(payloadType PayloadType, payload []byte) bool {
+	if expect == nil {
+		return true
+	}
+	// the response payload type of session setup payloads is always
+	// the request payload type plus one, see 13.27.1 ~ 13.27.4
+	if payloadType != expect.payloadType+1 {
+		return false
+	}
+	return len(payload) > 0 && payload[0] == expect.messageTag
+}
+
 // 13.24 RMCP+ and RAKP Message Status Codes
 type RmcpStatusCode uint8
 
@@ -487,3 +660,19 @@ func (c RmcpStatusCode) String() string {
 	}
 	return "Unknown"
 }
+
+// err returns the error of the status code, which wraps ErrAuthFailed
+// if the BMC rejects the user, the role or the authentication code.
+func (c RmcpStatusCode) err() error {
+	switch c {
+	case
+		RmcpStatusCodeInvalidRole,
+		RmcpStatusCodeUnauthorizedRoleOfPriLevel,
+		RmcpStatusCodeInvalidNameLength,
+		RmcpStatusCodeUnauthorizedName,
+		RmcpStatusCodeUnauthorizedGUID,
+		RmcpStatusCodeInvalidIntegrityCheckValue:
+		return fmt.Errorf("%w: %s (%#02x)", ErrAuthFailed, c, uint8(c))
+	}
+	return fmt.Errorf("%s (%#02x)", c, uint8(c))
+}
@@ -2,6 +2,7 @@ package ipmi
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 
 	"github.com/olekukonko/tablewriter"
@@ -185,53 +186,106 @@ func ParseSDR(data []byte, nextRecordID uint16) (*SDR, error) {
 	switch sdrHeader.RecordType {
 	case SDRRecordTypeFullSensor:
 		if err := parseSDRFullSensor(data, sdr); err != nil {
-			return nil, fmt.Errorf("parseSDRFullSensor failed, err: %s", err)
+			return nil, fmt.Errorf("parseSDRFullSensor failed, err: %w", err)
 		}
 	case SDRRecordTypeCompactSensor:
 		if err := parseSDRCompactSensor(data, sdr); err != nil {
-			return nil, fmt.Errorf("parseSDRCompactSensor failed, err: %s", err)
+			return nil, fmt.Errorf("parseSDRCompactSensor failed, err: %w", err)
 		}
 	case SDRRecordTypeEventOnly:
 		if err := parseSDREventOnly(data, sdr); err != nil {
-			return nil, fmt.Errorf("parseSDREventOnly failed, err: %s", err)
+			return nil, fmt.Errorf("parseSDREventOnly failed, err: %w", err)
 		}
 	case SDRRecordTypeEntityAssociation:
 		if err := parseSDREntityAssociation(data, sdr); err != nil {
-			return nil, fmt.Errorf("parseSDREntityAssociation failed, err: %s", err)
+			return nil, fmt.Errorf("parseSDREntityAssociation failed, err: %w", err)
 		}
 	case SDRRecordTypeDeviceRelativeEntityAssociation:
 		if err := parseSDRDeviceRelativeEntityAssociation(data, sdr); err != nil {
-			return nil, fmt.Errorf("parseSDRDeviceRelativeEntityAssociation failed, err: %s", err)
+			return nil, fmt.Errorf("parseSDRDeviceRelativeEntityAssociation failed, err: %w", err)
 		}
 	case SDRRecordTypeGenericLocator:
 		if err := parseSDRGenericLocator(data, sdr); err != nil {
-			return nil, fmt.Errorf("parseSDRGenericLocator failed, err: %s", err)
+			return nil, fmt.Errorf("parseSDRGenericLocator failed, err: %w", err)
 		}
 	case SDRRecordTypeFRUDeviceLocator:
 		if err := parseSDRFRUDeviceLocator(data, sdr); err != nil {
-			return nil, fmt.Errorf("parseSDRFRUDeviceLocator failed, err: %s", err)
+			return nil, fmt.Errorf("parseSDRFRUDeviceLocator failed, err: %w", err)
 		}
 	case SDRRecordTypeManagementControllerDeviceLocator:
 		if err := parseSDRManagementControllerDeviceLocator(data, sdr); err != nil {
-			return nil, fmt.Errorf("parseSDRManagementControllerDeviceLocator failed, err: %s", err)
+			return nil, fmt.Errorf("parseSDRManagementControllerDeviceLocator failed, err: %w", err)
 		}
 	case SDRRecordTypeManagementControllerConfirmation:
 		if err := parseSDRManagementControllerConfirmation(data, sdr); err != nil {
-			return nil, fmt.Errorf("parseSDRManagementControllerConfirmation failed, err: %s", err)
+			return nil, fmt.Errorf("parseSDRManagementControllerConfirmation failed, err: %w", err)
 		}
 	case SDRRecordTypeBMCMessageChannelInfo:
 		if err := parseSDRBMCMessageChannelInfo(data, sdr); err != nil {
-			return nil, fmt.Errorf("parseSDRBMCMessageChannelInfo failed, err: %s", err)
+			return nil, fmt.Errorf("parseSDRBMCMessageChannelInfo failed, err: %w", err)
 		}
 	case SDRRecordTypeOEM:
 		if err := parseSDROEM(data, sdr); err != nil {
-			return nil, fmt.Errorf("parseSDROEM failed, err: %s", err)
+			return nil, fmt.Errorf("parseSDROEM failed, err: %w", err)
 		}
 	}
 
 	return sdr, nil
 }
 
+// Pack packs the SDR to raw SDR record data, it's the reverse of ParseSDR.
+// The Record Length in the record header is set to the length of the packed record body,
+// and the SDR Version defaults to 51h if not set.
+func (sdr *SDR) Pack() ([]byte, error) {
+	if sdr.RecordHeader == nil {
+		return nil, fmt.Errorf("sdr record header is nil")
+	}
+
+	var body []byte
+	switch recordType := sdr.RecordHeader.RecordType; {
+	case recordType == SDRRecordTypeFullSensor && sdr.Full != nil:
+		body = sdr.Full.Pack()
+	case recordType == SDRRecordTypeCompactSensor && sdr.Compact != nil:
+		body = sdr.Compact.Pack()
+	case recordType == SDRRecordTypeEventOnly && sdr.EventOnly != nil:
+		body = sdr.EventOnly.Pack()
+	case recordType == SDRRecordTypeEntityAssociation && sdr.EntityAssociation != nil:
+		body = sdr.EntityAssociation.Pack()
+	case recordType == SDRRecordTypeDeviceRelativeEntityAssociation && sdr.DeviceRelative != nil:
+		body = sdr.DeviceRelative.Pack()
+	case recordType == SDRRecordTypeGenericLocator && sdr.GenericDeviceLocator != nil:
+		body = sdr.GenericDeviceLocator.Pack()
+	case recordType == SDRRecordTypeFRUDeviceLocator && sdr.FRUDeviceLocator != nil:
+		body = sdr.FRUDeviceLocator.Pack()
+	case recordType == SDRRecordTypeManagementControllerDeviceLocator && sdr.MgmtControllerDeviceLocator != nil:
+		body = sdr.MgmtControllerDeviceLocator.Pack()
+	case recordType == SDRRecordTypeManagementControllerConfirmation && sdr.MgmtControllerConfirmation != nil:
+		body = sdr.MgmtControllerConfirmation.Pack()
+	case recordType == SDRRecordTypeBMCMessageChannelInfo && sdr.BMCChannelInfo != nil:
+		body = sdr.BMCChannelInfo.Pack()
+	case recordType == SDRRecordTypeOEM && sdr.OEM != nil:
+		body = sdr.OEM.Pack()
+	default:
+		return nil, fmt.Errorf("no record of type (%#02x) to pack", uint8(recordType))
+	}
+
+	if len(body) > 0xff {
+		return nil, fmt.Errorf("record length (%d) exceeds 255", len(body))
+	}
+
+	sdrVersion := sdr.RecordHeader.SDRVersion
+	if sdrVersion == 0 {
+		sdrVersion = 0x51
+	}
+
+	out := make([]byte, 5, 5+len(body))
+	packUint16L(sdr.RecordHeader.RecordID, out, 0)
+	packUint8(sdrVersion, out, 2)
+	packUint8(uint8(sdr.RecordHeader.RecordType), out, 3)
+	packUint8(uint8(len(body)), out, 4)
+	return append(out, body...), nil
+}
+
 // Format SDRs of FRU record type
 func FormatSDRs_FRU(records []*SDR) string {
 	var buf = new(bytes.Buffer)
@@ -631,6 +685,165 @@ func (mask *Mask) ParseReading(b uint16) {
 	mask.Threshold.LNC.Readable = isBit0Set(msb)
 }
 
+// PackAssertLower is the reverse of ParseAssertLower.
+func (mask *Mask) PackAssertLower() uint16 {
+	var lsb, msb uint8
+
+	if mask.Discrete.Assert.State_14 || mask.Threshold.LNR.StatusReturned {
+		lsb = setBit6(lsb)
+	}
+	if mask.Discrete.Assert.State_13 || mask.Threshold.LCR.StatusReturned {
+		lsb = setBit5(lsb)
+	}
+	if mask.Discrete.Assert.State_12 || mask.Threshold.LNC.StatusReturned {
+		lsb = setBit4(lsb)
+	}
+	if mask.Discrete.Assert.State_11 || mask.Threshold.UNR.High_Assert {
+		lsb = setBit3(lsb)
+	}
+	if mask.Discrete.Assert.State_10 || mask.Threshold.UNR.Low_Assert {
+		lsb = setBit2(lsb)
+	}
+	if mask.Discrete.Assert.State_9 || mask.Threshold.UCR.High_Assert {
+		lsb = setBit1(lsb)
+	}
+	if mask.Discrete.Assert.State_8 || mask.Threshold.UCR.Low_Assert {
+		lsb = setBit0(lsb)
+	}
+	if mask.Discrete.Assert.State_7 || mask.Threshold.UNC.High_Assert {
+		msb = setBit7(msb)
+	}
+	if mask.Discrete.Assert.State_6 || mask.Threshold.UNC.Low_Assert {
+		msb = setBit6(msb)
+	}
+	if mask.Discrete.Assert.State_5 || mask.Threshold.LNR.High_Assert {
+		msb = setBit5(msb)
+	}
+	if mask.Discrete.Assert.State_4 || mask.Threshold.LNR.Low_Assert {
+		msb = setBit4(msb)
+	}
+	if mask.Discrete.Assert.State_3 || mask.Threshold.LCR.High_Assert {
+		msb = setBit3(msb)
+	}
+	if mask.Discrete.Assert.State_2 || mask.Threshold.LCR.Low_Assert {
+		msb = setBit2(msb)
+	}
+	if mask.Discrete.Assert.State_1 || mask.Threshold.LNC.High_Assert {
+		msb = setBit1(msb)
+	}
+	if mask.Discrete.Assert.State_0 || mask.Threshold.LNC.Low_Assert {
+		msb = setBit0(msb)
+	}
+
+	return uint16(msb)<<8 | uint16(lsb)
+}
+
+// PackDeassertUpper is the reverse of ParseDeassertUpper.
+func (mask *Mask) PackDeassertUpper() uint16 {
+	var lsb, msb uint8
+
+	if mask.Discrete.Deassert.State_14 || mask.Threshold.UNR.StatusReturned {
+		lsb = setBit6(lsb)
+	}
+	if mask.Discrete.Deassert.State_13 || mask.Threshold.UCR.StatusReturned {
+		lsb = setBit5(lsb)
+	}
+	if mask.Discrete.Deassert.State_12 || mask.Threshold.UNC.StatusReturned {
+		lsb = setBit4(lsb)
+	}
+	if mask.Discrete.Deassert.State_11 || mask.Threshold.UNR.High_Deassert {
+		lsb = setBit3(lsb)
+	}
+	if mask.Discrete.Deassert.State_10 || mask.Threshold.UNR.Low_Deassert {
+		lsb = setBit2(lsb)
+	}
+	if mask.Discrete.Deassert.State_9 || mask.Threshold.UCR.High_Deassert {
+		lsb = setBit1(lsb)
+	}
+	if mask.Discrete.Deassert.State_8 || mask.Threshold.UCR.Low_Deassert {
+		lsb = setBit0(lsb)
+	}
+	if mask.Discrete.Deassert.State_7 || mask.Threshold.UNC.High_Deassert {
+		msb = setBit7(msb)
+	}
+	if mask.Discrete.Deassert.State_6 || mask.Threshold.UNC.Low_Deassert {
+		msb = setBit6(msb)
+	}
+	if mask.Discrete.Deassert.State_5 || mask.Threshold.LNR.High_Deassert {
+		msb = setBit5(msb)
+	}
+	if mask.Discrete.Deassert.State_4 || mask.Threshold.LNR.Low_Deassert {
+		msb = setBit4(msb)
+	}
+	if mask.Discrete.Deassert.State_3 || mask.Threshold.LCR.High_Deassert {
+		msb = setBit3(msb)
+	}
+	if mask.Discrete.Deassert.State_2 || mask.Threshold.LCR.Low_Deassert {
+		msb = setBit2(msb)
+	}
+	if mask.Discrete.Deassert.State_1 || mask.Threshold.LNC.High_Deassert {
+		msb = setBit1(msb)
+	}
+	if mask.Discrete.Deassert.State_0 || mask.Threshold.LNC.Low_Deassert {
+		msb = setBit0(msb)
+	}
+
+	return uint16(msb)<<8 | uint16(lsb)
+}
+
+// PackReading is the reverse of ParseReading.
+func (mask *Mask) PackReading() uint16 {
+	var lsb, msb uint8
+
+	if mask.Discrete.Reading.State_14 {
+		lsb = setBit6(lsb)
+	}
+	if mask.Discrete.Reading.State_13 || mask.Threshold.UNR.Settable {
+		lsb = setBit5(lsb)
+	}
+	if mask.Discrete.Reading.State_12 || mask.Threshold.UCR.Settable {
+		lsb = setBit4(lsb)
+	}
+	if mask.Discrete.Reading.State_11 || mask.Threshold.UNC.Settable {
+		lsb = setBit3(lsb)
+	}
+	if mask.Discrete.Reading.State_10 || mask.Threshold.LNR.Settable {
+		lsb = setBit2(lsb)
+	}
+	if mask.Discrete.Reading.State_9 || mask.Threshold.LCR.Settable {
+		lsb = setBit1(lsb)
+	}
+	if mask.Discrete.Reading.State_8 || mask.Threshold.LNC.Settable {
+		lsb = setBit0(lsb)
+	}
+	if mask.Discrete.Reading.State_7 {
+		msb = setBit7(msb)
+	}
+	if mask.Discrete.Reading.State_6 {
+		msb = setBit6(msb)
+	}
+	if mask.Discrete.Reading.State_5 || mask.Threshold.UNR.Readable {
+		msb = setBit5(msb)
+	}
+	if mask.Discrete.Reading.State_4 || mask.Threshold.UCR.Readable {
+		msb = setBit4(msb)
+	}
+	if mask.Discrete.Reading.State_3 || mask.Threshold.UNC.Readable {
+		msb = setBit3(msb)
+	}
+	if mask.Discrete.Reading.State_2 || mask.Threshold.LNR.Readable {
+		msb = setBit2(msb)
+	}
+	if mask.Discrete.Reading.State_1 || mask.Threshold.LCR.Readable {
+		msb = setBit1(msb)
+	}
+	if mask.Discrete.Reading.State_0 || mask.Threshold.LNC.Readable {
+		msb = setBit0(msb)
+	}
+
+	return uint16(msb)<<8 | uint16(lsb)
+}
+
 // StatusReturnedThresholds returns all supported thresholds comparison status
 // via the Get Sensor Reading command.
 func (mask *Mask) StatusReturnedThresholds() SensorThresholdTypes {
@@ -859,15 +1072,57 @@ type SensorInitialization struct {
 	SensorScanningEnabled bool
 }
 
+// pack packs the Sensor Capabilities byte of Full and Compact SDR.
+func (capabilities SensorCapabilities) pack() uint8 {
+	b := uint8(capabilities.HysteresisAccess&0x03)<<4 | uint8(capabilities.ThresholdAccess&0x03)<<2 | uint8(capabilities.EventMessageControl&0x03)
+	if capabilities.IgnoreSensorIfNoEntity {
+		b = setBit7(b)
+	}
+	if capabilities.AutoRearm {
+		b = setBit6(b)
+	}
+	return b
+}
+
+// pack packs the Sensor Initialization byte of Full and Compact SDR.
+func (initialization SensorInitialization) pack() uint8 {
+	var b uint8
+	if initialization.Settable {
+		b = setBit7(b)
+	}
+	if initialization.InitScanning {
+		b = setBit6(b)
+	}
+	if initialization.InitEvents {
+		b = setBit5(b)
+	}
+	if initialization.InitThresholds {
+		b = setBit4(b)
+	}
+	if initialization.InitHysteresis {
+		b = setBit3(b)
+	}
+	if initialization.InitSensorType {
+		b = setBit2(b)
+	}
+	if initialization.EventGenerationEnabled {
+		b = setBit1(b)
+	}
+	if initialization.SensorScanningEnabled {
+		b = setBit0(b)
+	}
+	return b
+}
+
 // enhanceSDR will fill extra data for SDR
-func (c *Client) enhanceSDR(sdr *SDR) error {
+func (c *Client) enhanceSDR(ctx context.Context, sdr *SDR) error {
 	if sdr == nil {
 		return nil
 	}
 
-	sensor, err := c.sdrToSensor(sdr)
+	sensor, err := c.sdrToSensor(ctx, sdr)
 	if err != nil {
-		return fmt.Errorf("sdrToSensor failed, err: %s", err)
+		return fmt.Errorf("sdrToSensor failed, err: %w", err)
 	}
 
 	switch sdr.RecordHeader.RecordType {
@@ -57,8 +57,23 @@ type SDRCompact struct {
 	// 11b = reserved
 	SensorDirection uint8
 
+	// ID String Instance Modifier Type
+	// 00b = numeric
+	// 01b = alpha
+	IDStringInstanceModifierType uint8
+
+	// Share count (number of sensors sharing this record). Sensor numbers sharing this
+	// record are sequential starting with the sensor number specified by the Sensor
+	// Number field for this record.
+	ShareCount uint8
+
+	// 0b = Entity Instance same for all shared records
+	// 1b = Entity Instance increments for each shared record
 	EntityInstanceSharing uint8
 
+	// ID String Instance Modifier Offset, see SDREventOnly.IDStringInstanceModifierOffset
+	IDStringInstanceModifierOffset uint8
+
 	// Positive hysteresis is defined as the unsigned number of counts that are
 	// subtracted from the raw threshold values to create the "re-arm" point for all
 	// positive-going thresholds on the sensor. 0 indicates that there is no hysteresis on
@@ -77,6 +92,9 @@ type SDRCompact struct {
 	// compact SDR can have pos/neg hysteresis, but they cannot be analog!
 	NegativeHysteresisRaw uint8
 
+	// Reserved for OEM use.
+	OEM uint8
+
 	IDStringTypeLength TypeLength // Sensor ID String Type/Length Code
 	IDStringBytes      []byte     // Sensor ID String bytes.
 }
@@ -237,16 +255,29 @@ func parseSDRCompactSensor(data []byte, sdr *SDR) error {
 	b22, _, _ := unpackUint8(data, 22)
 	s.SensorUnit = SensorUnit{
 		AnalogDataFormat: SensorAnalogUnitFormat((b20 & 0xc0) >> 6),
-		RateUnit:         SensorRateUnit((b20 & 0x38) >> 4),
-		ModifierRelation: SensorModifierRelation((b20 & 0x06) >> 2),
+		RateUnit:         SensorRateUnit((b20 & 0x38) >> 3),
+		ModifierRelation: SensorModifierRelation((b20 & 0x06) >> 1),
 		Percentage:       isBit0Set(b20),
 		BaseUnit:         SensorUnitType(b21),
 		ModifierUnit:     SensorUnitType(b22),
 	}
 
+	b23, _, _ := unpackUint8(data, 23)
+	s.SensorDirection = b23 >> 6
+	s.IDStringInstanceModifierType = (b23 & 0x30) >> 4
+	s.ShareCount = b23 & 0x0f
+
+	b24, _, _ := unpackUint8(data, 24)
+	if isBit7Set(b24) {
+		s.EntityInstanceSharing = 1
+	}
+	s.IDStringInstanceModifierOffset = b24 & 0x7f
+
 	s.PositiveHysteresisRaw, _, _ = unpackUint8(data, 25)
 	s.NegativeHysteresisRaw, _, _ = unpackUint8(data, 26)
 
+	s.OEM, _, _ = unpackUint8(data, 30)
+
 	typeLength, _, _ := unpackUint8(data, 31)
 	s.IDStringTypeLength = TypeLength(typeLength)
 
@@ -257,3 +288,46 @@ func parseSDRCompactSensor(data []byte, sdr *SDR) error {
 	s.IDStringBytes, _, _ = unpackBytes(data, minSize, idStrLen)
 	return nil
 }
+
+// Pack packs the Compact Sensor Record, the record key and body following the record header,
+// it's the reverse of the parsing in ParseSDR.
+func (compact *SDRCompact) Pack() []byte {
+	const SDRCompactSensorMinSize int = 32
+
+	out := make([]byte, SDRCompactSensorMinSize)
+	packUint16L(uint16(compact.GeneratorID), out, 5)
+	packUint8(uint8(compact.SensorNumber), out, 7)
+	packUint8(uint8(compact.SensorEntityID), out, 8)
+
+	b9 := uint8(compact.SensorEntityInstance) & 0x7f
+	if compact.SensorEntityIsLogical {
+		b9 = setBit7(b9)
+	}
+	packUint8(b9, out, 9)
+
+	packUint8(compact.SensorInitialization.pack(), out, 10)
+	packUint8(compact.SensorCapabilities.pack(), out, 11)
+	packUint8(uint8(compact.SensorType), out, 12)
+	packUint8(uint8(compact.SensorEventReadingType), out, 13)
+
+	packUint16(compact.Mask.PackAssertLower(), out, 14)
+	packUint16(compact.Mask.PackDeassertUpper(), out, 16)
+	packUint16(compact.Mask.PackReading(), out, 18)
+
+	packBytes(compact.SensorUnit.pack(), out, 20)
+
+	packUint8((compact.SensorDirection&0x03)<<6|(compact.IDStringInstanceModifierType&0x03)<<4|compact.ShareCount&0x0f, out, 23)
+	b24 := compact.IDStringInstanceModifierOffset & 0x7f
+	if compact.EntityInstanceSharing != 0 {
+		b24 = setBit7(b24)
+	}
+	packUint8(b24, out, 24)
+
+	packUint8(compact.PositiveHysteresisRaw, out, 25)
+	packUint8(compact.NegativeHysteresisRaw, out, 26)
+
+	packUint8(compact.OEM, out, 30)
+
+	out = append(out[:31], packTypeLength(compact.IDStringTypeLength, compact.IDStringBytes)...)
+	return out[5:]
+}
@@ -143,6 +143,9 @@ type SDRFull struct {
 	// 负向迟滞量
 	NegativeHysteresisRaw uint8
 
+	// Reserved for OEM use.
+	OEM uint8
+
 	IDStringTypeLength TypeLength
 	IDStringBytes      []byte
 }
@@ -433,15 +436,15 @@ func parseSDRFullSensor(data []byte, sdr *SDR) error {
 	b22, _, _ := unpackUint8(data, 22)
 	s.SensorUnit = SensorUnit{
 		AnalogDataFormat: SensorAnalogUnitFormat((b20 & 0xc0) >> 6),
-		RateUnit:         SensorRateUnit((b20 & 0x38) >> 4),
-		ModifierRelation: SensorModifierRelation((b20 & 0x06) >> 2),
+		RateUnit:         SensorRateUnit((b20 & 0x38) >> 3),
+		ModifierRelation: SensorModifierRelation((b20 & 0x06) >> 1),
 		Percentage:       isBit0Set(b20),
 		BaseUnit:         SensorUnitType(b21),
 		ModifierUnit:     SensorUnitType(b22),
 	}
 
 	b23, _, _ := unpackUint8(data, 23)
-	s.LinearizationFunc = LinearizationFunc(b23)
+	s.LinearizationFunc = LinearizationFunc(b23 & 0x7f) // bit 7 is reserved
 
 	b24, _, _ := unpackUint8(data, 24)
 	b25, _, _ := unpackUint8(data, 25)
@@ -492,6 +495,8 @@ func parseSDRFullSensor(data []byte, sdr *SDR) error {
 	s.PositiveHysteresisRaw, _, _ = unpackUint8(data, 42)
 	s.NegativeHysteresisRaw, _, _ = unpackUint8(data, 43)
 
+	s.OEM, _, _ = unpackUint8(data, 46)
+
 	typeLength, _, _ := unpackUint8(data, 47)
 	s.IDStringTypeLength = TypeLength(typeLength)
 
@@ -505,6 +510,71 @@ func parseSDRFullSensor(data []byte, sdr *SDR) error {
 	return nil
 }
 
+// Pack packs the Full Sensor Record, the record key and body following the record header,
+// it's the reverse of the parsing in ParseSDR.
+func (full *SDRFull) Pack() []byte {
+	const SDRFullSensorMinSize int = 48
+
+	out := make([]byte, SDRFullSensorMinSize)
+	packUint16L(uint16(full.GeneratorID), out, 5)
+	packUint8(uint8(full.SensorNumber), out, 7)
+	packUint8(uint8(full.SensorEntityID), out, 8)
+
+	b9 := uint8(full.SensorEntityInstance) & 0x7f
+	if full.SensorEntityIsLogical {
+		b9 = setBit7(b9)
+	}
+	packUint8(b9, out, 9)
+
+	packUint8(full.SensorInitialization.pack(), out, 10)
+	packUint8(full.SensorCapabilities.pack(), out, 11)
+	packUint8(uint8(full.SensorType), out, 12)
+	packUint8(uint8(full.SensorEventReadingType), out, 13)
+
+	packUint16(full.Mask.PackAssertLower(), out, 14)
+	packUint16(full.Mask.PackDeassertUpper(), out, 16)
+	packUint16(full.Mask.PackReading(), out, 18)
+
+	packBytes(full.SensorUnit.pack(), out, 20)
+	packUint8(uint8(full.LinearizationFunc), out, 23)
+
+	packBytes(full.ReadingFactors.pack(), out, 24)
+	out[28] |= full.SensorDirection & 0x03
+
+	var b30 uint8
+	if full.NormalMinSpecified {
+		b30 = setBit2(b30)
+	}
+	if full.NormalMaxSpecified {
+		b30 = setBit1(b30)
+	}
+	if full.NominalReadingSpecified {
+		b30 = setBit0(b30)
+	}
+	packUint8(b30, out, 30)
+
+	packUint8(full.NominalReadingRaw, out, 31)
+	packUint8(full.NormalMaxRaw, out, 32)
+	packUint8(full.NormalMinRaw, out, 33)
+	packUint8(full.SensorMaxReadingRaw, out, 34)
+	packUint8(full.SensorMinReadingRaw, out, 35)
+
+	packUint8(full.UNR_Raw, out, 36)
+	packUint8(full.UCR_Raw, out, 37)
+	packUint8(full.UNC_Raw, out, 38)
+	packUint8(full.LNR_Raw, out, 39)
+	packUint8(full.LCR_Raw, out, 40)
+	packUint8(full.LNC_Raw, out, 41)
+
+	packUint8(full.PositiveHysteresisRaw, out, 42)
+	packUint8(full.NegativeHysteresisRaw, out, 43)
+
+	packUint8(full.OEM, out, 46)
+
+	out = append(out[:47], packTypeLength(full.IDStringTypeLength, full.IDStringBytes)...)
+	return out[5:]
+}
+
 func (full *SDRFull) HasAnalogReading() bool {
 	// Todo, logic is not clear.
 	/*
@@ -50,6 +50,9 @@ type SDREventOnly struct {
 	// (alpha characters are considered to be base 26 for ASCII)
 	IDStringInstanceModifierOffset uint8
 
+	// Reserved for OEM use.
+	OEM uint8
+
 	IDStringTypeLength TypeLength
 	IDStringBytes      []byte
 }
@@ -95,6 +98,17 @@ func parseSDREventOnly(data []byte, sdr *SDR) error {
 	eventReadingType, _, _ := unpackUint8(data, 11)
 	s.SensorEventReadingType = EventReadingType(eventReadingType)
 
+	b12, _, _ := unpackUint8(data, 12)
+	s.SensorDirection = b12 >> 6
+	s.IDStringInstanceModifierType = (b12 & 0x30) >> 4
+	s.ShareCount = b12 & 0x0f
+
+	b13, _, _ := unpackUint8(data, 13)
+	s.EntityInstanceSharing = isBit7Set(b13)
+	s.IDStringInstanceModifierOffset = b13 & 0x7f
+
+	s.OEM, _, _ = unpackUint8(data, 15)
+
 	typeLength, _, _ := unpackUint8(data, 16)
 	s.IDStringTypeLength = TypeLength(typeLength)
 
@@ -106,6 +120,37 @@ func parseSDREventOnly(data []byte, sdr *SDR) error {
 	return nil
 }
 
+// Pack packs the Event-Only Record, the record key and body following the record header.
+func (eventOnly *SDREventOnly) Pack() []byte {
+	const SDREventOnlyMinSize int = 17
+
+	out := make([]byte, SDREventOnlyMinSize)
+	packUint16L(uint16(eventOnly.GeneratorID), out, 5)
+	packUint8(uint8(eventOnly.SensorNumber), out, 7)
+	packUint8(uint8(eventOnly.SensorEntityID), out, 8)
+
+	b9 := uint8(eventOnly.SensorEntityInstance) & 0x7f
+	if eventOnly.SensorEntityIsLogical {
+		b9 = setBit7(b9)
+	}
+	packUint8(b9, out, 9)
+
+	packUint8(uint8(eventOnly.SensorType), out, 10)
+	packUint8(uint8(eventOnly.SensorEventReadingType), out, 11)
+
+	packUint8((eventOnly.SensorDirection&0x03)<<6|(eventOnly.IDStringInstanceModifierType&0x03)<<4|eventOnly.ShareCount&0x0f, out, 12)
+	b13 := eventOnly.IDStringInstanceModifierOffset & 0x7f
+	if eventOnly.EntityInstanceSharing {
+		b13 = setBit7(b13)
+	}
+	packUint8(b13, out, 13)
+
+	packUint8(eventOnly.OEM, out, 15)
+
+	out = append(out[:16], packTypeLength(eventOnly.IDStringTypeLength, eventOnly.IDStringBytes)...)
+	return out[5:]
+}
+
 // 43.4 SDR Type 08h - Entity Association Record
 type SDREntityAssociation struct {
 	//
@@ -175,6 +220,41 @@ func parseSDREntityAssociation(data []byte, sdr *SDR) error {
 	return nil
 }
 
+// Pack packs the Entity Association Record, the record key and body following the record header.
+func (s *SDREntityAssociation) Pack() []byte {
+	const SDREntityAssociationSize int = 16
+
+	out := make([]byte, SDREntityAssociationSize)
+	packUint8(s.ContainerEntityID, out, 5)
+	packUint8(s.ContainerEntityInstance, out, 6)
+	packUint8(packEntityAssociationFlags(s.ContainedEntitiesAsRange, s.LinkedEntityAssociationExist, s.PresenceSensorAlwaysAccessible), out, 7)
+
+	packUint8(s.ContainedEntity1ID, out, 8)
+	packUint8(s.ContainedEntity1Instance, out, 9)
+	packUint8(s.ContainedEntity2ID, out, 10)
+	packUint8(s.ContainedEntity2Instance, out, 11)
+	packUint8(s.ContainedEntity3ID, out, 12)
+	packUint8(s.ContainedEntity3Instance, out, 13)
+	packUint8(s.ContainedEntity4ID, out, 14)
+	packUint8(s.ContainedEntity4Instance, out, 15)
+	return out[5:]
+}
+
+// packEntityAssociationFlags packs the flags byte of the Entity Association Records.
+func packEntityAssociationFlags(asRange bool, linked bool, presenceSensorAlwaysAccessible bool) uint8 {
+	var flag uint8
+	if asRange {
+		flag = setBit7(flag)
+	}
+	if linked {
+		flag = setBit6(flag)
+	}
+	if presenceSensorAlwaysAccessible {
+		flag = setBit5(flag)
+	}
+	return flag
+}
+
 // 43.5 SDR Type 09h - Device-relative Entity Association Record
 type SDRDeviceRelative struct {
 	//
@@ -270,6 +350,39 @@ func parseSDRDeviceRelativeEntityAssociation(data []byte, sdr *SDR) error {
 	return nil
 }
 
+// Pack packs the Device-relative Entity Association Record, the record key and body following the record header.
+func (s *SDRDeviceRelative) Pack() []byte {
+	const SDRDeviceRelativeEntityAssociationSize = 32
+
+	out := make([]byte, SDRDeviceRelativeEntityAssociationSize)
+	packUint8(s.ContainerEntityID, out, 5)
+	packUint8(s.ContainerEntityInstance, out, 6)
+	packUint8(s.ContainerEntityDeviceAddress, out, 7)
+	packUint8(s.ContainerEntityDeviceChannel, out, 8)
+	packUint8(packEntityAssociationFlags(s.ContainedEntitiesAsRange, s.LinkedEntityAssociationExist, s.PresenceSensorAlwaysAccessible), out, 9)
+
+	packUint8(s.ContainedEntity1DeviceAddress, out, 10)
+	packUint8(s.ContainedEntity1DeviceChannel, out, 11)
+	packUint8(s.ContainedEntity1ID, out, 12)
+	packUint8(s.ContainedEntity1Instance, out, 13)
+
+	packUint8(s.ContainedEntity2DeviceAddress, out, 14)
+	packUint8(s.ContainedEntity2DeviceChannel, out, 15)
+	packUint8(s.ContainedEntity2ID, out, 16)
+	packUint8(s.ContainedEntity2Instance, out, 17)
+
+	packUint8(s.ContainedEntity3DeviceAddress, out, 18)
+	packUint8(s.ContainedEntity3DeviceChannel, out, 19)
+	packUint8(s.ContainedEntity3ID, out, 20)
+	packUint8(s.ContainedEntity3Instance, out, 21)
+
+	packUint8(s.ContainedEntity4DeviceAddress, out, 22)
+	packUint8(s.ContainedEntity4DeviceChannel, out, 23)
+	packUint8(s.ContainedEntity4ID, out, 24)
+	packUint8(s.ContainedEntity4Instance, out, 25)
+	return out[5:]
+}
+
 // 43.7 SDR Type 10h - Generic Device Locator Record
 // This record is used to store the location and type information for devices
 // on the IPMB or management controller private busses that are neither
@@ -300,6 +413,9 @@ type SDRGenericDeviceLocator struct {
 	EntityID           uint8
 	EntityInstance     uint8
 
+	// Reserved for OEM use.
+	OEM uint8
+
 	DeviceIDTypeLength TypeLength
 	DeviceIDString     []byte // Short ID string for the device
 }
@@ -321,7 +437,7 @@ func parseSDRGenericLocator(data []byte, sdr *SDR) error {
 	s.DeviceSlaveAddress = b
 
 	c, _, _ := unpackUint8(data, 7)
-	s.ChannelNumber = ((b & 0x01) << 4) | (c >> 5)
+	s.ChannelNumber = ((b & 0x01) << 3) | (c >> 5)
 	s.AccessLUN = (c & 0x1f) >> 3
 	s.PrivateBusID = (c & 0x07)
 
@@ -332,6 +448,8 @@ func parseSDRGenericLocator(data []byte, sdr *SDR) error {
 	s.EntityID, _, _ = unpackUint8(data, 12)
 	s.EntityInstance, _, _ = unpackUint8(data, 13)
 
+	s.OEM, _, _ = unpackUint8(data, 14)
+
 	typeLength, _, _ := unpackUint8(data, 15)
 	s.DeviceIDTypeLength = TypeLength(typeLength)
 
@@ -343,6 +461,25 @@ func parseSDRGenericLocator(data []byte, sdr *SDR) error {
 	return nil
 }
 
+// Pack packs the Generic Device Locator Record, the record key and body following the record header.
+func (s *SDRGenericDeviceLocator) Pack() []byte {
+	const SDRGenericLocatorMinSize = 16
+
+	out := make([]byte, SDRGenericLocatorMinSize)
+	packUint8(s.DeviceAccessAddress, out, 5)
+	packUint8(s.DeviceSlaveAddress&0xfe|(s.ChannelNumber>>3)&0x01, out, 6)
+	packUint8((s.ChannelNumber&0x07)<<5|(s.AccessLUN&0x03)<<3|s.PrivateBusID&0x07, out, 7)
+	packUint8(s.AddressSpan, out, 8)
+	packUint8(s.DeviceType, out, 10)
+	packUint8(s.DeviceTypeModifier, out, 11)
+	packUint8(s.EntityID, out, 12)
+	packUint8(s.EntityInstance, out, 13)
+	packUint8(s.OEM, out, 14)
+
+	out = append(out[:15], packTypeLength(s.DeviceIDTypeLength, s.DeviceIDString)...)
+	return out[5:]
+}
+
 // 43.8 SDR Type 11h - FRU Device Locator Record
 // 38. Accessing FRU Devices
 type SDRFRUDeviceLocator struct {
@@ -403,6 +540,9 @@ type SDRFRUDeviceLocator struct {
 	FRUEntityID       uint8
 	FRUEntityInstance uint8
 
+	// Reserved for OEM use.
+	OEM uint8
+
 	DeviceIDTypeLength TypeLength
 	DeviceIDBytes      []byte // Short ID string for the FRU Device
 }
@@ -452,7 +592,7 @@ func parseSDRFRUDeviceLocator(data []byte, sdr *SDR) error {
 	s.FRUEntityID, _, _ = unpackUint8(data, 12)
 	s.FRUEntityInstance, _, _ = unpackUint8(data, 13)
 
-	// index 14 Reserved for OEM use.
+	s.OEM, _, _ = unpackUint8(data, 14)
 
 	typeLength, _, _ := unpackUint8(data, 15)
 	s.DeviceIDTypeLength = TypeLength(typeLength)
@@ -472,6 +612,31 @@ func parseSDRFRUDeviceLocator(data []byte, sdr *SDR) error {
 	return nil
 }
 
+// Pack packs the FRU Device Locator Record, the record key and body following the record header.
+func (sdrFRU *SDRFRUDeviceLocator) Pack() []byte {
+	const SDRFRUDeviceLocatorMinSize = 16
+
+	out := make([]byte, SDRFRUDeviceLocatorMinSize)
+	packUint8(sdrFRU.DeviceAccessAddress, out, 5)
+	packUint8(sdrFRU.FRUDeviceID_SlaveAddress, out, 6)
+
+	b8 := (sdrFRU.AccessLUN&0x03)<<3 | sdrFRU.PrivateBusID&0x07
+	if sdrFRU.IsLogicalFRUDevice {
+		b8 = setBit7(b8)
+	}
+	packUint8(b8, out, 7)
+	packUint8(sdrFRU.ChannelNumber<<4, out, 8)
+
+	packUint8(uint8(sdrFRU.DeviceType), out, 10)
+	packUint8(sdrFRU.DeviceTypeModifier, out, 11)
+	packUint8(sdrFRU.FRUEntityID, out, 12)
+	packUint8(sdrFRU.FRUEntityInstance, out, 13)
+	packUint8(sdrFRU.OEM, out, 14)
+
+	out = append(out[:15], packTypeLength(sdrFRU.DeviceIDTypeLength, sdrFRU.DeviceIDBytes)...)
+	return out[5:]
+}
+
 // 43.9 SDR Type 12h - Management Controller Device Locator Record
 type SDRMgmtControllerDeviceLocator struct {
 	//
@@ -490,6 +655,13 @@ type SDRMgmtControllerDeviceLocator struct {
 	ControllerLogsInitializationAgentErrors  bool
 	LogInitializationAgentErrors             bool
 
+	// Global Initialization
+	// 00b = Enable event message generation from controller
+	// 01b = Disable event message generation from controller
+	// 10b = Do not initialize controller
+	// 11b = reserved
+	GlobalInitialization uint8
+
 	DeviceCap_ChassisDevice      bool // device functions as chassis device
 	DeviceCap_Bridge             bool // Controller responds to Bridge NetFn command
 	DeviceCap_IPMBEventGenerator bool // device generates event messages on IPMB
@@ -502,6 +674,9 @@ type SDRMgmtControllerDeviceLocator struct {
 	EntityID       uint8
 	EntityInstance uint8
 
+	// Reserved for OEM use.
+	OEM uint8
+
 	DeviceIDTypeLength TypeLength
 	DeviceIDBytes      []byte
 }
@@ -528,6 +703,7 @@ func parseSDRManagementControllerDeviceLocator(data []byte, sdr *SDR) error {
 	s.ACPIDevicePowerStateNotificationRequired = isBit6Set(b8)
 	s.ControllerLogsInitializationAgentErrors = isBit3Set(b8)
 	s.LogInitializationAgentErrors = isBit2Set(b8)
+	s.GlobalInitialization = b8 & 0x03
 
 	b9, _, _ := unpackUint8(data, 8)
 	s.DeviceCap_ChassisDevice = isBit7Set(b9)
@@ -542,6 +718,8 @@ func parseSDRManagementControllerDeviceLocator(data []byte, sdr *SDR) error {
 	s.EntityID, _, _ = unpackUint8(data, 12)
 	s.EntityInstance, _, _ = unpackUint8(data, 13)
 
+	s.OEM, _, _ = unpackUint8(data, 14)
+
 	typeLength, _, _ := unpackUint8(data, 15)
 	s.DeviceIDTypeLength = TypeLength(typeLength)
 
@@ -553,6 +731,64 @@ func parseSDRManagementControllerDeviceLocator(data []byte, sdr *SDR) error {
 	return nil
 }
 
+// Pack packs the Management Controller Device Locator Record, the record key and body following the record header.
+func (s *SDRMgmtControllerDeviceLocator) Pack() []byte {
+	const SDRManagementControllerDeviceLocatorMinSize = 16
+
+	out := make([]byte, SDRManagementControllerDeviceLocatorMinSize)
+	packUint8(s.DeviceSlaveAddress, out, 5)
+	packUint8(s.ChannelNumber, out, 6)
+
+	b8 := s.GlobalInitialization & 0x03
+	if s.ACPISystemPowerStateNotificationRequired {
+		b8 = setBit7(b8)
+	}
+	if s.ACPIDevicePowerStateNotificationRequired {
+		b8 = setBit6(b8)
+	}
+	if s.ControllerLogsInitializationAgentErrors {
+		b8 = setBit3(b8)
+	}
+	if s.LogInitializationAgentErrors {
+		b8 = setBit2(b8)
+	}
+	packUint8(b8, out, 7)
+
+	var b9 uint8
+	if s.DeviceCap_ChassisDevice {
+		b9 = setBit7(b9)
+	}
+	if s.DeviceCap_Bridge {
+		b9 = setBit6(b9)
+	}
+	if s.DeviceCap_IPMBEventGenerator {
+		b9 = setBit5(b9)
+	}
+	if s.DeviceCap_IPMBEventReceiver {
+		b9 = setBit4(b9)
+	}
+	if s.DeviceCap_FRUInventoryDevice {
+		b9 = setBit3(b9)
+	}
+	if s.DeviceCap_SELDevice {
+		b9 = setBit2(b9)
+	}
+	if s.DeviceCap_SDRRepoDevice {
+		b9 = setBit1(b9)
+	}
+	if s.DeviceCap_SensorDevice {
+		b9 = setBit0(b9)
+	}
+	packUint8(b9, out, 8)
+
+	packUint8(s.EntityID, out, 12)
+	packUint8(s.EntityInstance, out, 13)
+	packUint8(s.OEM, out, 14)
+
+	out = append(out[:15], packTypeLength(s.DeviceIDTypeLength, s.DeviceIDBytes)...)
+	return out[5:]
+}
+
 // 43.10 SDR Type 13h - Management Controller Confirmation Record
 type SDRMgmtControllerConfirmation struct {
 	//
@@ -617,6 +853,23 @@ func parseSDRManagementControllerConfirmation(data []byte, sdr *SDR) error {
 	return nil
 }
 
+// Pack packs the Management Controller Confirmation Record, the record key and body following the record header.
+func (s *SDRMgmtControllerConfirmation) Pack() []byte {
+	const SDRManagementControllerConfirmationSize = 32
+
+	out := make([]byte, SDRManagementControllerConfirmationSize)
+	packUint8(s.DeviceSlaveAddress, out, 5)
+	packUint8(s.DeviceID, out, 6)
+	packUint8(s.ChannelNumber<<4|s.DeviceRevision&0x0f, out, 7)
+	packUint8(s.FirmwareMajorRevision&0x7f, out, 8)
+	packUint8(s.FirmwareMinorRevision, out, 9)
+	packUint8(s.MinorIPMIVersion<<4|s.MajorIPMIVersion&0x0f, out, 10)
+	packUint24L(s.ManufacturerID, out, 11)
+	packUint16L(s.ProductID, out, 14)
+	copy(out[16:], s.DeviceGUID)
+	return out[5:]
+}
+
 // 43.11 SDR Type 14h - BMC Message Channel Info Record
 type SDRBMCChannelInfo struct {
 	//
@@ -655,6 +908,14 @@ func parseChannelInfo(b uint8) ChannelInfo {
 	}
 }
 
+func (info ChannelInfo) pack() uint8 {
+	b := (info.MessageReceiveLUN&0x07)<<4 | info.ChannelProtocol&0x0f
+	if info.TransmitSupported {
+		b = setBit7(b)
+	}
+	return b
+}
+
 func parseSDRBMCMessageChannelInfo(data []byte, sdr *SDR) error {
 	const SDRBMCMessageChannelInfoSize = 16
 	minSize := SDRBMCMessageChannelInfoSize
@@ -679,6 +940,19 @@ func parseSDRBMCMessageChannelInfo(data []byte, sdr *SDR) error {
 	return nil
 }
 
+// Pack packs the BMC Message Channel Info Record, the record body following the record header.
+func (s *SDRBMCChannelInfo) Pack() []byte {
+	const SDRBMCMessageChannelInfoSize = 16
+
+	out := make([]byte, SDRBMCMessageChannelInfoSize)
+	for i, channel := range []ChannelInfo{s.Channel0, s.Channel1, s.Channel2, s.Channel3, s.Channel4, s.Channel5, s.Channel6, s.Channel7} {
+		packUint8(channel.pack(), out, 5+i)
+	}
+	packUint8(s.MessagingInterruptType, out, 13)
+	packUint8(s.EventMessageBufferInterruptType, out, 14)
+	return out[5:]
+}
+
 // 43.12 SDR Type C0h - OEM Record
 type SDROEM struct {
 	//
@@ -709,6 +983,13 @@ func parseSDROEM(data []byte, sdr *SDR) error {
 	return nil
 }
 
+// Pack packs the OEM Record, the record body following the record header.
+func (s *SDROEM) Pack() []byte {
+	out := make([]byte, 3, 3+len(s.OEMData))
+	packUint24L(s.ManufacturerID, out, 0)
+	return append(out, s.OEMData...)
+}
+
 // 43.6 SDR Type 0Ah:0Fh - Reserved Records
 type SDRReserved struct {
 }
@@ -790,6 +1071,19 @@ func (tl TypeLength) Size() uint8 {
 	return size
 }
 
+// packTypeLength packs the Type/Length byte followed by the raw bytes of the ID string.
+// The length of tl is set to the length of raw, and the type defaults to 8-bit ASCII if tl is zero.
+func packTypeLength(tl TypeLength, raw []byte) []byte {
+	typeCode := uint8(tl) & 0xc0
+	if tl == 0 && len(raw) > 0 {
+		typeCode = 0xc0
+	}
+
+	out := make([]byte, 1, 1+len(raw))
+	out[0] = typeCode | uint8(len(raw))&0x3f
+	return append(out, raw...)
+}
+
 // Chars decodes the raw bytes to ASCII chars according to the encoding type code of TypeLength
 func (tl TypeLength) Chars(raw []byte) (chars []byte, err error) {
 	if len(raw) != int(tl.Length()) {
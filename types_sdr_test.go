@@ -0,0 +1,163 @@
+package ipmi
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSDR_Pack(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		raw   []byte
+		check func(sdr *SDR) bool
+	}{
+		{
+			name: "full sensor",
+			raw: append([]byte{
+				0x01, 0x00, 0x51, 0x01, 0x34,
+				0x20, 0x00, 0x01, 0x03, 0x01, 0x7f, 0x68, 0x01, 0x01,
+				0x80, 0x0a, 0x80, 0x7a, 0x38, 0x38,
+				0x0c, 0x01, 0x13, 0x00,
+				0xe3, 0xc2, 0x10, 0x45, 0x5a, 0xd2,
+				0x07, 0x28, 0x5a, 0x0a, 0xff, 0x00,
+				0x69, 0x64, 0x5f, 0x00, 0x00, 0x00, 0x02, 0x02,
+				0x00, 0x00, 0x00, 0xc9,
+			}, "CPU1 Temp"...),
+			check: func(sdr *SDR) bool {
+				full := sdr.Full
+				return full.SensorUnit.RateUnit == SensorRateUnit_PerMicroSec &&
+					full.SensorUnit.ModifierRelation == SensorModifierRelation_Mul &&
+					full.M == -29 && full.B == 272 && full.Accuracy == 325 && full.SensorDirection == 2 &&
+					full.R_Exp == -3 && full.B_Exp == 2 && full.Mask.Threshold.UCR.Readable
+			},
+		},
+		{
+			name: "compact sensor",
+			raw: append([]byte{
+				0x02, 0x00, 0x51, 0x02, 0x1f,
+				0x20, 0x00, 0x30, 0x07, 0x01, 0x67, 0x40, 0x08, 0x6f,
+				0x0f, 0x00, 0x0f, 0x00, 0x0f, 0x00,
+				0xc0, 0x00, 0x00, 0x42, 0x81, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0xc4,
+			}, "PSU "...),
+			check: func(sdr *SDR) bool {
+				compact := sdr.Compact
+				return compact.SensorDirection == 1 && compact.ShareCount == 2 &&
+					compact.EntityInstanceSharing == 1 && compact.IDStringInstanceModifierOffset == 1
+			},
+		},
+		{
+			name: "event-only",
+			raw: append([]byte{
+				0x03, 0x00, 0x51, 0x03, 0x17,
+				0x20, 0x00, 0x50, 0x22, 0x00, 0x0f, 0x6f, 0x00, 0x00, 0x00, 0x00, 0xcb,
+			}, "FW Progress"...),
+		},
+		{
+			name: "entity association",
+			raw: []byte{
+				0x04, 0x00, 0x51, 0x08, 0x0b,
+				0x17, 0x01, 0x80, 0x0a, 0x01, 0x0a, 0x02, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			name: "device-relative entity association",
+			raw: []byte{
+				0x05, 0x00, 0x51, 0x09, 0x1b,
+				0x1e, 0x01, 0x20, 0x00, 0x00,
+				0x20, 0x00, 0x1d, 0x01, 0x20, 0x00, 0x1d, 0x02,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			name: "generic device locator",
+			raw: append([]byte{
+				0x06, 0x00, 0x51, 0x10, 0x11,
+				0x20, 0xa1, 0x4a, 0x01, 0x00, 0x10, 0x00, 0x07, 0x01, 0x00, 0xc6,
+			}, "DIMM_A"...),
+			check: func(sdr *SDR) bool {
+				locator := sdr.GenericDeviceLocator
+				return locator.ChannelNumber == 10 && locator.AccessLUN == 1 && locator.PrivateBusID == 2
+			},
+		},
+		{
+			name: "fru device locator",
+			raw: append([]byte{
+				0x07, 0x00, 0x51, 0x11, 0x0e,
+				0x20, 0x01, 0x80, 0x10, 0x00, 0x10, 0x00, 0x0a, 0x01, 0x00, 0xc3,
+			}, "PS1"...),
+		},
+		{
+			name: "mgmt controller device locator",
+			raw: append([]byte{
+				0x08, 0x00, 0x51, 0x12, 0x0e,
+				0x20, 0x00, 0x0d, 0xbf, 0x00, 0x00, 0x00, 0x06, 0x00, 0x00, 0xc3,
+			}, "BMC"...),
+			check: func(sdr *SDR) bool {
+				return sdr.MgmtControllerDeviceLocator.GlobalInitialization == 1
+			},
+		},
+		{
+			name: "mgmt controller confirmation",
+			raw: []byte{
+				0x09, 0x00, 0x51, 0x13, 0x1b,
+				0x20, 0x20, 0x01, 0x02, 0x45, 0x02, 0x57, 0x01, 0x00, 0x34, 0x12,
+				0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
+			},
+		},
+		{
+			name: "bmc message channel info",
+			raw: []byte{
+				0x0a, 0x00, 0x51, 0x14, 0x0b,
+				0x81, 0x84, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xff, 0x00,
+			},
+		},
+		{
+			name: "oem",
+			raw: []byte{
+				0x0b, 0x00, 0x51, 0xc0, 0x06,
+				0x57, 0x01, 0x00, 0xde, 0xad, 0xbe,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sdr, err := ParseSDR(tt.raw, 0xffff)
+			if err != nil {
+				t.Fatalf("ParseSDR failed, err: %s", err)
+			}
+			if tt.check != nil && !tt.check(sdr) {
+				t.Errorf("parsed sdr not match, got: %+v", sdr)
+			}
+
+			got, err := sdr.Pack()
+			if err != nil {
+				t.Fatalf("Pack failed, err: %s", err)
+			}
+			if !bytes.Equal(got, tt.raw) {
+				t.Errorf("SDR.Pack() = %#v, want %#v", got, tt.raw)
+			}
+		})
+	}
+}
+
+func TestSDR_Pack_Invalid(t *testing.T) {
+	t.Parallel()
+
+	if _, err := (&SDR{}).Pack(); err == nil {
+		t.Errorf("expect error for sdr without record header")
+	}
+
+	sdr := &SDR{RecordHeader: &SDRHeader{RecordType: SDRRecordTypeFullSensor}}
+	if _, err := sdr.Pack(); err == nil {
+		t.Errorf("expect error for sdr without full sensor record")
+	}
+}
@@ -527,17 +527,17 @@ func (l LinearizationFunc) Apply(x float64) float64 {
 	case LinearizationFunc_LOG2:
 		return math.Log2(float64(x))
 	case LinearizationFunc_E:
-		return math.Pow(math.E, float64(x))
+		return math.Exp(float64(x))
 	case LinearizationFunc_EXP10:
-		return math.Pow10(int(x))
+		return math.Pow(10, float64(x))
 	case LinearizationFunc_EXP2:
 		return math.Exp2(float64(x))
 	case LinearizationFunc_1X:
-		return math.Pow(float64(x), -1)
+		return 1 / float64(x)
 	case LinearizationFunc_SQR:
-		return math.Pow(float64(x), 2.0)
+		return float64(x) * float64(x)
 	case LinearizationFunc_CUBE:
-		return math.Pow(float64(x), 3.0)
+		return float64(x) * float64(x) * float64(x)
 	case LinearizationFunc_SQRT:
 		return math.Sqrt(float64(x))
 	case LinearizationFunc_CBRT:
@@ -592,6 +592,15 @@ func (unit SensorUnit) IsAnalog() bool {
 	return unit.AnalogDataFormat != SensorAnalogUnitFormat_NotAnalog
 }
 
+// pack packs the Sensor Units 1, 2 and 3 bytes of Full and Compact SDR.
+func (unit SensorUnit) pack() []byte {
+	units1 := uint8(unit.AnalogDataFormat&0x03)<<6 | uint8(unit.RateUnit&0x07)<<3 | uint8(unit.ModifierRelation&0x03)<<1
+	if unit.Percentage {
+		units1 = setBit0(units1)
+	}
+	return []byte{units1, uint8(unit.BaseUnit), uint8(unit.ModifierUnit)}
+}
+
 type SensorAnalogUnitFormat uint8
 
 const (
@@ -787,6 +796,24 @@ func (f ReadingFactors) String() string {
 		f.M, f.Tolerance, f.B, f.Accuracy, f.Accuracy_Exp, f.R_Exp, f.B_Exp)
 }
 
+// pack packs the 6 bytes of the reading factors as in Full SDR,
+// the Sensor Direction bits [1:0] of the fifth byte are left zero.
+func (f ReadingFactors) pack() []byte {
+	m := twosComplementEncode(int32(f.M), 10)
+	b := twosComplementEncode(int32(f.B), 10)
+	rExp := twosComplementEncode(int32(f.R_Exp), 4)
+	bExp := twosComplementEncode(int32(f.B_Exp), 4)
+
+	out := make([]byte, 6)
+	out[0] = uint8(m)
+	out[1] = uint8(m>>8&0x03)<<6 | f.Tolerance&0x3f
+	out[2] = uint8(b)
+	out[3] = uint8(b>>8&0x03)<<6 | uint8(f.Accuracy)&0x3f
+	out[4] = uint8(f.Accuracy>>6&0x0f)<<4 | (f.Accuracy_Exp&0x03)<<2
+	out[5] = uint8(rExp&0x0f)<<4 | uint8(bExp&0x0f)
+	return out
+}
+
 // The raw analog data is unpacked as an unsigned integer.
 // But whether it is a positive number (>0) or negative number (<0) is determined
 // by the "analog data format" field (SensorUnit.AnalogDataFormat)
@@ -824,16 +851,33 @@ func ConvertReading(raw uint8, analogDataFormat SensorAnalogUnitFormat, factors
 
 	x := float64(analog)
 
-	M := float64(factors.M)
-	B := float64(factors.B)
-	Bexp := math.Pow(10, float64(factors.B_Exp))
-	Rexp := math.Pow(10, float64(factors.R_Exp))
-
-	y := (M*x + B*Bexp) * Rexp
+	y := scaleReading(factors.M, x, factors.B, factors.B_Exp, factors.R_Exp)
 
 	return linearizationFunc.Apply(y)
 }
 
+// scaleReading returns (Mx + (B * 10^B_Exp)) * 10^R_Exp.
+//
+// The sum is computed as an integer, and the exponents are applied at last by one multiplication
+// or division of an exact power of 10, so the result is the nearest float64 to the exact value,
+// e.g. 1.2 rather than 1.2000000000000002 for 12 * 10^-1.
+func scaleReading(m int16, x float64, b int16, bExp int8, rExp int8) float64 {
+	y := float64(m) * x
+	exp := int(rExp)
+	if bExp >= 0 {
+		y += float64(b) * math.Pow10(int(bExp))
+	} else {
+		// (Mx + B * 10^B_Exp) = (Mx * 10^-B_Exp + B) * 10^B_Exp
+		y = y*math.Pow10(-int(bExp)) + float64(b)
+		exp += int(bExp)
+	}
+
+	if exp < 0 {
+		return y / math.Pow10(-exp)
+	}
+	return y * math.Pow10(exp)
+}
+
 // ConvertSensorHysteresis converts raw sensor hysteresis value to real value in the desired units for the sensor.
 //
 // see: 36.3 Sensor Reading Conversion Formula
@@ -844,12 +888,7 @@ func ConvertSensorHysteresis(raw uint8, analogDataFormat SensorAnalogUnitFormat,
 
 	x := float64(analog)
 
-	M := float64(factors.M)
-	B := float64(factors.B)
-	Bexp := math.Pow(10, float64(factors.B_Exp))
-	Rexp := math.Pow(10, float64(factors.R_Exp))
-
-	y := (M*x + B*Bexp) * Rexp
+	y := scaleReading(factors.M, x, factors.B, factors.B_Exp, factors.R_Exp)
 
 	return linearizationFunc.Apply(y)
 }
@@ -864,10 +903,7 @@ func ConvertSensorTolerance(raw uint8, analogDataFormat SensorAnalogUnitFormat,
 
 	x := float64(analog)
 
-	M := float64(factors.M)
-	Rexp := math.Pow(10, float64(factors.R_Exp))
-
-	y := (M * x / 2) * Rexp
+	y := scaleReading(factors.M, x/2, 0, 0, factors.R_Exp)
 
 	return linearizationFunc.Apply(y)
 }
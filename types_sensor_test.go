@@ -1,54 +1,275 @@
 package ipmi
 
 import (
-	"fmt"
+	"context"
+	"math"
 	"testing"
 )
 
+// fullSensorSDR returns a Full Sensor Record whose bytes 20-29 (sensor units, linearization and reading factors)
+// are set to the conversion bytes.
+func fullSensorSDR(name string, conversion ...byte) []byte {
+	record := make([]byte, 48)
+	copy(record, []byte{0x01, 0x00, 0x51, 0x01, 0x00, 0x20, 0x00, 0x01, 0x07, 0x01, 0x7f, 0x68, 0x01, 0x01})
+	copy(record[20:], conversion)
+	record[47] = 0xc0 | uint8(len(name))
+	record = append(record, name...)
+	record[4] = uint8(len(record) - 5)
+	return record
+}
+
 func Test_ConvertReading(t *testing.T) {
+	tests := []struct {
+		name string
+		// sensor units 1/2/3, linearization, M, M/tolerance, B, B/accuracy, accuracy/direction, R_Exp/B_Exp
+		conversion []byte
+		raw        uint8
+		want       float64
+	}{
+		{
+			name:       "CPU Temp",
+			conversion: []byte{0x00, 0x01, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00},
+			raw:        0x2d,
+			want:       45,
+		},
+		{
+			name:       "Inlet Temp (2's complement)",
+			conversion: []byte{0x80, 0x01, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00},
+			raw:        0xf6,
+			want:       -10,
+		},
+		{
+			name:       "Ambient Temp (1's complement)",
+			conversion: []byte{0x40, 0x01, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00},
+			raw:        0xf5,
+			want:       -10,
+		},
+		{
+			name:       "12V",
+			conversion: []byte{0x00, 0x04, 0x00, 0x00, 0x44, 0x00, 0x00, 0x00, 0x00, 0xd0},
+			raw:        0xb0,
+			want:       11.968,
+		},
+		{
+			name:       "3.3V",
+			conversion: []byte{0x00, 0x04, 0x00, 0x00, 0x11, 0x00, 0x00, 0x00, 0x00, 0xd0},
+			raw:        0xc2,
+			want:       3.298,
+		},
+		{
+			name:       "VBAT",
+			conversion: []byte{0x00, 0x04, 0x00, 0x00, 0x0c, 0x00, 0x00, 0x00, 0x00, 0xe0},
+			raw:        0x19,
+			want:       3,
+		},
+		{
+			name:       "Fan1 RPM",
+			conversion: []byte{0x00, 0x12, 0x00, 0x00, 0x78, 0x00, 0x00, 0x00, 0x00, 0x00},
+			raw:        0x3c,
+			want:       7200,
+		},
+		{
+			name:       "PS1 Current",
+			conversion: []byte{0x00, 0x05, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00, 0x00, 0xf0},
+			raw:        0x06,
+			want:       1.2,
+		},
+		{
+			name:       "B with negative B_Exp",
+			conversion: []byte{0x00, 0x04, 0x00, 0x00, 0x01, 0x00, 0x05, 0x00, 0x00, 0xff},
+			raw:        0x0c,
+			want:       1.25,
+		},
+		{
+			name:       "negative M and B_Exp",
+			conversion: []byte{0x00, 0x01, 0x00, 0x00, 0xfe, 0xc0, 0x2c, 0x40, 0x00, 0xe1},
+			raw:        0x64,
+			want:       28,
+		},
+		{
+			name:       "ln",
+			conversion: []byte{0x00, 0x00, 0x00, 0x01, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00},
+			raw:        0x0a,
+			want:       math.Log(10),
+		},
+		{
+			name:       "log10",
+			conversion: []byte{0x00, 0x00, 0x00, 0x02, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00},
+			raw:        0x64,
+			want:       2,
+		},
+		{
+			name:       "log10 with reserved bit",
+			conversion: []byte{0x00, 0x00, 0x00, 0x82, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00},
+			raw:        0x64,
+			want:       2,
+		},
+		{
+			name:       "log2",
+			conversion: []byte{0x00, 0x00, 0x00, 0x03, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00},
+			raw:        0x40,
+			want:       6,
+		},
+		{
+			name:       "e",
+			conversion: []byte{0x00, 0x00, 0x00, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0xf0},
+			raw:        0x05,
+			want:       math.Exp(0.5),
+		},
+		{
+			name:       "exp10",
+			conversion: []byte{0x00, 0x00, 0x00, 0x05, 0x01, 0x00, 0x00, 0x00, 0x00, 0xf0},
+			raw:        0x0f,
+			want:       math.Pow(10, 1.5),
+		},
+		{
+			name:       "exp2",
+			conversion: []byte{0x00, 0x00, 0x00, 0x06, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00},
+			raw:        0x0a,
+			want:       1024,
+		},
+		{
+			name:       "1/x",
+			conversion: []byte{0x00, 0x00, 0x00, 0x07, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00},
+			raw:        0x08,
+			want:       0.125,
+		},
+		{
+			name:       "sqr",
+			conversion: []byte{0x00, 0x00, 0x00, 0x08, 0x01, 0x00, 0x00, 0x00, 0x00, 0xf0},
+			raw:        0x0f,
+			want:       2.25,
+		},
+		{
+			name:       "cube",
+			conversion: []byte{0x00, 0x00, 0x00, 0x09, 0x03, 0x00, 0x00, 0x00, 0x00, 0xf0},
+			raw:        0x0a,
+			want:       27,
+		},
+		{
+			name:       "sqrt",
+			conversion: []byte{0x00, 0x00, 0x00, 0x0a, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00},
+			raw:        0x90,
+			want:       12,
+		},
+		{
+			name:       "cube-1",
+			conversion: []byte{0x00, 0x00, 0x00, 0x0b, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00},
+			raw:        0x7d,
+			want:       5,
+		},
+		{
+			name:       "non-linear",
+			conversion: []byte{0x00, 0x01, 0x00, 0x70, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00},
+			raw:        0x14,
+			want:       40,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			sdr, err := ParseSDR(fullSensorSDR(tt.name, tt.conversion...), 0xffff)
+			if err != nil {
+				t.Fatalf("ParseSDR failed, err: %s", err)
+			}
+
+			if got := sdr.Full.ConvertReading(tt.raw); got != tt.want {
+				t.Errorf("ConvertReading(%#02x) = %v, want %v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+// sensorFactorsTransport serves the reading, thresholds and hysteresis of a non-linear sensor,
+// and the reading factors by the raw values.
+type sensorFactorsTransport struct {
+	rawTransport
+	raw     uint8
+	lcrRaw  uint8
+	factors map[uint8]uint8 // raw value to M
+}
+
+func (t *sensorFactorsTransport) Exchange(ctx context.Context, request Request, response Response) error {
+	switch req := request.(type) {
+	case *GetSensorReadingRequest:
+		return UnpackResponse([]byte{0x00, t.raw, 0xc0, 0x00}, response)
+	case *GetSensorThresholdsRequest:
+		return UnpackResponse([]byte{0x00, 0x02, 0x00, t.lcrRaw, 0x00, 0x00, 0x00, 0x00}, response)
+	case *GetSensorHysteresisRequest:
+		return UnpackResponse([]byte{0x00, 0x03, 0x04}, response)
+	case *GetSensorReadingFactorsRequest:
+		m, ok := t.factors[req.Reading]
+		if !ok {
+			return UnpackResponse([]byte{uint8(CompletionCodeRequestedDataNotPresent)}, response)
+		}
+		return UnpackResponse([]byte{0x00, 0xff, m, 0x00, 0x00, 0x00, 0x00, 0x00}, response)
+	}
+	return t.rawTransport.Exchange(ctx, request, response)
+}
 
+func Test_sdrToSensor_NonLinearFactors(t *testing.T) {
 	tests := []struct {
-		raw               uint8
-		analogDataFormat  SensorAnalogUnitFormat
-		factors           ReadingFactors
-		linearizationFunc LinearizationFunc
+		name    string
+		factors map[uint8]uint8
+		value   float64
+		lcr     float64
 	}{
 		{
-			raw:               0,
-			analogDataFormat:  SensorAnalogUnitFormat_Unsigned,
-			factors:           ReadingFactors{},
-			linearizationFunc: LinearizationFunc_Linear,
+			name:  "factors not supported",
+			value: 40,
+			lcr:   20,
 		},
 		{
-			raw:               0,
-			analogDataFormat:  SensorAnalogUnitFormat_NotAnalog,
-			factors:           ReadingFactors{},
-			linearizationFunc: LinearizationFunc_Linear,
+			name:    "same factors",
+			factors: map[uint8]uint8{0x14: 3, 0x0a: 3},
+			value:   60,
+			lcr:     30,
 		},
 		{
-			raw:              0,
-			analogDataFormat: SensorAnalogUnitFormat_1sComplement,
-			factors: ReadingFactors{
-				M:            1,
-				Tolerance:    0,
-				B:            0,
-				Accuracy:     0,
-				Accuracy_Exp: 0,
-				B_Exp:        0,
-			},
-			linearizationFunc: LinearizationFunc_Linear,
+			name:    "reading and threshold get different factors",
+			factors: map[uint8]uint8{0x14: 3, 0x0a: 5},
+			value:   60,
+			lcr:     50,
 		},
 		{
-			raw:               0,
-			analogDataFormat:  SensorAnalogUnitFormat_2sComplement,
-			factors:           ReadingFactors{},
-			linearizationFunc: LinearizationFunc_Linear,
+			name:    "threshold factors not present",
+			factors: map[uint8]uint8{0x14: 3},
+			value:   60,
+			lcr:     20,
 		},
 	}
 
+	// M is 2 in the SDR
+	sdr, err := ParseSDR(fullSensorSDR("Airflow", 0x00, 0x01, 0x00, 0x70, 0x02), 0xffff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	for _, tt := range tests {
-		v := ConvertReading(tt.raw, tt.analogDataFormat, tt.factors, tt.linearizationFunc)
-		fmt.Println(v)
-		// Todo
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClientWithTransport(&sensorFactorsTransport{raw: 0x14, lcrRaw: 0x0a, factors: tt.factors})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sensor, err := client.sdrToSensor(context.Background(), sdr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sensor.Value != tt.value {
+				t.Errorf("value not match, expect: %v, got: %v", tt.value, sensor.Value)
+			}
+			if sensor.Threshold.LCR != tt.lcr {
+				t.Errorf("lcr not match, expect: %v, got: %v", tt.lcr, sensor.Threshold.LCR)
+			}
+			// the hysteresis is converted by the SDR factors
+			if sensor.Threshold.M != 2 || sensor.Threshold.PositiveHysteresis != 6 || sensor.Threshold.NegativeHysteresis != 8 {
+				t.Errorf("the SDR factors not used, M: %d, hysteresis: %v/%v", sensor.Threshold.M, sensor.Threshold.PositiveHysteresis, sensor.Threshold.NegativeHysteresis)
+			}
+		})
 	}
 }
@@ -0,0 +1,92 @@
+package ipmi
+
+import "fmt"
+
+// SOLOperation is the Operation field of the SOL payload sent by the remote console.
+// see 15.9 SOL Payload Data Format
+type SOLOperation uint8
+
+const (
+	// NACK, the console is not accepting the characters of the packet being acked.
+	SOLOperationNACK SOLOperation = 1 << 6
+	// Assert RI (Ring Indicator), for Wake-On-Ring.
+	SOLOperationRingWOR SOLOperation = 1 << 5
+	// Generate BREAK (300ms, nominal).
+	SOLOperationGenerateBreak SOLOperation = 1 << 4
+	// Deassert CTS (clear to send) to the baseboard serial controller.
+	SOLOperationCTSPause SOLOperation = 1 << 3
+	// Deassert DCD/DSR to the baseboard serial controller.
+	SOLOperationDropDCDDSR SOLOperation = 1 << 2
+	// Flush Inbound, the characters from the remote console not yet sent to the serial controller.
+	SOLOperationFlushInbound SOLOperation = 1 << 1
+	// Flush Outbound, the characters from the serial controller not yet sent to the remote console.
+	SOLOperationFlushOutbound SOLOperation = 1 << 0
+)
+
+// SOLStatus is the Status field of the SOL payload sent by the BMC.
+type SOLStatus uint8
+
+const (
+	// NACK, the BMC is not accepting the characters of the packet being acked.
+	SOLStatusNACK SOLStatus = 1 << 6
+	// Character transfer is unavailable because the system is in a powered-down or sleep state.
+	SOLStatusCharacterTransferUnavailable SOLStatus = 1 << 5
+	// SOL is deactivating, the BMC is about to deactivate the SOL payload.
+	SOLStatusDeactivating SOLStatus = 1 << 4
+	// Characters were dropped between transmitting this packet and the previous packet.
+	SOLStatusTransmitOverrun SOLStatus = 1 << 3
+	// A break condition from the system has been detected.
+	SOLStatusBreak SOLStatus = 1 << 2
+)
+
+// SOLPacketSequenceMax is the max value of the SOL Packet Sequence Number which occupies 4 bits.
+// 0 is reserved for the ACK-only packets.
+const SOLPacketSequenceMax uint8 = 0x0f
+
+// 15.9 SOL Payload Data Format
+type SOLPacket struct {
+	// Packet Sequence Number, 0 means ACK-only packet.
+	SequenceNumber uint8
+
+	// Packet ACK/NACK Sequence Number, 0 means the packet carries no ACK/NACK.
+	AckSequenceNumber uint8
+
+	// The number of characters accepted from the acked packet.
+	AcceptedCharacterCount uint8
+
+	// The Operation (remote console to BMC) or Status (BMC to remote console) field.
+	OperationStatus uint8
+
+	Data []byte
+}
+
+func (p *SOLPacket) Pack() []byte {
+	out := make([]byte, 4+len(p.Data))
+	packUint8(p.SequenceNumber&0x0f, out, 0)
+	packUint8(p.AckSequenceNumber&0x0f, out, 1)
+	packUint8(p.AcceptedCharacterCount, out, 2)
+	packUint8(p.OperationStatus, out, 3)
+	packBytes(p.Data, out, 4)
+	return out
+}
+
+func (p *SOLPacket) Unpack(msg []byte) error {
+	if len(msg) < 4 {
+		return ErrUnpackedDataTooShortWith(len(msg), 4)
+	}
+	p.SequenceNumber = msg[0] & 0x0f
+	p.AckSequenceNumber = msg[1] & 0x0f
+	p.AcceptedCharacterCount = msg[2]
+	p.OperationStatus = msg[3]
+	p.Data, _, _ = unpackBytes(msg, 4, len(msg)-4)
+	return nil
+}
+
+func (p *SOLPacket) Status() SOLStatus {
+	return SOLStatus(p.OperationStatus)
+}
+
+func (p *SOLPacket) Format() string {
+	return fmt.Sprintf("seq: %d, ack seq: %d, accepted: %d, operation/status: %#02x, data: %d bytes",
+		p.SequenceNumber, p.AckSequenceNumber, p.AcceptedCharacterCount, p.OperationStatus, len(p.Data))
+}
@@ -142,7 +142,7 @@ func ParseSOLParamData(paramSelector SOLConfigParamSelector, paramData []byte, s
 	}
 
 	if err != nil {
-		return fmt.Errorf("unpack paramData for paramSelector (%d) failed, err: %s", paramSelector, err)
+		return fmt.Errorf("unpack paramData for paramSelector (%d) failed, err: %w", paramSelector, err)
 	}
 	return nil
 }
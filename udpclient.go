@@ -2,6 +2,7 @@ package ipmi
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -28,6 +29,9 @@ type UDPClient struct {
 	// lock is used to protect udp Exchange method to prevent another
 	// send/receive operation from occurring while one is in progress.
 	lock sync.Mutex
+
+	// connLock protects conn from being initialized or closed concurrently.
+	connLock sync.Mutex
 }
 
 func NewUDPClient(host string, port int) *UDPClient {
@@ -38,33 +42,48 @@ func NewUDPClient(host string, port int) *UDPClient {
 	return udpClient
 }
 
+// getConn returns the udp connection, the connection is created if not yet.
+func (c *UDPClient) getConn() (net.Conn, error) {
+	c.connLock.Lock()
+	defer c.connLock.Unlock()
+
+	if err := c.initConn(); err != nil {
+		return nil, err
+	}
+	return c.conn, nil
+}
+
 func (c *UDPClient) initConn() error {
 	if c.conn != nil {
 		return nil
 	}
 
 	if c.proxy != nil {
-		conn, err := c.proxy.Dial("udp", fmt.Sprintf("%s:%d", c.Host, c.Port))
+		conn, err := c.proxy.Dial("udp", c.addr())
 		if err != nil {
-			return fmt.Errorf("udp proxy dial failed, err: %s", err)
+			return fmt.Errorf("udp proxy dial failed, err: %w", err)
 		}
 		c.conn = conn
 		return nil
 	}
 
-	remoteAddr, err := net.ResolveUDPAddr("udp", fmt.Sprintf("%s:%d", c.Host, c.Port))
+	remoteAddr, err := net.ResolveUDPAddr("udp", c.addr())
 	if err != nil {
-		return fmt.Errorf("resolve addr failed, err: %s", err)
+		return fmt.Errorf("resolve addr failed, err: %w", err)
 	}
 	conn, err := net.DialUDP("udp", nil, remoteAddr)
 	if err != nil {
-		return fmt.Errorf("udp dial failed, err: %s", err)
+		return fmt.Errorf("udp dial failed, err: %w", err)
 	}
 	c.conn = conn
 
 	return nil
 }
 
+func (c *UDPClient) addr() string {
+	return net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+}
+
 func (c *UDPClient) SetProxy(proxy proxy.Dialer) *UDPClient {
 	c.proxy = proxy
 	return c
@@ -92,7 +111,7 @@ func (c *UDPClient) RemoteIP() string {
 }
 
 func (c *UDPClient) LocalIPPort() (string, int) {
-	conn, err := net.Dial("udp", fmt.Sprintf("%s:%d", c.Host, c.Port))
+	conn, err := net.Dial("udp", c.addr())
 	if err != nil {
 		return "", 0
 	}
@@ -103,12 +122,15 @@ func (c *UDPClient) LocalIPPort() (string, int) {
 }
 
 func (c *UDPClient) Close() error {
+	c.connLock.Lock()
+	defer c.connLock.Unlock()
+
 	if c.conn == nil {
 		return nil
 	}
 
 	if err := c.conn.Close(); err != nil {
-		return fmt.Errorf("close udp conn failed, err: %s", err)
+		return fmt.Errorf("close udp conn failed, err: %w", err)
 	}
 
 	c.conn = nil
@@ -119,58 +141,85 @@ func (c *UDPClient) Close() error {
 // It sends the request, and waits for a reply.
 // Exchange does not retry a failed query.
 // The sent content is read from reader.
+//
+// The wait for the reply is bounded by the client timeout and the deadline of ctx,
+// whichever comes first. Canceling ctx interrupts the pending read immediately.
 func (c *UDPClient) Exchange(ctx context.Context, reader io.Reader) ([]byte, error) {
-	if err := c.initConn(); err != nil {
-		return nil, fmt.Errorf("init udp connection failed, err: %s", err)
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("read request failed, err: %w", err)
+	}
+	return c.exchange(ctx, data, c.timeout, nil)
+}
+
+// exchange sends data, and then reads datagrams until one is accepted by the match
+// function, or the timeout elapses. A nil match function accepts any datagram.
+// Datagrams which are not accepted are dropped, which filters out late or
+// duplicated replies of the previous requests.
+//
+// The returned error wraps a net.Error whose Timeout() is true if no accepted
+// datagram is received in time, see isTimeoutError.
+func (c *UDPClient) exchange(ctx context.Context, data []byte, timeout time.Duration, match func(recv []byte) bool) ([]byte, error) {
+	conn, err := c.getConn()
+	if err != nil {
+		return nil, fmt.Errorf("init udp connection failed, err: %w", err)
 	}
 
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	recvBuffer := make([]byte, c.bufferSize)
+	// It is possible that this action blocks, although this
+	// should only occur in very resource-intensive situations:
+	// - when you've filled up the socket buffer and the OS
+	//   can't dequeue the queue fast enough.
+	if _, err := conn.Write(data); err != nil {
+		return nil, fmt.Errorf("write to conn failed, err: %w", err)
+	}
+
+	// Set a deadline for the Read operation so that we don't
+	// wait forever for a server that might not respond on
+	// a reasonable amount of time.
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetReadDeadline(deadline); err != nil {
+		return nil, fmt.Errorf("set conn read deadline failed, err: %w", err)
+	}
 
-	doneChan := make(chan error, 1)
-	// recvChan stores the integer number which indicates how many bytes
-	recvChan := make(chan int, 1)
+	// Moving the read deadline to now unblocks the pending Read when ctx is done.
+	done := make(chan struct{})
+	defer close(done)
 	go func() {
-		// It is possible that this action blocks, although this
-		// should only occur in very resource-intensive situations:
-		// - when you've filled up the socket buffer and the OS
-		//   can't dequeue the queue fast enough.
-		_, err := io.Copy(c.conn, reader)
-		if err != nil {
-			doneChan <- fmt.Errorf("write to conn failed, err: %w", err)
-			return
+		select {
+		case <-ctx.Done():
+			_ = conn.SetReadDeadline(time.Now())
+		case <-done:
 		}
+	}()
 
-		// Set a deadline for the Read operation so that we don't
-		// wait forever for a server that might not respond on
-		// a reasonable amount of time.
-		deadline := time.Now().Add(c.timeout)
-		err = c.conn.SetReadDeadline(deadline)
+	recvBuffer := make([]byte, c.bufferSize)
+	for {
+		nRead, err := conn.Read(recvBuffer)
 		if err != nil {
-			doneChan <- fmt.Errorf("set conn read deadline failed, err: %w", err)
-			return
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, fmt.Errorf("canceled from caller, err: %w", ctxErr)
+			}
+			return nil, fmt.Errorf("read from conn failed, err: %w", err)
 		}
 
-		nRead, err := c.conn.Read(recvBuffer)
-		if err != nil {
-			doneChan <- fmt.Errorf("read from conn failed, err: %w", err)
-			return
+		recv := recvBuffer[:nRead]
+		if match == nil || match(recv) {
+			return recv, nil
 		}
+	}
+}
 
-		doneChan <- nil
-		recvChan <- nRead
-	}()
-
-	select {
-	case <-ctx.Done():
-		return nil, fmt.Errorf("canceled from caller")
-	case err := <-doneChan:
-		if err != nil {
-			return nil, err
-		}
-		recvCount := <-recvChan
-		return recvBuffer[:recvCount], nil
+// isTimeoutError reports whether err is caused by a timeout, like the read timeout of the UDP connection.
+func isTimeoutError(err error) bool {
+	if errors.Is(err, ErrTimeout) {
+		return true
 	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
 }
@@ -0,0 +1,35 @@
+package ipmi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func Test_UDPClient_ExchangeCanceled(t *testing.T) {
+	// a server which never replies
+	server, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("listen udp failed, err: %s", err)
+	}
+	defer server.Close()
+
+	addr := server.LocalAddr().(*net.UDPAddr)
+	udpClient := NewUDPClient(addr.IP.String(), addr.Port).SetTimeout(10 * time.Second).SetBufferSize(DefaultBufferSize)
+	defer udpClient.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err = udpClient.Exchange(ctx, bytes.NewReader([]byte{0x06, 0x00, 0xff, 0x07}))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("exchange not aborted in time, elapsed: %s", elapsed)
+	}
+}